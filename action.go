@@ -6,10 +6,61 @@ package description
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
 
+const (
+	// ActionPending is the status of an action that is yet to run.
+	ActionPending = "pending"
+
+	// ActionRunning is the status of an action that is running.
+	ActionRunning = "running"
+
+	// ActionCompleted is the status of an action that ran successfully.
+	ActionCompleted = "completed"
+
+	// ActionFailed is the status of an action that ran unsuccessfully.
+	ActionFailed = "failed"
+
+	// ActionCancelled is the status of an action that was cancelled
+	// before it ran.
+	ActionCancelled = "cancelled"
+
+	// ActionError is the status of an action that couldn't be run.
+	ActionError = "error"
+
+	// ActionAborting is the status of an action that is being stopped
+	// while it runs.
+	ActionAborting = "aborting"
+
+	// ActionAborted is the status of an action that was stopped while it
+	// was running.
+	ActionAborted = "aborted"
+)
+
+// actionStatuses are the statuses of actions, and of operations.
+var actionStatuses = set.NewStrings(
+	ActionPending,
+	ActionRunning,
+	ActionCompleted,
+	ActionFailed,
+	ActionCancelled,
+	ActionError,
+	ActionAborting,
+	ActionAborted,
+)
+
+// validateActionStatus checks that the status is one of the enumerated
+// statuses.
+func validateActionStatus(status string) error {
+	if !actionStatuses.Contains(status) {
+		return errors.NotValidf("status %q", status)
+	}
+	return nil
+}
+
 // Action represents an action.
 type Action interface {
 	Id() string
@@ -266,6 +317,7 @@ var actionFieldsFuncs = map[int]fieldsFunc{
 	2: actionV2Fields,
 	3: actionV3Fields,
 	4: actionV4Fields,
+	5: actionV5Fields,
 }
 
 func actionV1Fields() (schema.Fields, schema.Defaults) {
@@ -309,6 +361,12 @@ func actionV4Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+// actionV5Fields is the same as v4. From v5 the status is one of the
+// enumerated statuses.
+func actionV5Fields() (schema.Fields, schema.Defaults) {
+	return actionV4Fields()
+}
+
 func importAction(source map[string]interface{}, importVersion int, fieldFunc func() (schema.Fields, schema.Defaults)) (*action, error) {
 	fields, defaults := fieldFunc()
 	checker := schema.FieldMap(fields, defaults)
@@ -152,7 +152,7 @@ func (s *ActionSerializationSuite) exportImportVersion(c *gc.C, action_ *action,
 }
 
 func (s *ActionSerializationSuite) exportImportLatest(c *gc.C, action_ *action) *action {
-	return s.exportImportVersion(c, action_, 4)
+	return s.exportImportVersion(c, action_, 5)
 }
 
 func (s *ActionSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
@@ -199,3 +199,13 @@ func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	actionResult := s.exportImportLatest(c, action)
 	c.Assert(actionResult, jc.DeepEquals, action)
 }
+
+func (s *ActionSerializationSuite) TestErrorAndAbortingStatuses(c *gc.C) {
+	for version := 1; version <= 5; version++ {
+		action := minimalAction()
+		action.Status_ = ActionError
+		c.Check(s.exportImportVersion(c, action, version).Status(), gc.Equals, ActionError)
+		action.Status_ = ActionAborting
+		c.Check(s.exportImportVersion(c, action, version).Status(), gc.Equals, ActionAborting)
+	}
+}
@@ -17,6 +17,11 @@ type Address interface {
 	SpaceID() string
 }
 
+// AddressOriginExternal is the origin used for addresses that are known to
+// be reachable, but aren't recorded against a machine's provider or machine
+// addresses, such as those of an external load balancer.
+const AddressOriginExternal = "external"
+
 // AddressArgs is an argument struct used to create a new internal address
 // type that supports the Address interface.
 type AddressArgs struct {
@@ -49,6 +54,16 @@ type address struct {
 	SpaceID_ string `yaml:"spaceid,omitempty"`
 }
 
+// emitYAML implements yamlEmitter.
+func (a *address) emitYAML(m *mapping) {
+	m.int("version", a.Version)
+	m.str("value", a.Value_)
+	m.str("type", a.Type_)
+	m.stringOmitEmpty("scope", a.Scope_)
+	m.stringOmitEmpty("origin", a.Origin_)
+	m.stringOmitEmpty("spaceid", a.SpaceID_)
+}
+
 // Value implements Address.
 func (a *address) Value() string {
 	return a.Value_
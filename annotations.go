@@ -4,6 +4,8 @@
 package description
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 	"github.com/juju/schema"
 )
 
@@ -41,13 +43,208 @@ func (a *Annotations_) SetAnnotations(annotations map[string]string) {
 	*a = annotations
 }
 
-func (a *Annotations_) importAnnotations(valid map[string]interface{}) {
+// ImportAnnotations sets the annotations from the "annotations" field of a
+// coerced entity map. It is used alongside AddAnnotationSchema.
+func (a *Annotations_) ImportAnnotations(valid map[string]interface{}) {
 	if annotations := convertToStringMap(valid["annotations"]); annotations != nil {
 		a.SetAnnotations(annotations)
 	}
 }
 
-func addAnnotationSchema(fields schema.Fields, defaults schema.Defaults) {
+// AddAnnotationSchema adds the optional "annotations" field to the schema of
+// an entity that composes Annotations_.
+func AddAnnotationSchema(fields schema.Fields, defaults schema.Defaults) {
 	fields["annotations"] = schema.StringMap(schema.String())
 	defaults["annotations"] = schema.Omit
 }
+
+// AnnotationsForEntity implements Model.
+func (m *model) AnnotationsForEntity(tag names.Tag) map[string]string {
+	owner, err := m.annotationOwner(tag)
+	if err != nil {
+		return nil
+	}
+	if owner != nil {
+		return owner.Annotations()
+	}
+	return m.EntityAnnotations_[tag.String()]
+}
+
+// SetAnnotationsForEntity implements Model.
+func (m *model) SetAnnotationsForEntity(tag names.Tag, annotations map[string]string) error {
+	m.checkMutable()
+	owner, err := m.annotationOwner(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if owner != nil {
+		owner.SetAnnotations(annotations)
+		return nil
+	}
+	if len(annotations) == 0 {
+		delete(m.EntityAnnotations_, tag.String())
+		return nil
+	}
+	if m.EntityAnnotations_ == nil {
+		m.EntityAnnotations_ = make(map[string]map[string]string)
+	}
+	m.EntityAnnotations_[tag.String()] = annotations
+	return nil
+}
+
+// AnnotationsIndex implements Model.
+func (m *model) AnnotationsIndex() map[string]map[string]string {
+	index := make(map[string]map[string]string)
+	add := func(tag names.Tag, annotations map[string]string) {
+		if len(annotations) > 0 {
+			index[tag.String()] = annotations
+		}
+	}
+	add(m.Tag(), m.Annotations())
+	machines, _ := m.machineMaps()
+	for id, machine := range machines {
+		add(names.NewMachineTag(id), machine.Annotations())
+	}
+	for _, application := range m.Applications_.Applications_ {
+		add(application.Tag(), application.Annotations())
+		for _, unit := range application.Units_.Units_ {
+			add(unit.Tag(), unit.Annotations())
+		}
+	}
+	for _, storage := range m.Storages_.Storages_ {
+		add(storage.Tag(), storage.Annotations())
+	}
+	for _, volume := range m.Volumes_.Volumes_ {
+		add(volume.Tag(), volume.Annotations())
+	}
+	for _, space := range m.Spaces_.Spaces_ {
+		// A space without a name has no tag.
+		if names.IsValidSpace(space.Name_) {
+			add(names.NewSpaceTag(space.Name_), space.Annotations())
+		}
+	}
+	for tag, annotations := range m.EntityAnnotations_ {
+		if len(annotations) > 0 {
+			index[tag] = annotations
+		}
+	}
+	return index
+}
+
+// annotationOwner returns the entity with the tag if it holds its own
+// annotations, as the model, machines, applications, units, storage
+// instances, volumes and spaces do. It returns nil for the other entities
+// that can be annotated, whose annotations the model holds. It fails if the model has no such entity,
+// or if entities of its kind can't be annotated.
+func (m *model) annotationOwner(tag names.Tag) (HasAnnotations, error) {
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		if tag.Id() == m.Tag().Id() {
+			return m, nil
+		}
+	case names.MachineTag:
+		machines, _ := m.machineMaps()
+		if machine, ok := machines[tag.Id()]; ok {
+			return machine, nil
+		}
+	case names.ApplicationTag:
+		if application := m.application(tag.Id()); application != nil {
+			return application, nil
+		}
+		// Remote applications are annotated in the model.
+		if m.remoteApplication(tag.Id()) != nil {
+			return nil, nil
+		}
+	case names.UnitTag:
+		application, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			break
+		}
+		if app := m.application(application); app != nil {
+			for _, unit := range app.Units_.Units_ {
+				if unit.Name_ == tag.Id() {
+					return unit, nil
+				}
+			}
+		}
+	case names.UserTag:
+		for _, user := range m.Users_.Users_ {
+			if user.Name().Id() == tag.Id() {
+				return nil, nil
+			}
+		}
+	case names.StorageTag:
+		for _, storage := range m.Storages_.Storages_ {
+			if storage.Tag() == tag {
+				return storage, nil
+			}
+		}
+	case names.VolumeTag:
+		for _, volume := range m.Volumes_.Volumes_ {
+			if volume.Tag() == tag {
+				return volume, nil
+			}
+		}
+	case names.FilesystemTag:
+		for _, filesystem := range m.Filesystems_.Filesystems_ {
+			if filesystem.Tag() == tag {
+				return nil, nil
+			}
+		}
+	case names.SpaceTag:
+		for _, space := range m.Spaces_.Spaces_ {
+			if space.Name() == tag.Id() {
+				return space, nil
+			}
+		}
+	default:
+		return nil, errors.NotSupportedf("annotations for %s", tag.Kind())
+	}
+	return nil, errors.NotFoundf("%s %q", tag.Kind(), tag.Id())
+}
+
+// validateEntityAnnotations checks that the annotations held by the model
+// belong to entities of the model that don't hold their own.
+func (m *model) validateEntityAnnotations() error {
+	for key := range m.EntityAnnotations_ {
+		tag, err := names.ParseTag(key)
+		if err != nil {
+			return errors.NotValidf("annotations for %q", key)
+		}
+		owner, err := m.annotationOwner(tag)
+		if err != nil {
+			return errors.NotValidf("annotations for %q: %v", key, err)
+		}
+		if owner != nil {
+			return errors.NotValidf("annotations for %q held by the model", key)
+		}
+	}
+	return nil
+}
+
+// adoptEntityAnnotations moves the annotations the model holds for
+// entities that hold their own to those entities. Storage instances,
+// volumes and spaces were annotated in the model before their sections
+// could hold annotations. Annotations the entity already has take
+// precedence.
+func (m *model) adoptEntityAnnotations() {
+	for key, annotations := range m.EntityAnnotations_ {
+		tag, err := names.ParseTag(key)
+		if err != nil {
+			continue
+		}
+		owner, err := m.annotationOwner(tag)
+		if err != nil || owner == nil {
+			continue
+		}
+		merged := make(map[string]string)
+		for name, value := range annotations {
+			merged[name] = value
+		}
+		for name, value := range owner.Annotations() {
+			merged[name] = value
+		}
+		owner.SetAnnotations(merged)
+		delete(m.EntityAnnotations_, key)
+	}
+}
@@ -5,6 +5,8 @@ package description
 
 import (
 	"encoding/base64"
+	"fmt"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -30,9 +32,22 @@ type Application interface {
 	ForceCharm() bool
 	MinUnits() int
 
+	// Life returns the life of the application, one of Alive, Dying or
+	// Dead.
+	Life() string
+
 	Exposed() bool
 	ExposedEndpoints() map[string]ExposedEndpoint
 
+	// ExposedAt returns when the application was last exposed. It is the
+	// zero time if the application isn't exposed or the time wasn't
+	// recorded.
+	ExposedAt() time.Time
+	// ExposedBy returns the user who last exposed the application. It is
+	// the zero tag if the application isn't exposed or the user wasn't
+	// recorded.
+	ExposedBy() names.UserTag
+
 	PasswordHash() string
 	PodSpec() string
 	DesiredScale() int
@@ -49,6 +64,11 @@ type Application interface {
 	Leader() string
 	LeadershipSettings() map[string]interface{}
 
+	// Lease returns the lease of the application's leadership, or nil if
+	// it hasn't been set.
+	Lease() Lease
+	SetLease(LeaseArgs) Lease
+
 	MetricsCredentials() []byte
 	StorageDirectives() map[string]StorageDirective
 
@@ -112,11 +132,14 @@ type application struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_ bool   `yaml:"force-charm,omitempty"`
+	MinUnits_   int    `yaml:"min-units,omitempty"`
+	Life_       string `yaml:"life,omitempty"`
 
 	Exposed_          bool                        `yaml:"exposed,omitempty"`
 	ExposedEndpoints_ map[string]*exposedEndpoint `yaml:"exposed-endpoints,omitempty"`
+	ExposedAt_        *time.Time                  `yaml:"exposed-at,omitempty"`
+	ExposedBy_        string                      `yaml:"exposed-by,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -131,6 +154,7 @@ type application struct {
 
 	Leader_             string                 `yaml:"leader,omitempty"`
 	LeadershipSettings_ map[string]interface{} `yaml:"leadership-settings"`
+	Lease_              *lease                 `yaml:"lease,omitempty"`
 
 	MetricsCredentials_ string `yaml:"metrics-creds,omitempty"`
 
@@ -170,19 +194,24 @@ type application struct {
 	CharmManifest_ *charmManifest `yaml:"charm-manifest,omitempty"`
 	CharmActions_  *charmActions  `yaml:"charm-actions,omitempty"`
 	CharmConfigs_  *charmConfigs  `yaml:"charm-configs,omitempty"`
+
+	UnknownFields_ map[string]interface{} `yaml:",inline"`
 }
 
 // ApplicationArgs is an argument struct used to add an application to the Model.
 type ApplicationArgs struct {
 	Tag  names.ApplicationTag
 	Type string
-	// Series obsolete from v9. Retained for tests.
+	// Deprecated: Series is obsolete from v9 and only retained for tests
+	// that write earlier versions. It will be removed along with import
+	// of application versions before 9.
 	Series               string
 	Subordinate          bool
 	CharmURL             string
 	Channel              string
 	CharmModifiedVersion int
 	ForceCharm           bool
+	Life                 string
 	PasswordHash         string
 	PodSpec              string
 	Placement            string
@@ -192,6 +221,8 @@ type ApplicationArgs struct {
 	MinUnits             int
 	Exposed              bool
 	ExposedEndpoints     map[string]ExposedEndpointArgs
+	ExposedAt            time.Time
+	ExposedBy            names.UserTag
 	EndpointBindings     map[string]string
 	ApplicationConfig    map[string]interface{}
 	CharmConfig          map[string]interface{}
@@ -213,7 +244,10 @@ func newApplication(args ApplicationArgs) *application {
 		Channel_:              args.Channel,
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
+		Life_:                 args.Life,
 		Exposed_:              args.Exposed,
+		ExposedAt_:            timePtr(args.ExposedAt),
+		ExposedBy_:            args.ExposedBy.Id(),
 		PasswordHash_:         args.PasswordHash,
 		PodSpec_:              args.PodSpec,
 		CloudService_:         newCloudService(args.CloudService),
@@ -227,7 +261,7 @@ func newApplication(args ApplicationArgs) *application {
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
-		StatusHistory_:        newStatusHistory(),
+		StatusHistory_:        NewStatusHistory(),
 		ProvisioningState_:    newProvisioningState(args.ProvisioningState),
 	}
 	app.setUnits(nil)
@@ -305,6 +339,23 @@ func (a *application) ExposedEndpoints() map[string]ExposedEndpoint {
 	return result
 }
 
+// ExposedAt implements Application.
+func (a *application) ExposedAt() time.Time {
+	var zero time.Time
+	if a.ExposedAt_ == nil {
+		return zero
+	}
+	return *a.ExposedAt_
+}
+
+// ExposedBy implements Application.
+func (a *application) ExposedBy() names.UserTag {
+	if a.ExposedBy_ == "" {
+		return names.UserTag{}
+	}
+	return names.NewUserTag(a.ExposedBy_)
+}
+
 // PasswordHash implements Application.
 func (a *application) PasswordHash() string {
 	return a.PasswordHash_
@@ -335,6 +386,11 @@ func (a *application) MinUnits() int {
 	return a.MinUnits_
 }
 
+// Life implements Application.
+func (a *application) Life() string {
+	return lifeOrAlive(a.Life_)
+}
+
 // EndpointBindings implements Application.
 func (a *application) EndpointBindings() map[string]string {
 	return a.EndpointBindings_
@@ -360,6 +416,21 @@ func (a *application) LeadershipSettings() map[string]interface{} {
 	return a.LeadershipSettings_
 }
 
+// Lease implements Application.
+func (a *application) Lease() Lease {
+	// To avoid typed nils check nil here.
+	if a.Lease_ == nil {
+		return nil
+	}
+	return a.Lease_
+}
+
+// SetLease implements Application.
+func (a *application) SetLease(args LeaseArgs) Lease {
+	a.Lease_ = newLease(args)
+	return a.Lease_
+}
+
 // StorageDirectives implements Application.
 func (a *application) StorageDirectives() map[string]StorageDirective {
 	result := make(map[string]StorageDirective)
@@ -451,13 +522,13 @@ func (a *application) unitNames() set.Strings {
 // AddUnit implements Application.
 func (a *application) AddUnit(args UnitArgs) Unit {
 	u := newUnit(args)
-	a.Units_.Units_ = append(a.Units_.Units_, u)
+	a.Units_.Units_ = insertByKey(a.Units_.Units_, u, unitKey)
 	return u
 }
 
 func (a *application) setUnits(unitList []*unit) {
 	a.Units_ = units{
-		Version: 3,
+		Version: 6,
 		Units_:  unitList,
 	}
 }
@@ -507,7 +578,7 @@ func (a *application) AddResource(args ResourceArgs) Resource {
 
 func (a *application) setResources(resourceList []*resource) {
 	a.Resources_ = resources{
-		Version:    1,
+		Version:    3,
 		Resources_: resourceList,
 	}
 }
@@ -631,25 +702,119 @@ func (a *application) setOffers(offers []*applicationOffer) {
 
 // Validate implements Application.
 func (a *application) Validate() error {
+	var errs validationErrors
+	a.validate(&errs, "")
+	return errs.first()
+}
+
+// validate records the application's failures, and those of its units,
+// against the path of the application in the model.
+func (a *application) validate(errs *validationErrors, path string) {
 	if a.Name_ == "" {
-		return errors.NotValidf("application missing name")
+		errs.add(path, errors.NotValidf("application missing name"))
+		return
+	}
+	if errs.add(path, validateLife("application", a.Name_, a.Life_)) {
+		return
 	}
 	if a.Status_ == nil {
-		return errors.NotValidf("application %q missing status", a.Name_)
+		if errs.add(path, errors.NotValidf("application %q missing status", a.Name_)) {
+			return
+		}
+	}
+	if a.Type_ == IAAS {
+		if a.PodSpec_ != "" {
+			if errs.add(path, errors.NotValidf("application %q pod spec on IAAS application", a.Name_)) {
+				return
+			}
+		}
+		if a.CloudService_ != nil {
+			if errs.add(path, errors.NotValidf("application %q cloud service on IAAS application", a.Name_)) {
+				return
+			}
+		}
+		if a.DesiredScale_ != 0 {
+			if errs.add(path, errors.NotValidf("application %q desired scale on IAAS application", a.Name_)) {
+				return
+			}
+		}
+		if a.ProvisioningState_ != nil {
+			if errs.add(path, errors.NotValidf("application %q provisioning state on IAAS application", a.Name_)) {
+				return
+			}
+		}
+	}
+	if errs.add(path, a.validateExposedEndpoints()) {
+		return
+	}
+	if a.CharmMetadata_ != nil {
+		if _, err := a.CharmMetadata_.AssumesExpression(); err != nil {
+			if errs.add(path, errors.Annotatef(err, "application %q charm metadata", a.Name_)) {
+				return
+			}
+		}
+		if a.CharmMetadata_.Subordinate_ != a.Subordinate_ {
+			err := errors.NotValidf("application %q subordinate %t with charm metadata subordinate %t",
+				a.Name_, a.Subordinate_, a.CharmMetadata_.Subordinate_)
+			if errs.add(path, err) {
+				return
+			}
+		}
+	}
+	if a.CharmActions_ != nil {
+		if err := a.CharmActions_.Validate(); err != nil {
+			if errs.add(path, errors.Annotatef(err, "application %q", a.Name_)) {
+				return
+			}
+		}
+	}
+	if a.CharmConfigs_ != nil {
+		if err := a.CharmConfigs_.Validate(); err != nil {
+			if errs.add(path, errors.Annotatef(err, "application %q", a.Name_)) {
+				return
+			}
+		}
+	}
+
+	if a.Offers_ != nil {
+		for i, offer := range a.Offers_.Offers {
+			if errs.add(fmt.Sprintf("%s.offers[%d]", path, i), a.validateOfferEndpoints(offer)) {
+				return
+			}
+		}
 	}
 
 	for _, resource := range a.Resources_.Resources_ {
 		if err := resource.Validate(); err != nil {
-			return errors.Annotatef(err, "resource %s", resource.Name_)
+			if errs.add(path, errors.Annotatef(err, "resource %s", resource.Name_)) {
+				return
+			}
 		}
 	}
 
+	resourceNames := set.NewStrings()
+	for _, resource := range a.Resources_.Resources_ {
+		resourceNames.Add(resource.Name_)
+	}
 	// If leader is set, it must match one of the units.
 	var leaderFound bool
 	// All of the applications units should also be valid.
-	for _, u := range a.Units() {
+	for i, u := range a.Units_.Units_ {
 		if err := u.Validate(); err != nil {
-			return errors.Trace(err)
+			if errs.add(fmt.Sprintf("%s.units[%d]", path, i), err) {
+				return
+			}
+			continue
+		}
+		// The resources a unit uses are those of its application, at
+		// revisions that may differ from the application's.
+		for _, resource := range u.Resources_.Resources_ {
+			if !resourceNames.Contains(resource.Name_) {
+				err := errors.NotValidf("unit %q resource %q not a resource of application %q", u.Name_, resource.Name_, a.Name_)
+				if errs.add(fmt.Sprintf("%s.units[%d]", path, i), err) {
+					return
+				}
+			}
 		}
 		// We know that the unit has a name, because it validated correctly.
 		if u.Name() == a.Leader_ {
@@ -657,7 +822,27 @@ func (a *application) Validate() error {
 		}
 	}
 	if a.Leader_ != "" && !leaderFound {
-		return errors.NotValidf("missing unit for leader %q", a.Leader_)
+		if errs.add(path, errors.NotValidf("missing unit for leader %q", a.Leader_)) {
+			return
+		}
+	}
+	errs.add(path, a.validateLease())
+}
+
+// validateLease checks that the lease of the application's leadership, if
+// there is one, is named for the application and held by its leader.
+func (a *application) validateLease() error {
+	if a.Lease_ == nil {
+		return nil
+	}
+	if err := a.Lease_.validate(); err != nil {
+		return errors.Annotatef(err, "application %q", a.Name_)
+	}
+	if a.Lease_.Name_ != a.Name_ {
+		return errors.NotValidf("application %q lease %q", a.Name_, a.Lease_.Name_)
+	}
+	if a.Lease_.Holder_ != a.Leader_ {
+		return errors.NotValidf("application %q lease holder %q not leader %q", a.Name_, a.Lease_.Holder_, a.Leader_)
 	}
 	return nil
 }
@@ -670,6 +855,61 @@ func (a *application) ProvisioningState() ProvisioningState {
 	return a.ProvisioningState_
 }
 
+// validateExposedEndpoints checks that expose settings, and when and by whom
+// the application was exposed, are only recorded for an exposed
+// application, and that the CIDRs in the settings are valid.
+func (a *application) validateExposedEndpoints() error {
+	if !a.Exposed_ && (a.ExposedAt_ != nil || a.ExposedBy_ != "") {
+		return errors.NotValidf("application %q exposed at or by when not exposed", a.Name_)
+	}
+	if a.ExposedBy_ != "" && !names.IsValidUser(a.ExposedBy_) {
+		return errors.NotValidf("application %q exposed by user %q", a.Name_, a.ExposedBy_)
+	}
+	if len(a.ExposedEndpoints_) == 0 {
+		return nil
+	}
+	if !a.Exposed_ {
+		return errors.NotValidf("application %q exposed endpoints when not exposed", a.Name_)
+	}
+	endpointNames := make([]string, 0, len(a.ExposedEndpoints_))
+	for name := range a.ExposedEndpoints_ {
+		endpointNames = append(endpointNames, name)
+	}
+	sort.Strings(endpointNames)
+	for _, name := range endpointNames {
+		if err := a.ExposedEndpoints_[name].validate(); err != nil {
+			return errors.Annotatef(err, "application %q exposed endpoint %q", a.Name_, name)
+		}
+	}
+	return nil
+}
+
+// validateOfferEndpoints checks that the endpoints of the offer are
+// relations that the application's charm provides or requires. Offers of
+// applications exported without their charm metadata aren't checked.
+func (a *application) validateOfferEndpoints(offer *applicationOffer) error {
+	if a.CharmMetadata_ == nil {
+		return nil
+	}
+	endpointNames := make([]string, 0, len(offer.Endpoints_))
+	for name := range offer.Endpoints_ {
+		endpointNames = append(endpointNames, name)
+	}
+	sort.Strings(endpointNames)
+	for _, name := range endpointNames {
+		endpoint := offer.Endpoints_[name]
+		if _, ok := a.CharmMetadata_.Provides_[endpoint]; ok {
+			continue
+		}
+		if _, ok := a.CharmMetadata_.Requires_[endpoint]; ok {
+			continue
+		}
+		return errors.NotValidf("application %q offer %q endpoint %q unknown charm relation %q",
+			a.Name_, offer.OfferName_, name, endpoint)
+	}
+	return nil
+}
+
 func importApplications(source map[string]interface{}) ([]*application, error) {
 	checker := versionedChecker("applications")
 	coerced, err := checker.Coerce(source, nil)
@@ -719,6 +959,9 @@ var applicationDeserializationFuncs = map[int]applicationDeserializationFunc{
 	11: importApplicationV11,
 	12: importApplicationV12,
 	13: importApplicationV13,
+	14: importApplicationV14,
+	15: importApplicationV15,
+	16: importApplicationV16,
 }
 
 func applicationV1Fields() (schema.Fields, schema.Defaults) {
@@ -754,9 +997,9 @@ func applicationV1Fields() (schema.Fields, schema.Defaults) {
 		"endpoint-bindings":   schema.Omit,
 		"application-config":  schema.Omit,
 	}
-	addAnnotationSchema(fields, defaults)
-	addConstraintsSchema(fields, defaults)
-	addStatusHistorySchema(fields)
+	AddAnnotationSchema(fields, defaults)
+	AddConstraintsSchema(fields, defaults)
+	AddStatusHistorySchema(fields)
 	return fields, defaults
 }
 
@@ -862,6 +1105,29 @@ func applicationV13Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func applicationV14Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := applicationV13Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
+func applicationV15Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := applicationV14Fields()
+	fields["lease"] = schema.FieldMap(leaseFields())
+	defaults["lease"] = schema.Omit
+	return fields, defaults
+}
+
+func applicationV16Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := applicationV15Fields()
+	fields["exposed-at"] = schema.Time()
+	fields["exposed-by"] = schema.String()
+	defaults["exposed-at"] = schema.Omit
+	defaults["exposed-by"] = ""
+	return fields, defaults
+}
+
 func importApplicationV1(source map[string]interface{}) (*application, error) {
 	fields, defaults := applicationV1Fields()
 	return importApplication(fields, defaults, 1, source)
@@ -927,6 +1193,21 @@ func importApplicationV13(source map[string]interface{}) (*application, error) {
 	return importApplication(fields, defaults, 13, source)
 }
 
+func importApplicationV14(source map[string]interface{}) (*application, error) {
+	fields, defaults := applicationV14Fields()
+	return importApplication(fields, defaults, 14, source)
+}
+
+func importApplicationV15(source map[string]interface{}) (*application, error) {
+	fields, defaults := applicationV15Fields()
+	return importApplication(fields, defaults, 15, source)
+}
+
+func importApplicationV16(source map[string]interface{}) (*application, error) {
+	fields, defaults := applicationV16Fields()
+	return importApplication(fields, defaults, 16, source)
+}
+
 func importApplication(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*application, error) {
 	checker := schema.FieldMap(fields, defaults)
 
@@ -951,7 +1232,7 @@ func importApplication(fields schema.Fields, defaults schema.Defaults, importVer
 		CharmConfig_:          valid["settings"].(map[string]interface{}),
 		Leader_:               valid["leader"].(string),
 		LeadershipSettings_:   valid["leadership-settings"].(map[string]interface{}),
-		StatusHistory_:        newStatusHistory(),
+		StatusHistory_:        NewStatusHistory(),
 	}
 
 	if importVersion >= 2 {
@@ -1074,9 +1355,24 @@ func importApplication(fields schema.Fields, defaults schema.Defaults, importVer
 		}
 	}
 
-	result.importAnnotations(valid)
+	if importVersion >= 14 {
+		result.Life_ = valid["life"].(string)
+	}
+
+	if importVersion >= 15 {
+		if leaseMap, ok := valid["lease"]; ok {
+			result.Lease_ = importLease(leaseMap.(map[string]interface{}))
+		}
+	}
+
+	if importVersion >= 16 {
+		result.ExposedAt_ = fieldToTimePtr(valid, "exposed-at")
+		result.ExposedBy_ = valid["exposed-by"].(string)
+	}
+
+	result.ImportAnnotations(valid)
 
-	if err := result.importStatusHistory(valid); err != nil {
+	if err := result.ImportStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -4,6 +4,9 @@
 package description
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -53,13 +56,13 @@ func minimalApplicationMap() map[interface{}]interface{} {
 		},
 		"metrics-creds": "c2Vrcml0", // base64 encoded
 		"resources": map[interface{}]interface{}{
-			"version": 1,
+			"version": 3,
 			"resources": []interface{}{
 				minimalResourceMap(),
 			},
 		},
 		"units": map[interface{}]interface{}{
-			"version": 3,
+			"version": 6,
 			"units": []interface{}{
 				minimalUnitMap(),
 			},
@@ -100,7 +103,7 @@ func minimalApplicationMapCAAS() map[interface{}]interface{} {
 		},
 	}
 	result["units"] = map[interface{}]interface{}{
-		"version": 3,
+		"version": 6,
 		"units": []interface{}{
 			minimalUnitMapCAAS(),
 		},
@@ -291,6 +294,84 @@ func (s *ApplicationSerializationSuite) TestMinimalCAASApplicationValid(c *gc.C)
 	c.Assert(application.Validate(), jc.ErrorIsNil)
 }
 
+func (s *ApplicationSerializationSuite) TestIAASApplicationPodSpecNotValid(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.PodSpec = "some-spec"
+	application := minimalApplication(args)
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" pod spec on IAAS application not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestIAASApplicationCloudServiceNotValid(c *gc.C) {
+	application := minimalApplication()
+	application.SetCloudService(CloudServiceArgs{ProviderId: "some-provider"})
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" cloud service on IAAS application not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestIAASApplicationDesiredScaleNotValid(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.DesiredScale = 3
+	application := minimalApplication(args)
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" desired scale on IAAS application not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestIAASApplicationProvisioningStateNotValid(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.ProvisioningState = &ProvisioningStateArgs{Scaling: true, ScaleTarget: 3}
+	application := minimalApplication(args)
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" provisioning state on IAAS application not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateCharmAssumes(c *gc.C) {
+	application := minimalApplication(minimalApplicationArgs(IAAS))
+	application.SetCharmMetadata(CharmMetadataArgs{Name: "ubuntu", Assumes: `["juju >= 3.1"]`})
+	c.Assert(application.Validate(), jc.ErrorIsNil)
+
+	application.SetCharmMetadata(CharmMetadataArgs{Name: "ubuntu", Assumes: `["juju => 3.1"]`})
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" charm metadata: assumes feature "juju => 3.1" not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateCharmSubordinate(c *gc.C) {
+	application := minimalApplication(minimalApplicationArgs(IAAS))
+	application.SetCharmMetadata(CharmMetadataArgs{Name: "ubuntu"})
+	c.Assert(application.Validate(), jc.ErrorIsNil)
+
+	application.SetCharmMetadata(CharmMetadataArgs{Name: "ubuntu", Subordinate: true})
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" subordinate false with charm metadata subordinate true not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateCharmActionsAndConfigs(c *gc.C) {
+	var application Application = minimalApplication(minimalApplicationArgs(IAAS))
+	application.SetCharmActions(CharmActionsArgs{
+		Actions: map[string]CharmAction{"backup": charmAction{Description_: "take a backup"}},
+	})
+	application.SetCharmConfigs(CharmConfigsArgs{
+		Configs: map[string]CharmConfig{"port": charmConfig{Type_: "int", Default_: 8080}},
+	})
+	c.Assert(application.Validate(), jc.ErrorIsNil)
+	c.Assert(application.CharmActions().Actions(), gc.HasLen, 1)
+	c.Assert(application.CharmConfigs().Configs(), gc.HasLen, 1)
+
+	application.SetCharmActions(CharmActionsArgs{
+		Actions: map[string]CharmAction{"back_up": charmAction{}},
+	})
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu": charm action name "back_up" not valid`)
+
+	application.SetCharmActions(CharmActionsArgs{})
+	application.SetCharmConfigs(CharmConfigsArgs{
+		Configs: map[string]CharmConfig{"port": charmConfig{Type_: "port"}},
+	})
+	err = application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu": charm config "port" type "port" not valid`)
+}
+
 func (s *ApplicationSerializationSuite) TestMinimalMatchesCAAS(c *gc.C) {
 	args := minimalApplicationArgs(CAAS)
 	bytes, err := yaml.Marshal(minimalApplication(args))
@@ -374,7 +455,7 @@ func (s *ApplicationSerializationSuite) exportImportVersion(c *gc.C, application
 }
 
 func (s *ApplicationSerializationSuite) exportImportLatest(c *gc.C, application_ *application) *application {
-	return s.exportImportVersion(c, application_, 13)
+	return s.exportImportVersion(c, application_, 16)
 }
 
 func (s *ApplicationSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
@@ -713,6 +794,71 @@ func (s *ApplicationSerializationSuite) TestExposeMetadata(c *gc.C) {
 	c.Assert(ep1.ExposeToCIDRs(), gc.DeepEquals, []string{"192.168.42.0/24"})
 }
 
+func (s *ApplicationSerializationSuite) TestValidateExposedEndpoints(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.Exposed = true
+	args.ExposedEndpoints = map[string]ExposedEndpointArgs{
+		"endpoint0": {ExposeToCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"}},
+	}
+	app := minimalApplication(args)
+	c.Assert(app.Validate(), jc.ErrorIsNil)
+
+	app.ExposedEndpoints_["endpoint0"].ExposeToCIDRs_ = []string{"10.0.0.1"}
+	err := app.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" exposed endpoint "endpoint0": CIDR "10.0.0.1" not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateExposedEndpointsNotExposed(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.ExposedEndpoints = map[string]ExposedEndpointArgs{
+		"endpoint0": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	app := minimalApplication(args)
+	err := app.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" exposed endpoints when not exposed not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestExposedAtAndBy(c *gc.C) {
+	exposedAt := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	args := minimalApplicationArgs(IAAS)
+	args.Exposed = true
+	args.ExposedAt = exposedAt
+	args.ExposedBy = names.NewUserTag("bob")
+	initial := minimalApplication(args)
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	application := s.exportImportLatest(c, initial)
+	c.Assert(application.ExposedAt(), gc.Equals, exposedAt)
+	c.Assert(application.ExposedBy(), gc.Equals, names.NewUserTag("bob"))
+
+	application = s.exportImportVersion(c, initial, 15)
+	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedAt().IsZero(), jc.IsTrue)
+	c.Assert(application.ExposedBy(), gc.Equals, names.UserTag{})
+}
+
+func (s *ApplicationSerializationSuite) TestValidateExposedAtNotExposed(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.Exposed = false
+	args.ExposedAt = time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	app := minimalApplication(args)
+	err := app.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" exposed at or by when not exposed not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateExposedBy(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.Exposed = true
+	app := minimalApplication(args)
+	app.ExposedBy_ = "not a user!"
+	err := app.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" exposed by user "not a user!" not valid`)
+}
+
 func (s *ApplicationSerializationSuite) TestApplicationSeriesToPlatform(c *gc.C) {
 	appInput := map[string]interface{}{
 		"version": 8,
@@ -768,3 +914,93 @@ func (s *ApplicationSerializationSuite) TestApplicationSeriesToPlatform(c *gc.C)
 
 	c.Assert(app[0].CharmOrigin().Platform(), gc.Equals, "unknown/ubuntu/20.04")
 }
+
+func (s *ApplicationSerializationSuite) TestLife(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.Life = Dying
+	initial := minimalApplication(args)
+	c.Assert(initial.Life(), gc.Equals, Dying)
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	application := s.exportImportLatest(c, initial)
+	c.Assert(application.Life(), gc.Equals, Dying)
+
+	application = s.exportImportVersion(c, initial, 13)
+	c.Assert(application.Life(), gc.Equals, Alive)
+}
+
+func (s *ApplicationSerializationSuite) TestLease(c *gc.C) {
+	initial := minimalApplication()
+	c.Assert(initial.Lease(), gc.IsNil)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	initial.SetLease(LeaseArgs{
+		Name:   "ubuntu",
+		Holder: "ubuntu/0",
+		Start:  start,
+		Expiry: start.Add(time.Minute),
+		Pinned: true,
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	application := s.exportImportLatest(c, initial)
+	lease := application.Lease()
+	c.Assert(lease, gc.NotNil)
+	c.Check(lease.Name(), gc.Equals, "ubuntu")
+	c.Check(lease.Holder(), gc.Equals, "ubuntu/0")
+	c.Check(lease.Start(), gc.Equals, start)
+	c.Check(lease.Expiry(), gc.Equals, start.Add(time.Minute))
+	c.Check(lease.Pinned(), jc.IsTrue)
+
+	application = s.exportImportVersion(c, initial, 14)
+	c.Assert(application.Lease(), gc.IsNil)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateLease(c *gc.C) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, test := range []struct {
+		args LeaseArgs
+		err  string
+	}{{
+		args: LeaseArgs{Name: "ubuntu", Start: start, Expiry: start},
+		err:  `application "ubuntu": lease "ubuntu" missing holder not valid`,
+	}, {
+		args: LeaseArgs{Name: "ubuntu", Holder: "ubuntu/0", Start: start, Expiry: start.Add(-time.Second)},
+		err:  `application "ubuntu": lease "ubuntu" expiry before start not valid`,
+	}, {
+		args: LeaseArgs{Name: "mysql", Holder: "ubuntu/0", Start: start, Expiry: start},
+		err:  `application "ubuntu" lease "mysql" not valid`,
+	}, {
+		args: LeaseArgs{Name: "ubuntu", Holder: "ubuntu/1", Start: start, Expiry: start},
+		err:  `application "ubuntu" lease holder "ubuntu/1" not leader "ubuntu/0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		application := minimalApplication()
+		application.SetLease(test.args)
+		err := application.Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ApplicationSerializationSuite) TestValidateUnitResources(c *gc.C) {
+	application := minimalApplication()
+	resource := application.AddResource(ResourceArgs{Name: "data"})
+	resource.SetApplicationRevision(ResourceRevisionArgs{Revision: 3})
+	unit := application.Units()[0]
+	unit.AddResource(UnitResourceArgs{Name: "data", RevisionArgs: ResourceRevisionArgs{Revision: 2}})
+	c.Assert(application.Validate(), jc.ErrorIsNil)
+
+	unit.AddResource(UnitResourceArgs{Name: "config", RevisionArgs: ResourceRevisionArgs{Revision: 1}})
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" resource "config" not a resource of application "ubuntu" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSerializationSuite) TestValidateLife(c *gc.C) {
+	args := minimalApplicationArgs(IAAS)
+	args.Life = "zombie"
+	err := minimalApplication(args).Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
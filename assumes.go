@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// AssumesExpression is a node in the tree of features a charm assumes
+// the model provides. A node is either a feature, optionally with a
+// version constraint, or a list of nodes of which all or any must be
+// satisfied.
+type AssumesExpression struct {
+	// Feature names the feature for a leaf node, e.g. "k8s-api".
+	Feature string
+	// Op and Version constrain the version of the feature, as in
+	// "juju >= 3.1". Op is one of ">=" and "<".
+	Op      string
+	Version string
+
+	AllOf []AssumesExpression
+	AnyOf []AssumesExpression
+}
+
+var (
+	assumesFeatureRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	assumesVersionRE = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+)
+
+// ParseAssumes parses the assumes block of charm metadata, as stored in
+// CharmMetadata.Assumes. A top level list of expressions must all be
+// satisfied. An empty block returns a nil expression.
+func ParseAssumes(assumes string) (*AssumesExpression, error) {
+	var source interface{}
+	if err := yaml.Unmarshal([]byte(assumes), &source); err != nil {
+		return nil, errors.NotValidf("assumes %q", assumes)
+	}
+	switch value := source.(type) {
+	case nil:
+		return nil, nil
+	case map[interface{}]interface{}:
+		if len(value) == 0 {
+			return nil, nil
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			return nil, nil
+		}
+		expressions, err := parseAssumesList(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &AssumesExpression{AllOf: expressions}, nil
+	}
+	expression, err := parseAssumesExpression(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &expression, nil
+}
+
+func parseAssumesList(source []interface{}) ([]AssumesExpression, error) {
+	result := make([]AssumesExpression, 0, len(source))
+	for _, value := range source {
+		expression, err := parseAssumesExpression(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, expression)
+	}
+	return result, nil
+}
+
+func parseAssumesExpression(source interface{}) (AssumesExpression, error) {
+	switch value := source.(type) {
+	case string:
+		return parseAssumesFeature(value)
+	case map[interface{}]interface{}:
+		if len(value) != 1 {
+			return AssumesExpression{}, errors.NotValidf("assumes block with %d keys", len(value))
+		}
+		for key, child := range value {
+			list, ok := child.([]interface{})
+			if !ok {
+				return AssumesExpression{}, errors.NotValidf("assumes %v block %T", key, child)
+			}
+			expressions, err := parseAssumesList(list)
+			if err != nil {
+				return AssumesExpression{}, errors.Trace(err)
+			}
+			switch key {
+			case "all-of":
+				return AssumesExpression{AllOf: expressions}, nil
+			case "any-of":
+				return AssumesExpression{AnyOf: expressions}, nil
+			}
+			return AssumesExpression{}, errors.NotValidf("assumes block %q", key)
+		}
+	}
+	return AssumesExpression{}, errors.NotValidf("assumes expression %v", source)
+}
+
+func parseAssumesFeature(source string) (AssumesExpression, error) {
+	fields := strings.Fields(source)
+	switch {
+	case len(fields) == 1 && assumesFeatureRE.MatchString(fields[0]):
+		return AssumesExpression{Feature: fields[0]}, nil
+	case len(fields) == 3 && assumesFeatureRE.MatchString(fields[0]) &&
+		(fields[1] == ">=" || fields[1] == "<") && assumesVersionRE.MatchString(fields[2]):
+		return AssumesExpression{Feature: fields[0], Op: fields[1], Version: fields[2]}, nil
+	}
+	return AssumesExpression{}, errors.NotValidf("assumes feature %q", source)
+}
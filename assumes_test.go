@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type AssumesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AssumesSuite{})
+
+func (*AssumesSuite) TestParseEmpty(c *gc.C) {
+	for _, assumes := range []string{"", "{}", "[]"} {
+		expression, err := ParseAssumes(assumes)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(expression, gc.IsNil)
+	}
+}
+
+func (*AssumesSuite) TestParse(c *gc.C) {
+	expression, err := ParseAssumes(`
+- juju >= 3.1
+- any-of:
+  - k8s-api
+  - all-of:
+    - lxd
+    - juju < 4
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expression, jc.DeepEquals, &AssumesExpression{
+		AllOf: []AssumesExpression{
+			{Feature: "juju", Op: ">=", Version: "3.1"},
+			{AnyOf: []AssumesExpression{
+				{Feature: "k8s-api"},
+				{AllOf: []AssumesExpression{
+					{Feature: "lxd"},
+					{Feature: "juju", Op: "<", Version: "4"},
+				}},
+			}},
+		},
+	})
+}
+
+func (*AssumesSuite) TestParseJSON(c *gc.C) {
+	expression, err := ParseAssumes(`{"any-of": ["k8s-api", "juju >= 3"]}`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expression, jc.DeepEquals, &AssumesExpression{
+		AnyOf: []AssumesExpression{
+			{Feature: "k8s-api"},
+			{Feature: "juju", Op: ">=", Version: "3"},
+		},
+	})
+}
+
+func (*AssumesSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		assumes string
+		err     string
+	}{{
+		assumes: `[Bad-Feature]`,
+		err:     `assumes feature "Bad-Feature" not valid`,
+	}, {
+		assumes: `["juju == 3"]`,
+		err:     `assumes feature "juju == 3" not valid`,
+	}, {
+		assumes: `["juju >= three"]`,
+		err:     `assumes feature "juju >= three" not valid`,
+	}, {
+		assumes: `[{none-of: [lxd]}]`,
+		err:     `assumes block "none-of" not valid`,
+	}, {
+		assumes: `[{any-of: lxd}]`,
+		err:     `assumes any-of block string not valid`,
+	}, {
+		assumes: `[{any-of: [lxd], all-of: [lxd]}]`,
+		err:     `assumes block with 2 keys not valid`,
+	}, {
+		assumes: `[`,
+		err:     `assumes "\[" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.assumes)
+		_, err := ParseAssumes(test.assumes)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
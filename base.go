@@ -0,0 +1,45 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2/series"
+)
+
+// SeriesToBase returns the base, such as "ubuntu@22.04", for the given
+// series, such as "jammy".
+func SeriesToBase(s string) (string, error) {
+	os, err := series.GetOSFromSeries(s)
+	if err != nil {
+		return "", errors.NotValidf("series %q", s)
+	}
+	version, err := series.SeriesVersion(s)
+	if err != nil {
+		return "", errors.NotValidf("series %q", s)
+	}
+	return fmt.Sprintf("%s@%s", strings.ToLower(os.String()), version), nil
+}
+
+// BaseToSeries returns the series, such as "jammy", for the given base,
+// such as "ubuntu@22.04". Any risk following the version in the base, as
+// in "ubuntu@22.04/stable", is ignored.
+func BaseToSeries(base string) (string, error) {
+	parts := strings.SplitN(base, "@", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", errors.NotValidf("base %q", base)
+	}
+	version := strings.SplitN(parts[1], "/", 2)[0]
+	s, err := series.VersionSeries(version)
+	if err != nil {
+		return "", errors.NotValidf("base %q", base)
+	}
+	if os, err := series.GetOSFromSeries(s); err != nil || !strings.EqualFold(os.String(), parts[0]) {
+		return "", errors.NotValidf("base %q", base)
+	}
+	return s, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type BaseSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BaseSuite{})
+
+func (*BaseSuite) TestSeriesToBase(c *gc.C) {
+	for _, test := range []struct {
+		series string
+		base   string
+	}{
+		{"focal", "ubuntu@20.04"},
+		{"jammy", "ubuntu@22.04"},
+	} {
+		base, err := SeriesToBase(test.series)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(base, gc.Equals, test.base)
+	}
+}
+
+func (*BaseSuite) TestSeriesToBaseInvalid(c *gc.C) {
+	_, err := SeriesToBase("nonsense")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `series "nonsense" not valid`)
+}
+
+func (*BaseSuite) TestBaseToSeries(c *gc.C) {
+	for _, test := range []struct {
+		base   string
+		series string
+	}{
+		{"ubuntu@20.04", "focal"},
+		{"ubuntu@22.04/stable", "jammy"},
+	} {
+		s, err := BaseToSeries(test.base)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(s, gc.Equals, test.series)
+	}
+}
+
+func (*BaseSuite) TestBaseToSeriesInvalid(c *gc.C) {
+	for _, base := range []string{"", "ubuntu", "ubuntu@", "ubuntu@1.0", "centos@20.04"} {
+		_, err := BaseToSeries(base)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `base ".*" not valid`)
+	}
+}
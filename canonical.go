@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+)
+
+// CanonicalSerialize serializes the model as Serialize does, except that
+// the entities of each collection are sorted by their natural keys, such
+// as ids and names, even those a caller appended directly to the model's
+// internal slices. Two exports of the same model then serialize the same,
+// so that they can be compared line by line. The keys of maps are always
+// written in sorted order. The model itself isn't changed.
+func CanonicalSerialize(m Model) ([]byte, error) {
+	var canonical *model
+	switch copied := m.Copy().(type) {
+	case *model:
+		canonical = copied
+	case *synchronizedModel:
+		canonical, _ = copied.model.(*model)
+	}
+	if canonical == nil {
+		return nil, errors.NotSupportedf("canonical serialization of %T", m)
+	}
+	canonical.canonicalize()
+	return marshalYAML(canonical)
+}
+
+// canonicalize sorts the entities of each of the model's collections by
+// their natural keys, the order the adders keep them in. It is called once
+// a model is imported, so that the getters need not sort.
+func (m *model) canonicalize() {
+	sortByKey(m.Users_.Users_, userKey)
+	sortMachines(m.Machines_.Machines_)
+	sortByKey(m.Applications_.Applications_, applicationKey)
+	for _, application := range m.Applications_.Applications_ {
+		sortByKey(application.Units_.Units_, unitKey)
+	}
+	sortByKey(m.Charms_.Charms_, charmKey)
+	sortByKey(m.Relations_.Relations_, relationKey)
+	sortByKey(m.RemoteEntities_.RemoteEntities, remoteEntityKey)
+	sortByKey(m.RelationNetworks_.RelationNetworks, relationNetworkKey)
+	sortByKey(m.OfferConnections_.OfferConnections, offerConnectionKey)
+	sortByKey(m.ExternalControllers_.ExternalControllers, externalControllerKey)
+	sortByKey(m.Spaces_.Spaces_, spaceKey)
+	sortByKey(m.LinkLayerDevices_.LinkLayerDevices_, func(d *linklayerdevice) string { return linkLayerDeviceKey(d) })
+	sortByKey(m.IPAddresses_.IPAddresses_, func(a *ipaddress) string { return ipAddressKey(a) })
+	sortByKey(m.Subnets_.Subnets_, subnetKey)
+	sortByKey(m.CloudImageMetadata_.CloudImageMetadata_, imageMetadataKey)
+	sortByKey(m.Actions_.Actions_, actionKey)
+	sortByKey(m.Operations_.Operations_, operationKey)
+	sortByKey(m.SSHHostKeys_.SSHHostKeys_, sshHostKeyKey)
+	sortByKey(m.VirtualHostKeys_.VirtualHostKeys_, virtualHostKeyKey)
+	sortByKey(m.Volumes_.Volumes_, volumeKey)
+	sortByKey(m.Filesystems_.Filesystems_, filesystemKey)
+	sortByKey(m.Storages_.Storages_, storageKey)
+	sortByKey(m.StoragePools_.Pools_, storagePoolKey)
+	sortByKey(m.FirewallRules_.FirewallRules, firewallRuleKey)
+	sortByKey(m.RemoteApplications_.RemoteApplications, remoteApplicationKey)
+	sortByKey(m.SecretBackends_.SecretBackends_, secretBackendKey)
+	sortByKey(m.Secrets_.Secrets_, secretKey)
+	sortByKey(m.RemoteSecrets_.RemoteSecrets_, remoteSecretKey)
+}
+
+// sortMachines sorts the machines, and the containers of each, by id.
+func sortMachines(machines []*machine) {
+	sortByKey(machines, machineKey)
+	for _, machine := range machines {
+		sortMachines(machine.Containers_)
+	}
+}
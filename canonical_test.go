@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CanonicalSuite struct{}
+
+var _ = gc.Suite(&CanonicalSuite{})
+
+// canonicalModel returns a model with machines, applications, units and
+// spaces. The adders keep them sorted, so if reversed is set the model's
+// slices are reversed afterwards, as a document listing them out of order
+// would leave them.
+func canonicalModel(reversed bool) Model {
+	model := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid", "name": "canonical"},
+	}).(*model)
+	for _, id := range []string{"1", "2", "10"} {
+		machine := model.AddMachine(MachineArgs{Id: names.NewMachineTag(id)})
+		if id == "2" {
+			for _, container := range []string{"2/lxd/9", "2/lxd/10"} {
+				machine.AddContainer(MachineArgs{Id: names.NewMachineTag(container)})
+			}
+		}
+		model.AddSpace(SpaceArgs{Id: id, Name: "space-" + id})
+	}
+	for _, name := range []string{"app9", "app10", "mysql"} {
+		application := model.AddApplication(ApplicationArgs{Tag: names.NewApplicationTag(name)})
+		for _, unit := range []string{name + "/3", name + "/11"} {
+			application.AddUnit(UnitArgs{Tag: names.NewUnitTag(unit)})
+		}
+	}
+	if reversed {
+		reverse(model.Machines_.Machines_)
+		reverse(model.Machines_.Machines_[1].Containers_)
+		reverse(model.Spaces_.Spaces_)
+		reverse(model.Applications_.Applications_)
+		for _, application := range model.Applications_.Applications_ {
+			reverse(application.Units_.Units_)
+		}
+	}
+	return model
+}
+
+func reverse[T any](entities []T) {
+	for i, j := 0, len(entities)-1; i < j; i, j = i+1, j-1 {
+		entities[i], entities[j] = entities[j], entities[i]
+	}
+}
+
+func (*CanonicalSuite) TestSameModelsSerializeTheSame(c *gc.C) {
+	ordered, reversed := canonicalModel(false), canonicalModel(true)
+
+	orderedBytes, err := Serialize(ordered)
+	c.Assert(err, jc.ErrorIsNil)
+	reversedBytes, err := Serialize(reversed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reversedBytes), gc.Not(gc.Equals), string(orderedBytes))
+
+	orderedBytes, err = CanonicalSerialize(ordered)
+	c.Assert(err, jc.ErrorIsNil)
+	reversedBytes, err = CanonicalSerialize(reversed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reversedBytes), gc.Equals, string(orderedBytes))
+
+	// The canonical order is the natural order of the keys.
+	canonical := reversed.Copy().(*model)
+	canonical.canonicalize()
+	var ids []string
+	for _, machine := range canonical.Machines_.Machines_ {
+		ids = append(ids, machine.Id_)
+	}
+	c.Check(ids, jc.DeepEquals, []string{"1", "2", "10"})
+	var containers []string
+	for _, container := range canonical.Machines_.Machines_[1].Containers_ {
+		containers = append(containers, container.Id_)
+	}
+	c.Check(containers, jc.DeepEquals, []string{"2/lxd/9", "2/lxd/10"})
+	var units []string
+	for _, unit := range canonical.Applications_.Applications_[0].Units_.Units_ {
+		units = append(units, unit.Name_)
+	}
+	c.Check(canonical.Applications_.Applications_[0].Name_, gc.Equals, "app9")
+	c.Check(units, jc.DeepEquals, []string{"app9/3", "app9/11"})
+}
+
+func (*CanonicalSuite) TestModelUnchanged(c *gc.C) {
+	reversed := canonicalModel(true)
+	before, err := Serialize(reversed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = CanonicalSerialize(reversed)
+	c.Assert(err, jc.ErrorIsNil)
+	after, err := Serialize(reversed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(after), gc.Equals, string(before))
+}
+
+func (*CanonicalSuite) TestSynchronizedModel(c *gc.C) {
+	expected, err := CanonicalSerialize(canonicalModel(false))
+	c.Assert(err, jc.ErrorIsNil)
+	serialized, err := CanonicalSerialize(NewSynchronizedModel(canonicalModel(true)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(serialized), gc.Equals, string(expected))
+}
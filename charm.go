@@ -0,0 +1,138 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// Charm represents a charm stored by the controller for the model. A
+// charm deployed by several applications is described once.
+type Charm interface {
+	URL() string
+	Revision() int
+	StoragePath() string
+	SHA256() string
+	LXDProfile() string
+}
+
+type charms struct {
+	Version int      `yaml:"version"`
+	Charms_ []*charm `yaml:"charms"`
+}
+
+type charm struct {
+	URL_         string `yaml:"url"`
+	Revision_    int    `yaml:"revision"`
+	StoragePath_ string `yaml:"storage-path,omitempty"`
+	SHA256_      string `yaml:"sha256,omitempty"`
+	LXDProfile_  string `yaml:"lxd-profile,omitempty"`
+}
+
+// CharmArgs is an argument struct used to add a charm to the Model.
+type CharmArgs struct {
+	URL         string
+	Revision    int
+	StoragePath string
+	SHA256      string
+	LXDProfile  string
+}
+
+func newCharm(args CharmArgs) *charm {
+	return &charm{
+		URL_:         args.URL,
+		Revision_:    args.Revision,
+		StoragePath_: args.StoragePath,
+		SHA256_:      args.SHA256,
+		LXDProfile_:  args.LXDProfile,
+	}
+}
+
+// URL implements Charm.
+func (c *charm) URL() string {
+	return c.URL_
+}
+
+// Revision implements Charm.
+func (c *charm) Revision() int {
+	return c.Revision_
+}
+
+// StoragePath implements Charm.
+func (c *charm) StoragePath() string {
+	return c.StoragePath_
+}
+
+// SHA256 implements Charm.
+func (c *charm) SHA256() string {
+	return c.SHA256_
+}
+
+// LXDProfile implements Charm.
+func (c *charm) LXDProfile() string {
+	return c.LXDProfile_
+}
+
+func importCharms(source map[string]interface{}) ([]*charm, error) {
+	checker := versionedChecker("charms")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "charms version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	getFields, ok := charmFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["charms"].([]interface{})
+	return importCharmList(sourceList, schema.FieldMap(getFields()), version)
+}
+
+func importCharmList(sourceList []interface{}, checker schema.Checker, version int) ([]*charm, error) {
+	result := make([]*charm, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for charm %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "charm %d v%d schema check failed", i, version)
+		}
+		valid := coerced.(map[string]interface{})
+		// From here we know that the map returned from the schema coercion
+		// contains fields of the right type.
+		result[i] = &charm{
+			URL_:         valid["url"].(string),
+			Revision_:    int(valid["revision"].(int64)),
+			StoragePath_: valid["storage-path"].(string),
+			SHA256_:      valid["sha256"].(string),
+			LXDProfile_:  valid["lxd-profile"].(string),
+		}
+	}
+	return result, nil
+}
+
+var charmFieldsFuncs = map[int]fieldsFunc{
+	1: charmV1Fields,
+}
+
+func charmV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"url":          schema.String(),
+		"revision":     schema.Int(),
+		"storage-path": schema.String(),
+		"sha256":       schema.String(),
+		"lxd-profile":  schema.String(),
+	}
+	defaults := schema.Defaults{
+		"storage-path": "",
+		"sha256":       "",
+		"lxd-profile":  "",
+	}
+	return fields, defaults
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type CharmSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&CharmSerializationSuite{})
+
+func (s *CharmSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "charms"
+	s.sliceName = "charms"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importCharms(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["charms"] = []interface{}{}
+	}
+}
+
+func minimalCharmMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"url":      "ch:amd64/jammy/ubuntu-1",
+		"revision": 1,
+	}
+}
+
+func minimalCharmArgs() CharmArgs {
+	return CharmArgs{
+		URL:      "ch:amd64/jammy/ubuntu-1",
+		Revision: 1,
+	}
+}
+
+func (*CharmSerializationSuite) TestNew(c *gc.C) {
+	charm := newCharm(CharmArgs{
+		URL:         "ch:amd64/jammy/ubuntu-1",
+		Revision:    1,
+		StoragePath: "charms/ubuntu-1",
+		SHA256:      "deadbeef",
+		LXDProfile:  "config: {}",
+	})
+	c.Check(charm.URL(), gc.Equals, "ch:amd64/jammy/ubuntu-1")
+	c.Check(charm.Revision(), gc.Equals, 1)
+	c.Check(charm.StoragePath(), gc.Equals, "charms/ubuntu-1")
+	c.Check(charm.SHA256(), gc.Equals, "deadbeef")
+	c.Check(charm.LXDProfile(), gc.Equals, "config: {}")
+}
+
+func (*CharmSerializationSuite) TestMinimalMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(newCharm(minimalCharmArgs()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, minimalCharmMap())
+}
+
+func (*CharmSerializationSuite) TestBadSchema(c *gc.C) {
+	m := minimalCharmMap()
+	m["revision"] = "one"
+	container := map[string]interface{}{
+		"version": 1,
+		"charms":  []interface{}{m},
+	}
+	_, err := importCharms(container)
+	c.Assert(err, gc.ErrorMatches, `charm 0 v1 schema check failed: revision: expected int, got string\("one"\)`)
+}
+
+func (s *CharmSerializationSuite) TestRoundTrip(c *gc.C) {
+	in := newCharm(CharmArgs{
+		URL:         "ch:amd64/jammy/ubuntu-1",
+		Revision:    1,
+		StoragePath: "charms/ubuntu-1",
+		SHA256:      "deadbeef",
+		LXDProfile:  "config: {}",
+	})
+	bytes, err := yaml.Marshal(&charms{Version: 1, Charms_: []*charm{in}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := importCharms(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, []*charm{in})
+}
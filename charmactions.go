@@ -4,6 +4,9 @@
 package description
 
 import (
+	"regexp"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
@@ -45,6 +48,24 @@ func (a *charmActions) Actions() map[string]CharmAction {
 	return actions
 }
 
+// validCharmActionName matches the action names that charms can declare.
+var validCharmActionName = regexp.MustCompile("^[a-z](?:[a-z-]*[a-z])?$")
+
+// Validate checks that the actions have names that charms can declare.
+func (a *charmActions) Validate() error {
+	names := make([]string, 0, len(a.Actions_))
+	for name := range a.Actions_ {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !validCharmActionName.MatchString(name) {
+			return errors.NotValidf("charm action name %q", name)
+		}
+	}
+	return nil
+}
+
 func importCharmActions(source map[string]interface{}) (*charmActions, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -169,3 +169,14 @@ func (s *CharmActionsSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
 	originResult := s.exportImportVersion(c, originV1, 1)
 	c.Assert(*originResult, jc.DeepEquals, originLatest)
 }
+
+func (s *CharmActionsSerializationSuite) TestValidate(c *gc.C) {
+	c.Assert(maximalCharmActions().Validate(), jc.ErrorIsNil)
+
+	actions := newCharmActions(CharmActionsArgs{
+		Actions: map[string]CharmAction{
+			"Echo": charmAction{},
+		},
+	})
+	c.Assert(actions.Validate(), gc.ErrorMatches, `charm action name "Echo" not valid`)
+}
@@ -4,6 +4,8 @@
 package description
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
@@ -44,6 +46,60 @@ func (c *charmConfigs) Configs() map[string]CharmConfig {
 	return configs
 }
 
+// Validate checks that the configs have the option types that charms can
+// declare, and that their defaults are of those types.
+func (c *charmConfigs) Validate() error {
+	names := make([]string, 0, len(c.Configs_))
+	for name := range c.Configs_ {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "" {
+			return errors.NotValidf("charm config with empty name")
+		}
+		config := c.Configs_[name]
+		if !validCharmConfigDefault(config.Type_, config.Default_) {
+			if _, known := charmConfigTypes[config.Type_]; !known {
+				return errors.NotValidf("charm config %q type %q", name, config.Type_)
+			}
+			return errors.NotValidf("charm config %q default %v for type %q", name, config.Default_, config.Type_)
+		}
+	}
+	return nil
+}
+
+// charmConfigTypes holds the option types that charms can declare.
+var charmConfigTypes = map[string]struct{}{
+	"string":  {},
+	"int":     {},
+	"float":   {},
+	"boolean": {},
+	"secret":  {},
+}
+
+// validCharmConfigDefault returns true if the type is known and the
+// default, if there is one, is of the type.
+func validCharmConfigDefault(configType string, value interface{}) bool {
+	if _, known := charmConfigTypes[configType]; !known {
+		return false
+	}
+	if value == nil {
+		return true
+	}
+	switch value.(type) {
+	case string:
+		return configType == "string" || configType == "secret"
+	case int, int64:
+		return configType == "int" || configType == "float"
+	case float64:
+		return configType == "float"
+	case bool:
+		return configType == "boolean"
+	}
+	return false
+}
+
 func importCharmConfigs(source map[string]interface{}) (*charmConfigs, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -157,3 +157,34 @@ func (s *CharmConfigsSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
 	originResult := s.exportImportVersion(c, originV1, 1)
 	c.Assert(*originResult, jc.DeepEquals, originLatest)
 }
+
+func (s *CharmConfigsSerializationSuite) TestValidate(c *gc.C) {
+	configs := newCharmConfigs(CharmConfigsArgs{
+		Configs: map[string]CharmConfig{
+			"name":    charmConfig{Type_: "string", Default_: "ubuntu"},
+			"port":    charmConfig{Type_: "int", Default_: 8080},
+			"ratio":   charmConfig{Type_: "float", Default_: 1},
+			"debug":   charmConfig{Type_: "boolean", Default_: false},
+			"api-key": charmConfig{Type_: "secret"},
+		},
+	})
+	c.Assert(configs.Validate(), jc.ErrorIsNil)
+}
+
+func (s *CharmConfigsSerializationSuite) TestValidateUnknownType(c *gc.C) {
+	configs := newCharmConfigs(CharmConfigsArgs{
+		Configs: map[string]CharmConfig{
+			"name": charmConfig{Type_: "text"},
+		},
+	})
+	c.Assert(configs.Validate(), gc.ErrorMatches, `charm config "name" type "text" not valid`)
+}
+
+func (s *CharmConfigsSerializationSuite) TestValidateDefaultType(c *gc.C) {
+	configs := newCharmConfigs(CharmConfigsArgs{
+		Configs: map[string]CharmConfig{
+			"port": charmConfig{Type_: "int", Default_: "8080"},
+		},
+	})
+	c.Assert(configs.Validate(), gc.ErrorMatches, `charm config "port" default 8080 for type "int" not valid`)
+}
@@ -241,6 +241,11 @@ func (m *charmMetadata) Assumes() string {
 	return m.Assumes_
 }
 
+// AssumesExpression returns the parsed assumes block of the charm.
+func (m *charmMetadata) AssumesExpression() (*AssumesExpression, error) {
+	return ParseAssumes(m.Assumes_)
+}
+
 // Provides returns the relations of the charm.
 func (m *charmMetadata) Provides() map[string]CharmMetadataRelation {
 	relations := make(map[string]CharmMetadataRelation, len(m.Provides_))
@@ -5,7 +5,6 @@ package description
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/os/v2/series"
@@ -60,7 +59,12 @@ func platformFromSeries(s string) (string, error) {
 		return "", fmt.Errorf("extracting os version from series %q: %w", s, err)
 	}
 
-	return fmt.Sprintf("unknown/%s/%s", strings.ToLower(os.String()), version), nil
+	platform := Platform{
+		Architecture: "unknown",
+		OS:           os.String(),
+		Channel:      version,
+	}
+	return platform.Normalize().String(), nil
 }
 
 // Source implements CharmOrigin.
@@ -161,18 +165,23 @@ func importCharmOriginVersion(source map[string]interface{}, importVersion int)
 
 	platform := valid["platform"].(string)
 	if importVersion < 2 {
-		parts := strings.Split(platform, "/")
-		if len(parts) < 3 {
-			return nil, errors.NotValidf("platform %q", platform)
+		// The channel of a v1 platform is a series, which has to be
+		// converted, so the platform has to parse.
+		p, err := ParsePlatform(platform)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
-		pSeries := parts[2]
-		vers, err := series.SeriesVersion(pSeries)
+		vers, err := series.SeriesVersion(p.Channel)
 		if err != nil {
-			return nil, errors.NotValidf("platform series %q", pSeries)
+			return nil, errors.NotValidf("platform series %q", p.Channel)
 		}
-		parts[2] = vers
-		parts = append(parts, "stable")
-		platform = strings.Join(parts, "/")
+		p.Channel = vers + "/stable"
+		platform = p.String()
+	} else if p, err := ParsePlatform(platform); err == nil {
+		// Later platforms were never checked on import, so those that
+		// don't parse, including empty ones filled in from the series by
+		// older application versions, are kept as they are.
+		platform = p.String()
 	}
 
 	return &charmOrigin{
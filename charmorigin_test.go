@@ -139,6 +139,45 @@ func (s *CharmOriginSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
 	c.Assert(*originResult, jc.DeepEquals, originLatest)
 }
 
+func (s *CharmOriginSerializationSuite) TestParsingNormalizesPlatform(c *gc.C) {
+	args := maximalCharmOriginArgs()
+	args.Platform = "AMD64/Ubuntu/22.04/Stable"
+	origin := s.exportImportVersion(c, newCharmOrigin(args), 2)
+	c.Assert(origin.Platform(), gc.Equals, "amd64/ubuntu/22.04/stable")
+}
+
+func (s *CharmOriginSerializationSuite) importVersionError(c *gc.C, platform string, version int) error {
+	args := maximalCharmOriginArgs()
+	args.Platform = platform
+	origin := newCharmOrigin(args)
+	origin.Version_ = version
+	bytes, err := yaml.Marshal(origin)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = importCharmOrigin(source)
+	return err
+}
+
+func (s *CharmOriginSerializationSuite) TestV1ParsingInvalidPlatform(c *gc.C) {
+	err := s.importVersionError(c, "amd64/ubuntu", 1)
+	c.Assert(err, gc.ErrorMatches, `platform "amd64/ubuntu" not valid`)
+}
+
+func (s *CharmOriginSerializationSuite) TestV1ParsingEmptyPlatform(c *gc.C) {
+	err := s.importVersionError(c, "", 1)
+	c.Assert(err, gc.ErrorMatches, `platform "" not valid`)
+}
+
+func (s *CharmOriginSerializationSuite) TestParsingInvalidPlatformKept(c *gc.C) {
+	args := maximalCharmOriginArgs()
+	args.Platform = "AMD64/Ubuntu"
+	origin := s.exportImportVersion(c, newCharmOrigin(args), 2)
+	c.Assert(origin.Platform(), gc.Equals, "AMD64/Ubuntu")
+}
+
 func (s *CharmOriginSerializationSuite) TestPlatformFromSeriesErrorsOnEmpty(c *gc.C) {
 	rval, err := platformFromSeries("")
 	c.Assert(err, gc.NotNil)
@@ -28,6 +28,9 @@ type cloudimagemetadata struct {
 	Priority_        int        `yaml:"priority"`
 	ImageId_         string     `yaml:"image-id"`
 	ExpireAt_        *time.Time `yaml:"expire-at,omitempty"`
+
+	Signature_         string `yaml:"signature,omitempty"`
+	SourceFingerprint_ string `yaml:"source-fingerprint,omitempty"`
 }
 
 // Stream implements CloudImageMetadata.
@@ -93,6 +96,16 @@ func (i *cloudimagemetadata) ExpireAt() *time.Time {
 	return i.ExpireAt_
 }
 
+// Signature implements CloudImageMetadata.
+func (i *cloudimagemetadata) Signature() string {
+	return i.Signature_
+}
+
+// SourceFingerprint implements CloudImageMetadata.
+func (i *cloudimagemetadata) SourceFingerprint() string {
+	return i.SourceFingerprint_
+}
+
 // CloudImageMetadataArgs is an argument struct used to create a
 // new internal cloudimagemetadata type that supports the CloudImageMetadata interface.
 type CloudImageMetadataArgs struct {
@@ -108,6 +121,9 @@ type CloudImageMetadataArgs struct {
 	Priority        int
 	ImageId         string
 	ExpireAt        *time.Time
+
+	Signature         string
+	SourceFingerprint string
 }
 
 func newCloudImageMetadata(args CloudImageMetadataArgs) *cloudimagemetadata {
@@ -124,6 +140,9 @@ func newCloudImageMetadata(args CloudImageMetadataArgs) *cloudimagemetadata {
 		Priority_:        args.Priority,
 		ImageId_:         args.ImageId,
 		ExpireAt_:        args.ExpireAt,
+
+		Signature_:         args.Signature,
+		SourceFingerprint_: args.SourceFingerprint,
 	}
 	return cloudimagemetadata
 }
@@ -192,11 +211,21 @@ func cloudImageMetadataV2Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func cloudImageMetadataV3Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := cloudImageMetadataV2Fields()
+	fields["signature"] = schema.String()
+	fields["source-fingerprint"] = schema.String()
+	defaults["signature"] = ""
+	defaults["source-fingerprint"] = ""
+	return fields, defaults
+}
+
 type cloudimagemetadataDeserializationFunc func(map[string]interface{}) (*cloudimagemetadata, error)
 
 var cloudimagemetadataDeserializationFuncs = map[int]cloudimagemetadataDeserializationFunc{
 	1: importCloudImageMetadataV1,
 	2: importCloudImageMetadataV2,
+	3: importCloudImageMetadataV3,
 }
 
 func importCloudImageMetadataV1(source map[string]interface{}) (*cloudimagemetadata, error) {
@@ -209,13 +238,18 @@ func importCloudImageMetadataV2(source map[string]interface{}) (*cloudimagemetad
 	return importCloudImageMetadata(fields, defaults, source, 2)
 }
 
+func importCloudImageMetadataV3(source map[string]interface{}) (*cloudimagemetadata, error) {
+	fields, defaults := cloudImageMetadataV3Fields()
+	return importCloudImageMetadata(fields, defaults, source, 3)
+}
+
 func importCloudImageMetadata(fields schema.Fields, defaults schema.Defaults, source map[string]interface{}, importVersion int) (*cloudimagemetadata, error) {
 
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "cloudimagemetadata v1 schema check failed")
+		return nil, errors.Annotatef(err, "cloudimagemetadata v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	_, ok := valid["root-storage-size"]
@@ -245,6 +279,10 @@ func importCloudImageMetadata(fields schema.Fields, defaults schema.Defaults, so
 		ImageId_:         valid["image-id"].(string),
 		ExpireAt_:        expireAtPtr,
 	}
+	if importVersion >= 3 {
+		cloudimagemetadata.Signature_ = valid["signature"].(string)
+		cloudimagemetadata.SourceFingerprint_ = valid["source-fingerprint"].(string)
+	}
 
 	return cloudimagemetadata, nil
 }
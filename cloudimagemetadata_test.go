@@ -45,6 +45,9 @@ func (s *CloudImageMetadataSerializationSuite) TestNewCloudImageMetadata(c *gc.C
 		ImageId:         "foo",
 		DateCreated:     0,
 		ExpireAt:        &now,
+
+		Signature:         "signature",
+		SourceFingerprint: "fingerprint",
 	}
 	metadata := newCloudImageMetadata(args)
 	c.Check(metadata.Stream(), gc.Equals, args.Stream)
@@ -61,13 +64,15 @@ func (s *CloudImageMetadataSerializationSuite) TestNewCloudImageMetadata(c *gc.C
 	c.Check(metadata.ImageId(), gc.Equals, args.ImageId)
 	c.Check(metadata.DateCreated(), gc.Equals, args.DateCreated)
 	c.Check(metadata.ExpireAt(), gc.DeepEquals, args.ExpireAt)
+	c.Check(metadata.Signature(), gc.Equals, args.Signature)
+	c.Check(metadata.SourceFingerprint(), gc.Equals, args.SourceFingerprint)
 }
 
 func (s *CloudImageMetadataSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	storageSize := uint64(3)
 	now := time.Now()
 	initial := cloudimagemetadataset{
-		Version: 2,
+		Version: 3,
 		CloudImageMetadata_: []*cloudimagemetadata{
 			newCloudImageMetadata(CloudImageMetadataArgs{
 				Stream:          "stream",
@@ -82,6 +87,9 @@ func (s *CloudImageMetadataSerializationSuite) TestParsingSerializedData(c *gc.C
 				ImageId:         "foo",
 				DateCreated:     0,
 				ExpireAt:        &now,
+
+				Signature:         "signature",
+				SourceFingerprint: "fingerprint",
 			}),
 			newCloudImageMetadata(CloudImageMetadataArgs{
 				Stream:  "stream",
@@ -103,3 +111,30 @@ func (s *CloudImageMetadataSerializationSuite) TestParsingSerializedData(c *gc.C
 
 	c.Assert(metadata, jc.DeepEquals, initial.CloudImageMetadata_)
 }
+
+func (s *CloudImageMetadataSerializationSuite) TestParsingV2WithoutSignature(c *gc.C) {
+	source := map[string]interface{}{
+		"version": 2,
+		"cloudimagemetadata": []interface{}{
+			map[string]interface{}{
+				"stream":             "stream",
+				"region":             "region-test",
+				"version":            "14.04",
+				"arch":               "arch",
+				"virt-type":          "virtType-test",
+				"root-storage-type":  "rootStorageType-test",
+				"date-created":       0,
+				"source":             "test",
+				"priority":           0,
+				"image-id":           "foo",
+				"signature":          "ignored",
+				"source-fingerprint": "ignored",
+			},
+		},
+	}
+	metadata, err := importCloudImageMetadatas(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata, gc.HasLen, 1)
+	c.Check(metadata[0].Signature(), gc.Equals, "")
+	c.Check(metadata[0].SourceFingerprint(), gc.Equals, "")
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/juju/schema"
 )
 
+// defaultModificationStatus is the modification status given to instances
+// imported without one.
+const defaultModificationStatus = "idle"
+
 // CloudInstance holds information particular to a machine
 // instance in a cloud.
 type CloudInstance interface {
@@ -24,10 +28,21 @@ type CloudInstance interface {
 	CpuCores() uint64
 	CpuPower() uint64
 	Tags() []string
+	// AvailabilityZone is the zone the provider started the instance in.
+	// It is distinct from the zones in the machine's constraints, which
+	// only restrict where the instance may be started.
 	AvailabilityZone() string
 	VirtType() string
 	CharmProfiles() []string
 
+	NetworkInterfaces() []NetworkInterface
+	AddNetworkInterface(NetworkInterfaceArgs) NetworkInterface
+
+	// PreviousInstances are the instances the machine had before it was
+	// re-provisioned with this one, oldest first.
+	PreviousInstances() []PreviousInstance
+	AddPreviousInstance(PreviousInstanceArgs) PreviousInstance
+
 	Validate() error
 }
 
@@ -54,7 +69,7 @@ func newCloudInstance(args CloudInstanceArgs) *cloudInstance {
 	profiles := make([]string, len(args.CharmProfiles))
 	copy(profiles, args.CharmProfiles)
 	return &cloudInstance{
-		Version:           6,
+		Version:           9,
 		InstanceId_:       args.InstanceId,
 		DisplayName_:      args.DisplayName,
 		Architecture_:     args.Architecture,
@@ -67,7 +82,7 @@ func newCloudInstance(args CloudInstanceArgs) *cloudInstance {
 		AvailabilityZone_: args.AvailabilityZone,
 		VirtType_:         args.VirtType,
 		CharmProfiles_:    profiles,
-		StatusHistory_:    newStatusHistory(),
+		StatusHistory_:    NewStatusHistory(),
 	}
 }
 
@@ -85,6 +100,8 @@ type cloudInstance struct {
 	// different from agent-status or machine-status, where the statuses tend to
 	// imply how the machine health is during a provisioning cycle or hook
 	// integration.
+	// It is required from v7, and given a default on import of earlier
+	// versions that are missing it.
 	ModificationStatus_ *status `yaml:"modification-status,omitempty"`
 
 	// For all the optional values, empty values make no sense, and
@@ -100,6 +117,9 @@ type cloudInstance struct {
 	AvailabilityZone_ string   `yaml:"availability-zone,omitempty"`
 	VirtType_         string   `yaml:"virt-type,omitempty"`
 	CharmProfiles_    []string `yaml:"charm-profiles,omitempty"`
+
+	NetworkInterfaces_ []*networkInterface `yaml:"network-interfaces,omitempty"`
+	PreviousInstances_ []*previousInstance `yaml:"previous-instances,omitempty"`
 }
 
 // InstanceId implements CloudInstance.
@@ -194,6 +214,38 @@ func (c *cloudInstance) CharmProfiles() []string {
 	return profiles
 }
 
+// NetworkInterfaces implements CloudInstance.
+func (c *cloudInstance) NetworkInterfaces() []NetworkInterface {
+	var result []NetworkInterface
+	for _, iface := range c.NetworkInterfaces_ {
+		result = append(result, iface)
+	}
+	return result
+}
+
+// AddNetworkInterface implements CloudInstance.
+func (c *cloudInstance) AddNetworkInterface(args NetworkInterfaceArgs) NetworkInterface {
+	iface := newNetworkInterface(args)
+	c.NetworkInterfaces_ = append(c.NetworkInterfaces_, iface)
+	return iface
+}
+
+// PreviousInstances implements CloudInstance.
+func (c *cloudInstance) PreviousInstances() []PreviousInstance {
+	var result []PreviousInstance
+	for _, previous := range c.PreviousInstances_ {
+		result = append(result, previous)
+	}
+	return result
+}
+
+// AddPreviousInstance implements CloudInstance.
+func (c *cloudInstance) AddPreviousInstance(args PreviousInstanceArgs) PreviousInstance {
+	previous := newPreviousInstance(args)
+	c.PreviousInstances_ = append(c.PreviousInstances_, previous)
+	return previous
+}
+
 // Validate implements CloudInstance.
 func (c *cloudInstance) Validate() error {
 	if c.InstanceId_ == "" {
@@ -202,6 +254,24 @@ func (c *cloudInstance) Validate() error {
 	if c.Status_ == nil {
 		return errors.NotValidf("instance %q missing status", c.InstanceId_)
 	}
+	if c.ModificationStatus_ == nil {
+		return errors.NotValidf("instance %q missing modification status", c.InstanceId_)
+	}
+	deviceNames := make(map[string]bool)
+	for _, iface := range c.NetworkInterfaces_ {
+		if err := iface.validate(); err != nil {
+			return errors.Annotatef(err, "instance %q", c.InstanceId_)
+		}
+		if deviceNames[iface.DeviceName_] {
+			return errors.NotValidf("instance %q duplicate network interface %q", c.InstanceId_, iface.DeviceName_)
+		}
+		deviceNames[iface.DeviceName_] = true
+	}
+	for _, previous := range c.PreviousInstances_ {
+		if previous.InstanceId_ == "" {
+			return errors.NotValidf("instance %q previous instance missing id", c.InstanceId_)
+		}
+	}
 	return nil
 }
 
@@ -226,6 +296,9 @@ var cloudInstanceFieldsFuncs = map[int]fieldsFunc{
 	4: cloudInstanceV4Fields,
 	5: cloudInstanceV5Fields,
 	6: cloudInstanceV6Fields,
+	7: cloudInstanceV7Fields,
+	8: cloudInstanceV8Fields,
+	9: cloudInstanceV9Fields,
 }
 
 func cloudInstanceV1Fields() (schema.Fields, schema.Defaults) {
@@ -258,7 +331,7 @@ func cloudInstanceV1Fields() (schema.Fields, schema.Defaults) {
 func cloudInstanceV2Fields() (schema.Fields, schema.Defaults) {
 	fields, defaults := cloudInstanceV1Fields()
 	fields["status"] = schema.StringMap(schema.Any())
-	addStatusHistorySchema(fields)
+	AddStatusHistorySchema(fields)
 	return fields, defaults
 }
 
@@ -290,6 +363,26 @@ func cloudInstanceV6Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func cloudInstanceV7Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := cloudInstanceV6Fields()
+	delete(defaults, "modification-status")
+	return fields, defaults
+}
+
+func cloudInstanceV8Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := cloudInstanceV7Fields()
+	fields["network-interfaces"] = schema.List(schema.StringMap(schema.Any()))
+	defaults["network-interfaces"] = schema.Omit
+	return fields, defaults
+}
+
+func cloudInstanceV9Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := cloudInstanceV8Fields()
+	fields["previous-instances"] = schema.List(schema.StringMap(schema.Any()))
+	defaults["previous-instances"] = schema.Omit
+	return fields, defaults
+}
+
 func importCloudInstanceVx(source map[string]interface{}, version int, fieldFunc func() (schema.Fields, schema.Defaults)) (*cloudInstance, error) {
 	fields, defaults := fieldFunc()
 	checker := schema.FieldMap(fields, defaults)
@@ -316,7 +409,7 @@ func newCloudInstanceFromValid(valid map[string]interface{}, importVersion int)
 		Tags_:             convertToStringSlice(valid["tags"]),
 		AvailabilityZone_: valid["availability-zone"].(string),
 		CharmProfiles_:    convertToStringSlice(valid["charm-profiles"]),
-		StatusHistory_:    newStatusHistory(),
+		StatusHistory_:    NewStatusHistory(),
 	}
 
 	if displayName, ok := valid["display-name"].(string); ok {
@@ -336,7 +429,7 @@ func newCloudInstanceFromValid(valid map[string]interface{}, importVersion int)
 			return nil, errors.Trace(err)
 		}
 		instance.Status_ = status
-		if err := instance.importStatusHistory(valid); err != nil {
+		if err := instance.ImportStatusHistory(valid); err != nil {
 			return nil, errors.Trace(err)
 		}
 
@@ -355,9 +448,35 @@ func newCloudInstanceFromValid(valid map[string]interface{}, importVersion int)
 		if importVersion > 5 {
 			instance.VirtType_ = valid["virt-type"].(string)
 		}
+
+		if interfaces, ok := valid["network-interfaces"]; importVersion > 7 && ok {
+			networkInterfaces, err := importNetworkInterfaces(interfaces.([]interface{}))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			instance.NetworkInterfaces_ = networkInterfaces
+		}
+
+		if previous, ok := valid["previous-instances"]; importVersion > 8 && ok {
+			previousInstances, err := importPreviousInstances(previous.([]interface{}))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			instance.PreviousInstances_ = previousInstances
+		}
 	default:
 		return nil, errors.NotValidf("unexpected version: %d", importVersion)
 	}
 
+	if instance.ModificationStatus_ == nil {
+		// Versions before 7 may be missing the modification status, so
+		// give them the status of an instance that hasn't been modified,
+		// as of when its status was last updated.
+		instance.ModificationStatus_ = newStatus(StatusArgs{
+			Value:   defaultModificationStatus,
+			Updated: instance.Status_.Updated(),
+		})
+	}
+
 	return instance, nil
 }
@@ -4,6 +4,8 @@
 package description
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -25,7 +27,7 @@ func (s *CloudInstanceSerializationSuite) SetUpTest(c *gc.C) {
 
 func minimalCloudInstanceMap() map[interface{}]interface{} {
 	return map[interface{}]interface{}{
-		"version":             6,
+		"version":             9,
 		"instance-id":         "instance id",
 		"status":              minimalStatusMap(),
 		"status-history":      emptyStatusHistoryMap(),
@@ -306,6 +308,7 @@ func (s *CloudInstanceSerializationSuite) TestParsingV6Full(c *gc.C) {
 	original := s.allV6Map()
 	imported := s.importCloudInstance(c, original)
 	expected := s.testCloudInstance()
+	expected.Version = 6
 	c.Assert(imported, jc.DeepEquals, expected)
 }
 
@@ -321,5 +324,134 @@ func (s *CloudInstanceSerializationSuite) TestParsingV6Minimal(c *gc.C) {
 	expected := newCloudInstance(minimalCloudInstanceArgs())
 	expected.SetStatus(minimalStatusArgs())
 	expected.SetModificationStatus(minimalStatusArgs())
+	expected.Version = 6
 	c.Assert(imported, jc.DeepEquals, expected)
 }
+
+func (s *CloudInstanceSerializationSuite) TestParsingV6DefaultsModificationStatus(c *gc.C) {
+	original := s.allV6Map()
+	delete(original, "modification-status")
+	imported := s.importCloudInstance(c, original)
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+	status := imported.ModificationStatus()
+	c.Assert(status.Value(), gc.Equals, "idle")
+	c.Assert(status.Updated(), gc.Equals, imported.Status().Updated())
+}
+
+func (s *CloudInstanceSerializationSuite) TestParsingV7Full(c *gc.C) {
+	original := s.allV6Map()
+	original["version"] = 7
+	imported := s.importCloudInstance(c, original)
+	expected := s.testCloudInstance()
+	expected.Version = 7
+	c.Assert(imported, jc.DeepEquals, expected)
+}
+
+func (s *CloudInstanceSerializationSuite) TestParsingV7IgnoresNetworkInterfaces(c *gc.C) {
+	original := s.allV6Map()
+	original["version"] = 7
+	original["network-interfaces"] = []interface{}{
+		map[string]interface{}{"device-name": "eth0"},
+	}
+	imported := s.importCloudInstance(c, original)
+	c.Assert(imported.NetworkInterfaces(), gc.HasLen, 0)
+}
+
+func (s *CloudInstanceSerializationSuite) TestNetworkInterfaces(c *gc.C) {
+	initial := s.testCloudInstance()
+	iface := initial.AddNetworkInterface(NetworkInterfaceArgs{
+		DeviceName:        "eth0",
+		MACAddress:        "00:16:3e:12:34:56",
+		ProviderID:        "eni-1234",
+		ProviderSubnetID:  "subnet-5678",
+		ProviderNetworkID: "vpc-9abc",
+		Addresses:         []AddressArgs{{Value: "10.0.0.4", Type: "ipv4", Scope: "local-cloud"}},
+		ShadowAddresses:   []AddressArgs{{Value: "54.1.2.3", Type: "ipv4", Scope: "public"}},
+	})
+	c.Check(iface.DeviceName(), gc.Equals, "eth0")
+	c.Check(iface.MACAddress(), gc.Equals, "00:16:3e:12:34:56")
+	c.Check(iface.ProviderID(), gc.Equals, "eni-1234")
+	c.Check(iface.ProviderSubnetID(), gc.Equals, "subnet-5678")
+	c.Check(iface.ProviderNetworkID(), gc.Equals, "vpc-9abc")
+	c.Assert(iface.Addresses(), gc.HasLen, 1)
+	c.Check(iface.Addresses()[0].Value(), gc.Equals, "10.0.0.4")
+	c.Assert(iface.ShadowAddresses(), gc.HasLen, 1)
+	c.Check(iface.ShadowAddresses()[0].Value(), gc.Equals, "54.1.2.3")
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := importCloudInstance(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.NetworkInterfaces(), jc.DeepEquals, initial.NetworkInterfaces())
+}
+
+func (s *CloudInstanceSerializationSuite) TestValidateNetworkInterfaces(c *gc.C) {
+	instance := s.testCloudInstance()
+	instance.AddNetworkInterface(NetworkInterfaceArgs{DeviceName: "eth0", MACAddress: "not-a-mac"})
+	c.Assert(instance.Validate(), gc.ErrorMatches, `instance "instance id": network interface "eth0" MAC address "not-a-mac" not valid`)
+
+	instance = s.testCloudInstance()
+	instance.AddNetworkInterface(NetworkInterfaceArgs{DeviceName: "eth0"})
+	instance.AddNetworkInterface(NetworkInterfaceArgs{DeviceName: "eth0"})
+	c.Assert(instance.Validate(), gc.ErrorMatches, `instance "instance id" duplicate network interface "eth0" not valid`)
+
+	instance = s.testCloudInstance()
+	instance.AddNetworkInterface(NetworkInterfaceArgs{})
+	c.Assert(instance.Validate(), gc.ErrorMatches, `instance "instance id": network interface missing device name not valid`)
+}
+
+func (s *CloudInstanceSerializationSuite) TestPreviousInstances(c *gc.C) {
+	initial := s.testCloudInstance()
+	replaced := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	previous := initial.AddPreviousInstance(PreviousInstanceArgs{InstanceId: "i-1", Replaced: replaced})
+	initial.AddPreviousInstance(PreviousInstanceArgs{InstanceId: "i-2", Replaced: replaced.Add(time.Hour)})
+	c.Check(previous.InstanceId(), gc.Equals, "i-1")
+	c.Check(previous.Replaced(), gc.Equals, replaced)
+	c.Check(initial.InstanceId(), gc.Equals, "instance id")
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := importCloudInstance(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.PreviousInstances(), jc.DeepEquals, initial.PreviousInstances())
+}
+
+func (s *CloudInstanceSerializationSuite) TestParsingV8IgnoresPreviousInstances(c *gc.C) {
+	original := s.allV6Map()
+	original["version"] = 8
+	original["previous-instances"] = []interface{}{
+		map[string]interface{}{"instance-id": "i-1", "replaced": "2024-03-04T05:06:07Z"},
+	}
+	imported := s.importCloudInstance(c, original)
+	c.Assert(imported.PreviousInstances(), gc.HasLen, 0)
+}
+
+func (s *CloudInstanceSerializationSuite) TestValidatePreviousInstances(c *gc.C) {
+	instance := s.testCloudInstance()
+	instance.AddPreviousInstance(PreviousInstanceArgs{Replaced: time.Now()})
+	c.Assert(instance.Validate(), gc.ErrorMatches, `instance "instance id" previous instance missing id not valid`)
+}
+
+func (s *CloudInstanceSerializationSuite) TestParsingV7RequiresModificationStatus(c *gc.C) {
+	original := s.allV6Map()
+	original["version"] = 7
+	delete(original, "modification-status")
+	_, err := importCloudInstance(original)
+	c.Assert(err, gc.ErrorMatches, `cloudInstance v7 schema check failed: modification-status: expected map, got nothing`)
+}
+
+func (s *CloudInstanceSerializationSuite) TestValidateMissingModificationStatus(c *gc.C) {
+	instance := newCloudInstance(minimalCloudInstanceArgs())
+	instance.SetStatus(minimalStatusArgs())
+	err := instance.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `instance "instance id" missing modification status not valid`)
+}
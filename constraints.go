@@ -31,6 +31,9 @@ type Constraints interface {
 
 	Spaces() []string
 	Tags() []string
+	// Zones are the availability zones an entity may be provisioned in.
+	// The zone a machine was actually started in is recorded by the
+	// AvailabilityZone of its CloudInstance.
 	Zones() []string
 
 	VirtType() string
@@ -195,6 +198,22 @@ func (c *constraints) VirtType() string {
 	return c.VirtType_
 }
 
+// NewConstraints returns a Constraints for use by entities defined outside
+// of this package that implement HasConstraints.
+func NewConstraints(args ConstraintsArgs) Constraints {
+	return newConstraints(args)
+}
+
+// ImportConstraints reads a Constraints from the serialized form written by
+// a Constraints returned from NewConstraints.
+func ImportConstraints(source map[string]interface{}) (Constraints, error) {
+	cons, err := importConstraints(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cons, nil
+}
+
 func importConstraints(source map[string]interface{}) (*constraints, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -348,7 +367,9 @@ func validatedConstraints(version int, valid map[string]interface{}, cores uint6
 	return cons
 }
 
-func addConstraintsSchema(fields schema.Fields, defaults schema.Defaults) {
+// AddConstraintsSchema adds the optional "constraints" field to the schema of
+// an entity that has constraints. The field is read with ImportConstraints.
+func AddConstraintsSchema(fields schema.Fields, defaults schema.Defaults) {
 	fields["constraints"] = schema.StringMap(schema.Any())
 	defaults["constraints"] = schema.Omit
 }
@@ -127,6 +127,26 @@ func (s *ConstraintsSerializationSuite) assertParsingSerializedConstraints(c *gc
 	c.Assert(instance, jc.DeepEquals, initial)
 }
 
+func (s *ConstraintsSerializationSuite) TestExportedNewAndImport(c *gc.C) {
+	initial := NewConstraints(s.allArgs())
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instance, err := ImportConstraints(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instance, jc.DeepEquals, initial)
+}
+
+func (s *ConstraintsSerializationSuite) TestExportedImportError(c *gc.C) {
+	instance, err := ImportConstraints(map[string]interface{}{"version": 42})
+	c.Assert(err, gc.ErrorMatches, "version 42 not valid")
+	c.Assert(instance, gc.IsNil)
+}
+
 func (s *ConstraintsSerializationSuite) testConstraints() *constraints {
 	return newConstraints(s.allArgs())
 }
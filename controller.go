@@ -0,0 +1,385 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// Controller is a database agnostic representation of a whole controller:
+// its config, CA certificate and nodes, and the models it hosts. It is
+// serialized as a document of its own, as when the controller is backed
+// up, with the models written out as they would be by Serialize.
+type Controller interface {
+	// Tag returns the tag of the controller, from the controller-uuid
+	// of its config.
+	Tag() names.ControllerTag
+	Config() map[string]interface{}
+	CACert() string
+
+	ControllerNodes() []ControllerNode
+	AddControllerNode(ControllerNodeArgs) ControllerNode
+
+	// Models returns the models of the controller in the order they
+	// were added.
+	Models() []Model
+	AddModel(Model)
+
+	Validate() error
+}
+
+// ControllerNode represents a machine running the controller.
+type ControllerNode interface {
+	Id() string
+	HasVote() bool
+	WantsVote() bool
+	Addresses() []Address
+}
+
+// ControllerArgs is an argument struct used to create a new Controller.
+type ControllerArgs struct {
+	Config map[string]interface{}
+	CACert string
+}
+
+// ControllerNodeArgs is an argument struct used to add a node to a
+// Controller.
+type ControllerNodeArgs struct {
+	Id        string
+	HasVote   bool
+	WantsVote bool
+	Addresses []AddressArgs
+}
+
+type controller struct {
+	Version int `yaml:"version"`
+
+	Config_          map[string]interface{} `yaml:"config"`
+	CACert_          string                 `yaml:"ca-cert"`
+	ControllerNodes_ controllerNodes        `yaml:"controller-nodes"`
+	Models_          []Model                `yaml:"models"`
+}
+
+type controllerNodes struct {
+	Version          int               `yaml:"version"`
+	ControllerNodes_ []*controllerNode `yaml:"controller-nodes"`
+}
+
+type controllerNode struct {
+	Id_        string     `yaml:"id"`
+	HasVote_   bool       `yaml:"has-vote"`
+	WantsVote_ bool       `yaml:"wants-vote"`
+	Addresses_ []*address `yaml:"addresses,omitempty"`
+}
+
+// NewController returns a Controller without nodes or models.
+func NewController(args ControllerArgs) Controller {
+	c := &controller{
+		Version: 1,
+		Config_: args.Config,
+		CACert_: args.CACert,
+	}
+	c.setControllerNodes(nil)
+	return c
+}
+
+// SerializeController serializes the controller to YAML. Models that were
+// made safe for concurrent use are locked while they are written out.
+func SerializeController(c Controller) ([]byte, error) {
+	locked := make(map[*synchronizedModel]bool)
+	for _, m := range c.Models() {
+		if synchronized, ok := m.(*synchronizedModel); ok && !locked[synchronized] {
+			locked[synchronized] = true
+			synchronized.mu.RLock()
+			defer synchronized.mu.RUnlock()
+		}
+	}
+	return marshalYAML(c)
+}
+
+// DeserializeController constructs a Controller from its serialized YAML
+// form. The options apply to the import of each of its models.
+func DeserializeController(bytes []byte, options ...ImportOption) (Controller, error) {
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c, err := importController(source, options...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+// Tag implements Controller.
+func (c *controller) Tag() names.ControllerTag {
+	uuid, _ := c.Config_["controller-uuid"].(string)
+	return names.NewControllerTag(uuid)
+}
+
+// Config implements Controller.
+func (c *controller) Config() map[string]interface{} {
+	return c.Config_
+}
+
+// CACert implements Controller.
+func (c *controller) CACert() string {
+	return c.CACert_
+}
+
+// ControllerNodes implements Controller.
+func (c *controller) ControllerNodes() []ControllerNode {
+	var result []ControllerNode
+	for _, node := range c.ControllerNodes_.ControllerNodes_ {
+		result = append(result, node)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return naturalLess(result[i].Id(), result[j].Id())
+	})
+	return result
+}
+
+// AddControllerNode implements Controller.
+func (c *controller) AddControllerNode(args ControllerNodeArgs) ControllerNode {
+	node := newControllerNode(args)
+	c.ControllerNodes_.ControllerNodes_ = append(c.ControllerNodes_.ControllerNodes_, node)
+	return node
+}
+
+func (c *controller) setControllerNodes(nodes []*controllerNode) {
+	c.ControllerNodes_ = controllerNodes{
+		Version:          1,
+		ControllerNodes_: nodes,
+	}
+}
+
+// Models implements Controller.
+func (c *controller) Models() []Model {
+	result := make([]Model, len(c.Models_))
+	copy(result, c.Models_)
+	return result
+}
+
+// AddModel implements Controller.
+func (c *controller) AddModel(m Model) {
+	c.Models_ = append(c.Models_, m)
+}
+
+// Validate implements Controller. It checks that the CA certificate is a
+// PEM encoded x509 certificate, that the nodes have unique ids, and that
+// the models are valid and have unique UUIDs.
+func (c *controller) Validate() error {
+	if !validCACert(c.CACert_) {
+		return errors.NotValidf("controller CA cert")
+	}
+	nodeIDs := set.NewStrings()
+	for i, node := range c.ControllerNodes_.ControllerNodes_ {
+		if node.Id_ == "" {
+			return errors.NotValidf("controller node %d missing id", i)
+		}
+		if nodeIDs.Contains(node.Id_) {
+			return errors.NotValidf("controller node %q duplicate id", node.Id_)
+		}
+		nodeIDs.Add(node.Id_)
+		for _, addr := range node.Addresses_ {
+			if addr.Value_ == "" {
+				return errors.NotValidf("controller node %q address missing value", node.Id_)
+			}
+		}
+	}
+	modelUUIDs := set.NewStrings()
+	for _, m := range c.Models_ {
+		uuid := m.Tag().Id()
+		if modelUUIDs.Contains(uuid) {
+			return errors.NotValidf("model %q duplicate uuid", uuid)
+		}
+		modelUUIDs.Add(uuid)
+		if err := m.Validate(); err != nil {
+			return errors.Annotatef(err, "model %q", uuid)
+		}
+	}
+	return nil
+}
+
+// validCACert returns true if the certificate is a PEM encoded x509
+// certificate.
+func validCACert(cert string) bool {
+	return parseCACert(cert) != nil
+}
+
+// parseCACert returns the PEM encoded x509 certificate, or nil if it isn't
+// one.
+func parseCACert(cert string) *x509.Certificate {
+	block, _ := pem.Decode([]byte(cert))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return certificate
+}
+
+func newControllerNode(args ControllerNodeArgs) *controllerNode {
+	node := &controllerNode{
+		Id_:        args.Id,
+		HasVote_:   args.HasVote,
+		WantsVote_: args.WantsVote,
+	}
+	for _, args := range args.Addresses {
+		node.Addresses_ = append(node.Addresses_, newAddress(args))
+	}
+	return node
+}
+
+// Id implements ControllerNode.
+func (n *controllerNode) Id() string {
+	return n.Id_
+}
+
+// HasVote implements ControllerNode.
+func (n *controllerNode) HasVote() bool {
+	return n.HasVote_
+}
+
+// WantsVote implements ControllerNode.
+func (n *controllerNode) WantsVote() bool {
+	return n.WantsVote_
+}
+
+// Addresses implements ControllerNode.
+func (n *controllerNode) Addresses() []Address {
+	var result []Address
+	for _, addr := range n.Addresses_ {
+		result = append(result, addr)
+	}
+	return result
+}
+
+func importController(source map[string]interface{}, options ...ImportOption) (*controller, error) {
+	version, err := getVersion(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	getFields, ok := controllerFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	coerced, err := schema.FieldMap(getFields()).Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "controller v%d schema check failed", version)
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &controller{
+		Version: 1,
+		CACert_: valid["ca-cert"].(string),
+	}
+	if config, ok := valid["config"]; ok {
+		result.Config_ = config.(map[string]interface{})
+	}
+
+	nodes, err := importControllerNodes(valid["controller-nodes"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setControllerNodes(nodes)
+
+	for i, value := range valid["models"].([]interface{}) {
+		m, err := importModel(value.(map[string]interface{}), options...)
+		if err != nil {
+			return nil, errors.Annotatef(err, "model %d", i)
+		}
+		result.Models_ = append(result.Models_, m)
+	}
+	return result, nil
+}
+
+var controllerFieldsFuncs = map[int]fieldsFunc{
+	1: controllerV1Fields,
+}
+
+func controllerV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"config":           schema.StringMap(schema.Any()),
+		"ca-cert":          schema.String(),
+		"controller-nodes": schema.StringMap(schema.Any()),
+		"models":           schema.List(schema.StringMap(schema.Any())),
+	}
+	defaults := schema.Defaults{
+		"config": schema.Omit,
+	}
+	return fields, defaults
+}
+
+func importControllerNodes(source map[string]interface{}) ([]*controllerNode, error) {
+	checker := versionedChecker("controller-nodes")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "controller nodes version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	getFields, ok := controllerNodeFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	checker = schema.FieldMap(getFields())
+	sourceList := valid["controller-nodes"].([]interface{})
+	result := make([]*controllerNode, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for controller node %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "controller node %d v%d schema check failed", i, version)
+		}
+		valid := coerced.(map[string]interface{})
+		node := &controllerNode{
+			Id_:        valid["id"].(string),
+			HasVote_:   valid["has-vote"].(bool),
+			WantsVote_: valid["wants-vote"].(bool),
+		}
+		if addresses, ok := valid["addresses"]; ok {
+			node.Addresses_, err = importAddresses(addresses.([]interface{}))
+			if err != nil {
+				return nil, errors.Annotatef(err, "controller node %d addresses", i)
+			}
+		}
+		result[i] = node
+	}
+	return result, nil
+}
+
+var controllerNodeFieldsFuncs = map[int]fieldsFunc{
+	1: controllerNodeV1Fields,
+}
+
+func controllerNodeV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"id":         schema.String(),
+		"has-vote":   schema.Bool(),
+		"wants-vote": schema.Bool(),
+		"addresses":  schema.List(schema.StringMap(schema.Any())),
+	}
+	defaults := schema.Defaults{
+		"has-vote":   false,
+		"wants-vote": false,
+		"addresses":  schema.Omit,
+	}
+	return fields, defaults
+}
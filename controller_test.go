@@ -0,0 +1,171 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ControllerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ControllerSuite{})
+
+func testController() Controller {
+	ctrl := NewController(ControllerArgs{
+		Config: map[string]interface{}{
+			"controller-uuid": "ctrl-uuid",
+			"api-port":        17070,
+		},
+		CACert: selfTestCACert,
+	})
+	ctrl.AddControllerNode(ControllerNodeArgs{
+		Id:        "10",
+		HasVote:   true,
+		WantsVote: true,
+		Addresses: []AddressArgs{{Value: "10.0.0.10", Type: "ipv4", Scope: "local-cloud"}},
+	})
+	ctrl.AddControllerNode(ControllerNodeArgs{Id: "2", WantsVote: true})
+	ctrl.AddModel(testControllerModel("model-uuid-1"))
+	ctrl.AddModel(testControllerModel("model-uuid-2"))
+	return ctrl
+}
+
+func testControllerModel(uuid string) Model {
+	m := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": uuid},
+	})
+	m.SetStatus(StatusArgs{Value: "available"})
+	return m
+}
+
+func (*ControllerSuite) TestNew(c *gc.C) {
+	ctrl := testController()
+	c.Check(ctrl.Tag(), gc.Equals, names.NewControllerTag("ctrl-uuid"))
+	c.Check(ctrl.CACert(), gc.Equals, selfTestCACert)
+	c.Check(ctrl.Config()["api-port"], gc.Equals, 17070)
+
+	nodes := ctrl.ControllerNodes()
+	c.Assert(nodes, gc.HasLen, 2)
+	c.Check(nodes[0].Id(), gc.Equals, "2")
+	c.Check(nodes[0].HasVote(), jc.IsFalse)
+	c.Check(nodes[0].WantsVote(), jc.IsTrue)
+	c.Check(nodes[1].Id(), gc.Equals, "10")
+	c.Check(nodes[1].HasVote(), jc.IsTrue)
+	c.Assert(nodes[1].Addresses(), gc.HasLen, 1)
+	c.Check(nodes[1].Addresses()[0].Value(), gc.Equals, "10.0.0.10")
+
+	models := ctrl.Models()
+	c.Assert(models, gc.HasLen, 2)
+	c.Check(models[0].Tag().Id(), gc.Equals, "model-uuid-1")
+	c.Check(models[1].Tag().Id(), gc.Equals, "model-uuid-2")
+}
+
+func (*ControllerSuite) TestRoundTrip(c *gc.C) {
+	initial := testController()
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	bytes, err := SerializeController(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := DeserializeController(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial)
+}
+
+func (*ControllerSuite) TestRoundTripSynchronizedModel(c *gc.C) {
+	initial := NewController(ControllerArgs{CACert: selfTestCACert})
+	initial.AddModel(NewSynchronizedModel(testControllerModel("model-uuid")))
+
+	bytes, err := SerializeController(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := DeserializeController(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Models(), gc.HasLen, 1)
+	c.Check(imported.Models()[0].Tag().Id(), gc.Equals, "model-uuid")
+}
+
+func (*ControllerSuite) TestDeserializeBadVersion(c *gc.C) {
+	_, err := DeserializeController([]byte("version: 2\n"))
+	c.Assert(err, gc.ErrorMatches, "version 2 not valid")
+}
+
+func (*ControllerSuite) TestDeserializeBadModel(c *gc.C) {
+	bytes := []byte(`
+version: 1
+ca-cert: ""
+controller-nodes:
+  version: 1
+  controller-nodes: []
+models:
+- version: 9999
+`)
+	_, err := DeserializeController(bytes)
+	c.Assert(err, gc.ErrorMatches, "model 0: version 9999 not valid")
+}
+
+func (*ControllerSuite) TestDeserializeBadNode(c *gc.C) {
+	bytes := []byte(`
+version: 1
+ca-cert: ""
+controller-nodes:
+  version: 1
+  controller-nodes:
+  - has-vote: true
+models: []
+`)
+	_, err := DeserializeController(bytes)
+	c.Assert(err, gc.ErrorMatches, `controller node 0 v1 schema check failed: id: expected string, got nothing`)
+}
+
+func (*ControllerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		modify   func(Controller) Controller
+		expected string
+	}{{
+		modify: func(ctrl Controller) Controller {
+			garbled := NewController(ControllerArgs{CACert: "not a cert"})
+			for _, m := range ctrl.Models() {
+				garbled.AddModel(m)
+			}
+			return garbled
+		},
+		expected: `controller CA cert not valid`,
+	}, {
+		modify: func(ctrl Controller) Controller {
+			ctrl.AddControllerNode(ControllerNodeArgs{})
+			return ctrl
+		},
+		expected: `controller node 2 missing id not valid`,
+	}, {
+		modify: func(ctrl Controller) Controller {
+			ctrl.AddControllerNode(ControllerNodeArgs{Id: "2"})
+			return ctrl
+		},
+		expected: `controller node "2" duplicate id not valid`,
+	}, {
+		modify: func(ctrl Controller) Controller {
+			ctrl.AddModel(testControllerModel("model-uuid-1"))
+			return ctrl
+		},
+		expected: `model "model-uuid-1" duplicate uuid not valid`,
+	}, {
+		modify: func(ctrl Controller) Controller {
+			m := testControllerModel("model-uuid-3")
+			m.SetPasswordHash("", PasswordHashPBKDF2)
+			ctrl.AddModel(m)
+			return ctrl
+		},
+		expected: `model "model-uuid-3": .*`,
+	}} {
+		c.Logf("test %d", i)
+		ctrl := test.modify(testController())
+		c.Check(ctrl.Validate(), gc.ErrorMatches, test.expected)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"reflect"
+)
+
+// Copy implements Model.
+func (m *model) Copy() Model {
+	result := deepCopy(reflect.ValueOf(m)).Interface().(*model)
+	result.setFrozen(false)
+	result.linkOperationActions()
+	return result
+}
+
+// deepCopy returns a copy of the value that shares nothing with it that
+// could be changed: the values of pointers, interfaces, slices and maps
+// are copied in turn. The model and its entities form a tree, so there
+// are no cycles to guard against. Unexported struct fields are copied as
+// they are, which is safe as the entities only keep flags in them, and
+// links to other entities that Copy makes again.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(deepCopy(v.Elem()))
+		return result
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(deepCopy(v.Elem()))
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+		return result
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				result.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return result
+	default:
+		return v
+	}
+}
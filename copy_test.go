@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CopySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CopySuite{})
+
+func (s *CopySuite) TestCopy(c *gc.C) {
+	original := selfTestModel()
+	copied := original.Copy()
+	c.Assert(copied, jc.DeepEquals, Model(original))
+
+	expected, err := Serialize(original)
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := Serialize(copied)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(obtained), gc.Equals, string(expected))
+
+	s.checkSharesNothing(c, reflect.ValueOf(original), reflect.ValueOf(copied), "model")
+}
+
+func (s *CopySuite) TestChangesToCopy(c *gc.C) {
+	original := selfTestModel()
+	expected, err := Serialize(original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	copied := original.Copy()
+	copied.AddMachine(MachineArgs{Id: names.NewMachineTag("5")})
+	copied.Applications()[0].CharmConfig()["extra"] = "value"
+	copied.Machines()[0].SetStatus(StatusArgs{Value: "down"})
+	copied.SetAnnotations(map[string]string{"changed": "yes"})
+
+	obtained, err := Serialize(original)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(obtained), gc.Equals, string(expected))
+}
+
+func (s *CopySuite) TestCopyFrozen(c *gc.C) {
+	original := selfTestModel()
+	reader := original.Freeze()
+	copied := reader.Copy()
+	copied.AddMachine(MachineArgs{Id: names.NewMachineTag("5")})
+	c.Assert(original.Machines(), gc.HasLen, 1)
+	c.Assert(func() { original.AddMachine(MachineArgs{}) }, gc.PanicMatches, ".*frozen model")
+}
+
+func (s *CopySuite) TestCopySynchronized(c *gc.C) {
+	copied := NewSynchronizedModel(selfTestModel()).Copy()
+	_, ok := copied.(*synchronizedModel)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(copied.Machines(), gc.HasLen, 1)
+}
+
+// checkSharesNothing checks that no pointer, slice or map reachable from
+// the copy is also reachable from the original.
+func (s *CopySuite) checkSharesNothing(c *gc.C, original, copied reflect.Value, path string) {
+	switch original.Kind() {
+	case reflect.Map, reflect.Slice:
+		// Empty ones may all share the same storage.
+		if original.Len() == 0 {
+			return
+		}
+		fallthrough
+	case reflect.Ptr:
+		if !original.IsNil() {
+			c.Check(copied.Pointer(), gc.Not(gc.Equals), original.Pointer(), gc.Commentf("%s shared", path))
+		}
+	}
+	switch original.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !original.IsNil() {
+			s.checkSharesNothing(c, original.Elem(), copied.Elem(), path)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < original.Len(); i++ {
+			s.checkSharesNothing(c, original.Index(i), copied.Index(i), path)
+		}
+	case reflect.Map:
+		iter := original.MapRange()
+		for iter.Next() {
+			s.checkSharesNothing(c, iter.Value(), copied.MapIndex(iter.Key()), fmt.Sprintf("%s[%v]", path, iter.Key()))
+		}
+	case reflect.Struct:
+		for i := 0; i < original.NumField(); i++ {
+			if field := original.Type().Field(i); field.PkgPath == "" {
+				s.checkSharesNothing(c, original.Field(i), copied.Field(i), path+"."+field.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,1070 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+)
+
+// CopyInto populates dst, which should be a new model returned by
+// NewModel, with the contents of src. Unlike Copy, it reads src only
+// through the Model interface and its entities only through theirs, so
+// that it can rebuild a model from an implementation outside this package,
+// or turn the read-only view of a frozen model back into a model that can
+// be changed. Nothing is shared between the models: the maps and slices
+// that src returns are copied before they are given to dst.
+//
+// The type, owner, config, cloud and other properties that NewModel takes
+// as ModelArgs replace those of dst, so dst must be a model that NewModel
+// or NewSynchronizedModel returned.
+func CopyInto(dst Model, src Model) error {
+	target := dst
+	if synchronized, ok := dst.(*synchronizedModel); ok {
+		synchronized.mu.Lock()
+		defer synchronized.mu.Unlock()
+		target = synchronized.model
+	}
+	m, ok := target.(*model)
+	if !ok {
+		return errors.NotSupportedf("copying into %T", dst)
+	}
+	if m.frozen {
+		return errors.NotSupportedf("copying into a frozen model")
+	}
+
+	m.AgentVersion_ = src.AgentVersion()
+	m.Type_ = src.Type()
+	m.Owner_ = src.Owner().Id()
+	m.Config_ = cloned(src.Config())
+	m.LatestToolsVersion_ = src.LatestToolsVersion()
+	m.EnvironVersion_ = src.EnvironVersion()
+	m.Blocks_ = cloned(src.Blocks())
+	m.Cloud_ = src.Cloud()
+	m.CloudRegion_ = src.CloudRegion()
+	m.SecretBackendID_ = src.SecretBackendID()
+	m.Description_ = src.Description()
+	m.SetPasswordHash(src.PasswordHash(), src.PasswordHashAlgorithm())
+	if labels := src.Labels(); len(labels) > 0 {
+		m.setLabels(cloned(labels))
+	}
+
+	copyModelDetails(m, src)
+	for _, user := range src.Users() {
+		m.AddUser(userArgs(user))
+	}
+	for _, machine := range src.Machines() {
+		copyMachine(m.AddMachine, machine)
+	}
+	for _, application := range src.Applications() {
+		copyApplication(m, application)
+	}
+	for _, charm := range src.Charms() {
+		m.AddCharm(CharmArgs{
+			URL:         charm.URL(),
+			Revision:    charm.Revision(),
+			StoragePath: charm.StoragePath(),
+			SHA256:      charm.SHA256(),
+			LXDProfile:  charm.LXDProfile(),
+		})
+	}
+	for _, relation := range src.Relations() {
+		copyRelation(m, relation)
+	}
+	for _, entity := range src.RemoteEntities() {
+		m.AddRemoteEntity(RemoteEntityArgs{
+			ID:       entity.ID(),
+			Token:    entity.Token(),
+			Macaroon: entity.Macaroon(),
+		})
+	}
+	for _, network := range src.RelationNetworks() {
+		m.AddRelationNetwork(RelationNetworkArgs{
+			ID:          network.ID(),
+			RelationKey: network.RelationKey(),
+			CIDRS:       cloned(network.CIDRS()),
+			Direction:   network.Direction(),
+			Origin:      network.Origin(),
+		})
+	}
+	for _, space := range src.Spaces() {
+		m.AddSpace(SpaceArgs{
+			Id:         space.Id(),
+			Name:       space.Name(),
+			Public:     space.Public(),
+			ProviderID: space.ProviderID(),
+		})
+	}
+	for _, device := range src.LinkLayerDevices() {
+		m.AddLinkLayerDevice(LinkLayerDeviceArgs{
+			Name:            device.Name(),
+			MTU:             device.MTU(),
+			ProviderID:      device.ProviderID(),
+			MachineID:       device.MachineID(),
+			Type:            device.Type(),
+			MACAddress:      device.MACAddress(),
+			IsAutoStart:     device.IsAutoStart(),
+			IsUp:            device.IsUp(),
+			ParentName:      device.ParentName(),
+			VirtualPortType: device.VirtualPortType(),
+			BondMode:        device.BondMode(),
+			VLANTag:         device.VLANTag(),
+			IsSRIOV:         device.IsSRIOV(),
+		})
+	}
+	for _, subnet := range src.Subnets() {
+		// Subnets and secrets have no tag to index their annotations
+		// by, so they are copied with them.
+		m.AddSubnet(subnetArgs(subnet)).SetAnnotations(cloned(subnet.Annotations()))
+	}
+	for _, address := range src.IPAddresses() {
+		m.AddIPAddress(IPAddressArgs{
+			ProviderID:        address.ProviderID(),
+			DeviceName:        address.DeviceName(),
+			MachineID:         address.MachineID(),
+			SubnetCIDR:        address.SubnetCIDR(),
+			ConfigMethod:      address.ConfigMethod(),
+			Value:             address.Value(),
+			DNSServers:        cloned(address.DNSServers()),
+			DNSSearchDomains:  cloned(address.DNSSearchDomains()),
+			GatewayAddress:    address.GatewayAddress(),
+			IsDefaultGateway:  address.IsDefaultGateway(),
+			ProviderNetworkID: address.ProviderNetworkID(),
+			ProviderSubnetID:  address.ProviderSubnetID(),
+			Origin:            address.Origin(),
+			IsShadow:          address.IsShadow(),
+			IsSecondary:       address.IsSecondary(),
+		})
+	}
+	for _, key := range src.SSHHostKeys() {
+		m.AddSSHHostKey(SSHHostKeyArgs{
+			MachineID: key.MachineID(),
+			Keys:      cloned(key.Keys()),
+		})
+	}
+	for _, key := range src.VirtualHostKeys() {
+		m.AddVirtualHostKey(VirtualHostKeyArgs{
+			ID:      key.ID(),
+			Target:  key.Target(),
+			HostKey: key.HostKey(),
+		})
+	}
+	for _, metadata := range src.CloudImageMetadata() {
+		m.AddCloudImageMetadata(cloudImageMetadataArgs(metadata))
+	}
+	for _, action := range src.Actions() {
+		m.AddAction(actionArgs(action))
+	}
+	for _, operation := range src.Operations() {
+		m.AddOperation(OperationArgs{
+			Id:                operation.Id(),
+			Summary:           operation.Summary(),
+			Enqueued:          operation.Enqueued(),
+			Started:           operation.Started(),
+			Completed:         operation.Completed(),
+			Status:            operation.Status(),
+			Fail:              operation.Fail(),
+			CompleteTaskCount: operation.CompleteTaskCount(),
+			SpawnedTaskCount:  operation.SpawnedTaskCount(),
+		})
+	}
+	for name, value := range src.Sequences() {
+		m.SetSequence(name, value)
+	}
+	for _, volume := range src.Volumes() {
+		copyVolume(m, volume)
+	}
+	for _, rule := range src.FirewallRules() {
+		m.AddFirewallRule(FirewallRuleArgs{
+			ID:               rule.ID(),
+			WellKnownService: rule.WellKnownService(),
+			WhitelistCIDRs:   cloned(rule.WhitelistCIDRs()),
+		})
+	}
+	for _, filesystem := range src.Filesystems() {
+		copyFilesystem(m, filesystem)
+	}
+	for _, storage := range src.Storages() {
+		args, err := storageArgs(storage)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.AddStorage(args)
+	}
+	for _, pool := range src.StoragePools() {
+		m.AddStoragePool(StoragePoolArgs{
+			Name:       pool.Name(),
+			Provider:   pool.Provider(),
+			Attributes: cloned(pool.Attributes()),
+		})
+	}
+	for _, backend := range src.SecretBackends() {
+		m.AddSecretBackend(SecretBackendArgs{
+			ID:                  backend.Id(),
+			Name:                backend.Name(),
+			BackendType:         backend.BackendType(),
+			Config:              cloned(backend.Config()),
+			TokenRotateInterval: cloned(backend.TokenRotateInterval()),
+		})
+	}
+	for _, secret := range src.Secrets() {
+		args, err := secretArgs(secret)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.AddSecret(args).SetAnnotations(cloned(secret.Annotations()))
+	}
+	for _, secret := range src.RemoteSecrets() {
+		consumer, err := secret.Consumer()
+		if err != nil {
+			return errors.Annotatef(err, "remote secret %q", secret.ID())
+		}
+		m.AddRemoteSecret(RemoteSecretArgs{
+			ID:              secret.ID(),
+			SourceUUID:      secret.SourceUUID(),
+			Consumer:        consumer,
+			Label:           secret.Label(),
+			CurrentRevision: secret.CurrentRevision(),
+			LatestRevision:  secret.LatestRevision(),
+		})
+	}
+	for _, application := range src.RemoteApplications() {
+		copyRemoteApplication(m, application)
+	}
+	for _, connection := range src.OfferConnections() {
+		m.AddOfferConnection(OfferConnectionArgs{
+			OfferUUID:       connection.OfferUUID(),
+			RelationID:      connection.RelationID(),
+			RelationKey:     connection.RelationKey(),
+			UserName:        connection.UserName(),
+			SourceModelUUID: connection.SourceModelUUID(),
+		})
+	}
+	for _, controller := range src.ExternalControllers() {
+		m.AddExternalController(ExternalControllerArgs{
+			Tag:    controller.ID(),
+			Alias:  controller.Alias(),
+			Addrs:  cloned(controller.Addrs()),
+			CACert: controller.CACert(),
+			Models: cloned(controller.Models()),
+		})
+	}
+
+	// The annotations are copied last, as the entities they are set on
+	// must be in the model by then.
+	for tag, annotations := range src.AnnotationsIndex() {
+		parsed, err := names.ParseTag(tag)
+		if err != nil {
+			return errors.Annotatef(err, "annotations of %q", tag)
+		}
+		if err := m.SetAnnotationsForEntity(parsed, cloned(annotations)); err != nil {
+			return errors.Annotatef(err, "annotations of %q", tag)
+		}
+	}
+	return nil
+}
+
+// cloned returns a copy of the value that shares none of its maps, slices
+// or pointers. A nil interface value is returned as it is.
+func cloned[T any](value T) T {
+	var result T
+	reflect.ValueOf(&result).Elem().Set(deepCopy(reflect.ValueOf(&value).Elem()))
+	return result
+}
+
+func copyModelDetails(dst *model, src Model) {
+	if constraints := src.Constraints(); constraints != nil {
+		dst.SetConstraints(constraintsArgs(constraints))
+	}
+	if status := src.Status(); status != nil {
+		dst.SetStatus(statusArgs(status))
+	}
+	dst.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	if credential := src.CloudCredential(); credential != nil {
+		dst.SetCloudCredential(CloudCredentialArgs{
+			Owner:      names.NewUserTag(credential.Owner()),
+			Cloud:      names.NewCloudTag(credential.Cloud()),
+			Name:       credential.Name(),
+			AuthType:   credential.AuthType(),
+			Attributes: cloned(credential.Attributes()),
+		})
+	}
+	if sla := src.SLA(); sla != nil {
+		dst.SetSLA(sla.Level(), sla.Owner(), sla.Credentials())
+	}
+	if meterStatus := src.MeterStatus(); meterStatus != nil {
+		dst.SetMeterStatus(meterStatus.Code(), meterStatus.Info())
+	}
+	if telemetry := src.Telemetry(); telemetry != nil {
+		dst.SetTelemetry(TelemetryArgs{
+			Enabled:        telemetry.Enabled(),
+			LastReportTime: telemetry.LastReportTime(),
+		})
+	}
+	if attempt := src.MigrationAttempt(); attempt != nil {
+		dst.SetMigrationAttempt(MigrationAttemptArgs{
+			Attempt:        attempt.Attempt(),
+			PreviousTarget: attempt.PreviousTarget(),
+			PhaseReached:   attempt.PhaseReached(),
+		})
+	}
+	if lease := src.Lease(); lease != nil {
+		dst.SetLease(leaseArgs(lease))
+	}
+}
+
+func leaseArgs(lease Lease) LeaseArgs {
+	return LeaseArgs{
+		Name:   lease.Name(),
+		Holder: lease.Holder(),
+		Start:  lease.Start(),
+		Expiry: lease.Expiry(),
+		Pinned: lease.Pinned(),
+	}
+}
+
+func userArgs(user User) UserArgs {
+	args := UserArgs{
+		Name:           user.Name(),
+		DisplayName:    user.DisplayName(),
+		CreatedBy:      user.CreatedBy(),
+		DateCreated:    user.DateCreated(),
+		LastConnection: user.LastConnection(),
+		Access:         user.Access(),
+	}
+	for _, grant := range user.AccessHistory() {
+		args.AccessHistory = append(args.AccessHistory, AccessGrantArgs{
+			Access:    grant.Access(),
+			GrantedBy: grant.GrantedBy(),
+			Granted:   grant.Granted(),
+		})
+	}
+	return args
+}
+
+func copyMachine(add func(MachineArgs) Machine, src Machine) {
+	args := MachineArgs{
+		Id:                 src.Tag(),
+		Nonce:              src.Nonce(),
+		PasswordHash:       src.PasswordHash(),
+		Placement:          src.Placement(),
+		Base:               src.Base(),
+		ContainerType:      src.ContainerType(),
+		Life:               storedLife(src.Life()),
+		Jobs:               cloned(src.Jobs()),
+		AgentVersion:       src.AgentVersion(),
+		RebootRequired:     src.RebootRequired(),
+		AgentStartTime:     src.AgentStartTime(),
+		HostnameVerifiedAt: src.HostnameVerifiedAt(),
+	}
+	if containers, ok := src.SupportedContainers(); ok {
+		containers = cloned(containers)
+		args.SupportedContainers = &containers
+	}
+	dst := add(args)
+	if constraints := src.Constraints(); constraints != nil {
+		dst.SetConstraints(constraintsArgs(constraints))
+	}
+	if status := src.Status(); status != nil {
+		dst.SetStatus(statusArgs(status))
+	}
+	dst.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	if instance := src.Instance(); instance != nil {
+		copyInstance(dst, instance)
+	}
+	dst.SetAddresses(addressesArgs(src.MachineAddresses()), addressesArgs(src.ProviderAddresses()))
+	var public, private AddressArgs
+	if address := src.PreferredPublicAddress(); address != nil {
+		public = addressArgs(address)
+	}
+	if address := src.PreferredPrivateAddress(); address != nil {
+		private = addressArgs(address)
+	}
+	dst.SetPreferredAddresses(public, private)
+	if lock := src.UpgradeSeriesLock(); lock != nil {
+		dst.SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+			FromBase:      lock.FromBase(),
+			ToBase:        lock.ToBase(),
+			MachineStatus: lock.MachineStatus(),
+			UnitStatuses:  cloned(lock.UnitStatuses()),
+		})
+	}
+	if tools := src.Tools(); tools != nil {
+		dst.SetTools(toolsArgs(tools))
+	}
+	for _, device := range src.BlockDevices() {
+		dst.AddBlockDevice(blockDeviceArgs(device))
+	}
+	copyPortRanges(src.OpenedPortRanges(), dst.AddOpenedPortRange)
+	for _, container := range src.Containers() {
+		copyMachine(dst.AddContainer, container)
+	}
+}
+
+func copyInstance(dst Machine, src CloudInstance) {
+	dst.SetInstance(CloudInstanceArgs{
+		InstanceId:       src.InstanceId(),
+		DisplayName:      src.DisplayName(),
+		Architecture:     src.Architecture(),
+		Memory:           src.Memory(),
+		RootDisk:         src.RootDisk(),
+		RootDiskSource:   src.RootDiskSource(),
+		CpuCores:         src.CpuCores(),
+		CpuPower:         src.CpuPower(),
+		Tags:             cloned(src.Tags()),
+		AvailabilityZone: src.AvailabilityZone(),
+		VirtType:         src.VirtType(),
+		CharmProfiles:    cloned(src.CharmProfiles()),
+	})
+	instance := dst.Instance()
+	if status := src.Status(); status != nil {
+		instance.SetStatus(statusArgs(status))
+	}
+	instance.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	if status := src.ModificationStatus(); status != nil {
+		instance.SetModificationStatus(statusArgs(status))
+	}
+	for _, device := range src.NetworkInterfaces() {
+		instance.AddNetworkInterface(NetworkInterfaceArgs{
+			DeviceName:        device.DeviceName(),
+			MACAddress:        device.MACAddress(),
+			ProviderID:        device.ProviderID(),
+			ProviderSubnetID:  device.ProviderSubnetID(),
+			ProviderNetworkID: device.ProviderNetworkID(),
+			Addresses:         addressesArgs(device.Addresses()),
+			ShadowAddresses:   addressesArgs(device.ShadowAddresses()),
+		})
+	}
+	for _, previous := range src.PreviousInstances() {
+		instance.AddPreviousInstance(PreviousInstanceArgs{
+			InstanceId: previous.InstanceId(),
+			Replaced:   previous.Replaced(),
+		})
+	}
+}
+
+func copyApplication(dst Model, src Application) {
+	args := ApplicationArgs{
+		Tag:                  src.Tag(),
+		Type:                 src.Type(),
+		Subordinate:          src.Subordinate(),
+		CharmURL:             src.CharmURL(),
+		Channel:              src.Channel(),
+		CharmModifiedVersion: src.CharmModifiedVersion(),
+		ForceCharm:           src.ForceCharm(),
+		Life:                 storedLife(src.Life()),
+		PasswordHash:         src.PasswordHash(),
+		PodSpec:              src.PodSpec(),
+		Placement:            src.Placement(),
+		HasResources:         src.HasResources(),
+		DesiredScale:         src.DesiredScale(),
+		MinUnits:             src.MinUnits(),
+		Exposed:              src.Exposed(),
+		ExposedAt:            src.ExposedAt(),
+		ExposedBy:            src.ExposedBy(),
+		EndpointBindings:     cloned(src.EndpointBindings()),
+		ApplicationConfig:    cloned(src.ApplicationConfig()),
+		CharmConfig:          cloned(src.CharmConfig()),
+		Leader:               src.Leader(),
+		LeadershipSettings:   cloned(src.LeadershipSettings()),
+		MetricsCredentials:   cloned(src.MetricsCredentials()),
+	}
+	if service := src.CloudService(); service != nil {
+		args.CloudService = &CloudServiceArgs{
+			ProviderId: service.ProviderId(),
+			Addresses:  addressesArgs(service.Addresses()),
+		}
+	}
+	if endpoints := src.ExposedEndpoints(); endpoints != nil {
+		args.ExposedEndpoints = make(map[string]ExposedEndpointArgs, len(endpoints))
+		for name, endpoint := range endpoints {
+			args.ExposedEndpoints[name] = ExposedEndpointArgs{
+				ExposeToSpaceIDs: cloned(endpoint.ExposeToSpaceIDs()),
+				ExposeToCIDRs:    cloned(endpoint.ExposeToCIDRs()),
+			}
+		}
+	}
+	if directives := src.StorageDirectives(); directives != nil {
+		args.StorageDirectives = make(map[string]StorageDirectiveArgs, len(directives))
+		for name, directive := range directives {
+			args.StorageDirectives[name] = StorageDirectiveArgs{
+				Pool:  directive.Pool(),
+				Size:  directive.Size(),
+				Count: directive.Count(),
+			}
+		}
+	}
+	if state := src.ProvisioningState(); state != nil {
+		args.ProvisioningState = &ProvisioningStateArgs{
+			Scaling:     state.Scaling(),
+			ScaleTarget: state.ScaleTarget(),
+		}
+	}
+	application := dst.AddApplication(args)
+
+	if constraints := src.Constraints(); constraints != nil {
+		application.SetConstraints(constraintsArgs(constraints))
+	}
+	if status := src.Status(); status != nil {
+		application.SetStatus(statusArgs(status))
+	}
+	application.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	if status := src.OperatorStatus(); status != nil {
+		application.SetOperatorStatus(statusArgs(status))
+	}
+	if origin := src.CharmOrigin(); origin != nil {
+		application.SetCharmOrigin(CharmOriginArgs{
+			Source:   origin.Source(),
+			ID:       origin.ID(),
+			Hash:     origin.Hash(),
+			Revision: origin.Revision(),
+			Channel:  origin.Channel(),
+			Platform: origin.Platform(),
+		})
+	}
+	if metadata := src.CharmMetadata(); metadata != nil {
+		application.SetCharmMetadata(CharmMetadataArgs{
+			Name:           metadata.Name(),
+			Summary:        metadata.Summary(),
+			Description:    metadata.Description(),
+			Subordinate:    metadata.Subordinate(),
+			MinJujuVersion: metadata.MinJujuVersion(),
+			RunAs:          metadata.RunAs(),
+			Assumes:        metadata.Assumes(),
+			Provides:       metadata.Provides(),
+			Peers:          metadata.Peers(),
+			Requires:       metadata.Requires(),
+			ExtraBindings:  cloned(metadata.ExtraBindings()),
+			Categories:     cloned(metadata.Categories()),
+			Tags:           cloned(metadata.Tags()),
+			Storage:        metadata.Storage(),
+			Devices:        metadata.Devices(),
+			Payloads:       metadata.Payloads(),
+			Resources:      metadata.Resources(),
+			Terms:          cloned(metadata.Terms()),
+			Containers:     metadata.Containers(),
+		})
+	}
+	if lease := src.Lease(); lease != nil {
+		application.SetLease(leaseArgs(lease))
+	}
+	if manifest := src.CharmManifest(); manifest != nil {
+		application.SetCharmManifest(CharmManifestArgs{Bases: manifest.Bases()})
+	}
+	if actions := src.CharmActions(); actions != nil {
+		application.SetCharmActions(CharmActionsArgs{Actions: actions.Actions()})
+	}
+	if configs := src.CharmConfigs(); configs != nil {
+		application.SetCharmConfigs(CharmConfigsArgs{Configs: configs.Configs()})
+	}
+	if tools := src.Tools(); tools != nil {
+		application.SetTools(toolsArgs(tools))
+	}
+	for _, offer := range src.Offers() {
+		application.AddOffer(ApplicationOfferArgs{
+			OfferUUID:              offer.OfferUUID(),
+			OfferName:              offer.OfferName(),
+			Endpoints:              cloned(offer.Endpoints()),
+			ACL:                    cloned(offer.ACL()),
+			ApplicationName:        offer.ApplicationName(),
+			ApplicationDescription: offer.ApplicationDescription(),
+		})
+	}
+	copyPortRanges(src.OpenedPortRanges(), application.AddOpenedPortRange)
+	for _, resource := range src.Resources() {
+		copied := application.AddResource(ResourceArgs{Name: resource.Name()})
+		if revision := resource.ApplicationRevision(); revision != nil {
+			copied.SetApplicationRevision(resourceRevisionArgs(revision))
+		}
+		if revision := resource.CharmStoreRevision(); revision != nil {
+			copied.SetCharmStoreRevision(resourceRevisionArgs(revision))
+		}
+	}
+	for _, unit := range src.Units() {
+		copyUnit(application, unit)
+	}
+}
+
+func copyUnit(dst Application, src Unit) {
+	args := UnitArgs{
+		Tag:              src.Tag(),
+		Type:             src.Type(),
+		Machine:          src.Machine(),
+		Life:             storedLife(src.Life()),
+		PasswordHash:     src.PasswordHash(),
+		Nonce:            src.Nonce(),
+		Principal:        src.Principal(),
+		Subordinates:     cloned(src.Subordinates()),
+		WorkloadVersion:  src.WorkloadVersion(),
+		MeterStatusCode:  src.MeterStatusCode(),
+		MeterStatusInfo:  src.MeterStatusInfo(),
+		AgentStartTime:   src.AgentStartTime(),
+		AgentVersion:     src.AgentVersion(),
+		CharmState:       cloned(src.CharmState()),
+		RelationState:    cloned(src.RelationState()),
+		UniterState:      src.UniterState(),
+		StorageState:     src.StorageState(),
+		MeterStatusState: src.MeterStatusState(),
+	}
+	if container := src.CloudContainer(); container != nil {
+		args.CloudContainer = &CloudContainerArgs{
+			ProviderId: container.ProviderId(),
+			Ports:      cloned(container.Ports()),
+		}
+		if address := container.Address(); address != nil {
+			args.CloudContainer.Address = addressArgs(address)
+		}
+	}
+	unit := dst.AddUnit(args)
+
+	if constraints := src.Constraints(); constraints != nil {
+		unit.SetConstraints(constraintsArgs(constraints))
+	}
+	if tools := src.Tools(); tools != nil {
+		unit.SetTools(toolsArgs(tools))
+	}
+	if status := src.WorkloadStatus(); status != nil {
+		unit.SetWorkloadStatus(statusArgs(status))
+	}
+	unit.SetWorkloadStatusHistory(statusHistoryArgs(src.WorkloadStatusHistory()))
+	unit.SetWorkloadVersionHistory(statusHistoryArgs(src.WorkloadVersionHistory()))
+	if status := src.AgentStatus(); status != nil {
+		unit.SetAgentStatus(statusArgs(status))
+	}
+	unit.SetAgentStatusHistory(statusHistoryArgs(src.AgentStatusHistory()))
+	for _, resource := range src.Resources() {
+		unit.AddResource(UnitResourceArgs{
+			Name:         resource.Name(),
+			RevisionArgs: resourceRevisionArgs(resource.Revision()),
+		})
+	}
+	for _, payload := range src.Payloads() {
+		unit.AddPayload(PayloadArgs{
+			Name:   payload.Name(),
+			Type:   payload.Type(),
+			RawID:  payload.RawID(),
+			State:  payload.State(),
+			Labels: cloned(payload.Labels()),
+		})
+	}
+}
+
+func copyRelation(dst Model, src Relation) {
+	relation := dst.AddRelation(RelationArgs{
+		Id:              src.Id(),
+		Key:             src.Key(),
+		Suspended:       src.Suspended(),
+		SuspendedReason: src.SuspendedReason(),
+		Life:            storedLife(src.Life()),
+	})
+	if status := src.Status(); status != nil {
+		relation.SetStatus(statusArgs(status))
+	}
+	for _, endpoint := range src.Endpoints() {
+		copied := relation.AddEndpoint(EndpointArgs{
+			ApplicationName: endpoint.ApplicationName(),
+			Name:            endpoint.Name(),
+			Role:            endpoint.Role(),
+			Interface:       endpoint.Interface(),
+			Optional:        endpoint.Optional(),
+			Limit:           endpoint.Limit(),
+			Scope:           endpoint.Scope(),
+		})
+		CopyEndpointSettings(copied, endpoint)
+	}
+}
+
+func copyVolume(dst Model, src Volume) {
+	volume := dst.AddVolume(VolumeArgs{
+		Tag:         src.Tag(),
+		Storage:     src.Storage(),
+		Provisioned: src.Provisioned(),
+		Size:        src.Size(),
+		Pool:        src.Pool(),
+		HardwareID:  src.HardwareID(),
+		WWN:         src.WWN(),
+		VolumeID:    src.VolumeID(),
+		Persistent:  src.Persistent(),
+		Life:        storedLife(src.Life()),
+		Encrypted:   src.Encrypted(),
+		KMSKeyID:    src.KMSKeyID(),
+		IOPS:        src.IOPS(),
+		Throughput:  src.Throughput(),
+	})
+	if status := src.Status(); status != nil {
+		volume.SetStatus(statusArgs(status))
+	}
+	volume.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	for _, attachment := range src.Attachments() {
+		args := VolumeAttachmentArgs{
+			Host:        attachment.Host(),
+			Provisioned: attachment.Provisioned(),
+			ReadOnly:    attachment.ReadOnly(),
+			DeviceName:  attachment.DeviceName(),
+			DeviceLink:  attachment.DeviceLink(),
+			BusAddress:  attachment.BusAddress(),
+		}
+		if info := attachment.VolumePlanInfo(); info != nil {
+			args.DeviceType = info.DeviceType()
+			args.DeviceAttributes = cloned(info.DeviceAttributes())
+		}
+		volume.AddAttachment(args)
+	}
+	for _, plan := range src.AttachmentPlans() {
+		args := VolumeAttachmentPlanArgs{Machine: plan.Machine()}
+		if device := plan.BlockDevice(); device != nil {
+			args.DeviceName = device.Name()
+			args.DeviceLinks = cloned(device.Links())
+			args.Label = device.Label()
+			args.UUID = device.UUID()
+			args.HardwareId = device.HardwareID()
+			args.WWN = device.WWN()
+			args.BusAddress = device.BusAddress()
+			args.Size = device.Size()
+			args.FilesystemType = device.FilesystemType()
+			args.InUse = device.InUse()
+			args.MountPoint = device.MountPoint()
+		}
+		if info := plan.VolumePlanInfo(); info != nil {
+			args.DeviceType = info.DeviceType()
+			args.DeviceAttributes = cloned(info.DeviceAttributes())
+		}
+		volume.AddAttachmentPlan(args)
+	}
+}
+
+func copyFilesystem(dst Model, src Filesystem) {
+	filesystem := dst.AddFilesystem(FilesystemArgs{
+		Tag:          src.Tag(),
+		Storage:      src.Storage(),
+		Volume:       src.Volume(),
+		Provisioned:  src.Provisioned(),
+		Size:         src.Size(),
+		Pool:         src.Pool(),
+		FilesystemID: src.FilesystemID(),
+		Life:         storedLife(src.Life()),
+	})
+	if status := src.Status(); status != nil {
+		filesystem.SetStatus(statusArgs(status))
+	}
+	filesystem.SetStatusHistory(statusHistoryArgs(src.StatusHistory()))
+	for _, attachment := range src.Attachments() {
+		filesystem.AddAttachment(FilesystemAttachmentArgs{
+			Host:        attachment.Host(),
+			Provisioned: attachment.Provisioned(),
+			ReadOnly:    attachment.ReadOnly(),
+			MountPoint:  attachment.MountPoint(),
+		})
+	}
+}
+
+func copyRemoteApplication(dst Model, src RemoteApplication) {
+	application := dst.AddRemoteApplication(RemoteApplicationArgs{
+		Tag:             src.Tag(),
+		OfferUUID:       src.OfferUUID(),
+		URL:             src.URL(),
+		SourceModel:     src.SourceModelTag(),
+		IsConsumerProxy: src.IsConsumerProxy(),
+		ConsumeVersion:  src.ConsumeVersion(),
+		Macaroon:        src.Macaroon(),
+		Bindings:        cloned(src.Bindings()),
+	})
+	if status := src.Status(); status != nil {
+		application.SetStatus(statusArgs(status))
+	}
+	for _, endpoint := range src.Endpoints() {
+		application.AddEndpoint(RemoteEndpointArgs{
+			Name:      endpoint.Name(),
+			Role:      endpoint.Role(),
+			Interface: endpoint.Interface(),
+		})
+	}
+	for _, space := range src.Spaces() {
+		copied := application.AddSpace(RemoteSpaceArgs{
+			CloudType:          space.CloudType(),
+			Name:               space.Name(),
+			ProviderId:         space.ProviderId(),
+			ProviderAttributes: cloned(space.ProviderAttributes()),
+		})
+		for _, subnet := range space.Subnets() {
+			copied.AddSubnet(subnetArgs(subnet))
+		}
+	}
+}
+
+func storageArgs(storage Storage) (StorageArgs, error) {
+	owner, err := storage.Owner()
+	if err != nil {
+		return StorageArgs{}, errors.Annotatef(err, "storage %q", storage.Tag().Id())
+	}
+	args := StorageArgs{
+		Tag:         storage.Tag(),
+		Kind:        storage.Kind(),
+		Owner:       owner,
+		Name:        storage.Name(),
+		Life:        storedLife(storage.Life()),
+		Attachments: cloned(storage.Attachments()),
+	}
+	if constraints, ok := storage.Constraints(); ok {
+		args.Constraints = &constraints
+	}
+	return args, nil
+}
+
+func secretArgs(secret Secret) (SecretArgs, error) {
+	owner, err := secret.Owner()
+	if err != nil {
+		return SecretArgs{}, errors.Annotatef(err, "secret %q", secret.Id())
+	}
+	args := SecretArgs{
+		ID:                     secret.Id(),
+		Version:                secret.Version(),
+		Description:            secret.Description(),
+		Label:                  secret.Label(),
+		RotatePolicy:           secret.RotatePolicy(),
+		AutoPrune:              secret.AutoPrune(),
+		Owner:                  owner,
+		Created:                secret.Created(),
+		Updated:                secret.Updated(),
+		NextRotateTime:         cloned(secret.NextRotateTime()),
+		LatestRevisionChecksum: secret.LatestRevisionChecksum(),
+	}
+	for _, revision := range secret.Revisions() {
+		revisionArgs := SecretRevisionArgs{
+			Number:        revision.Number(),
+			Created:       revision.Created(),
+			Updated:       revision.Updated(),
+			Obsolete:      revision.Obsolete(),
+			PendingDelete: revision.PendingDelete(),
+			Content:       cloned(revision.Content()),
+			ExpireTime:    cloned(revision.ExpireTime()),
+		}
+		if ref := revision.ValueRef(); ref != nil {
+			revisionArgs.ValueRef = &SecretValueRefArgs{
+				BackendID:  ref.BackendID(),
+				RevisionID: ref.RevisionID(),
+			}
+		}
+		args.Revisions = append(args.Revisions, revisionArgs)
+	}
+	if acl := secret.ACL(); acl != nil {
+		args.ACL = make(map[string]SecretAccessArgs, len(acl))
+		for subject, access := range acl {
+			args.ACL[subject] = SecretAccessArgs{
+				Scope: access.Scope(),
+				Role:  access.Role(),
+			}
+		}
+	}
+	for _, consumer := range secret.Consumers() {
+		tag, err := consumer.Consumer()
+		if err != nil {
+			return SecretArgs{}, errors.Annotatef(err, "secret %q consumer", secret.Id())
+		}
+		args.Consumers = append(args.Consumers, SecretConsumerArgs{
+			Consumer:        tag,
+			Label:           consumer.Label(),
+			CurrentRevision: consumer.CurrentRevision(),
+		})
+	}
+	for _, consumer := range secret.RemoteConsumers() {
+		tag, err := consumer.Consumer()
+		if err != nil {
+			return SecretArgs{}, errors.Annotatef(err, "secret %q remote consumer", secret.Id())
+		}
+		args.RemoteConsumers = append(args.RemoteConsumers, SecretRemoteConsumerArgs{
+			ID:              consumer.ID(),
+			Consumer:        tag,
+			CurrentRevision: consumer.CurrentRevision(),
+		})
+	}
+	return args, nil
+}
+
+func actionArgs(action Action) ActionArgs {
+	args := ActionArgs{
+		Id:             action.Id(),
+		Receiver:       action.Receiver(),
+		Name:           action.Name(),
+		Operation:      action.Operation(),
+		Parameters:     cloned(action.Parameters()),
+		Parallel:       action.Parallel(),
+		ExecutionGroup: action.ExecutionGroup(),
+		Enqueued:       action.Enqueued(),
+		Started:        action.Started(),
+		Completed:      action.Completed(),
+		Status:         action.Status(),
+		Message:        action.Message(),
+		Results:        cloned(action.Results()),
+	}
+	for _, message := range action.Logs() {
+		args.Messages = append(args.Messages, message)
+	}
+	return args
+}
+
+func cloudImageMetadataArgs(metadata CloudImageMetadata) CloudImageMetadataArgs {
+	args := CloudImageMetadataArgs{
+		Stream:            metadata.Stream(),
+		Region:            metadata.Region(),
+		Version:           metadata.Version(),
+		Arch:              metadata.Arch(),
+		VirtType:          metadata.VirtType(),
+		RootStorageType:   metadata.RootStorageType(),
+		DateCreated:       metadata.DateCreated(),
+		Source:            metadata.Source(),
+		Priority:          metadata.Priority(),
+		ImageId:           metadata.ImageId(),
+		ExpireAt:          cloned(metadata.ExpireAt()),
+		Signature:         metadata.Signature(),
+		SourceFingerprint: metadata.SourceFingerprint(),
+	}
+	if size, ok := metadata.RootStorageSize(); ok {
+		args.RootStorageSize = &size
+	}
+	return args
+}
+
+func subnetArgs(subnet Subnet) SubnetArgs {
+	return SubnetArgs{
+		ID:                subnet.ID(),
+		ProviderId:        subnet.ProviderId(),
+		ProviderNetworkId: subnet.ProviderNetworkId(),
+		ProviderSpaceId:   subnet.ProviderSpaceId(),
+		CIDR:              subnet.CIDR(),
+		VLANTag:           subnet.VLANTag(),
+		AvailabilityZones: cloned(subnet.AvailabilityZones()),
+		IsPublic:          subnet.IsPublic(),
+		SpaceName:         subnet.SpaceName(),
+		SpaceID:           subnet.SpaceID(),
+		FanLocalUnderlay:  subnet.FanLocalUnderlay(),
+		FanOverlay:        subnet.FanOverlay(),
+	}
+}
+
+func copyPortRanges(ranges PortRanges, add func(OpenedPortRangeArgs)) {
+	if ranges == nil {
+		return
+	}
+	for unitName, unitRanges := range ranges.ByUnit() {
+		for endpointName, endpointRanges := range unitRanges.ByEndpoint() {
+			for _, portRange := range endpointRanges {
+				add(OpenedPortRangeArgs{
+					UnitName:     unitName,
+					EndpointName: endpointName,
+					FromPort:     portRange.FromPort(),
+					ToPort:       portRange.ToPort(),
+					Protocol:     portRange.Protocol(),
+				})
+			}
+		}
+	}
+}
+
+func resourceRevisionArgs(revision ResourceRevision) ResourceRevisionArgs {
+	args := ResourceRevisionArgs{
+		Revision:       revision.Revision(),
+		Type:           revision.Type(),
+		Path:           revision.Path(),
+		Description:    revision.Description(),
+		Origin:         revision.Origin(),
+		FingerprintHex: revision.FingerprintHex(),
+		Size:           revision.Size(),
+		Timestamp:      revision.Timestamp(),
+		Username:       revision.Username(),
+	}
+	if image := revision.Image(); image != nil {
+		args.Image = &ResourceImageArgs{
+			RegistryPath: image.RegistryPath(),
+			Username:     image.Username(),
+			Password:     image.Password(),
+			PasswordHash: image.PasswordHash(),
+			SecretRef:    image.SecretRef(),
+		}
+	}
+	return args
+}
+
+func blockDeviceArgs(device BlockDevice) BlockDeviceArgs {
+	return BlockDeviceArgs{
+		Name:           device.Name(),
+		Links:          cloned(device.Links()),
+		Label:          device.Label(),
+		UUID:           device.UUID(),
+		HardwareID:     device.HardwareID(),
+		WWN:            device.WWN(),
+		BusAddress:     device.BusAddress(),
+		SerialID:       device.SerialID(),
+		Size:           device.Size(),
+		FilesystemType: device.FilesystemType(),
+		InUse:          device.InUse(),
+		MountPoint:     device.MountPoint(),
+	}
+}
+
+func constraintsArgs(constraints Constraints) ConstraintsArgs {
+	return ConstraintsArgs{
+		AllocatePublicIP: constraints.AllocatePublicIP(),
+		Architecture:     constraints.Architecture(),
+		Container:        constraints.Container(),
+		CpuCores:         constraints.CpuCores(),
+		CpuPower:         constraints.CpuPower(),
+		ImageID:          constraints.ImageID(),
+		InstanceType:     constraints.InstanceType(),
+		Memory:           constraints.Memory(),
+		RootDisk:         constraints.RootDisk(),
+		RootDiskSource:   constraints.RootDiskSource(),
+		Spaces:           cloned(constraints.Spaces()),
+		Tags:             cloned(constraints.Tags()),
+		Zones:            cloned(constraints.Zones()),
+		VirtType:         constraints.VirtType(),
+	}
+}
+
+func toolsArgs(tools AgentTools) AgentToolsArgs {
+	return AgentToolsArgs{
+		Version: tools.Version(),
+		URL:     tools.URL(),
+		SHA256:  tools.SHA256(),
+		Size:    tools.Size(),
+	}
+}
+
+func statusArgs(status Status) StatusArgs {
+	return StatusArgs{
+		Value:    status.Value(),
+		Message:  status.Message(),
+		Data:     cloned(status.Data()),
+		Updated:  status.Updated(),
+		NeverSet: status.NeverSet(),
+	}
+}
+
+func statusHistoryArgs(history []Status) []StatusArgs {
+	var result []StatusArgs
+	for _, status := range history {
+		result = append(result, statusArgs(status))
+	}
+	return result
+}
+
+func addressArgs(address Address) AddressArgs {
+	return AddressArgs{
+		Value:   address.Value(),
+		Type:    address.Type(),
+		Scope:   address.Scope(),
+		Origin:  address.Origin(),
+		SpaceID: address.SpaceID(),
+	}
+}
+
+func addressesArgs(addresses []Address) []AddressArgs {
+	var result []AddressArgs
+	for _, address := range addresses {
+		result = append(result, addressArgs(address))
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CopyIntoSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CopyIntoSuite{})
+
+// foreignModel stands in for an implementation of Model from outside the
+// package, which CopyInto can only read through the interface.
+type foreignModel struct {
+	Model
+}
+
+// checkCopyInto copies src into dst, reading it only through the Model
+// interface, and checks that dst is written out as src was before the
+// copy. Reading the opened port ranges of
+// a machine or application adds an empty section to src, so it isn't
+// written out again afterwards.
+func (s *CopyIntoSuite) checkCopyInto(c *gc.C, dst, src Model) {
+	expected, err := Serialize(src)
+	c.Assert(err, jc.ErrorIsNil)
+	err = CopyInto(dst, foreignModel{src})
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := Serialize(dst)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(obtained), gc.Equals, string(expected))
+}
+
+func (s *CopyIntoSuite) TestCopyInto(c *gc.C) {
+	src := selfTestModel()
+	dst := NewModel(ModelArgs{})
+	s.checkCopyInto(c, dst, src)
+	c.Assert(dst.Validate(), jc.ErrorIsNil)
+
+	(&CopySuite{}).checkSharesNothing(c, reflect.ValueOf(src), reflect.ValueOf(dst), "model")
+}
+
+func (s *CopyIntoSuite) TestCopyIntoReplacesModelArgs(c *gc.C) {
+	src := selfTestModel()
+	dst := NewModel(ModelArgs{
+		Owner:       names.NewUserTag("someone"),
+		Cloud:       "elsewhere",
+		Description: "replaced",
+	})
+	err := CopyInto(dst, src)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dst.Owner(), gc.Equals, src.Owner())
+	c.Check(dst.Cloud(), gc.Equals, "vapour")
+	c.Check(dst.Description(), gc.Equals, "self-test model")
+	c.Check(dst.Tag(), gc.Equals, src.Tag())
+}
+
+func (s *CopyIntoSuite) TestCopyIntoSynchronized(c *gc.C) {
+	src := selfTestModel()
+	s.checkCopyInto(c, NewSynchronizedModel(NewModel(ModelArgs{})), src)
+}
+
+func (s *CopyIntoSuite) TestCopyIntoFromImport(c *gc.C) {
+	bytes, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	src, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	src.Freeze()
+
+	dst := NewModel(ModelArgs{})
+	s.checkCopyInto(c, dst, src)
+
+	// The copy can be changed, even though the source is frozen.
+	dst.AddMachine(MachineArgs{Id: names.NewMachineTag("5")})
+	c.Assert(src.Machines(), gc.HasLen, 1)
+}
+
+func (s *CopyIntoSuite) TestCopyIntoEntityDetails(c *gc.C) {
+	src := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	machine := src.AddMachine(MachineArgs{
+		Id:                  names.NewMachineTag("0"),
+		SupportedContainers: &[]string{"lxd"},
+	})
+	machine.SetInstance(CloudInstanceArgs{InstanceId: "i-1"})
+	machine.Instance().AddPreviousInstance(PreviousInstanceArgs{InstanceId: "i-0"})
+	machine.AddOpenedPortRange(OpenedPortRangeArgs{
+		UnitName:     "ubuntu/0",
+		EndpointName: "web",
+		FromPort:     80,
+		ToPort:       80,
+		Protocol:     "tcp",
+	})
+	application := src.AddApplication(ApplicationArgs{
+		Tag:               names.NewApplicationTag("ubuntu"),
+		CloudService:      &CloudServiceArgs{ProviderId: "service"},
+		ProvisioningState: &ProvisioningStateArgs{Scaling: true, ScaleTarget: 2},
+		StorageDirectives: map[string]StorageDirectiveArgs{"data": {Pool: "fast", Count: 1}},
+	})
+	resource := application.AddResource(ResourceArgs{Name: "image"})
+	resource.SetApplicationRevision(ResourceRevisionArgs{
+		Revision: 1,
+		Type:     "oci-image",
+		Image:    &ResourceImageArgs{RegistryPath: "registry/image"},
+	})
+	unit := application.AddUnit(UnitArgs{
+		Tag:            names.NewUnitTag("ubuntu/0"),
+		CloudContainer: &CloudContainerArgs{ProviderId: "pod", Ports: []string{"80"}},
+		CharmState:     map[string]string{"key": "value"},
+	})
+	unit.AddPayload(PayloadArgs{Name: "payload", Type: "docker", RawID: "id", State: "running"})
+	remote := src.AddRemoteApplication(RemoteApplicationArgs{Tag: names.NewApplicationTag("mysql")})
+	space := remote.AddSpace(RemoteSpaceArgs{Name: "db"})
+	space.AddSubnet(SubnetArgs{CIDR: "10.1.0.0/24"})
+	volume := src.AddVolume(VolumeArgs{Tag: names.NewVolumeTag("0")})
+	volume.AddAttachment(VolumeAttachmentArgs{Host: names.NewMachineTag("0"), DeviceType: "iscsi"})
+	volume.AddAttachmentPlan(VolumeAttachmentPlanArgs{Machine: names.NewMachineTag("0"), DeviceName: "sdb"})
+	filesystem := src.AddFilesystem(FilesystemArgs{Tag: names.NewFilesystemTag("0")})
+	filesystem.AddAttachment(FilesystemAttachmentArgs{Host: names.NewMachineTag("0"), MountPoint: "/srv"})
+
+	s.checkCopyInto(c, NewModel(ModelArgs{}), src)
+}
+
+func (s *CopyIntoSuite) TestCopyIntoForeignModel(c *gc.C) {
+	err := CopyInto(foreignModel{NewModel(ModelArgs{})}, selfTestModel())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `copying into description.foreignModel not supported`)
+}
+
+func (s *CopyIntoSuite) TestCopyIntoFrozen(c *gc.C) {
+	dst := NewModel(ModelArgs{})
+	dst.Freeze()
+	err := CopyInto(dst, selfTestModel())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `copying into a frozen model not supported`)
+}
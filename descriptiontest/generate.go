@@ -0,0 +1,245 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package descriptiontest generates models for the tests and benchmarks
+// of code that exports, imports or migrates them.
+package descriptiontest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+	"github.com/rs/xid"
+
+	"github.com/juju/description/v7"
+)
+
+// Spec describes the size of a generated model. The zero Spec generates a
+// model with none of the entities counted here.
+type Spec struct {
+	// Machines is the number of machines. Units are placed on the
+	// machines in turn, and if there are units but no machines, there is
+	// one machine.
+	Machines int
+
+	// Applications is the number of applications. If there are units per
+	// application but no applications, there is one application.
+	Applications int
+
+	// UnitsPerApp is the number of units of each application.
+	UnitsPerApp int
+
+	// Secrets is the number of secrets, owned by the applications in
+	// turn, each with two revisions. There must be an application for
+	// there to be secrets.
+	Secrets int
+
+	// StatusHistory is the number of entries in the status history of
+	// each machine, application and unit.
+	StatusHistory int
+}
+
+// epoch is when everything in a generated model happened, so that models
+// generated from the same Spec are the same.
+var epoch = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// GenerateModel returns a valid IAAS model of the size described by the
+// spec. Each application is a peer of itself, with a relation carrying
+// settings for each of its units, and is led by its first unit. The model
+// is the same each time it is generated from the same spec.
+func GenerateModel(spec Spec) description.Model {
+	if spec.UnitsPerApp > 0 && spec.Applications == 0 {
+		spec.Applications = 1
+	}
+	if spec.Applications > 0 && spec.UnitsPerApp > 0 && spec.Machines == 0 {
+		spec.Machines = 1
+	}
+	if spec.Applications == 0 {
+		spec.Secrets = 0
+	}
+
+	owner := names.NewUserTag("admin")
+	m := description.NewModel(description.ModelArgs{
+		AgentVersion: "3.1.1",
+		Type:         description.IAAS,
+		Owner:        owner,
+		Config: map[string]interface{}{
+			"name": "generated",
+			"uuid": "a7c5e1d2-9b3f-4e6a-8c2d-1f0e9d8c7b6a",
+		},
+		LatestToolsVersion: version.MustParse("3.1.1"),
+		Cloud:              "vapour",
+		CloudRegion:        "east",
+	})
+	m.SetStatus(status("available", 0))
+	m.AddUser(description.UserArgs{
+		Name:        owner,
+		CreatedBy:   owner,
+		DateCreated: epoch,
+		Access:      "admin",
+	})
+
+	var machines []names.MachineTag
+	for i := 0; i < spec.Machines; i++ {
+		machines = append(machines, addMachine(m, spec, i))
+	}
+	var applications []names.ApplicationTag
+	for i := 0; i < spec.Applications; i++ {
+		applications = append(applications, addApplication(m, spec, i, machines))
+	}
+	for i := 0; i < spec.Secrets; i++ {
+		addSecret(m, i, applications[i%len(applications)])
+	}
+	return m
+}
+
+func addMachine(m description.Model, spec Spec, i int) names.MachineTag {
+	tag := names.NewMachineTag(fmt.Sprint(i))
+	machine := m.AddMachine(description.MachineArgs{
+		Id:           tag,
+		Nonce:        fmt.Sprintf("nonce-%d", i),
+		PasswordHash: fmt.Sprintf("machine-%d-hash", i),
+		Base:         "ubuntu@22.04",
+		Jobs:         []string{"host-units"},
+	})
+	machine.SetInstance(description.CloudInstanceArgs{
+		InstanceId:   fmt.Sprintf("i-%08x", i),
+		Architecture: "amd64",
+	})
+	machine.Instance().SetStatus(status("running", 0))
+	machine.Instance().SetModificationStatus(status("idle", 0))
+	machine.SetTools(tools())
+	machine.SetStatus(status("started", 0))
+	machine.SetStatusHistory(statusHistory("started", spec.StatusHistory))
+	machine.SetAddresses(
+		[]description.AddressArgs{{Value: address("10", i), Type: "ipv4", Scope: "local-cloud"}},
+		[]description.AddressArgs{{Value: address("100", i), Type: "ipv4", Scope: "public"}},
+	)
+	return tag
+}
+
+func addApplication(m description.Model, spec Spec, i int, machines []names.MachineTag) names.ApplicationTag {
+	name := fmt.Sprintf("app%d", i)
+	tag := names.NewApplicationTag(name)
+	charmURL := fmt.Sprintf("ch:amd64/jammy/%s-1", name)
+	args := description.ApplicationArgs{
+		Tag:                  tag,
+		Type:                 description.IAAS,
+		CharmURL:             charmURL,
+		Channel:              "stable",
+		CharmModifiedVersion: 1,
+		CharmConfig:          map[string]interface{}{"index": i},
+	}
+	if spec.UnitsPerApp > 0 {
+		args.Leader = name + "/0"
+		args.LeadershipSettings = map[string]interface{}{"leader": args.Leader}
+	}
+	application := m.AddApplication(args)
+	application.SetStatus(status("active", 0))
+	application.SetStatusHistory(statusHistory("active", spec.StatusHistory))
+	application.SetCharmOrigin(description.CharmOriginArgs{
+		Source:   "charm-hub",
+		Revision: 1,
+		Channel:  "stable",
+		Platform: "amd64/ubuntu/22.04",
+	})
+	m.AddCharm(description.CharmArgs{
+		URL:         charmURL,
+		Revision:    1,
+		StoragePath: "charms/" + name + "-1",
+		SHA256:      fmt.Sprintf("%064x", i),
+	})
+
+	relation := m.AddRelation(description.RelationArgs{Id: i, Key: name + ":peer"})
+	relation.SetStatus(status("joined", 0))
+	endpoint := relation.AddEndpoint(description.EndpointArgs{
+		ApplicationName: name,
+		Name:            "peer",
+		Role:            "peer",
+		Interface:       name + "-peer",
+		Scope:           "global",
+	})
+
+	for j := 0; j < spec.UnitsPerApp; j++ {
+		unitName := fmt.Sprintf("%s/%d", name, j)
+		unit := application.AddUnit(description.UnitArgs{
+			Tag:             names.NewUnitTag(unitName),
+			Type:            description.IAAS,
+			Machine:         machines[(i*spec.UnitsPerApp+j)%len(machines)],
+			PasswordHash:    fmt.Sprintf("unit-%s-%d-hash", name, j),
+			WorkloadVersion: "1.0",
+		})
+		unit.SetTools(tools())
+		unit.SetAgentStatus(status("idle", 0))
+		unit.SetAgentStatusHistory(statusHistory("idle", spec.StatusHistory))
+		unit.SetWorkloadStatus(status("active", 0))
+		unit.SetWorkloadStatusHistory(statusHistory("active", spec.StatusHistory))
+		endpoint.SetUnitSettings(unitName, map[string]interface{}{"unit": unitName})
+	}
+	return tag
+}
+
+func addSecret(m description.Model, i int, owner names.ApplicationTag) {
+	m.AddSecret(description.SecretArgs{
+		ID:      secretID(i),
+		Version: 1,
+		Owner:   owner,
+		Created: epoch,
+		Updated: epoch,
+		Revisions: []description.SecretRevisionArgs{{
+			Number:  1,
+			Created: epoch,
+			Updated: epoch,
+			Content: map[string]string{"password": "c2VjcmV0"},
+		}, {
+			Number:  2,
+			Created: epoch,
+			Updated: epoch.Add(time.Hour),
+			Content: map[string]string{"password": "cm90YXRlZA=="},
+		}},
+		ACL: map[string]description.SecretAccessArgs{
+			owner.String(): {Scope: owner.String(), Role: "manage"},
+		},
+	})
+}
+
+// secretID returns the i'th secret ID. Secret IDs are xids, which would
+// otherwise differ each time they were generated.
+func secretID(i int) string {
+	var id xid.ID
+	binary.BigEndian.PutUint32(id[:4], uint32(epoch.Unix()))
+	binary.BigEndian.PutUint32(id[8:], uint32(i))
+	return id.String()
+}
+
+// address returns the i'th address in the /8 network.
+func address(network string, i int) string {
+	return fmt.Sprintf("%s.%d.%d.%d", network, (i>>16)&0xff, (i>>8)&0xff, i&0xff)
+}
+
+func status(value string, i int) description.StatusArgs {
+	return description.StatusArgs{
+		Value:   value,
+		Updated: epoch.Add(time.Duration(i) * time.Minute),
+	}
+}
+
+func statusHistory(value string, count int) []description.StatusArgs {
+	history := make([]description.StatusArgs, count)
+	for i := range history {
+		history[i] = status(value, i)
+	}
+	return history
+}
+
+func tools() description.AgentToolsArgs {
+	return description.AgentToolsArgs{
+		Version: version.MustParseBinary("3.1.1-ubuntu-amd64"),
+		URL:     "https://example.com/tools/3.1.1-ubuntu-amd64.tgz",
+		SHA256:  "deadbeef",
+		Size:    1024,
+	}
+}
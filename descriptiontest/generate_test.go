@@ -0,0 +1,139 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package descriptiontest
+
+import (
+	"runtime"
+	stdtesting "testing"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/description/v7"
+)
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type GenerateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&GenerateSuite{})
+
+func (s *GenerateSuite) TestGenerateModel(c *gc.C) {
+	m := GenerateModel(Spec{
+		Machines:      4,
+		Applications:  3,
+		UnitsPerApp:   5,
+		Secrets:       7,
+		StatusHistory: 2,
+	})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+	c.Check(m.Machines(), gc.HasLen, 4)
+	c.Check(m.Applications(), gc.HasLen, 3)
+	c.Check(m.Relations(), gc.HasLen, 3)
+	c.Check(m.Secrets(), gc.HasLen, 7)
+	for _, application := range m.Applications() {
+		c.Check(application.Units(), gc.HasLen, 5)
+		c.Check(application.Leader(), gc.Equals, application.Name()+"/0")
+	}
+	c.Check(m.Machines()[0].StatusHistory(), gc.HasLen, 2)
+}
+
+func (s *GenerateSuite) TestGenerateModelRoundTrip(c *gc.C) {
+	m := GenerateModel(Spec{Machines: 2, UnitsPerApp: 3, Secrets: 2, StatusHistory: 1})
+	bytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := description.Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	reexported, err := description.Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reexported), gc.Equals, string(bytes))
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+}
+
+func (s *GenerateSuite) TestGenerateModelRepeatable(c *gc.C) {
+	spec := Spec{Machines: 3, Applications: 2, UnitsPerApp: 2, Secrets: 3}
+	first, err := description.Serialize(GenerateModel(spec))
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := description.Serialize(GenerateModel(spec))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(second), gc.Equals, string(first))
+}
+
+func (s *GenerateSuite) TestGenerateModelDefaults(c *gc.C) {
+	m := GenerateModel(Spec{UnitsPerApp: 2})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+	c.Check(m.Machines(), gc.HasLen, 1)
+	c.Check(m.Applications(), gc.HasLen, 1)
+
+	m = GenerateModel(Spec{Secrets: 2})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+	c.Check(m.Secrets(), gc.HasLen, 0)
+}
+
+// TestEstimateMemoryFootprint checks the estimate against the memory a
+// generated model holds on to once imported. The estimate is meant to be
+// generous, so it must be no less than that, and no more than ten times it.
+func (s *GenerateSuite) TestEstimateMemoryFootprint(c *gc.C) {
+	bytes, err := description.Serialize(GenerateModel(Spec{
+		Machines:      200,
+		Applications:  20,
+		UnitsPerApp:   20,
+		Secrets:       20,
+		StatusHistory: 2,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	estimate, err := description.EstimateMemoryFootprint(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	m, err := description.Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(m)
+
+	held := int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	comment := gc.Commentf("estimated %d bytes, imported model holds %d", estimate, held)
+	c.Check(estimate >= held, jc.IsTrue, comment)
+	c.Check(estimate <= 10*held, jc.IsTrue, comment)
+}
+
+var benchmarkSpec = Spec{
+	Machines:      1000,
+	Applications:  20,
+	UnitsPerApp:   50,
+	Secrets:       200,
+	StatusHistory: 20,
+}
+
+func BenchmarkSerialize(b *stdtesting.B) {
+	m := GenerateModel(benchmarkSpec)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := description.Serialize(m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeserialize(b *stdtesting.B) {
+	bytes, err := description.Serialize(GenerateModel(benchmarkSpec))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := description.Deserialize(bytes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
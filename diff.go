@@ -0,0 +1,380 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ModelDiff describes the differences between two model descriptions,
+// such as a model before export and the same model after import.
+type ModelDiff struct {
+	Machines           EntityDiff
+	Applications       EntityDiff
+	Units              EntityDiff
+	Charms             EntityDiff
+	Relations          EntityDiff
+	RemoteApplications EntityDiff
+	SecretBackends     EntityDiff
+	Secrets            EntityDiff
+	Users              EntityDiff
+	Spaces             EntityDiff
+	Subnets            EntityDiff
+	Storages           EntityDiff
+	Volumes            EntityDiff
+	Filesystems        EntityDiff
+}
+
+// Empty returns true if the models being compared had no differences.
+func (d ModelDiff) Empty() bool {
+	for _, section := range d.sections() {
+		if !section.diff.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// diffSection is the diff of one kind of entity, named as the section of
+// the serialized model holding them.
+type diffSection struct {
+	name string
+	diff EntityDiff
+}
+
+// sections returns the diffs of each kind of entity, in the order the
+// renderers write them.
+func (d ModelDiff) sections() []diffSection {
+	return []diffSection{
+		{"machines", d.Machines},
+		{"applications", d.Applications},
+		{"units", d.Units},
+		{"charms", d.Charms},
+		{"relations", d.Relations},
+		{"remote-applications", d.RemoteApplications},
+		{"secret-backends", d.SecretBackends},
+		{"secrets", d.Secrets},
+		{"users", d.Users},
+		{"spaces", d.Spaces},
+		{"subnets", d.Subnets},
+		{"storages", d.Storages},
+		{"volumes", d.Volumes},
+		{"filesystems", d.Filesystems},
+	}
+}
+
+// EntityDiff holds the sorted keys of the entities of one kind that were
+// only in the second model (Added), only in the first (Removed), or in
+// both but with different fields (Changed). Entities are keyed by id, name
+// or relation key, as appropriate for the kind. Changes to a unit or
+// container are also reported against the application or machine holding
+// it.
+type EntityDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	// Fields holds, for each changed entity, the sorted paths of the
+	// serialized fields that differ, such as "uniter-state" or
+	// "status.status.value". List items are given by their index, as in
+	// "units.units[0].uniter-state".
+	Fields map[string][]string `json:"fields,omitempty"`
+}
+
+// Empty returns true if there were no differences in the entities.
+func (d EntityDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the models and returns the entities that were added,
+// removed or changed from a to b.
+func Diff(a, b Model) ModelDiff {
+	return ModelDiff{
+		Machines:           diffEntities(machineEntities(a), machineEntities(b)),
+		Applications:       diffEntities(applicationEntities(a), applicationEntities(b)),
+		Units:              diffEntities(unitEntities(a), unitEntities(b)),
+		Charms:             diffEntities(charmEntities(a), charmEntities(b)),
+		Relations:          diffEntities(relationEntities(a), relationEntities(b)),
+		RemoteApplications: diffEntities(remoteApplicationEntities(a), remoteApplicationEntities(b)),
+		SecretBackends:     diffEntities(secretBackendEntities(a), secretBackendEntities(b)),
+		Secrets:            diffEntities(secretEntities(a), secretEntities(b)),
+		Users:              diffEntities(userEntities(a), userEntities(b)),
+		Spaces:             diffEntities(spaceEntities(a), spaceEntities(b)),
+		Subnets:            diffEntities(subnetEntities(a), subnetEntities(b)),
+		Storages:           diffEntities(storageEntities(a), storageEntities(b)),
+		Volumes:            diffEntities(volumeEntities(a), volumeEntities(b)),
+		Filesystems:        diffEntities(filesystemEntities(a), filesystemEntities(b)),
+	}
+}
+
+func diffEntities(a, b map[string]interface{}) EntityDiff {
+	var result EntityDiff
+	for key, before := range a {
+		after, ok := b[key]
+		if !ok {
+			result.Removed = append(result.Removed, key)
+			continue
+		}
+		if fields := changedFields(before, after); len(fields) > 0 {
+			result.Changed = append(result.Changed, key)
+			if result.Fields == nil {
+				result.Fields = make(map[string][]string)
+			}
+			result.Fields[key] = fields
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			result.Added = append(result.Added, key)
+		}
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Strings(result.Changed)
+	return result
+}
+
+// changedFields compares the serialized forms, which is what survives a
+// migration, and returns the sorted paths of the fields that differ.
+func changedFields(a, b interface{}) []string {
+	before, err := serializedFields(a)
+	if err != nil {
+		return []string{""}
+	}
+	after, err := serializedFields(b)
+	if err != nil {
+		return []string{""}
+	}
+	var fields []string
+	diffValues("", before, after, &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+func serializedFields(entity interface{}) (interface{}, error) {
+	bytes, err := yaml.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields interface{}
+	if err := yaml.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffValues adds to fields the paths under path at which a and b differ.
+// Differences that an import irons out aren't reported: a field that is
+// missing is the same as one with an empty value, and times are the same
+// if they are the same instant, whatever their location.
+func diffValues(path string, a, b interface{}, fields *[]string) {
+	if isEmptyValue(a) && isEmptyValue(b) {
+		return
+	}
+	switch a := a.(type) {
+	case map[interface{}]interface{}:
+		b, ok := b.(map[interface{}]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		keys := make(map[string]interface{})
+		for key := range a {
+			keys[fmt.Sprint(key)] = key
+		}
+		for key := range b {
+			keys[fmt.Sprint(key)] = key
+		}
+		for name, key := range keys {
+			diffValues(joinFieldPath(path, name), a[key], b[key], fields)
+		}
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range a {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), a[i], b[i], fields)
+		}
+	default:
+		if !sameScalar(a, b) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[interface{}]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	}
+	return false
+}
+
+func sameScalar(a, b interface{}) bool {
+	if a == b {
+		return true
+	}
+	as, ok := a.(string)
+	if !ok {
+		return false
+	}
+	bs, ok := b.(string)
+	if !ok {
+		return false
+	}
+	at, err := time.Parse(time.RFC3339Nano, as)
+	if err != nil {
+		return false
+	}
+	bt, err := time.Parse(time.RFC3339Nano, bs)
+	return err == nil && at.Equal(bt)
+}
+
+func machineEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	var add func([]Machine)
+	add = func(machines []Machine) {
+		for _, machine := range machines {
+			result[machine.Id()] = machine
+			add(machine.Containers())
+		}
+	}
+	add(m.Machines())
+	return result
+}
+
+func applicationEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, application := range m.Applications() {
+		result[application.Name()] = application
+	}
+	return result
+}
+
+func unitEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, application := range m.Applications() {
+		for _, unit := range application.Units() {
+			result[unit.Name()] = unit
+		}
+	}
+	return result
+}
+
+func charmEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, charm := range m.Charms() {
+		result[charm.URL()] = charm
+	}
+	return result
+}
+
+func relationEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, relation := range m.Relations() {
+		result[relation.Key()] = relation
+	}
+	return result
+}
+
+func remoteApplicationEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, application := range m.RemoteApplications() {
+		result[application.Name()] = application
+	}
+	return result
+}
+
+func secretBackendEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, backend := range m.SecretBackends() {
+		result[backend.Id()] = backend
+	}
+	return result
+}
+
+func secretEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, secret := range m.Secrets() {
+		result[secret.Id()] = secret
+	}
+	return result
+}
+
+func userEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, user := range m.Users() {
+		result[user.Name().Id()] = user
+	}
+	return result
+}
+
+func spaceEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, space := range m.Spaces() {
+		result[space.Id()] = space
+	}
+	return result
+}
+
+func subnetEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, subnet := range m.Subnets() {
+		key := subnet.ID()
+		if key == "" {
+			// Older subnets were only identified by their CIDR.
+			key = subnet.CIDR()
+		}
+		result[key] = subnet
+	}
+	return result
+}
+
+func storageEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, storage := range m.Storages() {
+		result[storage.Tag().Id()] = storage
+	}
+	return result
+}
+
+func volumeEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, volume := range m.Volumes() {
+		result[volume.Tag().Id()] = volume
+	}
+	return result
+}
+
+func filesystemEntities(m Model) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, filesystem := range m.Filesystems() {
+		result[filesystem.Tag().Id()] = filesystem
+	}
+	return result
+}
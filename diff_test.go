@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DiffSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DiffSuite{})
+
+func (*DiffSuite) TestRoundTripIsEmpty(c *gc.C) {
+	initial := selfTestModel()
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	diff := Diff(initial, imported)
+	c.Assert(diff.Empty(), jc.IsTrue, gc.Commentf("%#v", diff))
+}
+
+func (*DiffSuite) TestDiff(c *gc.C) {
+	before := selfTestModel()
+	after := selfTestModel()
+
+	after.AddSpace(SpaceArgs{Id: "2", Name: "beta"})
+	after.Relations_.Relations_ = nil
+	after.Applications()[0].Units()[0].SetUniterState("changed")
+	after.AddMachine(MachineArgs{Id: names.NewMachineTag("7")})
+
+	diff := Diff(before, after)
+	c.Check(diff.Empty(), jc.IsFalse)
+	c.Check(diff.Spaces, jc.DeepEquals, EntityDiff{Added: []string{"2"}})
+	c.Check(diff.Relations, jc.DeepEquals, EntityDiff{Removed: []string{"ubuntu:juju-info"}})
+	c.Check(diff.Applications, jc.DeepEquals, EntityDiff{
+		Changed: []string{"ubuntu"},
+		Fields:  map[string][]string{"ubuntu": {"units.units[0].uniter-state"}},
+	})
+	c.Check(diff.Units, jc.DeepEquals, EntityDiff{
+		Changed: []string{"ubuntu/0"},
+		Fields:  map[string][]string{"ubuntu/0": {"uniter-state"}},
+	})
+	c.Check(diff.Machines, jc.DeepEquals, EntityDiff{Added: []string{"7"}})
+	c.Check(diff.Secrets.Empty(), jc.IsTrue)
+}
+
+func (*DiffSuite) TestRoundTripFixturesIsEmpty(c *gc.C) {
+	paths, err := filepath.Glob(filepath.Join(fixturesDir, "model", "v*.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, path := range paths {
+		bytes, err := os.ReadFile(path)
+		c.Assert(err, jc.ErrorIsNil)
+		initial, err := Deserialize(bytes)
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", path))
+		bytes, err = Serialize(initial)
+		c.Assert(err, jc.ErrorIsNil)
+		imported, err := Deserialize(bytes)
+		c.Assert(err, jc.ErrorIsNil)
+
+		diff := Diff(initial, imported)
+		c.Check(diff.Empty(), jc.IsTrue, gc.Commentf("%s: %#v", path, diff))
+	}
+}
+
+func (*DiffSuite) TestDiffIgnoresNormalization(c *gc.C) {
+	before := selfTestModel()
+	after := selfTestModel()
+
+	// The same instant in another location serializes differently, but
+	// isn't a change.
+	status := after.Machines_.Machines_[0].Status_
+	status.Updated_ = status.Updated_.In(time.FixedZone("UTC+2", 2*60*60))
+
+	diff := Diff(before, after)
+	c.Check(diff.Empty(), jc.IsTrue, gc.Commentf("%#v", diff))
+}
+
+func (*DiffSuite) TestDiffFields(c *gc.C) {
+	before := selfTestModel()
+	after := selfTestModel()
+	after.Machines()[0].SetStatus(StatusArgs{
+		Value:   "stopped",
+		Updated: before.Machines()[0].Status().Updated(),
+	})
+
+	diff := Diff(before, after)
+	c.Check(diff.Machines, jc.DeepEquals, EntityDiff{
+		Changed: []string{"0"},
+		Fields:  map[string][]string{"0": {"status.status.message", "status.status.value"}},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// WriteText writes the diff for people to review, in the manner of a
+// unified diff of the models a and b given to Diff. Each kind of entity
+// with differences gets a hunk, headed "@@ machines @@" for example, with
+// a line for each entity removed ("-"), added ("+") or changed ("~"). The
+// fields that differ follow each changed entity, indented. Nothing is
+// written if the models had no differences.
+func (d ModelDiff) WriteText(w io.Writer) error {
+	if d.Empty() {
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("--- a\n+++ b\n")
+	for _, section := range d.sections() {
+		if section.diff.Empty() {
+			continue
+		}
+		fmt.Fprintf(&b, "@@ %s @@\n", section.name)
+		for _, key := range section.diff.Removed {
+			fmt.Fprintf(&b, "-%s\n", key)
+		}
+		for _, key := range section.diff.Added {
+			fmt.Fprintf(&b, "+%s\n", key)
+		}
+		for _, key := range section.diff.Changed {
+			fmt.Fprintf(&b, "~%s\n", key)
+			for _, field := range section.diff.Fields[key] {
+				fmt.Fprintf(&b, "    %s\n", field)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return errors.Trace(err)
+}
+
+// diffJSON is the document written by WriteJSON.
+type diffJSON struct {
+	Empty    bool                  `json:"empty"`
+	Sections map[string]EntityDiff `json:"sections"`
+}
+
+// WriteJSON writes the diff as a JSON document for tools to read, such as
+// the checks of a CI pipeline. The document records whether the models
+// had any differences, and the added, removed and changed entities, and
+// the changed fields, of each kind of entity with differences:
+//
+//	{
+//	  "empty": false,
+//	  "sections": {
+//	    "machines": {"added": ["7"]}
+//	  }
+//	}
+func (d ModelDiff) WriteJSON(w io.Writer) error {
+	doc := diffJSON{
+		Empty:    true,
+		Sections: make(map[string]EntityDiff),
+	}
+	for _, section := range d.sections() {
+		if !section.diff.Empty() {
+			doc.Empty = false
+			doc.Sections[section.name] = section.diff
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Trace(encoder.Encode(doc))
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type DiffFormatSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DiffFormatSuite{})
+
+// changedModelsDiff returns a diff with entities added, removed and changed.
+func changedModelsDiff() ModelDiff {
+	before := selfTestModel()
+	after := selfTestModel()
+	after.AddSpace(SpaceArgs{Id: "2", Name: "beta"})
+	after.Relations_.Relations_ = nil
+	after.Applications()[0].Units()[0].SetUniterState("changed")
+	after.AddMachine(MachineArgs{Id: names.NewMachineTag("7")})
+	return Diff(before, after)
+}
+
+func (*DiffFormatSuite) TestWriteText(c *gc.C) {
+	var buf bytes.Buffer
+	err := changedModelsDiff().WriteText(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+--- a
++++ b
+@@ machines @@
++7
+@@ applications @@
+~ubuntu
+    units.units[0].uniter-state
+@@ units @@
+~ubuntu/0
+    uniter-state
+@@ relations @@
+-ubuntu:juju-info
+@@ spaces @@
++2
+`[1:])
+}
+
+func (*DiffFormatSuite) TestWriteTextEmpty(c *gc.C) {
+	var buf bytes.Buffer
+	err := ModelDiff{}.WriteText(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, "")
+}
+
+func (*DiffFormatSuite) TestWriteJSON(c *gc.C) {
+	var buf bytes.Buffer
+	err := changedModelsDiff().WriteJSON(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var doc diffJSON
+	err = json.Unmarshal(buf.Bytes(), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc, jc.DeepEquals, diffJSON{
+		Empty: false,
+		Sections: map[string]EntityDiff{
+			"machines": {Added: []string{"7"}},
+			"applications": {
+				Changed: []string{"ubuntu"},
+				Fields:  map[string][]string{"ubuntu": {"units.units[0].uniter-state"}},
+			},
+			"units": {
+				Changed: []string{"ubuntu/0"},
+				Fields:  map[string][]string{"ubuntu/0": {"uniter-state"}},
+			},
+			"relations": {Removed: []string{"ubuntu:juju-info"}},
+			"spaces":    {Added: []string{"2"}},
+		},
+	})
+}
+
+func (*DiffFormatSuite) TestWriteJSONEmpty(c *gc.C) {
+	var buf bytes.Buffer
+	err := ModelDiff{}.WriteJSON(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+{
+  "empty": true,
+  "sections": {}
+}
+`[1:])
+}
+
+func (*DiffFormatSuite) TestWriteJSONFieldNames(c *gc.C) {
+	diff := ModelDiff{Volumes: EntityDiff{Removed: []string{"0"}}}
+	var buf bytes.Buffer
+	err := diff.WriteJSON(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+{
+  "empty": false,
+  "sections": {
+    "volumes": {
+      "removed": [
+        "0"
+      ]
+    }
+  }
+}
+`[1:])
+}
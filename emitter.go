@@ -0,0 +1,696 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"encoding"
+	"encoding/base64"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// marshalYAML writes the value out as a block style YAML document that
+// reads back the same as the output of yaml.Marshal. The yaml package
+// queues the events for the whole document before writing any of it,
+// which makes up most of the cost of serializing a large model, so the
+// document is written out directly instead. The entities that a model
+// holds many of write themselves out through emitYAML, and everything
+// else is written by reflection following the rules of the yaml package.
+func marshalYAML(value interface{}) (result []byte, err error) {
+	e := &emitter{}
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(emitterError)
+			if !ok {
+				panic(r)
+			}
+			result, err = nil, failure.err
+		}
+	}()
+	v := reflect.ValueOf(value)
+	switch kind := e.resolve(&v); {
+	case kind == nodeMapping:
+		e.mappingBody(v, 0, false)
+	case kind == nodeEmpty || kind == nodeScalar:
+		// Documents that aren't mappings are rare enough to be left to
+		// the yaml package.
+		return yaml.Marshal(value)
+	default:
+		e.sequenceBody(v, 0, false)
+	}
+	return e.buf, nil
+}
+
+// yamlEmitter is implemented by the entities that write themselves out as
+// a YAML mapping. They must write at least one key. Types that are embedded
+// in others, such as StatusHistory_, mustn't implement it, as the method
+// would be promoted to the types that embed them.
+type yamlEmitter interface {
+	emitYAML(m *mapping)
+}
+
+// emitterError carries an error out of the emitter to marshalYAML.
+type emitterError struct {
+	err error
+}
+
+type emitter struct {
+	buf []byte
+}
+
+// mapping writes the keys of a block mapping. The first key of a mapping
+// that is an item of a sequence follows the dash, on the same line.
+type mapping struct {
+	e      *emitter
+	indent int
+	inline bool
+}
+
+// key starts the entry of the mapping with the given key.
+func (m *mapping) key(name string) {
+	if m.inline {
+		m.inline = false
+	} else {
+		m.e.spaces(m.indent)
+	}
+	m.e.buf = append(m.e.buf, name...)
+	m.e.buf = append(m.e.buf, ':')
+}
+
+// str writes a string entry.
+func (m *mapping) str(name, value string) {
+	m.key(name)
+	m.e.str(value, m.indent)
+}
+
+// stringOmitEmpty writes a string entry if the string isn't empty.
+func (m *mapping) stringOmitEmpty(name, value string) {
+	if value != "" {
+		m.str(name, value)
+	}
+}
+
+// int writes an integer entry.
+func (m *mapping) int(name string, value int) {
+	m.key(name)
+	m.e.buf = append(m.e.buf, ' ')
+	m.e.buf = strconv.AppendInt(m.e.buf, int64(value), 10)
+	m.e.buf = append(m.e.buf, '\n')
+}
+
+// bool writes a boolean entry.
+func (m *mapping) bool(name string, value bool) {
+	m.key(name)
+	m.e.buf = append(m.e.buf, ' ')
+	m.e.buf = strconv.AppendBool(m.e.buf, value)
+	m.e.buf = append(m.e.buf, '\n')
+}
+
+// time writes a timestamp entry.
+func (m *mapping) time(name string, value time.Time) {
+	m.key(name)
+	m.e.buf = append(m.e.buf, ' ')
+	m.e.buf = value.AppendFormat(m.e.buf, time.RFC3339Nano)
+	m.e.buf = append(m.e.buf, '\n')
+}
+
+// value writes an entry of any type, by reflection.
+func (m *mapping) value(name string, value interface{}) {
+	m.key(name)
+	m.e.node(reflect.ValueOf(value), m.indent, false)
+}
+
+// entries writes the entries of a map with string keys, in the order of
+// their keys, as the yaml package writes a map or the fields inlined into
+// a struct from one.
+func (m *mapping) entries(v reflect.Value) {
+	keys := v.MapKeys()
+	for _, key := range keys {
+		if !plainKey(key.String()) {
+			m.e.marshalled(v, m)
+			return
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, key := range keys {
+		m.key(key.String())
+		m.e.node(v.MapIndex(key), m.indent, false)
+	}
+}
+
+// inlined writes the entries of a map inlined into the entity being
+// written, after its other fields.
+func (m *mapping) inlined(values map[string]interface{}) {
+	if len(values) > 0 {
+		m.entries(reflect.ValueOf(values))
+	}
+}
+
+// valueOmitEmpty writes an entry of any type if it isn't the zero value,
+// by the rules of omitempty.
+func (m *mapping) valueOmitEmpty(name string, value interface{}) {
+	v := reflect.ValueOf(value)
+	if !isZeroValue(v) {
+		m.key(name)
+		m.e.node(v, m.indent, false)
+	}
+}
+
+func (e *emitter) spaces(n int) {
+	for i := 0; i < n; i++ {
+		e.buf = append(e.buf, ' ')
+	}
+}
+
+func (e *emitter) fail(err error) {
+	panic(emitterError{err: err})
+}
+
+type nodeKind int
+
+const (
+	nodeNull nodeKind = iota
+	nodeScalar
+	nodeEmpty
+	nodeMapping
+	nodeSequence
+)
+
+var (
+	yamlEmitterType   = reflect.TypeOf((*yamlEmitter)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	mapSliceType      = reflect.TypeOf(yaml.MapSlice{})
+)
+
+// resolve dereferences the value and applies the marshalers of its type,
+// as the yaml package does, and reports how the value is written.
+func (e *emitter) resolve(v *reflect.Value) nodeKind {
+	for {
+		if !v.IsValid() {
+			return nodeNull
+		}
+		t := v.Type()
+		nillable := t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface
+		if nillable && v.IsNil() {
+			return nodeNull
+		}
+		switch {
+		case t == timeType:
+			return nodeScalar
+		case t.Kind() == reflect.Interface:
+		case t.Implements(yamlEmitterType):
+			return nodeMapping
+		case t.Implements(marshalerType):
+			value, err := v.Interface().(yaml.Marshaler).MarshalYAML()
+			if err != nil {
+				e.fail(err)
+			}
+			*v = reflect.ValueOf(value)
+			continue
+		case t != reflect.PtrTo(timeType) && t.Implements(textMarshalerType):
+			text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				e.fail(err)
+			}
+			*v = reflect.ValueOf(string(text))
+			continue
+		}
+		if !nillable {
+			break
+		}
+		*v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nodeEmpty
+		}
+		return nodeMapping
+	case reflect.Struct:
+		if v.CanAddr() && v.Addr().Type().Implements(yamlEmitterType) {
+			*v = v.Addr()
+			return nodeMapping
+		}
+		if !structInfoOf(v.Type()).hasFields(*v) {
+			return nodeEmpty
+		}
+		return nodeMapping
+	case reflect.Slice, reflect.Array:
+		if v.Type() == mapSliceType {
+			return nodeMapping
+		}
+		if v.Len() == 0 {
+			return nodeEmpty
+		}
+		return nodeSequence
+	}
+	return nodeScalar
+}
+
+// node writes the value that follows a mapping key or a sequence dash at
+// the given indent.
+func (e *emitter) node(v reflect.Value, indent int, inSequence bool) {
+	switch e.resolve(&v) {
+	case nodeNull:
+		e.buf = append(e.buf, " null\n"...)
+	case nodeScalar:
+		e.scalar(v, indent)
+	case nodeEmpty:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			e.buf = append(e.buf, " []\n"...)
+		} else {
+			e.buf = append(e.buf, " {}\n"...)
+		}
+	case nodeMapping:
+		if inSequence {
+			e.buf = append(e.buf, ' ')
+		} else {
+			e.buf = append(e.buf, '\n')
+		}
+		e.mappingBody(v, indent+2, inSequence)
+	case nodeSequence:
+		if inSequence {
+			e.buf = append(e.buf, ' ')
+			e.sequenceBody(v, indent+2, true)
+		} else {
+			// The yaml package doesn't indent sequences under their key.
+			e.buf = append(e.buf, '\n')
+			e.sequenceBody(v, indent, false)
+		}
+	}
+}
+
+func (e *emitter) mappingBody(v reflect.Value, indent int, inline bool) {
+	m := &mapping{e: e, indent: indent, inline: inline}
+	if v.Type().Implements(yamlEmitterType) {
+		v.Interface().(yamlEmitter).emitYAML(m)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		info := structInfoOf(v.Type())
+		if info.inline {
+			e.marshalled(v, m)
+			return
+		}
+		for _, field := range info.fields {
+			fv := v.Field(field.index)
+			if field.omitEmpty && isZeroValue(fv) {
+				continue
+			}
+			m.key(field.key)
+			e.node(fv, indent, false)
+		}
+		if info.inlineMap >= 0 {
+			m.entries(v.Field(info.inlineMap))
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			e.marshalled(v, m)
+			return
+		}
+		m.entries(v)
+	default:
+		// A yaml.MapSlice, which keeps the order of its keys.
+		e.marshalled(v, m)
+	}
+}
+
+func (e *emitter) sequenceBody(v reflect.Value, indent int, inline bool) {
+	for i := 0; i < v.Len(); i++ {
+		if inline && i == 0 {
+			e.buf = append(e.buf, '-')
+		} else {
+			e.spaces(indent)
+			e.buf = append(e.buf, '-')
+		}
+		e.node(v.Index(i), indent, true)
+	}
+}
+
+// marshalled writes out a mapping that the emitter doesn't handle itself
+// with the yaml package, indenting its lines to fit.
+func (e *emitter) marshalled(v reflect.Value, m *mapping) {
+	bytes, err := yaml.Marshal(v.Interface())
+	if err != nil {
+		e.fail(errors.Trace(err))
+	}
+	lines := strings.SplitAfter(string(bytes), "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if line != "\n" && !(i == 0 && m.inline) {
+			e.spaces(m.indent)
+		}
+		e.buf = append(e.buf, line...)
+	}
+	m.inline = false
+}
+
+func (e *emitter) scalar(v reflect.Value, indent int) {
+	if v.Kind() == reflect.String {
+		e.str(v.String(), indent)
+		return
+	}
+	e.buf = append(e.buf, ' ')
+	switch v.Kind() {
+	case reflect.Bool:
+		e.buf = strconv.AppendBool(e.buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf = strconv.AppendInt(e.buf, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.buf = strconv.AppendUint(e.buf, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		precision := 64
+		if v.Kind() == reflect.Float32 {
+			precision = 32
+		}
+		switch s := strconv.FormatFloat(v.Float(), 'g', -1, precision); s {
+		case "+Inf":
+			e.buf = append(e.buf, ".inf"...)
+		case "-Inf":
+			e.buf = append(e.buf, "-.inf"...)
+		case "NaN":
+			e.buf = append(e.buf, ".nan"...)
+		default:
+			e.buf = append(e.buf, s...)
+		}
+	case reflect.Struct:
+		e.buf = v.Interface().(time.Time).AppendFormat(e.buf, time.RFC3339Nano)
+	default:
+		e.fail(errors.Errorf("cannot marshal type: %v", v.Type()))
+	}
+	e.buf = append(e.buf, '\n')
+}
+
+// str writes a string value, with the space that separates it from its key
+// or dash, as a plain scalar where that reads back as the same string, as
+// a literal block if it has more than one line, and double quoted
+// otherwise.
+func (e *emitter) str(s string, indent int) {
+	switch {
+	case !utf8.ValidString(s):
+		e.buf = append(e.buf, " !!binary "...)
+		e.buf = append(e.buf, base64.StdEncoding.EncodeToString([]byte(s))...)
+		e.buf = append(e.buf, '\n')
+	case plainString(s):
+		e.buf = append(e.buf, ' ')
+		e.buf = append(e.buf, s...)
+		e.buf = append(e.buf, '\n')
+	case literalString(s):
+		e.literal(s, indent+2)
+	default:
+		e.buf = append(e.buf, ' ')
+		e.quoted(s)
+		e.buf = append(e.buf, '\n')
+	}
+}
+
+func (e *emitter) literal(s string, indent int) {
+	content := strings.TrimRight(s, "\n")
+	switch trailing := len(s) - len(content); {
+	case trailing == 0:
+		e.buf = append(e.buf, " |-\n"...)
+	case trailing == 1:
+		e.buf = append(e.buf, " |\n"...)
+	default:
+		e.buf = append(e.buf, " |+\n"...)
+		content = s[:len(s)-1]
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if line != "" {
+			e.spaces(indent)
+			e.buf = append(e.buf, line...)
+		}
+		e.buf = append(e.buf, '\n')
+	}
+}
+
+func (e *emitter) quoted(s string) {
+	const hex = "0123456789ABCDEF"
+	e.buf = append(e.buf, '"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			e.buf = append(e.buf, '\\', byte(r))
+		case r == '\n':
+			e.buf = append(e.buf, '\\', 'n')
+		case r == '\t':
+			e.buf = append(e.buf, '\\', 't')
+		case r == '\r':
+			e.buf = append(e.buf, '\\', 'r')
+		case r <= 0xff && !printable(r):
+			e.buf = append(e.buf, '\\', 'x', hex[r>>4], hex[r&0xf])
+		case !printable(r):
+			e.buf = append(e.buf, '\\', 'u', hex[r>>12&0xf], hex[r>>8&0xf], hex[r>>4&0xf], hex[r&0xf])
+		default:
+			e.buf = utf8.AppendRune(e.buf, r)
+		}
+	}
+	e.buf = append(e.buf, '"')
+}
+
+// printable reports whether the character may be written as it is in a
+// quoted scalar. Line breaks other than newlines would be folded, and the
+// byte order mark dropped, when the document is read.
+func printable(r rune) bool {
+	switch {
+	case r == '\t':
+		return true
+	case r < 0x20 || r == 0x7f:
+		return false
+	case r >= 0x80 && r <= 0xa0:
+		return false
+	case r == 0x2028 || r == 0x2029 || r == 0xfeff || r == 0xfffe || r == 0xffff:
+		return false
+	}
+	return true
+}
+
+// plainString reports whether the string can be written as a plain
+// scalar. Only strings that can't be read back as anything else, and
+// don't need any escaping, qualify.
+func plainString(s string) bool {
+	if s == "" || len(s) > 1024 {
+		return false
+	}
+	c := s[0]
+	digit := c >= '0' && c <= '9'
+	if !(digit || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '/' || c == '_') {
+		return false
+	}
+	last := len(s) - 1
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9':
+		case c == '.' || c == '/' || c == '_' || c == '-' || c == '+' || c == '@' || c == '=':
+		case c == ':':
+			if i == last || s[i+1] == ' ' {
+				return false
+			}
+		case c == ' ':
+			if i == last || s[i+1] == ' ' || s[i+1] == '#' {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	if digit {
+		return !numberLike(s)
+	}
+	if len(s) <= 5 {
+		switch strings.ToLower(s) {
+		case "y", "yes", "n", "no", "true", "false", "on", "off", "null":
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	floatPattern     = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	base60Pattern    = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?$`)
+	timestampPattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}`)
+)
+
+// numberLike reports whether a string that starts with a digit could be
+// read back as a number or a timestamp, as the yaml package would quote it.
+func numberLike(s string) bool {
+	plain := strings.Replace(s, "_", "", -1)
+	if _, err := strconv.ParseInt(plain, 0, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseUint(plain, 0, 64); err == nil {
+		return true
+	}
+	return floatPattern.MatchString(plain) || base60Pattern.MatchString(s) || timestampPattern.MatchString(s)
+}
+
+// plainKey reports whether the key of a map can be written as it is.
+func plainKey(s string) bool {
+	return plainString(s) && !strings.ContainsRune(s, ' ')
+}
+
+// literalString reports whether the string can be written as a literal
+// block: it has more than one line, starts with content, and has no
+// characters that would need escaping.
+func literalString(s string) bool {
+	if !strings.Contains(s, "\n") {
+		return false
+	}
+	switch s[0] {
+	case ' ', '\t', '\n':
+		return false
+	}
+	for _, r := range s {
+		if r != '\n' && !printable(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isZeroValue reports whether the value is omitted by omitempty, following
+// the rules of the yaml package.
+func isZeroValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	kind := v.Kind()
+	if z, ok := v.Interface().(yaml.IsZeroer); ok {
+		if (kind == reflect.Ptr || kind == reflect.Interface) && v.IsNil() {
+			return true
+		}
+		return z.IsZero()
+	}
+	switch kind {
+	case reflect.String:
+		return len(v.String()) == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Slice:
+		return v.Len() == 0
+	case reflect.Map:
+		return v.Len() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Struct:
+		t := v.Type()
+		for i := v.NumField() - 1; i >= 0; i-- {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			if !isZeroValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+type structInfo struct {
+	fields []fieldInfo
+	// inline is set for structs with inlined structs or flow style
+	// fields, which are left to the yaml package.
+	inline bool
+	// inlineMap is the index of the map field whose entries are inlined
+	// after the other fields, or -1 if there isn't one.
+	inlineMap int
+}
+
+type fieldInfo struct {
+	key       string
+	index     int
+	omitEmpty bool
+}
+
+// hasFields reports whether any field of the struct is written out.
+func (info *structInfo) hasFields(v reflect.Value) bool {
+	if info.inline {
+		return true
+	}
+	if info.inlineMap >= 0 && v.Field(info.inlineMap).Len() > 0 {
+		return true
+	}
+	for _, field := range info.fields {
+		if !field.omitEmpty || !isZeroValue(v.Field(field.index)) {
+			return true
+		}
+	}
+	return false
+}
+
+var structInfoCache sync.Map
+
+func structInfoOf(t reflect.Type) *structInfo {
+	if info, ok := structInfoCache.Load(t); ok {
+		return info.(*structInfo)
+	}
+	info := &structInfo{inlineMap: -1}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "" && !strings.Contains(string(field.Tag), ":") {
+			tag = string(field.Tag)
+		}
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		fi := fieldInfo{key: parts[0], index: i}
+		for _, flag := range parts[1:] {
+			switch flag {
+			case "omitempty":
+				fi.omitEmpty = true
+			case "inline":
+				if field.Type.Kind() == reflect.Map && field.PkgPath == "" {
+					info.inlineMap = i
+				} else {
+					info.inline = true
+				}
+			case "flow":
+				info.inline = true
+			}
+		}
+		if info.inlineMap == i {
+			continue
+		}
+		if field.PkgPath != "" {
+			// Unexported embedded fields are skipped, as they are by
+			// the yaml package unless they are inlined.
+			continue
+		}
+		if fi.key == "" {
+			fi.key = strings.ToLower(field.Name)
+		}
+		info.fields = append(info.fields, fi)
+	}
+	structInfoCache.Store(t, info)
+	return info
+}
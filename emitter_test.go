@@ -0,0 +1,214 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juju/names/v5"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type EmitterSuite struct {
+	jtesting.IsolationSuite
+}
+
+var _ = gc.Suite(&EmitterSuite{})
+
+// checkReadsBack checks that the value written by the emitter reads back
+// the same as when it is written by the yaml package.
+func (*EmitterSuite) checkReadsBack(c *gc.C, value interface{}) {
+	expected, err := yaml.Marshal(value)
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := marshalYAML(value)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var expectedSource, obtainedSource interface{}
+	c.Assert(yaml.Unmarshal(expected, &expectedSource), jc.ErrorIsNil)
+	err = yaml.Unmarshal(obtained, &obtainedSource)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", obtained))
+	c.Assert(obtainedSource, jc.DeepEquals, expectedSource, gc.Commentf("%s", obtained))
+}
+
+func (s *EmitterSuite) TestModel(c *gc.C) {
+	s.checkReadsBack(c, selfTestModel())
+	s.checkReadsBack(c, NewSynchronizedModel(selfTestModel()))
+}
+
+func (s *EmitterSuite) TestModelSameAsYAMLPackage(c *gc.C) {
+	expected, err := yaml.Marshal(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(obtained), gc.Equals, string(expected))
+}
+
+func (s *EmitterSuite) TestStrings(c *gc.C) {
+	for i, value := range []string{
+		"", "plain", "with space", "cs:trusty/ubuntu", "0/lxd/0", "3.1.1",
+		"10.0.0.1", "00:16:3e:00:00:01",
+		"yes", "No", "TRUE", "off", "null", "~", "y",
+		"1", "-1", "+1", "0x10", "0o17", "0b101", "1_000", "1.5", "1e3", ".5",
+		".inf", "-.inf", ".nan", "1:30", "2024-01-02", "2024-01-02T03:04:05Z",
+		"-", "- item", "a: b", "a:", "a #b", "#comment", " leading", "trailing ",
+		"?", "|", ">", "!tag", "&anchor", "*alias", "%directive", "@at", "`tick",
+		"{flow}", "[flow]", "a,b", "'single'", `"double"`, `back\slash`,
+		"tab\there", "bell\a", "nul\x00", "del\x7f", "next\u0085line",
+		"line\u2028separator", "\ufeffbom", "café", "\xff\xfe",
+		"two\nlines", "two\nlines\n", "trailing\nnewlines\n\n", "inner\n\nblank",
+		"\nleading newline", " indented\nlines", "spaces\n   \nline",
+		"carriage\r\nreturn", "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+		strings.Repeat("long ", 300),
+	} {
+		c.Logf("test %d: %q", i, value)
+		s.checkReadsBack(c, map[string]interface{}{"value": value, "list": []string{value}})
+	}
+}
+
+func (s *EmitterSuite) TestKeys(c *gc.C) {
+	for i, key := range []string{
+		"plain", "with space", "yes", "1", "a: b", "", strings.Repeat("k", 2000),
+	} {
+		c.Logf("test %d: %q", i, key)
+		s.checkReadsBack(c, map[string]interface{}{key: "value"})
+	}
+	s.checkReadsBack(c, map[int]string{1: "one", 2: "two"})
+}
+
+func (s *EmitterSuite) TestValues(c *gc.C) {
+	s.checkReadsBack(c, map[string]interface{}{
+		"int":    -42,
+		"uint":   uint64(42),
+		"float":  1.5,
+		"bool":   true,
+		"nil":    nil,
+		"time":   time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		"empty":  []string{},
+		"map":    map[string]interface{}{},
+		"nested": []interface{}{[]interface{}{"a", "b"}, map[string]interface{}{"c": []int{1}}},
+		"tag":    names.NewMachineTag("0").String(),
+	})
+}
+
+// TestEntitiesFollowFields checks that the entities that write themselves
+// out do so as the yaml package would write their fields, so that they
+// don't fall out of step when fields are added.
+func (s *EmitterSuite) TestEntitiesFollowFields(c *gc.C) {
+	for _, value := range []yamlEmitter{
+		&address{}, &StatusPoint_{}, &status{}, &unit{}, &machine{},
+	} {
+		c.Logf("%T", value)
+		s.checkReadsBack(c, value)
+		fillFields(reflect.ValueOf(value).Elem(), 4)
+		s.checkReadsBack(c, value)
+	}
+}
+
+// fillFields sets every exported field reachable from the value, to the
+// given depth of pointers, slices and maps, to a value that isn't zero.
+func fillFields(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf("value"))
+		}
+	case reflect.Ptr:
+		if depth > 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			fillFields(v.Elem(), depth-1)
+		}
+	case reflect.Slice:
+		if depth > 0 {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+			fillFields(v.Index(0), depth-1)
+		}
+	case reflect.Map:
+		if depth > 0 {
+			key := reflect.New(v.Type().Key()).Elem()
+			fillFields(key, depth-1)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			fillFields(elem, depth-1)
+			v.Set(reflect.MakeMap(v.Type()))
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fillFields(v.Field(i), depth)
+			}
+		}
+	}
+}
+
+// benchmarkModel returns a model with many units and machines, each with
+// some status history.
+func benchmarkModel() Model {
+	m := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	application := m.AddApplication(ApplicationArgs{Tag: names.NewApplicationTag("ubuntu")})
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var history []StatusArgs
+	for i := 0; i < 20; i++ {
+		history = append(history, StatusArgs{Value: "active", Message: "ready", Updated: when})
+	}
+	for i := 0; i < 1000; i++ {
+		machine := m.AddMachine(MachineArgs{Id: names.NewMachineTag(fmt.Sprint(i))})
+		machine.SetStatus(StatusArgs{Value: "started", Updated: when})
+		machine.SetStatusHistory(history)
+		machine.SetAddresses(
+			[]AddressArgs{{Value: "10.0.0.1", Type: "ipv4", Scope: "local-cloud"}},
+			[]AddressArgs{{Value: "203.0.113.1", Type: "ipv4", Scope: "public"}},
+		)
+		unit := application.AddUnit(UnitArgs{
+			Tag:     names.NewUnitTag(fmt.Sprintf("ubuntu/%d", i)),
+			Machine: machine.Tag(),
+		})
+		unit.SetAgentStatus(StatusArgs{Value: "idle", Updated: when})
+		unit.SetAgentStatusHistory(history)
+		unit.SetWorkloadStatus(StatusArgs{Value: "active", Updated: when})
+		unit.SetWorkloadStatusHistory(history)
+	}
+	return m
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	m := benchmarkModel()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Serialize(m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSerializeYAMLPackage is the baseline for BenchmarkSerialize.
+func BenchmarkSerializeYAMLPackage(b *testing.B) {
+	m := benchmarkModel()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := yaml.Marshal(m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
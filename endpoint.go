@@ -28,9 +28,13 @@ type Endpoint interface {
 	// UnitCount returns the number of units the endpoint has settings for.
 	UnitCount() int
 
+	// AllSettings returns the settings of every unit keyed by unit name.
 	AllSettings() map[string]map[string]interface{}
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
+	// SetAllUnitSettings replaces the settings of every unit with those
+	// given, keyed by unit name.
+	SetAllUnitSettings(settings map[string]map[string]interface{})
 	ApplicationSettings() map[string]interface{}
 	SetApplicationSettings(settings map[string]interface{})
 }
@@ -145,6 +149,14 @@ func (e *endpoint) SetUnitSettings(unitName string, settings map[string]interfac
 	e.UnitSettings_[unitName] = settings
 }
 
+// SetAllUnitSettings implements Endpoint.
+func (e *endpoint) SetAllUnitSettings(settings map[string]map[string]interface{}) {
+	e.UnitSettings_ = make(map[string]map[string]interface{})
+	for name, unitSettings := range settings {
+		e.UnitSettings_[name] = unitSettings
+	}
+}
+
 // ApplicationSettings implements Endpoint.
 func (e *endpoint) ApplicationSettings() map[string]interface{} {
 	return e.ApplicationSettings_
@@ -155,6 +167,16 @@ func (e *endpoint) SetApplicationSettings(settings map[string]interface{}) {
 	e.ApplicationSettings_ = settings
 }
 
+// CopyEndpointSettings replaces the unit and application settings of dst
+// with deep copies of those held by src, so that later changes to either
+// endpoint are not seen by the other.
+func CopyEndpointSettings(dst, src Endpoint) {
+	dst.SetAllUnitSettings(cloned(src.AllSettings()))
+	if settings := src.ApplicationSettings(); settings != nil {
+		dst.SetApplicationSettings(cloned(settings))
+	}
+}
+
 func importEndpoints(source map[string]interface{}) ([]*endpoint, error) {
 	checker := versionedChecker("endpoints")
 	coerced, err := checker.Coerce(source, nil)
@@ -110,6 +110,37 @@ func (s *EndpointSerializationSuite) TestNewEndpoint(c *gc.C) {
 	})
 }
 
+func (s *EndpointSerializationSuite) TestSetAllUnitSettings(c *gc.C) {
+	endpoint := endpointWithSettings()
+	settings := map[string]map[string]interface{}{
+		"ubuntu/2": {"name": "unit three"},
+	}
+	endpoint.SetAllUnitSettings(settings)
+
+	c.Assert(endpoint.UnitCount(), gc.Equals, 1)
+	c.Assert(endpoint.Settings("ubuntu/0"), gc.IsNil)
+	c.Assert(endpoint.AllSettings(), jc.DeepEquals, settings)
+
+	// Adding a unit to the endpoint doesn't change the caller's map.
+	endpoint.SetUnitSettings("ubuntu/3", map[string]interface{}{})
+	c.Assert(settings, gc.HasLen, 1)
+}
+
+func (s *EndpointSerializationSuite) TestCopyEndpointSettings(c *gc.C) {
+	src := endpointWithSettings()
+	dst := minimalEndpoint()
+	CopyEndpointSettings(dst, src)
+
+	c.Assert(dst.AllSettings(), jc.DeepEquals, src.AllSettings())
+	c.Assert(dst.ApplicationSettings(), jc.DeepEquals, src.ApplicationSettings())
+
+	// The copies are independent of the source.
+	dst.Settings("ubuntu/0")["name"] = "changed"
+	dst.ApplicationSettings()["venusian"] = "changed"
+	c.Assert(src.Settings("ubuntu/0")["name"], gc.Equals, "unit one")
+	c.Assert(src.ApplicationSettings()["venusian"], gc.Equals, "superbug")
+}
+
 func (s *EndpointSerializationSuite) TestMinimalMatches(c *gc.C) {
 	bytes, err := yaml.Marshal(minimalEndpoint())
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,822 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+)
+
+// ExportOptions controls which entities SerializeWithOptions leaves out of
+// the serialized model. The zero value excludes nothing, giving the same
+// result as Serialize.
+type ExportOptions struct {
+	// ExcludeDeadOrDying leaves out machines, applications, units,
+	// relations, storage instances, volumes and filesystems that are
+	// dying or dead, along with anything that belongs to them.
+	ExcludeDeadOrDying bool
+
+	// ExcludeCompletedActions leaves out actions that have a completed
+	// time, whatever their final status.
+	ExcludeCompletedActions bool
+
+	// ExcludeObsoleteSecretRevisions leaves out secret revisions that
+	// have been marked as obsolete.
+	ExcludeObsoleteSecretRevisions bool
+
+	// ExcludeStoppedContainers leaves out containers whose status is
+	// "stopped", along with their addresses, link layer devices and host
+	// keys, and the units placed on them.
+	ExcludeStoppedContainers bool
+
+	// RedactImageCredentials leaves out the registry passwords of OCI
+	// image resource revisions, of applications and of units, keeping
+	// the registry paths, usernames, password hashes and secret
+	// references.
+	RedactImageCredentials bool
+
+	// CompactStatusHistory writes the values and messages of status
+	// history entries as indexes into a table of strings shared by the
+	// whole model, which is much smaller when entries repeat. The history
+	// is written at version 3, which older importers will reject.
+	CompactStatusHistory bool
+
+	// PreserveVersion writes an imported model at the model version it
+	// was imported from, rather than normalizing it to the current
+	// version. Models that weren't imported are written at the current
+	// version. Sections can't be downgraded, so it fails if any section
+	// would be written at a different version from the one it was
+	// imported at, which an importer of the earlier version may not
+	// read. It also fails if the model holds anything that the earlier
+	// version can't represent, or if the earlier version is older than
+	// can be written.
+	PreserveVersion bool
+
+	// Integrity appends an integrity section recording a digest of each
+	// of the other sections of the serialized model, against which
+	// Deserialize checks them, so that a model that was truncated or
+	// altered is rejected before it is imported. Older importers ignore
+	// the section.
+	Integrity bool
+
+	// Labels, if set, are written as the labels of the serialized model in
+	// place of any it was read with. They describe the document, such as
+	// the backup job that wrote it, rather than the model, and are read by
+	// PreScan without importing the model.
+	Labels map[string]string
+
+	// Metrics, if set, receives the number of entities of each kind and
+	// the size of each section written out, and how long the export took.
+	Metrics ExportMetrics
+}
+
+const stoppedStatus = "stopped"
+
+// rewrites returns true if the options change the model that is written
+// out, which means writing a copy of it.
+func (o ExportOptions) rewrites() bool {
+	return o.ExcludeDeadOrDying ||
+		o.ExcludeCompletedActions ||
+		o.ExcludeObsoleteSecretRevisions ||
+		o.ExcludeStoppedContainers ||
+		o.RedactImageCredentials ||
+		o.PreserveVersion ||
+		o.Labels != nil
+}
+
+// SerializeWithOptions serializes the model like Serialize, leaving out the
+// entities excluded by the options. The model passed in isn't modified.
+func SerializeWithOptions(model Model, options ExportOptions) ([]byte, error) {
+	started := time.Now()
+	integrity, metrics := options.Integrity, options.Metrics
+	options.Integrity, options.Metrics = false, nil
+	bytes, exported, err := serializeWithOptions(model, options)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if integrity {
+		if bytes, err = addIntegrity(bytes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if metrics != nil {
+		reportExportMetrics(metrics, exported, bytes, time.Since(started))
+	}
+	return bytes, nil
+}
+
+// serializeWithOptions returns the serialized model along with the model
+// that was written out, which is a filtered copy if the options exclude
+// any entities.
+func serializeWithOptions(model Model, options ExportOptions) ([]byte, Model, error) {
+	if !options.rewrites() {
+		bytes, err := Serialize(model)
+		if err == nil && options.CompactStatusHistory {
+			bytes, err = compactStatusHistory(bytes)
+		}
+		return bytes, model, errors.Trace(err)
+	}
+	version, sections := sourceVersions(model)
+
+	// Work on a copy of the model, so that the caller's model is left as
+	// it was.
+	filtered, err := exportCopy(model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if options.ExcludeDeadOrDying {
+		filtered.excludeDeadOrDying()
+	}
+	if options.ExcludeCompletedActions {
+		filtered.excludeCompletedActions()
+	}
+	if options.ExcludeObsoleteSecretRevisions {
+		filtered.excludeObsoleteSecretRevisions()
+	}
+	if options.ExcludeStoppedContainers {
+		filtered.excludeStoppedContainers()
+	}
+	if options.RedactImageCredentials {
+		filtered.redactImageCredentials()
+	}
+	if options.Labels != nil {
+		filtered.setLabels(options.Labels)
+	}
+	bytes, err := marshalYAML(filtered)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if options.PreserveVersion && version != 0 {
+		if bytes, err = downgradeModel(filtered, bytes, version, sections); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	if options.CompactStatusHistory {
+		bytes, err = compactStatusHistory(bytes)
+		return bytes, filtered, errors.Trace(err)
+	}
+	return bytes, filtered, nil
+}
+
+// exportCopy returns a copy of the model for the export options to filter.
+// Models of this package are copied with Copy, and any other
+// implementation is rebuilt through its interface with CopyInto.
+func exportCopy(m Model) (*model, error) {
+	switch m.(type) {
+	case *model, *synchronizedModel, *lazyModel:
+		copied := m.Copy()
+		if synchronized, ok := copied.(*synchronizedModel); ok {
+			return synchronized.model, nil
+		}
+		return copied.(*model), nil
+	}
+	result := NewModel(ModelArgs{}).(*model)
+	if err := CopyInto(result, m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// isDeadOrDying reports whether an entity with the life is to be left out
+// by ExcludeDeadOrDying.
+func isDeadOrDying(life string) bool {
+	return life != Alive
+}
+
+// excludeDeadOrDying drops the machines, applications, units, relations,
+// storage instances, volumes and filesystems that aren't alive, then
+// everything that belongs to them, so that the filtered model still
+// validates.
+func (m *model) excludeDeadOrDying() {
+	removed := newRemovedEntities()
+	var machines []*machine
+	for _, machine := range m.Machines_.Machines_ {
+		if isDeadOrDying(machine.Life()) {
+			machine.addIds(removed.machines)
+			continue
+		}
+		machine.excludeDeadOrDyingContainers(removed.machines)
+		machines = append(machines, machine)
+	}
+	m.setMachines(machines)
+	m.removeEntities(removed, isDeadOrDying)
+}
+
+// removeEntities drops what belongs to the removed machines, which have
+// already been dropped from the model, and the applications, units,
+// relations, storage instances, volumes and filesystems whose life exclude
+// reports true for. The units hosted on removed machines, and their
+// subordinates, go with them. Finally it drops the references the rest of
+// the model holds to everything removed.
+func (m *model) removeEntities(removed removedEntities, exclude func(life string) bool) {
+	m.removeMachineEntities(removed.machines)
+
+	for _, application := range m.Applications_.Applications_ {
+		excluded := exclude(application.Life())
+		if excluded {
+			removed.applications.Add(application.Name_)
+		}
+		for _, unit := range application.Units_.Units_ {
+			if excluded || exclude(unit.Life()) || removed.machines.Contains(unit.Machine_) {
+				removed.units.Add(unit.Name_)
+			}
+		}
+	}
+	// Subordinates share the machines of their principals, but are
+	// removed along with a principal removed for any reason.
+	for _, application := range m.Applications_.Applications_ {
+		for _, unit := range application.Units_.Units_ {
+			if removed.units.Contains(unit.Principal_) {
+				removed.units.Add(unit.Name_)
+			}
+		}
+	}
+
+	var applications []*application
+	for _, application := range m.Applications_.Applications_ {
+		if removed.applications.Contains(application.Name_) {
+			continue
+		}
+		var units []*unit
+		for _, unit := range application.Units_.Units_ {
+			if !removed.units.Contains(unit.Name_) {
+				unit.removeSubordinates(removed.units)
+				units = append(units, unit)
+			}
+		}
+		application.setUnits(units)
+		if removed.units.Contains(application.Leader_) {
+			application.Leader_ = ""
+			application.Lease_ = nil
+		}
+		application.OpenedPortRanges_.removeUnits(removed.units)
+		applications = append(applications, application)
+	}
+	m.setApplications(applications)
+	for _, machine := range m.Machines_.Machines_ {
+		machine.removeUnits(removed.units)
+	}
+
+	var relations []*relation
+	for _, relation := range m.Relations_.Relations_ {
+		if exclude(relation.Life()) || relation.hasApplication(removed.applications) {
+			removed.relations.Add(relation.Key_)
+			removed.relationIds.Add(relation.Id_)
+			continue
+		}
+		for _, endpoint := range relation.Endpoints_.Endpoints_ {
+			for _, name := range removed.units.Values() {
+				delete(endpoint.UnitSettings_, name)
+			}
+		}
+		relations = append(relations, relation)
+	}
+	m.setRelations(relations)
+
+	m.removeStorage(removed, exclude)
+	m.removeReferences(removed)
+}
+
+// removeStorage drops the storage instances, volumes and filesystems whose
+// life exclude reports true for, or that belong to removed entities, and
+// the attachments to removed machines and units.
+func (m *model) removeStorage(removed removedEntities, exclude func(life string) bool) {
+	var storages []*storage
+	for _, storage := range m.Storages_.Storages_ {
+		if exclude(storage.Life()) || removed.contains(storage.Owner_) {
+			removed.storage.Add(storage.ID_)
+			continue
+		}
+		var attachments []string
+		for _, unit := range storage.Attachments_ {
+			if !removed.units.Contains(unit) {
+				attachments = append(attachments, unit)
+			}
+		}
+		storage.Attachments_ = attachments
+		storages = append(storages, storage)
+	}
+	m.setStorages(storages)
+
+	var volumes []*volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		if exclude(volume.Life()) || removed.storage.Contains(volume.StorageID_) {
+			removed.volumes.Add(volume.ID_)
+			continue
+		}
+		var attachments []*volumeAttachment
+		for _, attachment := range volume.Attachments_.Attachments_ {
+			if !removed.isHost(attachment.HostID_) {
+				attachments = append(attachments, attachment)
+			}
+		}
+		volume.Attachments_.Attachments_ = attachments
+		volumes = append(volumes, volume)
+	}
+	m.setVolumes(volumes)
+
+	var filesystems []*filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if exclude(filesystem.Life()) ||
+			removed.storage.Contains(filesystem.StorageID_) ||
+			removed.volumes.Contains(filesystem.VolumeID_) {
+			removed.filesystems.Add(filesystem.ID_)
+			continue
+		}
+		var attachments []*filesystemAttachment
+		for _, attachment := range filesystem.Attachments_.Attachments_ {
+			if !removed.isHost(attachment.HostID_) {
+				attachments = append(attachments, attachment)
+			}
+		}
+		filesystem.Attachments_.Attachments_ = attachments
+		filesystems = append(filesystems, filesystem)
+	}
+	m.setFilesystems(filesystems)
+}
+
+// removeReferences drops the offer connections, remote entities, relation
+// networks, secrets, secret consumers and grants, virtual host keys and
+// annotations that refer to removed entities.
+func (m *model) removeReferences(removed removedEntities) {
+	var connections []*offerConnection
+	for _, connection := range m.OfferConnections_.OfferConnections {
+		if !removed.relationIds.Contains(connection.RelationID_) {
+			connections = append(connections, connection)
+		}
+	}
+	m.setOfferConnections(connections)
+
+	var entities []*remoteEntity
+	for _, entity := range m.RemoteEntities_.RemoteEntities {
+		if !removed.contains(entity.ID_) {
+			entities = append(entities, entity)
+		}
+	}
+	m.setRemoteEntities(entities)
+
+	var networks []*relationNetwork
+	for _, network := range m.RelationNetworks_.RelationNetworks {
+		if !removed.relations.Contains(network.RelationKey_) {
+			networks = append(networks, network)
+		}
+	}
+	m.setRelationNetworks(networks)
+
+	var secrets []*secret
+	for _, secret := range m.Secrets_.Secrets_ {
+		if removed.contains(secret.Owner_) {
+			continue
+		}
+		var consumers []*secretConsumer
+		for _, consumer := range secret.Consumers_ {
+			if !removed.contains(consumer.Consumer_) {
+				consumers = append(consumers, consumer)
+			}
+		}
+		secret.Consumers_ = consumers
+		for subject := range secret.ACL_ {
+			if removed.contains(subject) {
+				delete(secret.ACL_, subject)
+			}
+		}
+		secrets = append(secrets, secret)
+	}
+	m.setSecrets(secrets)
+
+	var remoteSecrets []*remoteSecret
+	for _, remoteSecret := range m.RemoteSecrets_.RemoteSecrets_ {
+		if !removed.contains(remoteSecret.Consumer_) {
+			remoteSecrets = append(remoteSecrets, remoteSecret)
+		}
+	}
+	m.setRemoteSecrets(remoteSecrets)
+
+	var hostKeys []*virtualHostKey
+	for _, key := range m.VirtualHostKeys_.VirtualHostKeys_ {
+		if !removed.contains(key.Target_) {
+			hostKeys = append(hostKeys, key)
+		}
+	}
+	m.setVirtualHostKeys(hostKeys)
+
+	for key := range m.EntityAnnotations_ {
+		if removed.contains(key) {
+			delete(m.EntityAnnotations_, key)
+		}
+	}
+}
+
+// removedEntities records the entities dropped from a model, by id, so
+// that the references to them can be dropped too.
+type removedEntities struct {
+	machines     set.Strings
+	applications set.Strings
+	units        set.Strings
+	relations    set.Strings
+	relationIds  set.Ints
+	storage      set.Strings
+	volumes      set.Strings
+	filesystems  set.Strings
+}
+
+func newRemovedEntities() removedEntities {
+	return removedEntities{
+		machines:     set.NewStrings(),
+		applications: set.NewStrings(),
+		units:        set.NewStrings(),
+		relations:    set.NewStrings(),
+		relationIds:  set.NewInts(),
+		storage:      set.NewStrings(),
+		volumes:      set.NewStrings(),
+		filesystems:  set.NewStrings(),
+	}
+}
+
+// contains reports whether the tag string names a removed entity.
+func (r removedEntities) contains(tagString string) bool {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return false
+	}
+	switch tag.Kind() {
+	case names.MachineTagKind:
+		return r.machines.Contains(tag.Id())
+	case names.ApplicationTagKind:
+		return r.applications.Contains(tag.Id())
+	case names.UnitTagKind:
+		return r.units.Contains(tag.Id())
+	case names.RelationTagKind:
+		return r.relations.Contains(tag.Id())
+	case names.StorageTagKind:
+		return r.storage.Contains(tag.Id())
+	case names.VolumeTagKind:
+		return r.volumes.Contains(tag.Id())
+	case names.FilesystemTagKind:
+		return r.filesystems.Contains(tag.Id())
+	}
+	return false
+}
+
+// isHost reports whether the storage attachment host id, a machine id or
+// a unit name, is that of a removed machine or unit.
+func (r removedEntities) isHost(id string) bool {
+	return r.machines.Contains(id) || r.units.Contains(id)
+}
+
+// removeUnits drops the port ranges opened by the removed units on the
+// machine and its containers, and their statuses in any series upgrade.
+func (m *machine) removeUnits(units set.Strings) {
+	m.OpenedPortRanges_.removeUnits(units)
+	if m.UpgradeSeriesLock_ != nil {
+		for _, name := range units.Values() {
+			delete(m.UpgradeSeriesLock_.UnitStatuses_, name)
+		}
+	}
+	for _, container := range m.Containers_ {
+		container.removeUnits(units)
+	}
+}
+
+// removeSubordinates drops the removed units from the subordinates of the
+// unit.
+func (u *unit) removeSubordinates(units set.Strings) {
+	var subordinates []string
+	for _, name := range u.Subordinates_ {
+		if !units.Contains(name) {
+			subordinates = append(subordinates, name)
+		}
+	}
+	u.Subordinates_ = subordinates
+}
+
+// removeUnits drops the port ranges opened by the units.
+func (p *deployedPortRanges) removeUnits(units set.Strings) {
+	if p == nil {
+		return
+	}
+	for _, name := range units.Values() {
+		delete(p.ByUnit_, name)
+	}
+}
+
+func (r *relation) hasApplication(names set.Strings) bool {
+	for _, endpoint := range r.Endpoints_.Endpoints_ {
+		if names.Contains(endpoint.ApplicationName_) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) excludeCompletedActions() {
+	var kept []*action
+	for _, action := range m.Actions_.Actions_ {
+		if action.Completed_ == nil {
+			kept = append(kept, action)
+		}
+	}
+	m.setActions(kept)
+}
+
+func (m *model) excludeObsoleteSecretRevisions() {
+	for _, secret := range m.Secrets_.Secrets_ {
+		var kept []*secretRevision
+		for _, revision := range secret.Revisions_ {
+			if !revision.Obsolete_ {
+				kept = append(kept, revision)
+			}
+		}
+		secret.Revisions_ = kept
+	}
+}
+
+func (m *model) redactImageCredentials() {
+	for _, application := range m.Applications_.Applications_ {
+		for _, resource := range application.Resources_.Resources_ {
+			resource.ApplicationRevision_.redact()
+			if resource.CharmStoreRevision_ != nil {
+				resource.CharmStoreRevision_.redact()
+			}
+		}
+		for _, unit := range application.Units_.Units_ {
+			for _, resource := range unit.Resources_.Resources_ {
+				resource.Revision_.redact()
+			}
+		}
+	}
+}
+
+// excludeStoppedContainers drops the stopped containers, then everything
+// that belongs to them, including the units placed on them.
+func (m *model) excludeStoppedContainers() {
+	removed := newRemovedEntities()
+	for _, machine := range m.Machines_.Machines_ {
+		machine.excludeStoppedContainers(removed.machines)
+	}
+	if removed.machines.IsEmpty() {
+		return
+	}
+	m.removeEntities(removed, func(string) bool { return false })
+}
+
+// removeMachineEntities drops the addresses, link layer devices, ssh host
+// keys and virtual host keys of the removed machines.
+func (m *model) removeMachineEntities(removed set.Strings) {
+	if removed.IsEmpty() {
+		return
+	}
+
+	var addresses []*ipaddress
+	for _, address := range m.IPAddresses_.IPAddresses_ {
+		if !removed.Contains(address.MachineID_) {
+			addresses = append(addresses, address)
+		}
+	}
+	m.setIPAddresses(addresses)
+
+	var devices []*linklayerdevice
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		if !removed.Contains(device.MachineID_) {
+			devices = append(devices, device)
+		}
+	}
+	m.setLinkLayerDevices(devices)
+
+	var keys []*sshHostKey
+	for _, key := range m.SSHHostKeys_.SSHHostKeys_ {
+		if !removed.Contains(key.MachineID_) {
+			keys = append(keys, key)
+		}
+	}
+	m.setSSHHostKeys(keys)
+
+	var hostKeys []*virtualHostKey
+	for _, key := range m.VirtualHostKeys_.VirtualHostKeys_ {
+		if tag, err := names.ParseMachineTag(key.Target_); err != nil || !removed.Contains(tag.Id()) {
+			hostKeys = append(hostKeys, key)
+		}
+	}
+	m.setVirtualHostKeys(hostKeys)
+}
+
+// excludeStoppedContainers drops the stopped containers of the machine,
+// and of its remaining containers, adding their ids to removed.
+func (m *machine) excludeStoppedContainers(removed set.Strings) {
+	var kept []*machine
+	for _, container := range m.Containers_ {
+		if container.Status_ != nil && container.Status_.Value_ == stoppedStatus {
+			container.addIds(removed)
+			continue
+		}
+		container.excludeStoppedContainers(removed)
+		kept = append(kept, container)
+	}
+	m.Containers_ = kept
+}
+
+// excludeDeadOrDyingContainers drops the containers of the machine, at any
+// depth, that aren't alive, adding their ids to removed.
+func (m *machine) excludeDeadOrDyingContainers(removed set.Strings) {
+	var kept []*machine
+	for _, container := range m.Containers_ {
+		if container.Life() != Alive {
+			container.addIds(removed)
+			continue
+		}
+		container.excludeDeadOrDyingContainers(removed)
+		kept = append(kept, container)
+	}
+	m.Containers_ = kept
+}
+
+func (m *machine) addIds(ids set.Strings) {
+	ids.Add(m.Id_)
+	for _, container := range m.Containers_ {
+		container.addIds(ids)
+	}
+}
+
+// sourceVersions returns the earlier version the model was upgraded from
+// on import, or zero if it wasn't upgraded, along with the versions its
+// sections were imported at.
+func sourceVersions(m Model) (int, map[string]int) {
+	switch m := m.(type) {
+	case *model:
+		return m.sourceVersion, m.sourceSectionVersions
+	case *synchronizedModel:
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		return sourceVersions(m.model)
+	case *lazyModel:
+		return sourceVersions(m.model)
+	}
+	return 0, nil
+}
+
+// sectionVersions returns the versions of the versioned top level sections
+// of the serialized model.
+func sectionVersions(source map[string]interface{}) map[string]int {
+	result := make(map[string]int)
+	for name, value := range source {
+		if version, ok := sectionVersion(value); ok {
+			result[name] = version
+		}
+	}
+	return result
+}
+
+// sectionVersion returns the version of the serialized section, if it is
+// versioned.
+func sectionVersion(section interface{}) (int, bool) {
+	var version interface{}
+	switch section := section.(type) {
+	case yaml.MapSlice:
+		for _, item := range section {
+			if item.Key == "version" {
+				version = item.Value
+			}
+		}
+	case map[interface{}]interface{}:
+		version = section["version"]
+	case map[string]interface{}:
+		version = section["version"]
+	}
+	switch version := version.(type) {
+	case int:
+		return version, true
+	case int64:
+		return int(version), true
+	}
+	return 0, false
+}
+
+// modelDowngrade describes the field added to the model at a version, so
+// that a model can be written at the version before.
+type modelDowngrade struct {
+	field string
+	// check returns an error if the field holds something that the
+	// version before can't represent.
+	check func(*model) error
+}
+
+var modelDowngrades = map[int]modelDowngrade{
+	11: {field: "agent-version", check: func(m *model) error {
+		// Before v11 the agent version was read from config.
+		if m.AgentVersion_ != "" && m.Config_["agent-version"] != m.AgentVersion_ {
+			return errors.NotSupportedf("agent version %q outside config", m.AgentVersion_)
+		}
+		return nil
+	}},
+	12: {field: "telemetry", check: func(m *model) error {
+		// Before v12 telemetry was read from config.
+		fromConfig := telemetryFromConfig(m.Config_)
+		if m.Telemetry_ == nil || (fromConfig != nil && *m.Telemetry_ == *fromConfig) {
+			return nil
+		}
+		return errors.NotSupportedf("telemetry outside config")
+	}},
+	13: {field: "description", check: func(m *model) error {
+		if m.Description_ != "" {
+			return errors.NotSupportedf("description")
+		}
+		return nil
+	}},
+	14: {field: "charms", check: func(m *model) error {
+		if len(m.Charms_.Charms_) > 0 {
+			return errors.NotSupportedf("charms")
+		}
+		return nil
+	}},
+	15: {field: "password-hash-algorithm", check: func(m *model) error {
+		// Earlier versions assume the hash is SHA-512.
+		if m.PasswordHashAlgorithm_ != "" && m.PasswordHashAlgorithm_ != PasswordHashSHA512 {
+			return errors.NotSupportedf("password hash algorithm %q", m.PasswordHashAlgorithm_)
+		}
+		return nil
+	}},
+	16: {field: "secret-backends", check: func(m *model) error {
+		if len(m.SecretBackends_.SecretBackends_) > 0 {
+			return errors.NotSupportedf("secret backends")
+		}
+		return nil
+	}},
+	17: {field: "entity-annotations", check: func(m *model) error {
+		if len(m.EntityAnnotations_) > 0 {
+			return errors.NotSupportedf("entity annotations")
+		}
+		return nil
+	}},
+	18: {field: "migration-attempt", check: func(m *model) error {
+		if m.MigrationAttempt_ != nil {
+			return errors.NotSupportedf("migration attempt")
+		}
+		return nil
+	}},
+	19: {field: "lease", check: func(m *model) error {
+		if m.Lease_ != nil {
+			return errors.NotSupportedf("model lease")
+		}
+		return nil
+	}},
+	20: {field: "virtual-host-keys", check: func(m *model) error {
+		if len(m.VirtualHostKeys_.VirtualHostKeys_) > 0 {
+			return errors.NotSupportedf("virtual host keys")
+		}
+		return nil
+	}},
+}
+
+// downgradeModel rewrites the serialized model at the earlier version,
+// removing the fields added since. The sections are the versions of the
+// sections the model was imported with, which the sections written must
+// match.
+func downgradeModel(m *model, bytes []byte, version int, sections map[string]int) ([]byte, error) {
+	for v := m.Version; v > version; v-- {
+		if _, ok := modelDowngrades[v]; !ok {
+			return nil, errors.NotSupportedf("writing model v%d", version)
+		}
+	}
+	// A MapSlice keeps the order that the model was written in.
+	var source yaml.MapSlice
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for v := m.Version; v > version; v-- {
+		downgrade := modelDowngrades[v]
+		if err := downgrade.check(m); err != nil {
+			return nil, errors.Annotatef(err, "writing model v%d", version)
+		}
+		var result yaml.MapSlice
+		for _, item := range source {
+			if item.Key != downgrade.field {
+				result = append(result, item)
+			}
+		}
+		source = result
+	}
+	for i, item := range source {
+		if item.Key == "version" {
+			source[i].Value = version
+			continue
+		}
+		written, ok := sectionVersion(item.Value)
+		if !ok {
+			continue
+		}
+		name, _ := item.Key.(string)
+		imported, ok := sections[name]
+		if !ok {
+			return nil, errors.NotSupportedf("writing model v%d with %s not imported", version, name)
+		}
+		if written != imported {
+			return nil, errors.NotSupportedf("writing model v%d with %s v%d imported at v%d", version, name, written, imported)
+		}
+	}
+	return yaml.Marshal(source)
+}
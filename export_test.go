@@ -0,0 +1,687 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ExportOptionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ExportOptionsSuite{})
+
+func (s *ExportOptionsSuite) newModel() Model {
+	model := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	model.SetStatus(minimalStatusArgs())
+
+	running := minimalMachine("0/lxd/0")
+	running.SetStatus(StatusArgs{Value: "started"})
+	stopped := minimalMachine("0/lxd/1")
+	stopped.SetStatus(StatusArgs{Value: stoppedStatus})
+	addMinimalMachine(model, "0")
+	model.Machines()[0].(*machine).Containers_ = []*machine{running, stopped}
+	for _, id := range []string{"0", "0/lxd/0", "0/lxd/1"} {
+		model.AddSSHHostKey(SSHHostKeyArgs{MachineID: id, Keys: []string{"key"}})
+		model.AddIPAddress(IPAddressArgs{MachineID: id, DeviceName: "eth0", Value: "10.0.0.1"})
+		model.AddLinkLayerDevice(LinkLayerDeviceArgs{MachineID: id, Name: "eth0"})
+	}
+
+	now := time.Now().UTC()
+	model.AddAction(ActionArgs{Id: "1", Receiver: "ubuntu/0", Name: "backup", Enqueued: now, Completed: now, Status: "completed"})
+	model.AddAction(ActionArgs{Id: "2", Receiver: "ubuntu/0", Name: "backup", Enqueued: now, Status: "running"})
+
+	model.AddSecret(testSecretArgs())
+	return model
+}
+
+func (s *ExportOptionsSuite) exportImport(c *gc.C, model Model, options ExportOptions) Model {
+	bytes, err := SerializeWithOptions(model, options)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	return imported
+}
+
+func (s *ExportOptionsSuite) TestNoOptionsMatchesSerialize(c *gc.C) {
+	model := s.newModel()
+	expected, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := SerializeWithOptions(model, ExportOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, string(expected))
+}
+
+func (s *ExportOptionsSuite) TestExcludeCompletedActions(c *gc.C) {
+	model := s.newModel()
+	imported := s.exportImport(c, model, ExportOptions{ExcludeCompletedActions: true})
+
+	actions := imported.Actions()
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Id(), gc.Equals, "2")
+	// The original model is left alone.
+	c.Assert(model.Actions(), gc.HasLen, 2)
+}
+
+func (s *ExportOptionsSuite) TestExcludeObsoleteSecretRevisions(c *gc.C) {
+	model := s.newModel()
+	imported := s.exportImport(c, model, ExportOptions{ExcludeObsoleteSecretRevisions: true})
+
+	revisions := imported.Secrets()[0].Revisions()
+	c.Assert(revisions, gc.HasLen, 1)
+	c.Assert(revisions[0].Number(), gc.Equals, 2)
+	c.Assert(model.Secrets()[0].Revisions(), gc.HasLen, 2)
+}
+
+func (s *ExportOptionsSuite) TestRedactImageCredentials(c *gc.C) {
+	model := s.newModel()
+	addMinimalApplication(model)
+	application := model.Applications()[0]
+	image := &ResourceImageArgs{
+		RegistryPath: "registry.example.com/ubuntu:22.04",
+		Username:     "docker",
+		Password:     "hunter2",
+		PasswordHash: "Il/M2+WlhkUA5zASHj+QvE66",
+	}
+	resource := application.AddResource(ResourceArgs{Name: "image"})
+	resource.SetApplicationRevision(ResourceRevisionArgs{Revision: 1, Type: ResourceOCIImage, Image: image})
+	resource.SetCharmStoreRevision(ResourceRevisionArgs{Revision: 2, Type: ResourceOCIImage, Image: image})
+	application.Units()[0].AddResource(UnitResourceArgs{
+		Name:         "image",
+		RevisionArgs: ResourceRevisionArgs{Revision: 1, Type: ResourceOCIImage, Image: image},
+	})
+	imported := s.exportImport(c, model, ExportOptions{RedactImageCredentials: true})
+
+	importedApplication := imported.Applications()[0]
+	importedResource := importedApplication.Resources()[0]
+	for _, image := range []ResourceImage{
+		importedResource.ApplicationRevision().Image(),
+		importedResource.CharmStoreRevision().Image(),
+		importedApplication.Units()[0].Resources()[0].Revision().Image(),
+	} {
+		c.Check(image.RegistryPath(), gc.Equals, "registry.example.com/ubuntu:22.04")
+		c.Check(image.Username(), gc.Equals, "docker")
+		c.Check(image.Password(), gc.Equals, "")
+		c.Check(image.PasswordHash(), gc.Equals, "Il/M2+WlhkUA5zASHj+QvE66")
+	}
+	c.Assert(resource.ApplicationRevision().Image().Password(), gc.Equals, "hunter2")
+}
+
+func (s *ExportOptionsSuite) TestExcludeStoppedContainers(c *gc.C) {
+	model := s.newModel()
+	for _, id := range []string{"0", "0/lxd/0", "0/lxd/1"} {
+		model.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag(id), []byte("key")))
+	}
+	imported := s.exportImport(c, model, ExportOptions{ExcludeStoppedContainers: true})
+
+	containers := imported.Machines()[0].Containers()
+	c.Assert(containers, gc.HasLen, 1)
+	c.Assert(containers[0].Id(), gc.Equals, "0/lxd/0")
+
+	var keyMachines, addressMachines, deviceMachines []string
+	for _, key := range imported.SSHHostKeys() {
+		keyMachines = append(keyMachines, key.MachineID())
+	}
+	for _, address := range imported.IPAddresses() {
+		addressMachines = append(addressMachines, address.MachineID())
+	}
+	for _, device := range imported.LinkLayerDevices() {
+		deviceMachines = append(deviceMachines, device.MachineID())
+	}
+	c.Assert(keyMachines, jc.DeepEquals, []string{"0", "0/lxd/0"})
+	c.Assert(addressMachines, jc.DeepEquals, []string{"0", "0/lxd/0"})
+	c.Assert(deviceMachines, jc.DeepEquals, []string{"0", "0/lxd/0"})
+	var hostKeys []string
+	for _, key := range imported.VirtualHostKeys() {
+		hostKeys = append(hostKeys, key.ID())
+	}
+	c.Assert(hostKeys, jc.DeepEquals, []string{"machine-0-hostkey", "machine-0-lxd-0-hostkey"})
+	c.Assert(model.Machines()[0].Containers(), gc.HasLen, 2)
+}
+
+// newValidModel returns a model that validates, with machine 0 hosting
+// a running container 0/lxd/0 and a stopped container 0/lxd/1, and the
+// application ubuntu with its unit ubuntu/0 on machine 0.
+func (s *ExportOptionsSuite) newValidModel() Model {
+	initial := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	initial.SetStatus(minimalStatusArgs())
+	running := minimalMachine("0/lxd/0")
+	running.SetStatus(StatusArgs{Value: "started"})
+	stopped := minimalMachine("0/lxd/1")
+	stopped.SetStatus(StatusArgs{Value: stoppedStatus})
+	addMinimalMachine(initial, "0")
+	initial.Machines()[0].(*machine).Containers_ = []*machine{running, stopped}
+	addMinimalApplication(initial)
+	return initial
+}
+
+// addUnitOn adds a unit of the ubuntu application on the machine.
+func addUnitOn(initial Model, name, machineID string) {
+	ubuntu := initial.Applications()[0]
+	args := minimalUnitArgs(ubuntu.Type())
+	args.Tag = names.NewUnitTag(name)
+	args.Machine = names.NewMachineTag(machineID)
+	unit := ubuntu.AddUnit(args)
+	unit.SetAgentStatus(minimalStatusArgs())
+	unit.SetWorkloadStatus(minimalStatusArgs())
+	unit.SetTools(minimalAgentToolsArgs())
+}
+
+func (s *ExportOptionsSuite) TestExcludeStoppedContainersRemovesTheirUnits(c *gc.C) {
+	initial := s.newValidModel()
+	addUnitOn(initial, "ubuntu/1", "0/lxd/1")
+	ubuntu := initial.Applications()[0]
+	ubuntu.(*application).Leader_ = "ubuntu/1"
+	for _, name := range []string{"ubuntu/0", "ubuntu/1"} {
+		ubuntu.AddOpenedPortRange(OpenedPortRangeArgs{
+			UnitName: name, FromPort: 80, ToPort: 80, Protocol: "tcp",
+		})
+	}
+	initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/1"), []byte("key")))
+	initial.Machines()[0].SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  map[string]string{"ubuntu/0": "prepare started", "ubuntu/1": "prepare started"},
+	})
+	relation := initial.AddRelation(RelationArgs{Id: 1, Key: "ubuntu:peer"})
+	relation.SetStatus(minimalStatusArgs())
+	endpoint := relation.AddEndpoint(EndpointArgs{ApplicationName: "ubuntu", Name: "peer"})
+	endpoint.SetUnitSettings("ubuntu/0", map[string]interface{}{})
+	endpoint.SetUnitSettings("ubuntu/1", map[string]interface{}{})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	imported := s.exportImport(c, initial, ExportOptions{ExcludeStoppedContainers: true})
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+
+	remaining := imported.Applications()[0]
+	units := remaining.Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Check(units[0].Name(), gc.Equals, "ubuntu/0")
+	c.Check(remaining.Leader(), gc.Equals, "")
+	c.Check(remaining.OpenedPortRanges().ByUnit(), gc.HasLen, 1)
+	c.Check(imported.VirtualHostKeys(), gc.HasLen, 0)
+	lock := imported.Machines()[0].UpgradeSeriesLock()
+	c.Assert(lock, gc.NotNil)
+	c.Check(lock.UnitStatuses(), jc.DeepEquals, map[string]string{"ubuntu/0": "prepare started"})
+	settings := imported.Relations()[0].Endpoints()[0].AllSettings()
+	c.Check(settings, gc.HasLen, 1)
+	c.Check(settings["ubuntu/0"], gc.NotNil)
+	// The original model is left alone.
+	c.Assert(initial.Applications()[0].Units(), gc.HasLen, 2)
+}
+
+func (s *ExportOptionsSuite) TestExcludeDeadOrDyingRemovesUnitsOfRemovedMachines(c *gc.C) {
+	initial := s.newValidModel()
+	dying := minimalMachine("1")
+	dying.Life_ = Dying
+	m := initial.(*model)
+	m.Machines_.Machines_ = append(m.Machines_.Machines_, dying)
+	m.Machines_.Machines_[0].Containers_[0].Life_ = Dead
+	addUnitOn(initial, "ubuntu/1", "1")
+	addUnitOn(initial, "ubuntu/2", "0/lxd/0")
+	addUnitOn(initial, "ubuntu/3", "0/lxd/1")
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	imported := s.exportImport(c, initial, ExportOptions{ExcludeDeadOrDying: true})
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+
+	var unitNames []string
+	for _, unit := range imported.Applications()[0].Units() {
+		unitNames = append(unitNames, unit.Name())
+	}
+	c.Assert(unitNames, jc.DeepEquals, []string{"ubuntu/0", "ubuntu/3"})
+}
+
+func (s *ExportOptionsSuite) TestSerializeWithOptionsUsesTheEmitter(c *gc.C) {
+	model := s.newModel()
+	expected, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := SerializeWithOptions(model, ExportOptions{PreserveVersion: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, string(expected))
+}
+
+func (s *ExportOptionsSuite) TestSerializeWithOptionsFiltersCopy(c *gc.C) {
+	model := NewSynchronizedModel(s.newModel())
+	imported := s.exportImport(c, model, ExportOptions{ExcludeStoppedContainers: true})
+	c.Assert(imported.Machines()[0].Containers(), gc.HasLen, 1)
+	c.Assert(model.Machines()[0].Containers(), gc.HasLen, 2)
+}
+
+func (s *ExportOptionsSuite) TestExcludeDeadOrDying(c *gc.C) {
+	initial := s.newModel()
+	m := initial.(*model)
+	dying := minimalMachine("1")
+	dying.Life_ = Dying
+	m.Machines_.Machines_ = append(m.Machines_.Machines_, dying)
+	initial.AddSSHHostKey(SSHHostKeyArgs{MachineID: "1", Keys: []string{"key"}})
+	initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("1"), []byte("key")))
+	m.Machines_.Machines_[0].Containers_[0].Life_ = Dead
+
+	ubuntu := minimalApplication()
+	dyingUnit := minimalUnit()
+	dyingUnit.Name_ = "ubuntu/1"
+	dyingUnit.Life_ = Dying
+	ubuntu.Units_.Units_ = append(ubuntu.Units_.Units_, dyingUnit)
+	gone := minimalApplication()
+	gone.Name_ = "gone"
+	gone.Life_ = Dead
+	m.setApplications([]*application{ubuntu, gone})
+	initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("key")))
+	initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/1"), []byte("key")))
+
+	relation := initial.AddRelation(RelationArgs{Id: 1, Key: "ubuntu:peer"})
+	endpoint := relation.AddEndpoint(EndpointArgs{ApplicationName: "ubuntu", Name: "peer"})
+	endpoint.SetUnitSettings("ubuntu/0", map[string]interface{}{})
+	endpoint.SetUnitSettings("ubuntu/1", map[string]interface{}{})
+	initial.AddRelation(RelationArgs{Id: 2, Key: "gone:peer"}).AddEndpoint(EndpointArgs{ApplicationName: "gone", Name: "peer"})
+	initial.AddRelation(RelationArgs{Id: 3, Key: "ubuntu:other", Life: Dying})
+
+	constraints := &StorageInstanceConstraints{Pool: "fast", Size: 1024}
+	initial.AddStorage(StorageArgs{Tag: names.NewStorageTag("data/0"), Constraints: constraints})
+	initial.AddStorage(StorageArgs{Tag: names.NewStorageTag("data/1"), Constraints: constraints, Life: Dying})
+	for _, args := range []VolumeArgs{
+		{Tag: names.NewVolumeTag("0")},
+		{Tag: names.NewVolumeTag("1"), Life: Dead},
+	} {
+		initial.AddVolume(args).SetStatus(minimalStatusArgs())
+	}
+	for _, args := range []FilesystemArgs{
+		{Tag: names.NewFilesystemTag("0")},
+		{Tag: names.NewFilesystemTag("1"), Life: Dying},
+	} {
+		initial.AddFilesystem(args).SetStatus(minimalStatusArgs())
+	}
+
+	imported := s.exportImport(c, initial, ExportOptions{ExcludeDeadOrDying: true})
+
+	machines := imported.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Id(), gc.Equals, "0")
+	containers := machines[0].Containers()
+	c.Assert(containers, gc.HasLen, 1)
+	c.Assert(containers[0].Id(), gc.Equals, "0/lxd/1")
+	var keyMachines []string
+	for _, key := range imported.SSHHostKeys() {
+		keyMachines = append(keyMachines, key.MachineID())
+	}
+	c.Assert(keyMachines, jc.DeepEquals, []string{"0", "0/lxd/1"})
+	var hostKeys []string
+	for _, key := range imported.VirtualHostKeys() {
+		hostKeys = append(hostKeys, key.ID())
+	}
+	c.Assert(hostKeys, jc.DeepEquals, []string{"unit-ubuntu-0-hostkey"})
+
+	applications := imported.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	units := applications[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Name(), gc.Equals, "ubuntu/0")
+
+	relations := imported.Relations()
+	c.Assert(relations, gc.HasLen, 1)
+	c.Assert(relations[0].Id(), gc.Equals, 1)
+	c.Assert(relations[0].Endpoints()[0].AllSettings(), gc.HasLen, 1)
+
+	c.Assert(imported.Storages(), gc.HasLen, 1)
+	c.Assert(imported.Storages()[0].Tag().Id(), gc.Equals, "data/0")
+	c.Assert(imported.Volumes(), gc.HasLen, 1)
+	c.Assert(imported.Volumes()[0].Tag().Id(), gc.Equals, "0")
+	c.Assert(imported.Filesystems(), gc.HasLen, 1)
+	c.Assert(imported.Filesystems()[0].Tag().Id(), gc.Equals, "0")
+}
+
+func (s *ExportOptionsSuite) TestExcludeDeadOrDyingPrunesReferences(c *gc.C) {
+	initial := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	initial.SetStatus(minimalStatusArgs())
+	for _, args := range []MachineArgs{
+		{Id: names.NewMachineTag("0")},
+		{Id: names.NewMachineTag("1"), Life: Dying},
+	} {
+		machine := initial.AddMachine(args)
+		machine.SetInstance(CloudInstanceArgs{InstanceId: "magic"})
+		machine.SetTools(minimalAgentToolsArgs())
+		machine.SetStatus(minimalStatusArgs())
+		machine.Instance().SetStatus(minimalStatusArgs())
+		machine.Instance().SetModificationStatus(minimalStatusArgs())
+	}
+	addApplication := func(name, leader, life string, units ...UnitArgs) {
+		application := initial.AddApplication(ApplicationArgs{
+			Tag:                names.NewApplicationTag(name),
+			Leader:             leader,
+			Life:               life,
+			CharmConfig:        map[string]interface{}{},
+			LeadershipSettings: map[string]interface{}{},
+		})
+		application.SetStatus(minimalStatusArgs())
+		for _, args := range units {
+			args.Machine = names.NewMachineTag("0")
+			unit := application.AddUnit(args)
+			unit.SetTools(minimalAgentToolsArgs())
+			unit.SetAgentStatus(minimalStatusArgs())
+			unit.SetWorkloadStatus(minimalStatusArgs())
+		}
+	}
+	addApplication("ubuntu", "ubuntu/1", Alive,
+		UnitArgs{Tag: names.NewUnitTag("ubuntu/0")},
+		UnitArgs{Tag: names.NewUnitTag("ubuntu/1"), Life: Dying})
+	addApplication("gone", "gone/0", Dead, UnitArgs{Tag: names.NewUnitTag("gone/0")})
+
+	relation := initial.AddRelation(RelationArgs{Id: 1, Key: "gone:peer"})
+	relation.SetStatus(minimalStatusArgs())
+	relation.AddEndpoint(EndpointArgs{ApplicationName: "gone", Name: "peer"}).
+		SetUnitSettings("gone/0", map[string]interface{}{})
+	initial.AddOfferConnection(OfferConnectionArgs{OfferUUID: "offer-uuid", RelationID: 1, RelationKey: "gone:peer"})
+	initial.AddRemoteEntity(RemoteEntityArgs{ID: names.NewApplicationTag("gone").String(), Token: "token"})
+	initial.AddRemoteEntity(RemoteEntityArgs{ID: names.NewApplicationTag("ubuntu").String(), Token: "token"})
+
+	constraints := &StorageInstanceConstraints{Pool: "fast", Size: 1024}
+	initial.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Owner:       names.NewUnitTag("ubuntu/1"),
+		Attachments: []names.UnitTag{names.NewUnitTag("ubuntu/1")},
+		Constraints: constraints,
+	})
+	initial.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/1"),
+		Owner:       names.NewUnitTag("ubuntu/0"),
+		Attachments: []names.UnitTag{names.NewUnitTag("ubuntu/0"), names.NewUnitTag("ubuntu/1")},
+		Constraints: constraints,
+	})
+	for _, args := range []VolumeArgs{
+		{Tag: names.NewVolumeTag("0"), Storage: names.NewStorageTag("data/0")},
+		{Tag: names.NewVolumeTag("1"), Storage: names.NewStorageTag("data/1")},
+	} {
+		volume := initial.AddVolume(args)
+		volume.SetStatus(minimalStatusArgs())
+		volume.AddAttachment(VolumeAttachmentArgs{Host: names.NewMachineTag("0")})
+		volume.AddAttachment(VolumeAttachmentArgs{Host: names.NewMachineTag("1")})
+	}
+	for _, args := range []FilesystemArgs{
+		{Tag: names.NewFilesystemTag("0"), Volume: names.NewVolumeTag("0")},
+		{Tag: names.NewFilesystemTag("1")},
+	} {
+		filesystem := initial.AddFilesystem(args)
+		filesystem.SetStatus(minimalStatusArgs())
+		filesystem.AddAttachment(FilesystemAttachmentArgs{Host: names.NewUnitTag("ubuntu/0")})
+		filesystem.AddAttachment(FilesystemAttachmentArgs{Host: names.NewUnitTag("ubuntu/1")})
+	}
+	initial.Machines()[0].SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  map[string]string{"ubuntu/0": "prepare started", "ubuntu/1": "prepare started"},
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	imported := s.exportImport(c, initial, ExportOptions{ExcludeDeadOrDying: true})
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+
+	application := imported.Applications()[0]
+	c.Check(application.Leader(), gc.Equals, "")
+	c.Check(imported.OfferConnections(), gc.HasLen, 0)
+	c.Assert(imported.RemoteEntities(), gc.HasLen, 1)
+	c.Check(imported.RemoteEntities()[0].ID(), gc.Equals, "application-ubuntu")
+
+	storages := imported.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+	c.Check(storages[0].Tag().Id(), gc.Equals, "data/1")
+	c.Check(storages[0].Attachments(), jc.DeepEquals, []names.UnitTag{names.NewUnitTag("ubuntu/0")})
+	volumes := imported.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	c.Check(volumes[0].Tag().Id(), gc.Equals, "1")
+	c.Assert(volumes[0].Attachments(), gc.HasLen, 1)
+	c.Check(volumes[0].Attachments()[0].Host().Id(), gc.Equals, "0")
+	filesystems := imported.Filesystems()
+	c.Assert(filesystems, gc.HasLen, 1)
+	c.Check(filesystems[0].Tag().Id(), gc.Equals, "1")
+	c.Assert(filesystems[0].Attachments(), gc.HasLen, 1)
+	c.Check(filesystems[0].Attachments()[0].Host().Id(), gc.Equals, "ubuntu/0")
+	lock := imported.Machines()[0].UpgradeSeriesLock()
+	c.Assert(lock, gc.NotNil)
+	c.Check(lock.UnitStatuses(), jc.DeepEquals, map[string]string{"ubuntu/0": "prepare started"})
+}
+
+func (s *ExportOptionsSuite) TestCompactStatusHistory(c *gc.C) {
+	model := selfTestModel()
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var history []StatusArgs
+	for i := 0; i < 100; i++ {
+		history = append(history, StatusArgs{Value: "active", Message: "ready", Updated: when})
+	}
+	model.SetStatusHistory(history)
+	model.Applications()[0].Units()[0].SetAgentStatusHistory(history)
+
+	plain, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	compact, err := SerializeWithOptions(model, ExportOptions{CompactStatusHistory: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(len(compact) < len(plain), jc.IsTrue)
+	c.Check(string(compact), jc.Contains, "status-strings:\n- active\n- ready\n")
+
+	imported, err := Deserialize(compact)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(Diff(model, imported).Empty(), jc.IsTrue)
+	c.Check(imported.StatusHistory(), gc.HasLen, 100)
+	c.Check(imported.StatusHistory()[99].Message(), gc.Equals, "ready")
+	reexported, err := Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(reexported), gc.Equals, string(plain))
+}
+
+func (s *ExportOptionsSuite) TestCompactStatusHistoryBadIndex(c *gc.C) {
+	model := s.newModel()
+	model.SetStatusHistory([]StatusArgs{{Value: "available", Updated: time.Now()}})
+	bytes, err := SerializeWithOptions(model, ExportOptions{CompactStatusHistory: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	source[statusStringsKey] = []interface{}{}
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = Deserialize(bytes)
+	c.Assert(err, gc.ErrorMatches, "status history 0 value index 0 not valid")
+}
+
+func (s *ExportOptionsSuite) importAtVersion(c *gc.C, model Model, version int) Model {
+	bytes, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	source["version"] = version
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	return imported
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersion(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 12)
+
+	// Without the option the model is normalized to the current version.
+	bytes, err := SerializeWithOptions(imported, ExportOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	c.Check(source["version"], gc.Equals, 20)
+
+	bytes, err = SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, jc.ErrorIsNil)
+	source = nil
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	c.Check(source["version"], gc.Equals, 12)
+	_, ok := source["charms"]
+	c.Check(ok, jc.IsFalse)
+
+	reimported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(Diff(imported, reimported).Empty(), jc.IsTrue)
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionNotRepresentable(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 13)
+	imported.AddCharm(CharmArgs{URL: "cs:trusty/ubuntu"})
+
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v13: charms not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionPasswordHashAlgorithm(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 14)
+
+	imported.SetPasswordHash("hash", PasswordHashSHA512)
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported.SetPasswordHash("hash", PasswordHashPBKDF2)
+	_, err = SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, `writing model v14: password hash algorithm "pbkdf2" not supported`)
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionSecretBackends(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 15)
+	imported.AddSecretBackend(minimalSecretBackendArgs())
+
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v15: secret backends not supported")
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionEntityAnnotations(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 16)
+	imported.AddUser(UserArgs{Name: names.NewUserTag("bob"), CreatedBy: names.NewUserTag("owner")})
+
+	// Annotations held by the entities themselves can still be written.
+	err := imported.SetAnnotationsForEntity(names.NewMachineTag("0"), map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = imported.SetAnnotationsForEntity(names.NewUserTag("bob"), map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v16: entity annotations not supported")
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionSectionVersions(c *gc.C) {
+	bytes, err := Serialize(s.newModel())
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	source["version"] = 16
+	source["spaces"] = map[string]interface{}{"version": 1, "spaces": []interface{}{}}
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The spaces are written at v3, which the model was read without.
+	_, err = SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v16 with spaces v3 imported at v1 not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionTooOld(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 9)
+
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v9 not supported")
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionNotImported(c *gc.C) {
+	bytes, err := SerializeWithOptions(s.newModel(), ExportOptions{PreserveVersion: true})
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	c.Check(source["version"], gc.Equals, 20)
+}
+
+func (s *ExportOptionsSuite) TestLabels(c *gc.C) {
+	model := s.newModel()
+	labels := map[string]string{"backup-job-id": "42", "environment": "staging"}
+	bytes, err := SerializeWithOptions(model, ExportOptions{Labels: labels, Integrity: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Labels(), gc.IsNil)
+
+	// The labels are held by the document rather than the model's
+	// annotations.
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Labels(), jc.DeepEquals, labels)
+	c.Check(imported.Annotations(), gc.HasLen, 0)
+
+	summary, err := PreScan(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Labels, jc.DeepEquals, labels)
+
+	// They are written out again with the model, unless replaced.
+	reserialized, err := Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	summary, err = PreScan(reserialized)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Labels, jc.DeepEquals, labels)
+
+	replaced := map[string]string{"environment": "production"}
+	bytes, err = SerializeWithOptions(imported, ExportOptions{Labels: replaced})
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Labels(), jc.DeepEquals, replaced)
+}
+
+func (s *ExportOptionsSuite) TestLabelsAnyVersion(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 12)
+	bytes, err := SerializeWithOptions(imported, ExportOptions{
+		Labels:          map[string]string{"environment": "staging"},
+		PreserveVersion: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Labels(), jc.DeepEquals, map[string]string{"environment": "staging"})
+}
+
+func (s *ExportOptionsSuite) TestLabelsNotStrings(c *gc.C) {
+	bytes, err := Serialize(s.newModel())
+	c.Assert(err, jc.ErrorIsNil)
+	bytes = append(bytes, "labels:\n  nested: [a]\n"...)
+	_, err = Deserialize(bytes)
+	c.Assert(err, gc.ErrorMatches, `labels schema check failed: labels.nested: expected string, got .*`)
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionMigrationAttempt(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 17)
+	imported.SetMigrationAttempt(MigrationAttemptArgs{Attempt: 1})
+
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v17: migration attempt not supported")
+}
+
+func (s *ExportOptionsSuite) TestPreserveVersionVirtualHostKeys(c *gc.C) {
+	imported := s.importAtVersion(c, s.newModel(), 19)
+	imported.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("key")))
+
+	_, err := SerializeWithOptions(imported, ExportOptions{PreserveVersion: true})
+	c.Assert(err, gc.ErrorMatches, "writing model v19: virtual host keys not supported")
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"sort"
+	"time"
+)
+
+// ExportMetrics receives measurements of a model written out by
+// SerializeWithOptions, so that the performance of migration exports can
+// be monitored over time. The kinds and sections passed to it are fixed
+// names, suitable for use as metric labels, and every kind is reported
+// for each export, even when there are none of that kind.
+type ExportMetrics interface {
+	// EntitiesSerialized records the number of entities of a kind, such
+	// as "units", that were written out.
+	EntitiesSerialized(kind string, count int)
+
+	// SectionSerialized records the size in bytes of a top level section
+	// of the serialized model, such as "applications".
+	SectionSerialized(section string, size int)
+
+	// ExportCompleted records how long the export took.
+	ExportCompleted(duration time.Duration)
+}
+
+// reportExportMetrics passes the measurements of the export of the model
+// to the metrics.
+func reportExportMetrics(metrics ExportMetrics, exported Model, serialized []byte, duration time.Duration) {
+	counts := entityCounts(exported)
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		metrics.EntitiesSerialized(kind, counts[kind])
+	}
+
+	sizes := sectionSizes(serialized)
+	sections := make([]string, 0, len(sizes))
+	for section := range sizes {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	for _, section := range sections {
+		metrics.SectionSerialized(section, sizes[section])
+	}
+
+	metrics.ExportCompleted(duration)
+}
+
+// entityCounts returns the number of entities of each kind in the model.
+// Machines include their containers.
+func entityCounts(m Model) map[string]int {
+	var machines, units, offers, blockDevices int
+	var countMachines func([]Machine)
+	countMachines = func(list []Machine) {
+		for _, machine := range list {
+			machines++
+			blockDevices += len(machine.BlockDevices())
+			countMachines(machine.Containers())
+		}
+	}
+	countMachines(m.Machines())
+	for _, application := range m.Applications() {
+		units += len(application.Units())
+		offers += len(application.Offers())
+	}
+	return map[string]int{
+		"actions":              len(m.Actions()),
+		"applications":         len(m.Applications()),
+		"block-devices":        blockDevices,
+		"charms":               len(m.Charms()),
+		"cloud-image-metadata": len(m.CloudImageMetadata()),
+		"external-controllers": len(m.ExternalControllers()),
+		"filesystems":          len(m.Filesystems()),
+		"firewall-rules":       len(m.FirewallRules()),
+		"ip-addresses":         len(m.IPAddresses()),
+		"link-layer-devices":   len(m.LinkLayerDevices()),
+		"machines":             machines,
+		"offer-connections":    len(m.OfferConnections()),
+		"offers":               offers,
+		"operations":           len(m.Operations()),
+		"relation-networks":    len(m.RelationNetworks()),
+		"relations":            len(m.Relations()),
+		"remote-applications":  len(m.RemoteApplications()),
+		"remote-entities":      len(m.RemoteEntities()),
+		"remote-secrets":       len(m.RemoteSecrets()),
+		"secret-backends":      len(m.SecretBackends()),
+		"secrets":              len(m.Secrets()),
+		"spaces":               len(m.Spaces()),
+		"ssh-host-keys":        len(m.SSHHostKeys()),
+		"storage-pools":        len(m.StoragePools()),
+		"storages":             len(m.Storages()),
+		"subnets":              len(m.Subnets()),
+		"units":                units,
+		"users":                len(m.Users()),
+		"virtual-host-keys":    len(m.VirtualHostKeys()),
+		"volumes":              len(m.Volumes()),
+	}
+}
+
+// sectionSizes returns the size in bytes of each top level section of a
+// serialized model.
+func sectionSizes(serialized []byte) map[string]int {
+	sizes := make(map[string]int)
+	for _, section := range topLevelSections(serialized) {
+		sizes[section.name] += len(section.bytes)
+	}
+	return sizes
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ExportMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ExportMetricsSuite{})
+
+type recordingMetrics struct {
+	entities  map[string]int
+	sections  map[string]int
+	durations []time.Duration
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		entities: make(map[string]int),
+		sections: make(map[string]int),
+	}
+}
+
+func (r *recordingMetrics) EntitiesSerialized(kind string, count int) {
+	r.entities[kind] = count
+}
+
+func (r *recordingMetrics) SectionSerialized(section string, size int) {
+	r.sections[section] = size
+}
+
+func (r *recordingMetrics) ExportCompleted(duration time.Duration) {
+	r.durations = append(r.durations, duration)
+}
+
+func (s *ExportMetricsSuite) TestMetrics(c *gc.C) {
+	metrics := newRecordingMetrics()
+	bytes, err := SerializeWithOptions(selfTestModel(), ExportOptions{Metrics: metrics})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metrics don't change what is written.
+	plain, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, string(plain))
+
+	c.Check(metrics.entities["machines"], gc.Equals, 2)
+	c.Check(metrics.entities["applications"], gc.Equals, 1)
+	c.Check(metrics.entities["units"], gc.Equals, 1)
+	c.Check(metrics.entities["volumes"], gc.Equals, len(selfTestModel().Volumes()))
+	c.Check(metrics.entities, gc.HasLen, len(entityCounts(selfTestModel())))
+
+	total := 0
+	for _, size := range metrics.sections {
+		total += size
+	}
+	c.Check(total, gc.Equals, len(bytes))
+	c.Check(metrics.sections["applications"] > 0, jc.IsTrue)
+	c.Check(metrics.sections["version"], gc.Equals, len("version: 20\n"))
+	c.Check(metrics.durations, gc.HasLen, 1)
+}
+
+func (s *ExportMetricsSuite) TestMetricsWithOtherOptions(c *gc.C) {
+	model := (&ExportOptionsSuite{}).newModel()
+	metrics := newRecordingMetrics()
+	_, err := SerializeWithOptions(model, ExportOptions{
+		ExcludeStoppedContainers: true,
+		Integrity:                true,
+		Metrics:                  metrics,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The stopped container isn't counted, as it isn't written out.
+	c.Check(metrics.entities["machines"], gc.Equals, 2)
+	c.Check(metrics.entities["ssh-host-keys"], gc.Equals, 2)
+	c.Check(metrics.sections[integrityKey] > 0, jc.IsTrue)
+}
+
+func (s *ExportMetricsSuite) TestSectionSizes(c *gc.C) {
+	serialized := []byte("" +
+		"version: 1\n" +
+		"machines:\n" +
+		"  version: 2\n" +
+		"  machines:\n" +
+		"  - id: \"0\"\n" +
+		"list:\n" +
+		"- a\n" +
+		"- b\n" +
+		"cert: |\n" +
+		"  line\n" +
+		"\n" +
+		"  line\n")
+	c.Assert(sectionSizes(serialized), jc.DeepEquals, map[string]int{
+		"version":  11,
+		"machines": 47,
+		"list":     14,
+		"cert":     23,
+	})
+}
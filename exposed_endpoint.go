@@ -4,6 +4,8 @@
 package description
 
 import (
+	"net"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
@@ -40,6 +42,17 @@ func (exp *exposedEndpoint) ExposeToCIDRs() []string {
 	return exp.ExposeToCIDRs_
 }
 
+// validate checks that each of the CIDRs the endpoint is exposed to can be
+// parsed, whether IPv4 or IPv6.
+func (exp *exposedEndpoint) validate() error {
+	for _, cidr := range exp.ExposeToCIDRs_ {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
 func importExposedEndpointsMap(sourceMap map[string]interface{}) (map[string]*exposedEndpoint, error) {
 	result := make(map[string]*exposedEndpoint)
 	for key, value := range sourceMap {
@@ -111,6 +111,16 @@ func importExternalControllerList(sourceList []interface{}, importFunc externalC
 	return result, nil
 }
 
+// Validate checks that the CA certificate of the external controller is a
+// PEM encoded x509 certificate, without which no connection to it could be
+// trusted.
+func (e *externalController) Validate() error {
+	if !validCACert(e.CACert_) {
+		return errors.NotValidf("external controller %q CA cert", e.ID_)
+	}
+	return nil
+}
+
 var externalControllerDeserializationFuncs = map[int]externalControllerDeserializationFunc{
 	1: importExternalControllerV1,
 }
@@ -4,6 +4,9 @@
 package description
 
 import (
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -137,3 +140,20 @@ func (s *ExternalControllerSerializationSuite) exportImport(c *gc.C, controllerI
 	c.Assert(externalControllersOut, gc.HasLen, 1)
 	return externalControllersOut[0]
 }
+
+func (*ExternalControllerSerializationSuite) TestValidate(c *gc.C) {
+	e := minimalExternalController()
+	e.CACert_ = selfTestCACert
+	c.Assert(e.Validate(), jc.ErrorIsNil)
+}
+
+func (*ExternalControllerSerializationSuite) TestValidateCACert(c *gc.C) {
+	garbled := strings.Replace(selfTestCACert, "MII", "XXX", 1)
+	for _, cert := range []string{"", "magic-cert", garbled} {
+		e := minimalExternalController()
+		e.CACert_ = cert
+		err := e.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `external controller "ext-ctrl" CA cert not valid`)
+	}
+}
@@ -23,6 +23,7 @@ type filesystem struct {
 	Size_         uint64 `yaml:"size"`
 	Pool_         string `yaml:"pool,omitempty"`
 	FilesystemID_ string `yaml:"filesystem-id,omitempty"`
+	Life_         string `yaml:"life,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -51,6 +52,7 @@ type FilesystemArgs struct {
 	Size         uint64
 	Pool         string
 	FilesystemID string
+	Life         string
 }
 
 func newFilesystem(args FilesystemArgs) *filesystem {
@@ -62,7 +64,8 @@ func newFilesystem(args FilesystemArgs) *filesystem {
 		Size_:          args.Size,
 		Pool_:          args.Pool,
 		FilesystemID_:  args.FilesystemID,
-		StatusHistory_: newStatusHistory(),
+		Life_:          args.Life,
+		StatusHistory_: NewStatusHistory(),
 	}
 	f.setAttachments(nil)
 	return f
@@ -109,6 +112,11 @@ func (f *filesystem) FilesystemID() string {
 	return f.FilesystemID_
 }
 
+// Life implements Filesystem.
+func (f *filesystem) Life() string {
+	return lifeOrAlive(f.Life_)
+}
+
 // Status implements Filesystem.
 func (f *filesystem) Status() Status {
 	// To avoid typed nils check nil here.
@@ -151,6 +159,9 @@ func (f *filesystem) Validate() error {
 	if f.ID_ == "" {
 		return errors.NotValidf("filesystem missing id")
 	}
+	if err := validateLife("filesystem", f.ID_, f.Life_); err != nil {
+		return errors.Trace(err)
+	}
 	if f.Size_ == 0 {
 		return errors.NotValidf("filesystem %q missing size", f.ID_)
 	}
@@ -197,9 +208,20 @@ type filesystemDeserializationFunc func(map[string]interface{}) (*filesystem, er
 
 var filesystemDeserializationFuncs = map[int]filesystemDeserializationFunc{
 	1: importFilesystemV1,
+	2: importFilesystemV2,
 }
 
 func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
+	fields, defaults := filesystemV1Fields()
+	return importFilesystem(fields, defaults, 1, source)
+}
+
+func importFilesystemV2(source map[string]interface{}) (*filesystem, error) {
+	fields, defaults := filesystemV2Fields()
+	return importFilesystem(fields, defaults, 2, source)
+}
+
+func filesystemV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"id":            schema.String(),
 		"storage-id":    schema.String(),
@@ -219,12 +241,23 @@ func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
 		"filesystem-id": "",
 		"attachments":   schema.Omit,
 	}
-	addStatusHistorySchema(fields)
+	AddStatusHistorySchema(fields)
+	return fields, defaults
+}
+
+func filesystemV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := filesystemV1Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
+func importFilesystem(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*filesystem, error) {
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "filesystem v1 schema check failed")
+		return nil, errors.Annotatef(err, "filesystem v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
@@ -237,9 +270,12 @@ func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
 		Size_:          valid["size"].(uint64),
 		Pool_:          valid["pool"].(string),
 		FilesystemID_:  valid["filesystem-id"].(string),
-		StatusHistory_: newStatusHistory(),
+		StatusHistory_: NewStatusHistory(),
+	}
+	if importVersion >= 2 {
+		result.Life_ = valid["life"].(string)
 	}
-	if err := result.importStatusHistory(valid); err != nil {
+	if err := result.ImportStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -138,8 +138,12 @@ func (s *FilesystemSerializationSuite) TestFilesystemMatches(c *gc.C) {
 }
 
 func (s *FilesystemSerializationSuite) exportImport(c *gc.C, filesystem_ *filesystem) *filesystem {
+	return s.exportImportVersion(c, filesystem_, 2)
+}
+
+func (s *FilesystemSerializationSuite) exportImportVersion(c *gc.C, filesystem_ *filesystem, version int) *filesystem {
 	initial := filesystems{
-		Version:      1,
+		Version:      version,
 		Filesystems_: []*filesystem{filesystem_},
 	}
 
@@ -297,3 +301,24 @@ func (s *FilesystemAttachmentSerializationSuite) TestUnitAttachmentParsing(c *gc
 		Provisioned_: true,
 	})
 }
+
+func (s *FilesystemSerializationSuite) TestLife(c *gc.C) {
+	original := testFilesystem()
+	c.Assert(original.Life(), gc.Equals, Alive)
+	original.Life_ = Dead
+	c.Assert(original.Validate(), jc.ErrorIsNil)
+
+	filesystem := s.exportImport(c, original)
+	c.Assert(filesystem, jc.DeepEquals, original)
+
+	filesystem = s.exportImportVersion(c, original, 1)
+	c.Assert(filesystem.Life(), gc.Equals, Alive)
+}
+
+func (s *FilesystemSerializationSuite) TestValidateLife(c *gc.C) {
+	original := testFilesystem()
+	original.Life_ = "zombie"
+	err := original.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "1234" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
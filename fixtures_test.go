@@ -0,0 +1,246 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+// The golden fixtures in testdata/fixtures hold the serialized form of the
+// model, and of each of its sections, for every version that has been
+// written out. Running
+//
+//	go test -check.f FixturesSuite -update-fixtures
+//
+// writes fixtures for the current versions that don't have one yet, so
+// that bumping the version of a section adds a new fixture alongside the
+// old. An existing fixture is never rewritten: it records what was written
+// when its version was current, and TestParseFixtures ensures that every
+// such historical fixture still imports with the current code.
+var updateFixtures = flag.Bool("update-fixtures", false, "write golden fixtures for the current serialization versions")
+
+const fixturesDir = "testdata/fixtures"
+
+// fixtureSections maps the top level keys of a serialized model to the
+// functions that import them.
+var fixtureSections = map[string]func(map[string]interface{}) error{
+	"actions":              func(m map[string]interface{}) error { _, err := importActions(m); return err },
+	"applications":         func(m map[string]interface{}) error { _, err := importApplications(m); return err },
+	"charms":               func(m map[string]interface{}) error { _, err := importCharms(m); return err },
+	"cloud-image-metadata": func(m map[string]interface{}) error { _, err := importCloudImageMetadatas(m); return err },
+	"external-controllers": func(m map[string]interface{}) error { _, err := importExternalControllers(m); return err },
+	"filesystems":          func(m map[string]interface{}) error { _, err := importFilesystems(m); return err },
+	"firewall-rules":       func(m map[string]interface{}) error { _, err := importFirewallRules(m); return err },
+	"ip-addresses":         func(m map[string]interface{}) error { _, err := importIPAddresses(m); return err },
+	"link-layer-devices":   func(m map[string]interface{}) error { _, err := importLinkLayerDevices(m); return err },
+	"machines":             func(m map[string]interface{}) error { _, err := importMachines(m); return err },
+	"offer-connections":    func(m map[string]interface{}) error { _, err := importOfferConnections(m); return err },
+	"operations":           func(m map[string]interface{}) error { _, err := importOperations(m); return err },
+	"relation-networks":    func(m map[string]interface{}) error { _, err := importRelationNetworks(m); return err },
+	"relations":            func(m map[string]interface{}) error { _, err := importRelations(m); return err },
+	"remote-applications":  func(m map[string]interface{}) error { _, err := importRemoteApplications(m); return err },
+	"remote-entities":      func(m map[string]interface{}) error { _, err := importRemoteEntities(m); return err },
+	"remote-secrets":       func(m map[string]interface{}) error { _, err := importRemoteSecrets(m); return err },
+	"secret-backends":      func(m map[string]interface{}) error { _, err := importSecretBackends(m); return err },
+	"secrets":              func(m map[string]interface{}) error { _, err := importSecrets(m); return err },
+	"spaces":               func(m map[string]interface{}) error { _, err := importSpaces(m); return err },
+	"ssh-host-keys":        func(m map[string]interface{}) error { _, err := importSSHHostKeys(m); return err },
+	"storage-pools":        func(m map[string]interface{}) error { _, err := importStoragePools(m); return err },
+	"storages":             func(m map[string]interface{}) error { _, err := importStorages(m); return err },
+	"subnets":              func(m map[string]interface{}) error { _, err := importSubnets(m); return err },
+	"users":                func(m map[string]interface{}) error { _, err := importUsers(m); return err },
+	"virtual-host-keys":    func(m map[string]interface{}) error { _, err := importVirtualHostKeys(m); return err },
+	"volumes":              func(m map[string]interface{}) error { _, err := importVolumes(m); return err },
+}
+
+type FixturesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FixturesSuite{})
+
+// fixtureModel returns a model built from the canonical minimal entities,
+// with something in most of its sections.
+func fixtureModel() Model {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	owner := names.NewUserTag("admin")
+	model := NewModel(ModelArgs{
+		AgentVersion: "3.1.1",
+		Type:         IAAS,
+		Owner:        owner,
+		Config: map[string]interface{}{
+			"name": "fixture",
+			"uuid": "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6",
+		},
+		LatestToolsVersion:    version.MustParse("3.1.2"),
+		Cloud:                 "vapour",
+		CloudRegion:           "east-west",
+		Description:           "fixture model, owner: team-x",
+		PasswordHash:          "fixture-hash",
+		PasswordHashAlgorithm: PasswordHashPBKDF2,
+	})
+	model.SetStatus(StatusArgs{Value: "available", Updated: created})
+	model.SetTelemetry(TelemetryArgs{Enabled: true, LastReportTime: created})
+	model.AddUser(UserArgs{
+		Name:        owner,
+		CreatedBy:   owner,
+		DateCreated: created,
+		Access:      "admin",
+		AccessHistory: []AccessGrantArgs{
+			{Access: "read", GrantedBy: owner, Granted: created},
+			{Access: "admin", GrantedBy: owner, Granted: created.Add(time.Hour)},
+		},
+	})
+	addMinimalMachine(model, "0")
+	model.Machines()[0].SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  map[string]string{"ubuntu/0": "prepare started"},
+	})
+	addMinimalApplication(model)
+	model.AddCharm(CharmArgs{URL: "cs:trusty/ubuntu", Revision: 1, StoragePath: "charms/ubuntu"})
+	model.AddSpace(SpaceArgs{Id: "1", Name: "alpha", ProviderID: "p-alpha"})
+	model.AddSubnet(SubnetArgs{
+		ID:      "2",
+		CIDR:    "10.0.0.0/24",
+		SpaceID: "1",
+	})
+	model.AddSSHHostKey(SSHHostKeyArgs{MachineID: "0", Keys: []string{"ssh-rsa fixture"}})
+	model.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("ssh-ed25519 fixture")))
+	model.AddStoragePool(StoragePoolArgs{Name: "fast", Provider: "loop"})
+	model.AddSecretBackend(SecretBackendArgs{
+		ID:          "b7b5c0de-3f0e-4e7a-9c1a-5d2f3e4a5b6c",
+		Name:        "vault",
+		BackendType: "vault",
+		Config:      map[string]interface{}{"endpoint": "http://vault:8200"},
+	})
+	model.AddFirewallRule(FirewallRuleArgs{
+		ID:               "ssh",
+		WellKnownService: "ssh",
+		WhitelistCIDRs:   []string{"0.0.0.0/0"},
+	})
+	return model
+}
+
+// currentFixtures returns the serialized model, and each of its sections,
+// keyed by their fixture path.
+func currentFixtures(c *gc.C) map[string][]byte {
+	bytes, err := Serialize(fixtureModel())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := map[string][]byte{
+		fixturePath("model", source["version"]): bytes,
+	}
+	for name := range fixtureSections {
+		section, ok := source[name].(map[interface{}]interface{})
+		c.Assert(ok, jc.IsTrue, gc.Commentf("section %q", name))
+		sectionBytes, err := yaml.Marshal(section)
+		c.Assert(err, jc.ErrorIsNil)
+		result[fixturePath(name, section["version"])] = sectionBytes
+	}
+	return result
+}
+
+func fixturePath(name string, version interface{}) string {
+	return filepath.Join(fixturesDir, name, fmt.Sprintf("v%v.yaml", version))
+}
+
+func (s *FixturesSuite) TestUpdateFixtures(c *gc.C) {
+	if !*updateFixtures {
+		c.Skip("-update-fixtures not set")
+	}
+	for path, bytes := range currentFixtures(c) {
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		c.Logf("writing %s", path)
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), jc.ErrorIsNil)
+		c.Assert(writeNewFixture(path, bytes), jc.ErrorIsNil)
+	}
+}
+
+// writeNewFixture writes the fixture, failing rather than replacing one
+// that already exists.
+func writeNewFixture(path string, bytes []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bytes); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *FixturesSuite) TestWriteNewFixtureRefusesOverwrite(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "v1.yaml")
+	c.Assert(writeNewFixture(path, []byte("version: 1\n")), jc.ErrorIsNil)
+
+	err := writeNewFixture(path, []byte("version: 2\n"))
+	c.Assert(os.IsExist(err), jc.IsTrue, gc.Commentf("%v", err))
+	bytes, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, "version: 1\n")
+}
+
+func (s *FixturesSuite) TestCurrentVersionsHaveFixtures(c *gc.C) {
+	var missing []string
+	for path := range currentFixtures(c) {
+		if _, err := os.Stat(path); err != nil {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	c.Assert(missing, gc.HasLen, 0, gc.Commentf("run with -update-fixtures to add %v", missing))
+}
+
+func (s *FixturesSuite) TestParseFixtures(c *gc.C) {
+	paths, err := filepath.Glob(filepath.Join(fixturesDir, "*", "v*.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, gc.Not(gc.HasLen), 0)
+
+	for _, path := range paths {
+		c.Logf("parsing %s", path)
+		bytes, err := os.ReadFile(path)
+		c.Assert(err, jc.ErrorIsNil)
+
+		name := filepath.Base(filepath.Dir(path))
+		if name == "model" {
+			model, err := Deserialize(bytes)
+			c.Check(err, jc.ErrorIsNil, gc.Commentf("%s", path))
+			if err == nil {
+				c.Check(model.Validate(), jc.ErrorIsNil, gc.Commentf("%s", path))
+			}
+			continue
+		}
+
+		importFunc, ok := fixtureSections[name]
+		if !ok {
+			c.Errorf("fixture %s for unknown section %q", path, name)
+			continue
+		}
+		var source map[string]interface{}
+		err = yaml.Unmarshal(bytes, &source)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(importFunc(source), jc.ErrorIsNil, gc.Commentf("%s", path))
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// ImportOption configures how Deserialize imports a model.
+type ImportOption func(*importOptions)
+
+type importOptions struct {
+	logger             *slog.Logger
+	requireStatusTimes bool
+	charmStoreURLs     map[string]string
+	translated         *[]CharmURLTranslation
+	skipped            *[]SkippedEntity
+
+	preserveUnknownFields bool
+
+	// releaseSections has the import drop the parsed source of each
+	// section once it has been imported, for ValidateDocument.
+	releaseSections bool
+
+	// lazy is given the sections holding the model's entities to import
+	// on first use, rather than them being imported, for LazyDeserialize.
+	lazy *lazySections
+}
+
+// WithLogger has Deserialize and ValidateDocument record diagnostics
+// about the import at debug level to the logger: the version of each
+// section, any upgrade from an older model version, schema check failures,
+// and how long each section took to import. ValidateDocument also records
+// how long each part of the validation of the model took, and the failure
+// it found, if any.
+func WithLogger(logger *slog.Logger) ImportOption {
+	return func(o *importOptions) {
+		o.logger = logger
+	}
+}
+
+// RequireStatusTimes has Deserialize fail, rather than fill in a status
+// that wasn't recorded, when importing versions that are missing one.
+// These are the status of a v1 model, which would be given the time of
+// the import, the status of a v1 cloud instance, and the modification
+// status of cloud instances before v7. Without the option, the statuses
+// filled in lose the "since" times shown by juju status.
+func RequireStatusTimes() ImportOption {
+	return func(o *importOptions) {
+		o.requireStatusTimes = true
+	}
+}
+
+// CharmURLTranslation describes a charm store URL of an application that
+// TranslateCharmStoreURLs came across.
+type CharmURLTranslation struct {
+	// Application is the name of the application deployed from the
+	// charm store.
+	Application string
+	// From is the charm store URL the application was exported with.
+	From string
+	// To is the charm hub URL it was rewritten to, or empty if the
+	// mapping has none, and the URL was left as it was.
+	To string
+}
+
+// TranslateCharmStoreURLs has Deserialize rewrite the charm URLs of
+// applications deployed from the charm store to the charm hub URLs given
+// by the mapping, which is keyed by the full "cs:" URL. The charm origin
+// of a rewritten application is moved to the charm hub. Charm store URLs
+// missing from the mapping are left as they are. Each URL rewritten or
+// left is appended to translated, if it isn't nil, and reported to the
+// logger given by WithLogger, at info level.
+func TranslateCharmStoreURLs(mapping map[string]string, translated *[]CharmURLTranslation) ImportOption {
+	return func(o *importOptions) {
+		o.charmStoreURLs = mapping
+		o.translated = translated
+	}
+}
+
+// releaseSections has the import drop the parsed source of each section
+// once it has been imported.
+func releaseSections() ImportOption {
+	return func(o *importOptions) {
+		o.releaseSections = true
+	}
+}
+
+func newImportOptions(options []ImportOption) importOptions {
+	var result importOptions
+	for _, option := range options {
+		option(&result)
+	}
+	return result
+}
+
+// debug logs the message if a logger was given.
+func (o importOptions) debug(msg string, args ...any) {
+	if o.logger != nil {
+		o.logger.Debug(msg, args...)
+	}
+}
+
+// info logs the message if a logger was given.
+func (o importOptions) info(msg string, args ...any) {
+	if o.logger != nil {
+		o.logger.Info(msg, args...)
+	}
+}
+
+// logSectionVersions logs the version of each versioned section of the
+// model being imported.
+func (o importOptions) logSectionVersions(valid map[string]interface{}) {
+	if o.logger == nil {
+		return
+	}
+	var names []string
+	for name, value := range valid {
+		if section, ok := value.(map[string]interface{}); ok && section["version"] != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o.debug("section version", "section", name, "version", valid[name].(map[string]interface{})["version"])
+	}
+}
+
+// sectionTimer logs how long each section of a model took to import, as
+// the time since the previous section was imported. When the import is
+// releasing sections, it also drops the parsed source of each section as
+// it is marked.
+type sectionTimer struct {
+	options importOptions
+	valid   map[string]interface{}
+	last    time.Time
+}
+
+func (o importOptions) newSectionTimer(valid map[string]interface{}) *sectionTimer {
+	return &sectionTimer{options: o, valid: valid, last: time.Now()}
+}
+
+// mark logs the time taken to import the section.
+func (t *sectionTimer) mark(section string) {
+	now := time.Now()
+	t.options.debug("imported section", "section", section, "duration", now.Sub(t.last))
+	t.last = now
+	if t.options.releaseSections {
+		delete(t.valid, section)
+	}
+}
+
+// checkStatusTimes returns an error satisfying errors.IsNotValid if
+// importing the model would fill in a status that the source is missing.
+func checkStatusTimes(source map[string]interface{}, version int) error {
+	if version < 2 {
+		return errors.NotValidf("model v%d missing status", version)
+	}
+	machines, ok := sourceMap(source["machines"])
+	if !ok {
+		return nil
+	}
+	list, _ := machines["machines"].([]interface{})
+	return checkMachineStatusTimes(list)
+}
+
+func checkMachineStatusTimes(list []interface{}) error {
+	for _, value := range list {
+		machine, ok := sourceMap(value)
+		if !ok {
+			continue
+		}
+		if instance, ok := sourceMap(machine["instance"]); ok {
+			// Badly formed instances are left for the import to report.
+			version, err := getVersion(instance)
+			if err == nil && version < 2 {
+				return errors.NotValidf("machine %v instance v%d missing status", machine["id"], version)
+			}
+			if err == nil && version < 7 && instance["modification-status"] == nil {
+				return errors.NotValidf("machine %v instance v%d missing modification status", machine["id"], version)
+			}
+		}
+		containers, _ := machine["containers"].([]interface{})
+		if err := checkMachineStatusTimes(containers); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sourceMap returns the value as a map with string keys, if it is a map.
+func sourceMap(value interface{}) (map[string]interface{}, bool) {
+	coerced, err := schema.StringMap(schema.Any()).Coerce(value, nil)
+	if err != nil {
+		return nil, false
+	}
+	return coerced.(map[string]interface{}), true
+}
+
+// translateCharmStoreURLs rewrites the charm store URLs of the model's
+// applications with the charm hub URLs in the mapping.
+func (o importOptions) translateCharmStoreURLs(m *model) {
+	if o.charmStoreURLs == nil {
+		return
+	}
+	for _, application := range m.Applications_.Applications_ {
+		from := application.CharmURL_
+		if !strings.HasPrefix(from, "cs:") {
+			continue
+		}
+		to, ok := o.charmStoreURLs[from]
+		if o.translated != nil {
+			*o.translated = append(*o.translated, CharmURLTranslation{
+				Application: application.Name_,
+				From:        from,
+				To:          to,
+			})
+		}
+		if !ok {
+			o.info("charm store URL not translated", "application", application.Name_, "url", from)
+			continue
+		}
+		application.CharmURL_ = to
+		if application.CharmOrigin_ != nil && application.CharmOrigin_.Source_ == "charm-store" {
+			application.CharmOrigin_.Source_ = "charm-hub"
+		}
+		o.info("translated charm store URL", "application", application.Name_, "from", from, "to", to)
+	}
+}
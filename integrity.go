@@ -0,0 +1,150 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// integrityKey is the top level key of the section of a serialized model
+// that records the digests of its other sections.
+const integrityKey = "integrity"
+
+// integrityAlgorithm is the only algorithm that section digests are
+// computed with.
+const integrityAlgorithm = "sha256"
+
+// Checksum implements Model.
+func (m *model) Checksum() (string, error) {
+	bytes, err := marshalYAML(m)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return "", errors.Trace(err)
+	}
+	digests, err := sectionDigests(source)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return combineDigests(digests), nil
+}
+
+// sectionDigests returns the digest of each top level section of the
+// model source, other than the integrity section. A section is digested in
+// its serialized form, with the keys of its maps sorted, so that the
+// digests of a model don't depend on the order it was written in.
+func sectionDigests(source map[string]interface{}) (map[string]string, error) {
+	digests := make(map[string]string, len(source))
+	for key, value := range source {
+		if key == integrityKey {
+			continue
+		}
+		bytes, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "section %q", key)
+		}
+		sum := sha256.Sum256(bytes)
+		digests[key] = hex.EncodeToString(sum[:])
+	}
+	return digests, nil
+}
+
+// combineDigests returns a single digest of the section digests.
+func combineDigests(digests map[string]string) string {
+	keys := make([]string, 0, len(digests))
+	for key := range digests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key + " " + digests[key] + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// addIntegrity appends to a serialized model an integrity section holding
+// the digests of its sections.
+func addIntegrity(bytes []byte) ([]byte, error) {
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, ok := source[integrityKey]; ok {
+		return bytes, nil
+	}
+	digests, err := sectionDigests(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	section, err := yaml.Marshal(map[string]interface{}{
+		integrityKey: map[string]interface{}{
+			"algorithm": integrityAlgorithm,
+			"sections":  digests,
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(bytes, section...), nil
+}
+
+// verifyIntegrity checks the sections of the model source against the
+// digests of its integrity section, if it has one, so that a truncated or
+// altered model is rejected before it is imported. It returns the source
+// without the integrity section.
+func verifyIntegrity(source map[string]interface{}) (map[string]interface{}, error) {
+	value, ok := source[integrityKey]
+	if !ok {
+		return source, nil
+	}
+	checker := schema.FieldMap(schema.Fields{
+		"algorithm": schema.String(),
+		"sections":  schema.StringMap(schema.String()),
+	}, nil) // no defaults
+	coerced, err := checker.Coerce(value, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "integrity schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	if algorithm := valid["algorithm"].(string); algorithm != integrityAlgorithm {
+		return nil, errors.NotSupportedf("integrity algorithm %q", algorithm)
+	}
+
+	digests, err := sectionDigests(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	recorded := valid["sections"].(map[string]interface{})
+	for key, digest := range recorded {
+		actual, ok := digests[key]
+		if !ok {
+			return nil, errors.NotValidf("integrity: section %q missing", key)
+		}
+		if actual != digest.(string) {
+			return nil, errors.NotValidf("integrity: section %q digest", key)
+		}
+	}
+	for key := range digests {
+		if _, ok := recorded[key]; !ok {
+			return nil, errors.NotValidf("integrity: section %q unrecorded", key)
+		}
+	}
+
+	result := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		if key != integrityKey {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type IntegritySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&IntegritySuite{})
+
+func (*IntegritySuite) serializeSource(c *gc.C, model Model) map[string]interface{} {
+	bytes, err := SerializeWithOptions(model, ExportOptions{Integrity: true})
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (*IntegritySuite) deserializeSource(c *gc.C, source map[string]interface{}) (Model, error) {
+	bytes, err := yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+	return Deserialize(bytes)
+}
+
+func (s *IntegritySuite) TestRoundTrip(c *gc.C) {
+	model := selfTestModel()
+	bytes, err := SerializeWithOptions(model, ExportOptions{Integrity: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	integrity, ok := source[integrityKey].(map[interface{}]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(integrity["algorithm"], gc.Equals, "sha256")
+	sections := integrity["sections"].(map[interface{}]interface{})
+	c.Check(sections["machines"], gc.Matches, "[0-9a-f]{64}")
+	c.Check(sections["applications"], gc.Matches, "[0-9a-f]{64}")
+	c.Check(sections[integrityKey], gc.IsNil)
+
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	plain, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := Deserialize(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, expected)
+}
+
+func (s *IntegritySuite) TestWithoutIntegrity(c *gc.C) {
+	bytes, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source[integrityKey], gc.IsNil)
+}
+
+func (s *IntegritySuite) TestTamperedSection(c *gc.C) {
+	source := s.serializeSource(c, selfTestModel())
+	source["owner"] = "mallory"
+	_, err := s.deserializeSource(c, source)
+	c.Assert(err, gc.ErrorMatches, `integrity: section "owner" digest not valid`)
+	c.Assert(errors.Is(err, errors.NotValid), jc.IsTrue)
+}
+
+func (s *IntegritySuite) TestTruncatedSection(c *gc.C) {
+	source := s.serializeSource(c, selfTestModel())
+	delete(source, "secrets")
+	_, err := s.deserializeSource(c, source)
+	c.Assert(err, gc.ErrorMatches, `integrity: section "secrets" missing not valid`)
+}
+
+func (s *IntegritySuite) TestUnrecordedSection(c *gc.C) {
+	source := s.serializeSource(c, selfTestModel())
+	source["extra"] = "value"
+	_, err := s.deserializeSource(c, source)
+	c.Assert(err, gc.ErrorMatches, `integrity: section "extra" unrecorded not valid`)
+}
+
+func (s *IntegritySuite) TestUnknownAlgorithm(c *gc.C) {
+	source := s.serializeSource(c, selfTestModel())
+	source[integrityKey].(map[interface{}]interface{})["algorithm"] = "md5"
+	_, err := s.deserializeSource(c, source)
+	c.Assert(err, gc.ErrorMatches, `integrity algorithm "md5" not supported`)
+}
+
+func (s *IntegritySuite) TestChecksum(c *gc.C) {
+	model := selfTestModel()
+	checksum, err := model.Checksum()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checksum, gc.Matches, "[0-9a-f]{64}")
+
+	bytes, err := SerializeWithOptions(model, ExportOptions{Integrity: true})
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	importedChecksum, err := imported.Checksum()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(importedChecksum, gc.Equals, checksum)
+
+	synchronizedChecksum, err := NewSynchronizedModel(model).Checksum()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(synchronizedChecksum, gc.Equals, checksum)
+
+	model.SetPasswordHash("new-hash", PasswordHashSHA512)
+	changed, err := model.Checksum()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changed, gc.Not(gc.Equals), checksum)
+}
+
+func (s *IntegritySuite) TestWithOtherOptions(c *gc.C) {
+	bytes, err := SerializeWithOptions(selfTestModel(), ExportOptions{
+		Integrity:            true,
+		CompactStatusHistory: true,
+		ExcludeDeadOrDying:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+}
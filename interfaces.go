@@ -21,6 +21,8 @@ type AgentTools interface {
 
 // Space represents a network space, which is a named collection of subnets.
 type Space interface {
+	HasAnnotations
+
 	Id() string
 	Name() string
 	Public() bool
@@ -39,6 +41,12 @@ type LinkLayerDevice interface {
 	IsUp() bool
 	ParentName() string
 	VirtualPortType() string
+	// BondMode is the mode of a bond device, such as "802.3ad".
+	BondMode() string
+	// VLANTag is the tag of a VLAN device, or 0 if it isn't one.
+	VLANTag() int
+	// IsSRIOV reports whether the device is an SR-IOV virtual function.
+	IsSRIOV() bool
 }
 
 // IPAddress represents an IP address.
@@ -79,11 +87,21 @@ type CloudImageMetadata interface {
 	Source() string
 	Priority() int
 	ImageId() string
+	// ExpireAt is the time after which the metadata is stale and should
+	// be fetched again from its source, or nil if it doesn't expire.
 	ExpireAt() *time.Time
+	// Signature is the detached signature of the metadata, as published
+	// by its source, so that the metadata can be verified again after it
+	// is migrated. It is empty for unsigned metadata.
+	Signature() string
+	// SourceFingerprint is the fingerprint of the key that the source
+	// signed the metadata with.
+	SourceFingerprint() string
 }
 
 // Volume represents a volume (disk, logical volume, etc.) in the model.
 type Volume interface {
+	HasAnnotations
 	HasStatus
 	HasStatusHistory
 
@@ -100,6 +118,19 @@ type Volume interface {
 	VolumeID() string
 	Persistent() bool
 
+	// Life returns the life of the volume, one of Alive, Dying or Dead.
+	Life() string
+
+	// Encrypted reports whether the volume is encrypted at rest, using
+	// the provider key identified by KMSKeyID if one was specified.
+	Encrypted() bool
+	KMSKeyID() string
+
+	// IOPS and Throughput are the provisioned performance of the volume,
+	// zero when the provider default applies.
+	IOPS() uint64
+	Throughput() uint64
+
 	Attachments() []VolumeAttachment
 	AttachmentPlans() []VolumeAttachmentPlan
 	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
@@ -144,6 +175,10 @@ type Filesystem interface {
 
 	FilesystemID() string
 
+	// Life returns the life of the filesystem, one of Alive, Dying or
+	// Dead.
+	Life() string
+
 	Attachments() []FilesystemAttachment
 	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
 }
@@ -159,6 +194,8 @@ type FilesystemAttachment interface {
 // Storage represents the state of a unit or application-wide storage instance
 // in the model.
 type Storage interface {
+	HasAnnotations
+
 	Tag() names.StorageTag
 	Kind() string
 	// Owner returns the tag of the application or unit that owns this storage
@@ -166,6 +203,10 @@ type Storage interface {
 	Owner() (names.Tag, error)
 	Name() string
 
+	// Life returns the life of the storage instance, one of Alive, Dying
+	// or Dead.
+	Life() string
+
 	Attachments() []names.UnitTag
 
 	// Constraints returns the storage instance constraints, and a boolean
@@ -203,6 +244,8 @@ type StorageInstanceConstraints struct {
 
 // Subnet represents a network subnet.
 type Subnet interface {
+	HasAnnotations
+
 	ID() string
 	ProviderId() string
 	ProviderNetworkId() string
@@ -245,6 +288,7 @@ type CharmMetadata interface {
 	MinJujuVersion() string
 	RunAs() string
 	Assumes() string
+	AssumesExpression() (*AssumesExpression, error)
 	Provides() map[string]CharmMetadataRelation
 	Requires() map[string]CharmMetadataRelation
 	Peers() map[string]CharmMetadataRelation
@@ -8,6 +8,25 @@ import (
 	"github.com/juju/schema"
 )
 
+// The ways an IP address may be configured on its device. An address with
+// no config method was configured in an unknown way.
+const (
+	AddressConfigDHCP     = "dhcp"
+	AddressConfigStatic   = "static"
+	AddressConfigManual   = "manual"
+	AddressConfigLoopback = "loopback"
+)
+
+// validAddressConfigMethod reports whether the method is one of the known
+// address config methods.
+func validAddressConfigMethod(method string) bool {
+	switch method {
+	case "", AddressConfigDHCP, AddressConfigStatic, AddressConfigManual, AddressConfigLoopback:
+		return true
+	}
+	return false
+}
+
 type ipaddresses struct {
 	Version      int          `yaml:"version"`
 	IPAddresses_ []*ipaddress `yaml:"ip-addresses"`
@@ -101,7 +120,7 @@ func (i *ipaddress) IsShadow() bool {
 	return i.IsShadow_
 }
 
-// IsShadow implements IPAddress.
+// IsSecondary implements IPAddress.
 func (i *ipaddress) IsSecondary() bool {
 	return i.IsSecondary_
 }
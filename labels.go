@@ -0,0 +1,50 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// labelsKey is the top level key of the section of a serialized model
+// that holds the labels of the document. The section isn't part of any
+// model version, and is read whatever the version of the model.
+const labelsKey = "labels"
+
+// Labels implements Model.
+func (m *model) Labels() map[string]string {
+	if m.Labels_ == nil {
+		return nil
+	}
+	result := make(map[string]string, len(m.Labels_))
+	for key, value := range m.Labels_ {
+		result[key] = value
+	}
+	return result
+}
+
+func (m *model) setLabels(labels map[string]string) {
+	m.Labels_ = nil
+	if len(labels) == 0 {
+		return
+	}
+	m.Labels_ = make(map[string]string, len(labels))
+	for key, value := range labels {
+		m.Labels_[key] = value
+	}
+}
+
+// importLabels returns the labels of the model source, if it has any.
+func importLabels(source map[string]interface{}) (map[string]string, error) {
+	value, ok := source[labelsKey]
+	if !ok {
+		return nil, nil
+	}
+	coerced, err := schema.StringMap(schema.String()).Coerce(value, []string{labelsKey})
+	if err != nil {
+		return nil, errors.Annotatef(err, "labels schema check failed")
+	}
+	return convertToStringMap(coerced), nil
+}
@@ -0,0 +1,792 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// LazyDeserialize constructs a Model from a serialized YAML byte stream as
+// Deserialize does, except that the sections holding the model's entities,
+// such as its machines and applications, are imported the first time they
+// are read through the Model rather than up front. Tools that inspect only
+// part of a large model then pay for importing only that part.
+//
+// Reading the entities of a section imports that section alone. Anything
+// that needs the whole model, such as validating, serializing, copying or
+// freezing it, or adding to or changing its entities, imports every section
+// still pending first. The entities imported are kept, so a section is only
+// imported once and the entities read are those the whole model holds.
+//
+// The accessors can't return an error, so a section that can't be imported
+// is left empty; the error is returned by Validate, ValidateAll,
+// ValidateWith, Checksum and Summary, and when the model is serialized.
+func LazyDeserialize(bytes []byte, options ...ImportOption) (Model, error) {
+	var source map[string]interface{}
+	err := yaml.Unmarshal(bytes, &source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	pending := &lazySections{}
+	options = append([]ImportOption{deferSections(pending)}, options...)
+	model, err := importModel(source, options...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pending.options = newImportOptions(options)
+	pending.imported = make(map[string]bool)
+	return &lazyModel{model: model, pending: pending}, nil
+}
+
+// lazySections holds what a lazy model needs to import the sections it
+// has yet to import.
+type lazySections struct {
+	valid    map[string]interface{}
+	version  int
+	options  importOptions
+	imported map[string]bool
+
+	// source is the whole of the source of the model, kept when the
+	// fields it has that aren't known are to be preserved.
+	source map[string]interface{}
+}
+
+// deferSections has the import leave the sections holding the model's
+// entities for a lazy model to import, recording what it needs in pending.
+func deferSections(pending *lazySections) ImportOption {
+	return func(o *importOptions) {
+		o.lazy = pending
+	}
+}
+
+type lazyModel struct {
+	mu      sync.Mutex
+	model   *model
+	pending *lazySections
+	err     error
+}
+
+// load imports the named sections, if they haven't been imported.
+func (l *lazyModel) load(sections ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending == nil {
+		return
+	}
+	timer := l.pending.options.newSectionTimer(l.pending.valid)
+	for _, section := range entitySections {
+		for _, name := range sections {
+			if section.name == name && l.importSection(section) {
+				timer.mark(name)
+			}
+		}
+	}
+}
+
+// loadAll imports every section that hasn't been imported, and returns
+// the error from the first section that couldn't be.
+func (l *lazyModel) loadAll() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending == nil {
+		return l.err
+	}
+	timer := l.pending.options.newSectionTimer(l.pending.valid)
+	for _, section := range entitySections {
+		if l.importSection(section) {
+			timer.mark(section.name)
+		}
+	}
+	if l.pending.source != nil {
+		keepUnknownFields(l.model, l.pending.source)
+	}
+	// Nothing is left to import, so the source can go.
+	l.pending = nil
+	return l.err
+}
+
+// importSection imports the section unless it has been imported already,
+// and reports whether it did.
+func (l *lazyModel) importSection(section modelSection) bool {
+	if l.pending.imported[section.name] {
+		return false
+	}
+	l.pending.imported[section.name] = true
+	err := section.importInto(l.model, l.pending.valid, l.pending.version, l.pending.options)
+	l.model.canonicalize()
+	if err != nil && l.err == nil {
+		l.err = errors.Trace(err)
+	}
+	return true
+}
+
+// MarshalYAML implements yaml.Marshaler, writing out the whole model.
+func (l *lazyModel) MarshalYAML() (interface{}, error) {
+	if err := l.loadAll(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return l.model, nil
+}
+
+// Config implements Model.
+func (l *lazyModel) Config() map[string]interface{} {
+	return l.model.Config()
+}
+
+// Annotations implements Model.
+func (l *lazyModel) Annotations() map[string]string {
+	return l.model.Annotations()
+}
+
+// SetAnnotations implements Model.
+func (l *lazyModel) SetAnnotations(annotations map[string]string) {
+	l.model.SetAnnotations(annotations)
+}
+
+// Constraints implements Model.
+func (l *lazyModel) Constraints() Constraints {
+	return l.model.Constraints()
+}
+
+// SetConstraints implements Model.
+func (l *lazyModel) SetConstraints(args ConstraintsArgs) {
+	l.model.SetConstraints(args)
+}
+
+// Status implements Model.
+func (l *lazyModel) Status() Status {
+	return l.model.Status()
+}
+
+// SetStatus implements Model.
+func (l *lazyModel) SetStatus(args StatusArgs) {
+	l.model.SetStatus(args)
+}
+
+// StatusHistory implements Model.
+func (l *lazyModel) StatusHistory() []Status {
+	return l.model.StatusHistory()
+}
+
+// SetStatusHistory implements Model.
+func (l *lazyModel) SetStatusHistory(args []StatusArgs) {
+	l.model.SetStatusHistory(args)
+}
+
+// AppendStatusHistory implements Model.
+func (l *lazyModel) AppendStatusHistory(args ...StatusArgs) {
+	l.model.AppendStatusHistory(args...)
+}
+
+// AgentVersion implements Model.
+func (l *lazyModel) AgentVersion() string {
+	return l.model.AgentVersion()
+}
+
+// Type implements Model.
+func (l *lazyModel) Type() string {
+	return l.model.Type()
+}
+
+// Cloud implements Model.
+func (l *lazyModel) Cloud() string {
+	return l.model.Cloud()
+}
+
+// Description implements Model.
+func (l *lazyModel) Description() string {
+	return l.model.Description()
+}
+
+// CloudRegion implements Model.
+func (l *lazyModel) CloudRegion() string {
+	return l.model.CloudRegion()
+}
+
+// CloudCredential implements Model.
+func (l *lazyModel) CloudCredential() CloudCredential {
+	return l.model.CloudCredential()
+}
+
+// SetCloudCredential implements Model.
+func (l *lazyModel) SetCloudCredential(args CloudCredentialArgs) {
+	l.model.SetCloudCredential(args)
+}
+
+// Tag implements Model.
+func (l *lazyModel) Tag() names.ModelTag {
+	return l.model.Tag()
+}
+
+// Owner implements Model.
+func (l *lazyModel) Owner() names.UserTag {
+	return l.model.Owner()
+}
+
+// LatestToolsVersion implements Model.
+func (l *lazyModel) LatestToolsVersion() version.Number {
+	return l.model.LatestToolsVersion()
+}
+
+// EnvironVersion implements Model.
+func (l *lazyModel) EnvironVersion() int {
+	return l.model.EnvironVersion()
+}
+
+// UpdateConfig implements Model.
+func (l *lazyModel) UpdateConfig(config map[string]interface{}) {
+	l.model.UpdateConfig(config)
+}
+
+// Blocks implements Model.
+func (l *lazyModel) Blocks() map[string]string {
+	return l.model.Blocks()
+}
+
+// Users implements Model.
+func (l *lazyModel) Users() []User {
+	l.load("users")
+	return l.model.Users()
+}
+
+// AddUser implements Model.
+func (l *lazyModel) AddUser(args UserArgs) {
+	l.loadAll()
+	l.model.AddUser(args)
+}
+
+// Machines implements Model.
+func (l *lazyModel) Machines() []Machine {
+	l.load("machines")
+	return l.model.Machines()
+}
+
+// AddMachine implements Model.
+func (l *lazyModel) AddMachine(args MachineArgs) Machine {
+	l.loadAll()
+	return l.model.AddMachine(args)
+}
+
+// Applications implements Model.
+func (l *lazyModel) Applications() []Application {
+	l.load("applications")
+	return l.model.Applications()
+}
+
+// ApplicationNames implements Model.
+func (l *lazyModel) ApplicationNames() []string {
+	l.load("applications")
+	return l.model.ApplicationNames()
+}
+
+// UnitNames implements Model.
+func (l *lazyModel) UnitNames() []string {
+	l.load("applications")
+	return l.model.UnitNames()
+}
+
+// AddApplication implements Model.
+func (l *lazyModel) AddApplication(args ApplicationArgs) Application {
+	l.loadAll()
+	return l.model.AddApplication(args)
+}
+
+// Relations implements Model.
+func (l *lazyModel) Relations() []Relation {
+	l.load("relations")
+	return l.model.Relations()
+}
+
+// AddRelation implements Model.
+func (l *lazyModel) AddRelation(args RelationArgs) Relation {
+	l.loadAll()
+	return l.model.AddRelation(args)
+}
+
+// RemoteEntities implements Model.
+func (l *lazyModel) RemoteEntities() []RemoteEntity {
+	l.load("remote-entities")
+	return l.model.RemoteEntities()
+}
+
+// AddRemoteEntity implements Model.
+func (l *lazyModel) AddRemoteEntity(args RemoteEntityArgs) RemoteEntity {
+	l.loadAll()
+	return l.model.AddRemoteEntity(args)
+}
+
+// RelationNetworks implements Model.
+func (l *lazyModel) RelationNetworks() []RelationNetwork {
+	l.load("relation-networks")
+	return l.model.RelationNetworks()
+}
+
+// RelationIngressNetworks implements Model.
+func (l *lazyModel) RelationIngressNetworks() []RelationNetwork {
+	l.load("relation-networks")
+	return l.model.RelationIngressNetworks()
+}
+
+// RelationEgressNetworks implements Model.
+func (l *lazyModel) RelationEgressNetworks() []RelationNetwork {
+	l.load("relation-networks")
+	return l.model.RelationEgressNetworks()
+}
+
+// AddRelationNetwork implements Model.
+func (l *lazyModel) AddRelationNetwork(args RelationNetworkArgs) RelationNetwork {
+	l.loadAll()
+	return l.model.AddRelationNetwork(args)
+}
+
+// Spaces implements Model.
+func (l *lazyModel) Spaces() []Space {
+	l.load("spaces")
+	return l.model.Spaces()
+}
+
+// AddSpace implements Model.
+func (l *lazyModel) AddSpace(args SpaceArgs) Space {
+	l.loadAll()
+	return l.model.AddSpace(args)
+}
+
+// LinkLayerDevices implements Model.
+func (l *lazyModel) LinkLayerDevices() []LinkLayerDevice {
+	l.load("link-layer-devices")
+	return l.model.LinkLayerDevices()
+}
+
+// AddLinkLayerDevice implements Model.
+func (l *lazyModel) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	l.loadAll()
+	return l.model.AddLinkLayerDevice(args)
+}
+
+// Subnets implements Model.
+func (l *lazyModel) Subnets() []Subnet {
+	l.load("subnets")
+	return l.model.Subnets()
+}
+
+// SubnetsInSpace implements Model.
+func (l *lazyModel) SubnetsInSpace(spaceID string) []Subnet {
+	l.load("subnets")
+	return l.model.SubnetsInSpace(spaceID)
+}
+
+// AddSubnet implements Model.
+func (l *lazyModel) AddSubnet(args SubnetArgs) Subnet {
+	l.loadAll()
+	return l.model.AddSubnet(args)
+}
+
+// IPAddresses implements Model.
+func (l *lazyModel) IPAddresses() []IPAddress {
+	l.load("ip-addresses")
+	return l.model.IPAddresses()
+}
+
+// AddIPAddress implements Model.
+func (l *lazyModel) AddIPAddress(args IPAddressArgs) IPAddress {
+	l.loadAll()
+	return l.model.AddIPAddress(args)
+}
+
+// SSHHostKeys implements Model.
+func (l *lazyModel) SSHHostKeys() []SSHHostKey {
+	l.load("ssh-host-keys")
+	return l.model.SSHHostKeys()
+}
+
+// AddSSHHostKey implements Model.
+func (l *lazyModel) AddSSHHostKey(args SSHHostKeyArgs) SSHHostKey {
+	l.loadAll()
+	return l.model.AddSSHHostKey(args)
+}
+
+// VirtualHostKeys implements Model.
+func (l *lazyModel) VirtualHostKeys() []VirtualHostKey {
+	l.load("virtual-host-keys")
+	return l.model.VirtualHostKeys()
+}
+
+// AddVirtualHostKey implements Model.
+func (l *lazyModel) AddVirtualHostKey(args VirtualHostKeyArgs) VirtualHostKey {
+	l.loadAll()
+	return l.model.AddVirtualHostKey(args)
+}
+
+// CloudImageMetadata implements Model.
+func (l *lazyModel) CloudImageMetadata() []CloudImageMetadata {
+	l.load("cloud-image-metadata")
+	return l.model.CloudImageMetadata()
+}
+
+// AddCloudImageMetadata implements Model.
+func (l *lazyModel) AddCloudImageMetadata(args CloudImageMetadataArgs) CloudImageMetadata {
+	l.loadAll()
+	return l.model.AddCloudImageMetadata(args)
+}
+
+// Actions implements Model.
+func (l *lazyModel) Actions() []Action {
+	l.load("actions", "operations")
+	return l.model.Actions()
+}
+
+// AddAction implements Model.
+func (l *lazyModel) AddAction(args ActionArgs) Action {
+	l.loadAll()
+	return l.model.AddAction(args)
+}
+
+// Operations implements Model.
+func (l *lazyModel) Operations() []Operation {
+	l.load("operations", "actions")
+	return l.model.Operations()
+}
+
+// AddOperation implements Model.
+func (l *lazyModel) AddOperation(args OperationArgs) Operation {
+	l.loadAll()
+	return l.model.AddOperation(args)
+}
+
+// Sequences implements Model. The sequences are copied, as SetSequence
+func (l *lazyModel) Sequences() map[string]int {
+	return cloned(l.model.Sequences())
+}
+
+// SetSequence implements Model.
+func (l *lazyModel) SetSequence(name string, value int) {
+	l.model.SetSequence(name, value)
+}
+
+// Volumes implements Model.
+func (l *lazyModel) Volumes() []Volume {
+	l.load("volumes")
+	return l.model.Volumes()
+}
+
+// AddVolume implements Model.
+func (l *lazyModel) AddVolume(args VolumeArgs) Volume {
+	l.loadAll()
+	return l.model.AddVolume(args)
+}
+
+// Charms implements Model.
+func (l *lazyModel) Charms() []Charm {
+	l.load("charms")
+	return l.model.Charms()
+}
+
+// AddCharm implements Model.
+func (l *lazyModel) AddCharm(args CharmArgs) Charm {
+	l.loadAll()
+	return l.model.AddCharm(args)
+}
+
+// FirewallRules implements Model.
+func (l *lazyModel) FirewallRules() []FirewallRule {
+	l.load("firewall-rules")
+	return l.model.FirewallRules()
+}
+
+// AddFirewallRule implements Model.
+func (l *lazyModel) AddFirewallRule(args FirewallRuleArgs) FirewallRule {
+	l.loadAll()
+	return l.model.AddFirewallRule(args)
+}
+
+// Filesystems implements Model.
+func (l *lazyModel) Filesystems() []Filesystem {
+	l.load("filesystems")
+	return l.model.Filesystems()
+}
+
+// AddFilesystem implements Model.
+func (l *lazyModel) AddFilesystem(args FilesystemArgs) Filesystem {
+	l.loadAll()
+	return l.model.AddFilesystem(args)
+}
+
+// Storages implements Model.
+func (l *lazyModel) Storages() []Storage {
+	l.load("storages")
+	return l.model.Storages()
+}
+
+// AddStorage implements Model.
+func (l *lazyModel) AddStorage(args StorageArgs) Storage {
+	l.loadAll()
+	return l.model.AddStorage(args)
+}
+
+// StoragePools implements Model.
+func (l *lazyModel) StoragePools() []StoragePool {
+	l.load("storage-pools")
+	return l.model.StoragePools()
+}
+
+// AddStoragePool implements Model.
+func (l *lazyModel) AddStoragePool(args StoragePoolArgs) StoragePool {
+	l.loadAll()
+	return l.model.AddStoragePool(args)
+}
+
+// SecretBackendID implements Model.
+func (l *lazyModel) SecretBackendID() string {
+	return l.model.SecretBackendID()
+}
+
+// SecretBackends implements Model.
+func (l *lazyModel) SecretBackends() []SecretBackend {
+	l.load("secret-backends")
+	return l.model.SecretBackends()
+}
+
+// AddSecretBackend implements Model.
+func (l *lazyModel) AddSecretBackend(args SecretBackendArgs) SecretBackend {
+	l.loadAll()
+	return l.model.AddSecretBackend(args)
+}
+
+// Secrets implements Model.
+func (l *lazyModel) Secrets() []Secret {
+	l.load("secrets")
+	return l.model.Secrets()
+}
+
+// AddSecret implements Model.
+func (l *lazyModel) AddSecret(args SecretArgs) Secret {
+	l.loadAll()
+	return l.model.AddSecret(args)
+}
+
+// RemoteSecrets implements Model.
+func (l *lazyModel) RemoteSecrets() []RemoteSecret {
+	l.load("remote-secrets")
+	return l.model.RemoteSecrets()
+}
+
+// AddRemoteSecret implements Model.
+func (l *lazyModel) AddRemoteSecret(args RemoteSecretArgs) RemoteSecret {
+	l.loadAll()
+	return l.model.AddRemoteSecret(args)
+}
+
+// RemoteApplications implements Model.
+func (l *lazyModel) RemoteApplications() []RemoteApplication {
+	l.load("remote-applications")
+	return l.model.RemoteApplications()
+}
+
+// AddRemoteApplication implements Model.
+func (l *lazyModel) AddRemoteApplication(args RemoteApplicationArgs) RemoteApplication {
+	l.loadAll()
+	return l.model.AddRemoteApplication(args)
+}
+
+// OfferConnections implements Model.
+func (l *lazyModel) OfferConnections() []OfferConnection {
+	l.load("offer-connections")
+	return l.model.OfferConnections()
+}
+
+// AddOfferConnection implements Model.
+func (l *lazyModel) AddOfferConnection(args OfferConnectionArgs) OfferConnection {
+	l.loadAll()
+	return l.model.AddOfferConnection(args)
+}
+
+// ExternalControllers implements Model.
+func (l *lazyModel) ExternalControllers() []ExternalController {
+	l.load("external-controllers")
+	return l.model.ExternalControllers()
+}
+
+// AddExternalController implements Model.
+func (l *lazyModel) AddExternalController(args ExternalControllerArgs) ExternalController {
+	l.loadAll()
+	return l.model.AddExternalController(args)
+}
+
+// Validate implements Model.
+func (l *lazyModel) Validate() error {
+	if err := l.loadAll(); err != nil {
+		return errors.Trace(err)
+	}
+	return l.model.Validate()
+}
+
+// ValidateAll implements Model.
+func (l *lazyModel) ValidateAll() error {
+	if err := l.loadAll(); err != nil {
+		return errors.Trace(err)
+	}
+	return l.model.ValidateAll()
+}
+
+// ValidateWith implements Model.
+func (l *lazyModel) ValidateWith(rules ...ValidationRule) error {
+	if err := l.loadAll(); err != nil {
+		return errors.Trace(err)
+	}
+	return l.model.ValidateWith(rules...)
+}
+
+// DanglingPrincipals implements Model.
+func (l *lazyModel) DanglingPrincipals() []string {
+	l.loadAll()
+	return l.model.DanglingPrincipals()
+}
+
+// AnnotationsForEntity implements Model.
+func (l *lazyModel) AnnotationsForEntity(tag names.Tag) map[string]string {
+	l.loadAll()
+	return l.model.AnnotationsForEntity(tag)
+}
+
+// SetAnnotationsForEntity implements Model.
+func (l *lazyModel) SetAnnotationsForEntity(tag names.Tag, annotations map[string]string) error {
+	l.loadAll()
+	return l.model.SetAnnotationsForEntity(tag, annotations)
+}
+
+// AnnotationsIndex implements Model.
+func (l *lazyModel) AnnotationsIndex() map[string]map[string]string {
+	l.loadAll()
+	return l.model.AnnotationsIndex()
+}
+
+// Checksum implements Model.
+func (l *lazyModel) Checksum() (string, error) {
+	if err := l.loadAll(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return l.model.Checksum()
+}
+
+// Summary implements Model.
+func (l *lazyModel) Summary(w io.Writer) error {
+	if err := l.loadAll(); err != nil {
+		return errors.Trace(err)
+	}
+	return l.model.Summary(w)
+}
+
+// NormalizeRelationNetworks implements Model.
+func (l *lazyModel) NormalizeRelationNetworks() []string {
+	l.loadAll()
+	return l.model.NormalizeRelationNetworks()
+}
+
+// CleanDanglingPrincipals implements Model.
+func (l *lazyModel) CleanDanglingPrincipals() []string {
+	l.loadAll()
+	return l.model.CleanDanglingPrincipals()
+}
+
+// RemoveDanglingRelations implements Model.
+func (l *lazyModel) RemoveDanglingRelations() []string {
+	l.loadAll()
+	return l.model.RemoveDanglingRelations()
+}
+
+// SetSLA implements Model.
+func (l *lazyModel) SetSLA(level, owner, credentials string) SLA {
+	return l.model.SetSLA(level, owner, credentials)
+}
+
+// SLA implements Model.
+func (l *lazyModel) SLA() SLA {
+	return l.model.SLA()
+}
+
+// SetMeterStatus implements Model.
+func (l *lazyModel) SetMeterStatus(code, info string) MeterStatus {
+	return l.model.SetMeterStatus(code, info)
+}
+
+// MeterStatus implements Model.
+func (l *lazyModel) MeterStatus() MeterStatus {
+	return l.model.MeterStatus()
+}
+
+// SetTelemetry implements Model.
+func (l *lazyModel) SetTelemetry(args TelemetryArgs) Telemetry {
+	return l.model.SetTelemetry(args)
+}
+
+// Telemetry implements Model.
+func (l *lazyModel) Telemetry() Telemetry {
+	return l.model.Telemetry()
+}
+
+// SetMigrationAttempt implements Model.
+func (l *lazyModel) SetMigrationAttempt(args MigrationAttemptArgs) MigrationAttempt {
+	return l.model.SetMigrationAttempt(args)
+}
+
+// MigrationAttempt implements Model.
+func (l *lazyModel) MigrationAttempt() MigrationAttempt {
+	return l.model.MigrationAttempt()
+}
+
+// SetLease implements Model.
+func (l *lazyModel) SetLease(args LeaseArgs) Lease {
+	return l.model.SetLease(args)
+}
+
+// Lease implements Model.
+func (l *lazyModel) Lease() Lease {
+	return l.model.Lease()
+}
+
+// PasswordHash implements Model.
+func (l *lazyModel) PasswordHash() string {
+	return l.model.PasswordHash()
+}
+
+// PasswordHashAlgorithm implements Model.
+func (l *lazyModel) PasswordHashAlgorithm() string {
+	return l.model.PasswordHashAlgorithm()
+}
+
+// SetPasswordHash implements Model.
+func (l *lazyModel) SetPasswordHash(hash, algorithm string) {
+	l.model.SetPasswordHash(hash, algorithm)
+}
+
+// AddBlockDevice implements Model.
+func (l *lazyModel) AddBlockDevice(machineId string, args BlockDeviceArgs) error {
+	l.loadAll()
+	return l.model.AddBlockDevice(machineId, args)
+}
+
+// Freeze implements Model.
+func (l *lazyModel) Freeze() ModelReader {
+	l.loadAll()
+	return l.model.Freeze()
+}
+
+// Labels implements Model.
+func (l *lazyModel) Labels() map[string]string {
+	return l.model.Labels()
+}
+
+// Copy implements Model. The copy is of the whole model, with every
+// section imported.
+func (l *lazyModel) Copy() Model {
+	l.loadAll()
+	return l.model.Copy()
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type LazyModelSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LazyModelSuite{})
+
+func (s *LazyModelSuite) serialized(c *gc.C) []byte {
+	bytes, err := Serialize(fixtureModel())
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *LazyModelSuite) TestMatchesDeserialize(c *gc.C) {
+	bytes := s.serialized(c)
+	lazy, err := LazyDeserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lazy.Validate(), jc.ErrorIsNil)
+
+	eager, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := Serialize(eager)
+	c.Assert(err, jc.ErrorIsNil)
+	reserialized, err := Serialize(lazy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reserialized), gc.Equals, string(expected))
+}
+
+func (s *LazyModelSuite) TestImportsSectionsOnFirstUse(c *gc.C) {
+	lazy, err := LazyDeserialize(s.serialized(c))
+	c.Assert(err, jc.ErrorIsNil)
+	pending := lazy.(*lazyModel).pending
+	c.Assert(pending.imported, gc.HasLen, 0)
+	c.Check(lazy.Owner().Id(), gc.Equals, "admin")
+	c.Assert(pending.imported, gc.HasLen, 0)
+
+	applications := lazy.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Check(pending.imported, jc.DeepEquals, map[string]bool{"applications": true})
+	c.Check(lazy.Applications()[0], gc.Equals, applications[0])
+
+	// Importing the rest of the model keeps the entities already read.
+	c.Assert(lazy.Validate(), jc.ErrorIsNil)
+	c.Check(lazy.(*lazyModel).pending, gc.IsNil)
+	c.Check(lazy.Applications()[0], gc.Equals, applications[0])
+	c.Check(lazy.Machines(), gc.HasLen, 1)
+}
+
+func (s *LazyModelSuite) TestSectionErrorReturnedByValidate(c *gc.C) {
+	var data map[string]interface{}
+	err := yaml.Unmarshal(s.serialized(c), &data)
+	c.Assert(err, jc.ErrorIsNil)
+	machines := data["machines"].(map[interface{}]interface{})["machines"].([]interface{})
+	machines[0].(map[interface{}]interface{})["id"] = []interface{}{0}
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	lazy, err := LazyDeserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(lazy.Applications(), gc.HasLen, 1)
+	c.Check(lazy.Machines(), gc.HasLen, 0)
+
+	err = lazy.Validate()
+	c.Assert(err, gc.ErrorMatches, `machines: machine 0: machine v\d+ schema check failed: id: .*`)
+	_, err = Serialize(lazy)
+	c.Assert(err, gc.ErrorMatches, `machines: .*`)
+}
+
+func (s *LazyModelSuite) TestModelErrorReturnedUpFront(c *gc.C) {
+	_, err := LazyDeserialize([]byte("version: 42\n"))
+	c.Assert(err, gc.ErrorMatches, "version 42 not valid")
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// Lease describes a lease held when the model was exported, so that the
+// target controller can claim it again for the same holder rather than
+// hold a new election. An application holds the lease of its leadership,
+// and the model its singular lease, which the controller managing the
+// model holds.
+type Lease interface {
+	// Name is the name of the lease: the application name for the
+	// leadership of an application, and the model UUID for the singular
+	// lease of a model.
+	Name() string
+	// Holder is the unit or controller holding the lease.
+	Holder() string
+	// Start and Expiry are when the lease was claimed, and when it
+	// expires unless it is extended.
+	Start() time.Time
+	Expiry() time.Time
+	// Pinned reports whether the lease is pinned, so that it doesn't
+	// expire, as it is while the holder is being upgraded.
+	Pinned() bool
+}
+
+// LeaseArgs is an argument struct used to set the lease of an Application
+// or the Model.
+type LeaseArgs struct {
+	Name   string
+	Holder string
+	Start  time.Time
+	Expiry time.Time
+	Pinned bool
+}
+
+type lease struct {
+	Name_   string    `yaml:"name"`
+	Holder_ string    `yaml:"holder"`
+	Start_  time.Time `yaml:"start"`
+	Expiry_ time.Time `yaml:"expiry"`
+	Pinned_ bool      `yaml:"pinned,omitempty"`
+}
+
+func newLease(args LeaseArgs) *lease {
+	return &lease{
+		Name_:   args.Name,
+		Holder_: args.Holder,
+		Start_:  args.Start.UTC(),
+		Expiry_: args.Expiry.UTC(),
+		Pinned_: args.Pinned,
+	}
+}
+
+// Name implements Lease.
+func (l *lease) Name() string {
+	return l.Name_
+}
+
+// Holder implements Lease.
+func (l *lease) Holder() string {
+	return l.Holder_
+}
+
+// Start implements Lease.
+func (l *lease) Start() time.Time {
+	return l.Start_
+}
+
+// Expiry implements Lease.
+func (l *lease) Expiry() time.Time {
+	return l.Expiry_
+}
+
+// Pinned implements Lease.
+func (l *lease) Pinned() bool {
+	return l.Pinned_
+}
+
+// validate checks that the lease is named and held, and doesn't expire
+// before it starts.
+func (l *lease) validate() error {
+	if l.Name_ == "" {
+		return errors.NotValidf("lease missing name")
+	}
+	if l.Holder_ == "" {
+		return errors.NotValidf("lease %q missing holder", l.Name_)
+	}
+	if l.Expiry_.Before(l.Start_) {
+		return errors.NotValidf("lease %q expiry before start", l.Name_)
+	}
+	return nil
+}
+
+func leaseFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"name":   schema.String(),
+		"holder": schema.String(),
+		"start":  schema.Time(),
+		"expiry": schema.Time(),
+		"pinned": schema.Bool(),
+	}
+	defaults := schema.Defaults{
+		"pinned": false,
+	}
+	return fields, defaults
+}
+
+func importLease(source map[string]interface{}) *lease {
+	return &lease{
+		Name_:   source["name"].(string),
+		Holder_: source["holder"].(string),
+		Start_:  source["start"].(time.Time),
+		Expiry_: source["expiry"].(time.Time),
+		Pinned_: source["pinned"].(bool),
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+)
+
+// The life values an entity may have. An entity that was serialized without
+// a life, such as one from before life was recorded, is alive.
+const (
+	Alive = "alive"
+	Dying = "dying"
+	Dead  = "dead"
+)
+
+// lifeOrAlive returns the life to report for a stored life value.
+func lifeOrAlive(life string) string {
+	if life == "" {
+		return Alive
+	}
+	return life
+}
+
+// storedLife returns the stored life value for a reported life, the
+// reverse of lifeOrAlive, so that an entity copied through its interface
+// is written out as the original was.
+func storedLife(life string) string {
+	if life == Alive {
+		return ""
+	}
+	return life
+}
+
+// validateLife checks that the stored life of the named entity is one of
+// the known values.
+func validateLife(kind, id, life string) error {
+	switch life {
+	case "", Alive, Dying, Dead:
+		return nil
+	}
+	return errors.NotValidf("%s %q life %q", kind, id, life)
+}
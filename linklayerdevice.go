@@ -24,6 +24,9 @@ type linklayerdevice struct {
 	IsUp_            bool   `yaml:"is-up"`
 	ParentName_      string `yaml:"parent-name"`
 	VirtualPortType_ string `yaml:"virtual-port-type,omitempty"`
+	BondMode_        string `yaml:"bond-mode,omitempty"`
+	VLANTag_         int    `yaml:"vlan-tag,omitempty"`
+	IsSRIOV_         bool   `yaml:"is-sriov,omitempty"`
 }
 
 // ProviderID implements LinkLayerDevice.
@@ -76,6 +79,21 @@ func (i *linklayerdevice) VirtualPortType() string {
 	return i.VirtualPortType_
 }
 
+// BondMode implements LinkLayerDevice.
+func (i *linklayerdevice) BondMode() string {
+	return i.BondMode_
+}
+
+// VLANTag implements LinkLayerDevice.
+func (i *linklayerdevice) VLANTag() int {
+	return i.VLANTag_
+}
+
+// IsSRIOV implements LinkLayerDevice.
+func (i *linklayerdevice) IsSRIOV() bool {
+	return i.IsSRIOV_
+}
+
 // LinkLayerDeviceArgs is an argument struct used to create a
 // new internal linklayerdevice type that supports the LinkLayerDevice interface.
 type LinkLayerDeviceArgs struct {
@@ -89,6 +107,9 @@ type LinkLayerDeviceArgs struct {
 	IsUp            bool
 	ParentName      string
 	VirtualPortType string
+	BondMode        string
+	VLANTag         int
+	IsSRIOV         bool
 }
 
 func newLinkLayerDevice(args LinkLayerDeviceArgs) *linklayerdevice {
@@ -103,6 +124,9 @@ func newLinkLayerDevice(args LinkLayerDeviceArgs) *linklayerdevice {
 		IsUp_:            args.IsUp,
 		ParentName_:      args.ParentName,
 		VirtualPortType_: args.VirtualPortType,
+		BondMode_:        args.BondMode,
+		VLANTag_:         args.VLANTag,
+		IsSRIOV_:         args.IsSRIOV,
 	}
 }
 
@@ -144,6 +168,7 @@ type linklayerdeviceDeserializationFunc func(map[string]interface{}) (*linklayer
 var linklayerdeviceDeserializationFuncs = map[int]linklayerdeviceDeserializationFunc{
 	1: importLinkLayerDeviceV1,
 	2: importLinkLayerDeviceV2,
+	3: importLinkLayerDeviceV3,
 }
 
 func importLinkLayerDeviceV1(source map[string]interface{}) (*linklayerdevice, error) {
@@ -166,6 +191,16 @@ func importLinkLayerDeviceV2(source map[string]interface{}) (*linklayerdevice, e
 	return linkLayerDeviceV2(coerced.(map[string]interface{})), nil
 }
 
+func importLinkLayerDeviceV3(source map[string]interface{}) (*linklayerdevice, error) {
+	fields, defaults := linkLayerDeviceV3Schema()
+	checker := schema.FieldMap(fields, defaults)
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "linklayerdevice v3 schema check failed")
+	}
+	return linkLayerDeviceV3(coerced.(map[string]interface{})), nil
+}
+
 func linkLayerDeviceV1(valid map[string]interface{}) *linklayerdevice {
 	return &linklayerdevice{
 		ProviderID_:  valid["provider-id"].(string),
@@ -186,6 +221,14 @@ func linkLayerDeviceV2(valid map[string]interface{}) *linklayerdevice {
 	return lld
 }
 
+func linkLayerDeviceV3(valid map[string]interface{}) *linklayerdevice {
+	lld := linkLayerDeviceV2(valid)
+	lld.BondMode_ = valid["bond-mode"].(string)
+	lld.VLANTag_ = int(valid["vlan-tag"].(int64))
+	lld.IsSRIOV_ = valid["is-sriov"].(bool)
+	return lld
+}
+
 func linkLayerDeviceV1Schema() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"provider-id":  schema.String(),
@@ -214,3 +257,16 @@ func linkLayerDeviceV2Schema() (schema.Fields, schema.Defaults) {
 
 	return fields, defaults
 }
+
+func linkLayerDeviceV3Schema() (schema.Fields, schema.Defaults) {
+	fields, defaults := linkLayerDeviceV2Schema()
+
+	fields["bond-mode"] = schema.String()
+	fields["vlan-tag"] = schema.Int()
+	fields["is-sriov"] = schema.Bool()
+	defaults["bond-mode"] = ""
+	defaults["vlan-tag"] = int64(0)
+	defaults["is-sriov"] = false
+
+	return fields, defaults
+}
@@ -39,6 +39,9 @@ func (s *LinkLayerDeviceSerializationSuite) TestNewLinkLayerDevice(c *gc.C) {
 		IsUp:            true,
 		ParentName:      "bam",
 		VirtualPortType: "ovs",
+		BondMode:        "802.3ad",
+		VLANTag:         100,
+		IsSRIOV:         true,
 	}
 	device := newLinkLayerDevice(args)
 	c.Assert(device.ProviderID(), gc.Equals, args.ProviderID)
@@ -51,6 +54,9 @@ func (s *LinkLayerDeviceSerializationSuite) TestNewLinkLayerDevice(c *gc.C) {
 	c.Assert(device.IsUp(), gc.Equals, args.IsUp)
 	c.Assert(device.ParentName(), gc.Equals, args.ParentName)
 	c.Assert(device.VirtualPortType(), gc.Equals, args.VirtualPortType)
+	c.Assert(device.BondMode(), gc.Equals, args.BondMode)
+	c.Assert(device.VLANTag(), gc.Equals, args.VLANTag)
+	c.Assert(device.IsSRIOV(), gc.Equals, args.IsSRIOV)
 }
 
 func (s *LinkLayerDeviceSerializationSuite) TestParsingSerializedDataV1(c *gc.C) {
@@ -118,3 +124,42 @@ func (s *LinkLayerDeviceSerializationSuite) TestParsingSerializedDataV2(c *gc.C)
 
 	c.Assert(devices, jc.DeepEquals, initial.LinkLayerDevices_)
 }
+
+func (s *LinkLayerDeviceSerializationSuite) TestParsingSerializedDataV3(c *gc.C) {
+	initial := linklayerdevices{
+		Version: 3,
+		LinkLayerDevices_: []*linklayerdevice{
+			newLinkLayerDevice(LinkLayerDeviceArgs{
+				MachineID:   "bar",
+				Name:        "bond0",
+				MTU:         9000,
+				Type:        "bond",
+				MACAddress:  "DEADBEEF",
+				IsAutoStart: true,
+				IsUp:        true,
+				// V3 adds the BondMode, VLANTag and IsSRIOV fields
+				BondMode: "802.3ad",
+			}),
+			newLinkLayerDevice(LinkLayerDeviceArgs{
+				MachineID:  "bar",
+				Name:       "bond0.100",
+				Type:       "802.1q",
+				ParentName: "bond0",
+				VLANTag:    100,
+			}),
+			newLinkLayerDevice(LinkLayerDeviceArgs{Name: "ens1f0v0", Type: "ethernet", IsSRIOV: true}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices, err := importLinkLayerDevices(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(devices, jc.DeepEquals, initial.LinkLayerDevices_)
+}
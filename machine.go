@@ -4,16 +4,34 @@
 package description
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
-	"github.com/juju/os/v2/series"
 	"github.com/juju/schema"
 	"github.com/juju/version/v2"
 )
 
+// The container types understood by the description package. Top level
+// machines have a container type of ContainerTypeNone, or are left empty
+// by older exports.
+const (
+	ContainerTypeNone = "none"
+	ContainerTypeLXD  = "lxd"
+	ContainerTypeKVM  = "kvm"
+)
+
+// IsValidContainerType returns true if the container type is one of the
+// known container types, or is empty.
+func IsValidContainerType(containerType string) bool {
+	switch containerType {
+	case "", ContainerTypeNone, ContainerTypeLXD, ContainerTypeKVM:
+		return true
+	}
+	return false
+}
+
 // Machine represents an existing live machine or container running in the
 // model.
 type Machine interface {
@@ -35,7 +53,15 @@ type Machine interface {
 	Instance() CloudInstance
 	SetInstance(CloudInstanceArgs)
 
-	// Life() string -- only transmit alive things?
+	// Life returns the life of the machine, one of Alive, Dying or Dead.
+	Life() string
+
+	// AgentStartTime returns when the machine agent last started, and
+	// HostnameVerifiedAt when the agent last confirmed the hostname of
+	// the machine. Both are the zero time if they aren't known.
+	AgentStartTime() time.Time
+	HostnameVerifiedAt() time.Time
+
 	ProviderAddresses() []Address
 	MachineAddresses() []Address
 	SetAddresses(machine []AddressArgs, provider []AddressArgs)
@@ -47,6 +73,20 @@ type Machine interface {
 	Tools() AgentTools
 	SetTools(AgentToolsArgs)
 
+	// AgentVersion returns the version that the machine agent is to run,
+	// which differs from the version of its tools while the model is
+	// being upgraded. It is empty if it wasn't recorded.
+	AgentVersion() string
+
+	// RebootRequired reports whether the machine agent had flagged the
+	// machine to be rebooted.
+	RebootRequired() bool
+
+	// UpgradeSeriesLock returns the series upgrade in progress on the
+	// machine, or nil if there isn't one.
+	UpgradeSeriesLock() UpgradeSeriesLock
+	SetUpgradeSeriesLock(UpgradeSeriesLockArgs) UpgradeSeriesLock
+
 	Containers() []Machine
 	AddContainer(MachineArgs) Machine
 
@@ -70,10 +110,14 @@ type machine struct {
 	PasswordHash_ string         `yaml:"password-hash"`
 	Placement_    string         `yaml:"placement,omitempty"`
 	Instance_     *cloudInstance `yaml:"instance,omitempty"`
-	// Series obsolete from v3. Retained for tests.
-	Series_        string `yaml:"series,omitempty"`
+	// Base replaces the series of versions before 3, which is converted
+	// to a base on import.
 	Base_          string `yaml:"base"`
 	ContainerType_ string `yaml:"container-type,omitempty"`
+	Life_          string `yaml:"life,omitempty"`
+
+	AgentStartTime_     *time.Time `yaml:"agent-start-time,omitempty"`
+	HostnameVerifiedAt_ *time.Time `yaml:"hostname-verified-at,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -84,8 +128,12 @@ type machine struct {
 	PreferredPublicAddress_  *address `yaml:"preferred-public-address,omitempty"`
 	PreferredPrivateAddress_ *address `yaml:"preferred-private-address,omitempty"`
 
-	Tools_ *agentTools `yaml:"tools"`
-	Jobs_  []string    `yaml:"jobs"`
+	Tools_        *agentTools `yaml:"tools"`
+	AgentVersion_ string      `yaml:"agent-version,omitempty"`
+	Jobs_         []string    `yaml:"jobs"`
+
+	RebootRequired_    bool               `yaml:"reboot-required,omitempty"`
+	UpgradeSeriesLock_ *upgradeSeriesLock `yaml:"upgrade-series-lock,omitempty"`
 
 	SupportedContainers_ *[]string `yaml:"supported-containers,omitempty"`
 
@@ -98,18 +146,31 @@ type machine struct {
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
 	BlockDevices_ blockdevices `yaml:"block-devices,omitempty"`
+
+	UnknownFields_ map[string]interface{} `yaml:",inline"`
 }
 
 // MachineArgs is an argument struct used to add a machine to the Model.
 type MachineArgs struct {
-	Id            names.MachineTag
-	Nonce         string
-	PasswordHash  string
-	Placement     string
+	Id           names.MachineTag
+	Nonce        string
+	PasswordHash string
+	Placement    string
+	// Deprecated: Series is only used to derive the Base when that isn't
+	// set, and will be removed along with import of machine versions
+	// before 3. Use Base instead.
 	Series        string
 	Base          string
 	ContainerType string
+	Life          string
 	Jobs          []string
+	AgentVersion  string
+
+	RebootRequired bool
+
+	AgentStartTime     time.Time
+	HostnameVerifiedAt time.Time
+
 	// A null value means that we don't yet know which containers
 	// are supported. An empty slice means 'no containers are supported'.
 	SupportedContainers *[]string
@@ -126,21 +187,66 @@ func newMachine(args MachineArgs) *machine {
 		Nonce_:         args.Nonce,
 		PasswordHash_:  args.PasswordHash,
 		Placement_:     args.Placement,
-		Series_:        args.Series,
 		Base_:          args.Base,
 		ContainerType_: args.ContainerType,
+		Life_:          args.Life,
 		Jobs_:          jobs,
-		StatusHistory_: newStatusHistory(),
+		AgentVersion_:  args.AgentVersion,
+		StatusHistory_: NewStatusHistory(),
+
+		RebootRequired_: args.RebootRequired,
+
+		AgentStartTime_:     timePtr(args.AgentStartTime),
+		HostnameVerifiedAt_: timePtr(args.HostnameVerifiedAt),
 	}
 	if args.SupportedContainers != nil {
 		supported := make([]string, len(*args.SupportedContainers))
 		copy(supported, *args.SupportedContainers)
 		m.SupportedContainers_ = &supported
 	}
+	if m.Base_ == "" && args.Series != "" {
+		// An unknown series leaves the base unset.
+		if base, err := SeriesToBase(args.Series); err == nil {
+			m.Base_ = base
+		}
+	}
 	m.setBlockDevices(nil)
 	return m
 }
 
+// emitYAML implements yamlEmitter. The entries follow the fields of
+// machine, which must be kept in step.
+func (m *machine) emitYAML(out *mapping) {
+	out.str("id", m.Id_)
+	out.str("nonce", m.Nonce_)
+	out.str("password-hash", m.PasswordHash_)
+	out.stringOmitEmpty("placement", m.Placement_)
+	out.valueOmitEmpty("instance", m.Instance_)
+	out.str("base", m.Base_)
+	out.stringOmitEmpty("container-type", m.ContainerType_)
+	out.stringOmitEmpty("life", m.Life_)
+	out.valueOmitEmpty("agent-start-time", m.AgentStartTime_)
+	out.valueOmitEmpty("hostname-verified-at", m.HostnameVerifiedAt_)
+	out.value("status", m.Status_)
+	out.value("status-history", &m.StatusHistory_)
+	out.valueOmitEmpty("provider-addresses", m.ProviderAddresses_)
+	out.valueOmitEmpty("machine-addresses", m.MachineAddresses_)
+	out.valueOmitEmpty("preferred-public-address", m.PreferredPublicAddress_)
+	out.valueOmitEmpty("preferred-private-address", m.PreferredPrivateAddress_)
+	out.value("tools", m.Tools_)
+	out.stringOmitEmpty("agent-version", m.AgentVersion_)
+	out.value("jobs", m.Jobs_)
+	out.valueOmitEmpty("reboot-required", m.RebootRequired_)
+	out.valueOmitEmpty("upgrade-series-lock", m.UpgradeSeriesLock_)
+	out.valueOmitEmpty("supported-containers", m.SupportedContainers_)
+	out.value("containers", m.Containers_)
+	out.valueOmitEmpty("opened-port-ranges", m.OpenedPortRanges_)
+	out.valueOmitEmpty("annotations", m.Annotations_)
+	out.valueOmitEmpty("constraints", m.Constraints_)
+	out.valueOmitEmpty("block-devices", m.BlockDevices_)
+	out.inlined(m.UnknownFields_)
+}
+
 // Id implements Machine.
 func (m *machine) Id() string {
 	return m.Id_
@@ -190,6 +296,54 @@ func (m *machine) ContainerType() string {
 	return m.ContainerType_
 }
 
+// Life implements Machine.
+func (m *machine) Life() string {
+	return lifeOrAlive(m.Life_)
+}
+
+// AgentVersion implements Machine.
+func (m *machine) AgentVersion() string {
+	return m.AgentVersion_
+}
+
+// RebootRequired implements Machine.
+func (m *machine) RebootRequired() bool {
+	return m.RebootRequired_
+}
+
+// UpgradeSeriesLock implements Machine.
+func (m *machine) UpgradeSeriesLock() UpgradeSeriesLock {
+	// To avoid typed nils check nil here.
+	if m.UpgradeSeriesLock_ == nil {
+		return nil
+	}
+	return m.UpgradeSeriesLock_
+}
+
+// SetUpgradeSeriesLock implements Machine.
+func (m *machine) SetUpgradeSeriesLock(args UpgradeSeriesLockArgs) UpgradeSeriesLock {
+	m.UpgradeSeriesLock_ = newUpgradeSeriesLock(args)
+	return m.UpgradeSeriesLock_
+}
+
+// AgentStartTime implements Machine.
+func (m *machine) AgentStartTime() time.Time {
+	var zero time.Time
+	if m.AgentStartTime_ == nil {
+		return zero
+	}
+	return *m.AgentStartTime_
+}
+
+// HostnameVerifiedAt implements Machine.
+func (m *machine) HostnameVerifiedAt() time.Time {
+	var zero time.Time
+	if m.HostnameVerifiedAt_ == nil {
+		return zero
+	}
+	return *m.HostnameVerifiedAt_
+}
+
 // Status implements Machine.
 func (m *machine) Status() Status {
 	// To avoid typed nils check nil here.
@@ -326,7 +480,7 @@ func (m *machine) setBlockDevices(devices []*blockdevice) {
 // AddContainer implements Machine.
 func (m *machine) AddContainer(args MachineArgs) Machine {
 	container := newMachine(args)
-	m.Containers_ = append(m.Containers_, container)
+	m.Containers_ = insertByKey(m.Containers_, container, machineKey)
 	return container
 }
 
@@ -372,11 +526,20 @@ func (m *machine) Validate() error {
 	if m.Id_ == "" {
 		return errors.NotValidf("machine missing id")
 	}
-	if m.Base_ != "" {
-		parts := strings.Split(m.Base_, "@")
-		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
-			return errors.NotValidf("machine %q base %q", m.Id_, m.Base_)
-		}
+	if m.Base_ != "" && !validBase(m.Base_) {
+		return errors.NotValidf("machine %q base %q", m.Id_, m.Base_)
+	}
+	if err := m.validateContainerType(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateLife("machine", m.Id_, m.Life_); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validatePreferredAddress("public", m.PreferredPublicAddress_); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validatePreferredAddress("private", m.PreferredPrivateAddress_); err != nil {
+		return errors.Trace(err)
 	}
 	if m.Status_ == nil {
 		return errors.NotValidf("machine %q missing status", m.Id_)
@@ -386,12 +549,23 @@ func (m *machine) Validate() error {
 	if m.Tools_ == nil {
 		return errors.NotValidf("machine %q missing tools", m.Id_)
 	}
+	if err := validateAgentVersion("machine", m.Id_, m.AgentVersion_); err != nil {
+		return errors.Trace(err)
+	}
+	if m.UpgradeSeriesLock_ != nil {
+		if err := m.UpgradeSeriesLock_.validate(m.Id_); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if m.Instance_ == nil {
 		return errors.NotValidf("machine %q missing instance", m.Id_)
 	}
 	if err := m.Instance_.Validate(); err != nil {
 		return errors.Annotatef(err, "machine %q instance", m.Id_)
 	}
+	if err := m.validateAvailabilityZone(); err != nil {
+		return errors.Trace(err)
+	}
 	for _, container := range m.Containers_ {
 		if err := container.Validate(); err != nil {
 			return errors.Trace(err)
@@ -401,6 +575,70 @@ func (m *machine) Validate() error {
 	return nil
 }
 
+// validateContainerType ensures that the container type is known, and that
+// it agrees with the container type embedded in the machine id. An empty
+// container type is accepted for containers as older exports didn't always
+// record it.
+func (m *machine) validateContainerType() error {
+	if !IsValidContainerType(m.ContainerType_) {
+		return errors.NotValidf("machine %q container type %q", m.Id_, m.ContainerType_)
+	}
+	if !names.IsValidMachine(m.Id_) {
+		return errors.NotValidf("machine id %q", m.Id_)
+	}
+	var idType string
+	if names.IsContainerMachine(m.Id_) {
+		idType = m.Tag().ContainerType()
+	}
+	switch m.ContainerType_ {
+	case "":
+		return nil
+	case ContainerTypeNone:
+		if idType != "" {
+			return errors.NotValidf("container %q with container type %q", m.Id_, m.ContainerType_)
+		}
+	default:
+		if idType != m.ContainerType_ {
+			return errors.NotValidf("machine %q container type %q not matching id", m.Id_, m.ContainerType_)
+		}
+	}
+	return nil
+}
+
+// validateAvailabilityZone ensures that when the machine's constraints
+// restrict the zones it may be started in, the instance is in one of them.
+func (m *machine) validateAvailabilityZone() error {
+	zone := m.Instance_.AvailabilityZone_
+	if zone == "" || m.Constraints_ == nil || len(m.Constraints_.Zones_) == 0 {
+		return nil
+	}
+	for _, allowed := range m.Constraints_.Zones_ {
+		if zone == allowed {
+			return nil
+		}
+	}
+	return errors.NotValidf("machine %q availability zone %q not in constraint zones %v",
+		m.Id_, zone, m.Constraints_.Zones_)
+}
+
+// validatePreferredAddress ensures that the preferred address, if set, is one
+// of the provider or machine addresses, unless it has been explicitly flagged
+// as external.
+func (m *machine) validatePreferredAddress(kind string, preferred *address) error {
+	if preferred == nil || preferred.Origin_ == AddressOriginExternal {
+		return nil
+	}
+	for _, addrs := range [][]*address{m.ProviderAddresses_, m.MachineAddresses_} {
+		for _, addr := range addrs {
+			if addr.Value_ == preferred.Value_ {
+				return nil
+			}
+		}
+	}
+	return errors.NotValidf("machine %q preferred %s address %q not in machine addresses",
+		m.Id_, kind, preferred.Value_)
+}
+
 func importMachines(source map[string]interface{}) ([]*machine, error) {
 	checker := versionedChecker("machines")
 	coerced, err := checker.Coerce(source, nil)
@@ -440,6 +678,10 @@ var machineDeserializationFuncs = map[int]machineDeserializationFunc{
 	1: importMachineV1,
 	2: importMachineV2,
 	3: importMachineV3,
+	4: importMachineV4,
+	5: importMachineV5,
+	6: importMachineV6,
+	7: importMachineV7,
 }
 
 func importMachineV1(source map[string]interface{}) (*machine, error) {
@@ -457,6 +699,26 @@ func importMachineV3(source map[string]interface{}) (*machine, error) {
 	return importMachine(fields, defaults, 3, source, importMachineV3)
 }
 
+func importMachineV4(source map[string]interface{}) (*machine, error) {
+	fields, defaults := machineSchemaV4()
+	return importMachine(fields, defaults, 4, source, importMachineV4)
+}
+
+func importMachineV5(source map[string]interface{}) (*machine, error) {
+	fields, defaults := machineSchemaV5()
+	return importMachine(fields, defaults, 5, source, importMachineV5)
+}
+
+func importMachineV6(source map[string]interface{}) (*machine, error) {
+	fields, defaults := machineSchemaV6()
+	return importMachine(fields, defaults, 6, source, importMachineV6)
+}
+
+func importMachineV7(source map[string]interface{}) (*machine, error) {
+	fields, defaults := machineSchemaV7()
+	return importMachine(fields, defaults, 7, source, importMachineV7)
+}
+
 func importMachine(
 	fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{},
 	importFunc machineDeserializationFunc,
@@ -477,26 +739,38 @@ func importMachine(
 		PasswordHash_:  valid["password-hash"].(string),
 		Placement_:     valid["placement"].(string),
 		ContainerType_: valid["container-type"].(string),
-		StatusHistory_: newStatusHistory(),
+		StatusHistory_: NewStatusHistory(),
 		Jobs_:          convertToStringSlice(valid["jobs"]),
 	}
 	if importVersion < 3 {
 		mSeries := valid["series"].(string)
-		os, err := series.GetOSFromSeries(mSeries)
+		base, err := SeriesToBase(mSeries)
 		if err != nil {
 			return nil, errors.NotValidf("base series %q", mSeries)
 		}
-		vers, err := series.SeriesVersion(mSeries)
-		if err != nil {
-			return nil, errors.NotValidf("base series %q", mSeries)
-		}
-		result.Base_ = fmt.Sprintf("%s@%s", strings.ToLower(os.String()), vers)
+		result.Base_ = base
 	} else {
 		result.Base_ = valid["base"].(string)
 	}
+	if importVersion >= 4 {
+		result.Life_ = valid["life"].(string)
+	}
+	if importVersion >= 5 {
+		result.AgentStartTime_ = fieldToTimePtr(valid, "agent-start-time")
+		result.HostnameVerifiedAt_ = fieldToTimePtr(valid, "hostname-verified-at")
+	}
+	if importVersion >= 6 {
+		result.AgentVersion_ = valid["agent-version"].(string)
+	}
+	if importVersion >= 7 {
+		result.RebootRequired_ = valid["reboot-required"].(bool)
+		if lockMap, ok := valid["upgrade-series-lock"]; ok {
+			result.UpgradeSeriesLock_ = importUpgradeSeriesLock(lockMap.(map[string]interface{}))
+		}
+	}
 
-	result.importAnnotations(valid)
-	if err := result.importStatusHistory(valid); err != nil {
+	result.ImportAnnotations(valid)
+	if err := result.ImportStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -667,9 +941,9 @@ func machineSchemaV1() (schema.Fields, schema.Defaults) {
 		"preferred-private-address": schema.Omit,
 	}
 
-	addAnnotationSchema(fields, defaults)
-	addConstraintsSchema(fields, defaults)
-	addStatusHistorySchema(fields)
+	AddAnnotationSchema(fields, defaults)
+	AddConstraintsSchema(fields, defaults)
+	AddStatusHistorySchema(fields)
 
 	return fields, defaults
 }
@@ -693,6 +967,65 @@ func machineSchemaV3() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func machineSchemaV4() (schema.Fields, schema.Defaults) {
+	fields, defaults := machineSchemaV3()
+
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+
+	return fields, defaults
+}
+
+func machineSchemaV5() (schema.Fields, schema.Defaults) {
+	fields, defaults := machineSchemaV4()
+
+	fields["agent-start-time"] = schema.Time()
+	fields["hostname-verified-at"] = schema.Time()
+	defaults["agent-start-time"] = schema.Omit
+	defaults["hostname-verified-at"] = schema.Omit
+
+	return fields, defaults
+}
+
+func machineSchemaV6() (schema.Fields, schema.Defaults) {
+	fields, defaults := machineSchemaV5()
+
+	fields["agent-version"] = schema.String()
+	defaults["agent-version"] = ""
+
+	return fields, defaults
+}
+
+func machineSchemaV7() (schema.Fields, schema.Defaults) {
+	fields, defaults := machineSchemaV6()
+
+	fields["reboot-required"] = schema.Bool()
+	fields["upgrade-series-lock"] = schema.FieldMap(upgradeSeriesLockFields())
+	defaults["reboot-required"] = false
+	defaults["upgrade-series-lock"] = schema.Omit
+
+	return fields, defaults
+}
+
+// validBase reports whether the base is in the form "ubuntu@22.04", of an
+// OS and a channel.
+func validBase(base string) bool {
+	parts := strings.Split(base, "@")
+	return len(parts) >= 2 && parts[0] != "" && parts[1] != ""
+}
+
+// validateAgentVersion checks that the agent version of a machine or unit,
+// if it was recorded, is a version number.
+func validateAgentVersion(kind, id, agentVersion string) error {
+	if agentVersion == "" {
+		return nil
+	}
+	if _, err := version.Parse(agentVersion); err != nil {
+		return errors.NotValidf("%s %q agent version %q", kind, id, agentVersion)
+	}
+	return nil
+}
+
 // AgentToolsArgs is an argument struct used to add information about the
 // tools the agent is using to a Machine.
 type AgentToolsArgs struct {
@@ -5,6 +5,7 @@ package description
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
@@ -111,6 +112,12 @@ func minimalMachineWithPriorInstanceMap(id string, containers ...*machine) *mach
 	// minimalMachineMapWithPriorInstanceMap.
 	m.Instance_.Version = 3
 	m.Instance().SetStatus(minimalStatusArgs())
+	// Version 3 instances have no modification status, so are given the
+	// default one on import.
+	m.Instance().SetModificationStatus(StatusArgs{
+		Value:   defaultModificationStatus,
+		Updated: minimalStatusArgs().Updated,
+	})
 	m.SetTools(minimalAgentToolsArgs())
 	m.SetStatus(minimalStatusArgs())
 	return m
@@ -140,6 +147,9 @@ func addMinimalMachineWithMissingModificationStatus(model Model, id string) {
 		Jobs:         []string{"host-units"},
 	})
 	m.SetInstance(minimalCloudInstanceArgs())
+	// Only instances from before v7 may be missing the modification
+	// status.
+	m.Instance().(*cloudInstance).Version = 6
 	m.SetTools(minimalAgentToolsArgs())
 	m.SetStatus(minimalStatusArgs())
 	m.Instance().SetStatus(minimalStatusArgs())
@@ -152,7 +162,7 @@ func (s *MachineSerializationSuite) machineArgs(id string) MachineArgs {
 		PasswordHash:  "some-hash",
 		Placement:     "placement",
 		Base:          "ubuntu@22.04",
-		ContainerType: "magic",
+		ContainerType: "none",
 		Jobs:          []string{"this", "that"},
 	}
 }
@@ -165,7 +175,7 @@ func (s *MachineSerializationSuite) TestNewMachine(c *gc.C) {
 	c.Assert(m.PasswordHash(), gc.Equals, "some-hash")
 	c.Assert(m.Placement(), gc.Equals, "placement")
 	c.Assert(m.Base(), gc.Equals, "ubuntu@22.04")
-	c.Assert(m.ContainerType(), gc.Equals, "magic")
+	c.Assert(m.ContainerType(), gc.Equals, "none")
 	c.Assert(m.Jobs(), jc.DeepEquals, []string{"this", "that"})
 	supportedContainers, ok := m.SupportedContainers()
 	c.Assert(ok, jc.IsFalse)
@@ -218,6 +228,113 @@ func (s *MachineSerializationSuite) TestValidateChecksInstance(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `machine "42" instance: instance "instance id" missing status not valid`)
 }
 
+func (s *MachineSerializationSuite) TestValidateUnknownContainerType(c *gc.C) {
+	m := minimalMachine("42")
+	m.ContainerType_ = "magic"
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42" container type "magic" not valid`)
+}
+
+func (s *MachineSerializationSuite) TestValidateContainerTypeMatchesId(c *gc.C) {
+	m := minimalMachine("42/lxd/0")
+	m.ContainerType_ = ContainerTypeKVM
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42/lxd/0" container type "kvm" not matching id not valid`)
+
+	m.ContainerType_ = ContainerTypeLXD
+	c.Check(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateContainerTypeOnTopLevelMachine(c *gc.C) {
+	m := minimalMachine("42")
+	m.ContainerType_ = ContainerTypeLXD
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42" container type "lxd" not matching id not valid`)
+
+	m.ContainerType_ = ContainerTypeNone
+	c.Check(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateContainerWithNoneContainerType(c *gc.C) {
+	m := minimalMachine("42/lxd/0")
+	m.ContainerType_ = ContainerTypeNone
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `container "42/lxd/0" with container type "none" not valid`)
+}
+
+func (s *MachineSerializationSuite) TestValidateChecksContainers(c *gc.C) {
+	container := minimalMachine("42/lxd/0")
+	container.ContainerType_ = ContainerTypeKVM
+	m := minimalMachine("42", container)
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42/lxd/0" container type "kvm" not matching id not valid`)
+}
+
+func (s *MachineSerializationSuite) TestValidateAvailabilityZoneInConstraints(c *gc.C) {
+	m := minimalMachine("42")
+	m.Instance_.AvailabilityZone_ = "az2"
+	m.SetConstraints(ConstraintsArgs{Zones: []string{"az1", "az2"}})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateAvailabilityZoneNoConstraintZones(c *gc.C) {
+	m := minimalMachine("42")
+	m.Instance_.AvailabilityZone_ = "az3"
+	m.SetConstraints(ConstraintsArgs{Architecture: "amd64"})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateAvailabilityZoneNotInConstraints(c *gc.C) {
+	m := minimalMachine("42")
+	m.Instance_.AvailabilityZone_ = "az3"
+	m.SetConstraints(ConstraintsArgs{Zones: []string{"az1", "az2"}})
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42" availability zone "az3" not in constraint zones \[az1 az2\] not valid`)
+}
+
+func (s *MachineSerializationSuite) TestValidatePreferredAddresses(c *gc.C) {
+	m := minimalMachine("42")
+	m.SetAddresses(
+		[]AddressArgs{{Value: "10.0.0.10", Type: "ipv4"}},
+		[]AddressArgs{{Value: "54.1.2.3", Type: "ipv4"}},
+	)
+	m.SetPreferredAddresses(
+		AddressArgs{Value: "54.1.2.3", Type: "ipv4"},
+		AddressArgs{Value: "10.0.0.10", Type: "ipv4"},
+	)
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateStalePreferredAddress(c *gc.C) {
+	m := minimalMachine("42")
+	m.SetAddresses(
+		[]AddressArgs{{Value: "10.0.0.10", Type: "ipv4"}},
+		[]AddressArgs{{Value: "54.1.2.3", Type: "ipv4"}},
+	)
+	m.SetPreferredAddresses(
+		AddressArgs{Value: "54.1.2.3", Type: "ipv4"},
+		AddressArgs{Value: "10.0.0.99", Type: "ipv4"},
+	)
+	err := m.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `machine "42" preferred private address "10.0.0.99" not in machine addresses not valid`)
+}
+
+func (s *MachineSerializationSuite) TestValidateExternalPreferredAddress(c *gc.C) {
+	m := minimalMachine("42")
+	m.SetPreferredAddresses(
+		AddressArgs{Value: "54.1.2.3", Type: "ipv4", Origin: AddressOriginExternal},
+		AddressArgs{},
+	)
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+}
+
 func (s *MachineSerializationSuite) TestNewMachineWithSupportedContainers(c *gc.C) {
 	supported := []string{"lxd", "kvm"}
 	args := s.machineArgs("id")
@@ -402,7 +519,7 @@ func (s *MachineSerializationSuite) TestConstraints(c *gc.C) {
 }
 
 func (s *MachineSerializationSuite) exportImport(c *gc.C, machine_ *machine) *machine {
-	return s.exportImportVersion(c, machine_, 3)
+	return s.exportImportVersion(c, machine_, 7)
 }
 
 func (s *MachineSerializationSuite) exportImportVersion(c *gc.C, machine_ *machine, version int) *machine {
@@ -537,14 +654,34 @@ func (s *MachineSerializationSuite) TestParsingSerializedData(c *gc.C) {
 func (s *MachineSerializationSuite) TestV1ParsingReturnsLatest(c *gc.C) {
 	mV1 := minimalMachine("0")
 	mV1.Base_ = ""
-	mV1.Series_ = "focal"
 
-	mLatest := mV1
-	mResult := s.exportImportVersion(c, mV1, 1)
+	bytes, err := yaml.Marshal(machines{
+		Version:   1,
+		Machines_: []*machine{mV1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	// Machines stopped having a series in v3, so it's added by hand.
+	entry := source["machines"].([]interface{})[0].(map[interface{}]interface{})
+	entry["series"] = "focal"
+
+	mResult, err := importMachines(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mResult, gc.HasLen, 1)
 
+	mLatest := minimalMachine("0")
 	mLatest.Base_ = "ubuntu@20.04"
-	mLatest.Series_ = ""
-	c.Assert(mResult, jc.DeepEquals, mLatest)
+	c.Assert(mResult[0], jc.DeepEquals, mLatest)
+}
+
+func (s *MachineSerializationSuite) TestNewMachineDerivesBaseFromSeries(c *gc.C) {
+	m := newMachine(MachineArgs{Id: names.NewMachineTag("0"), Series: "jammy"})
+	c.Assert(m.Base(), gc.Equals, "ubuntu@22.04")
+
+	m = newMachine(MachineArgs{Id: names.NewMachineTag("0"), Series: "jammy", Base: "ubuntu@20.04"})
+	c.Assert(m.Base(), gc.Equals, "ubuntu@20.04")
 }
 
 type AgentToolsSerializationSuite struct {
@@ -626,3 +763,179 @@ func (s *AgentToolsSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(instance, jc.DeepEquals, initial)
 }
+
+func (s *MachineSerializationSuite) TestLife(c *gc.C) {
+	initial := minimalMachine("1")
+	c.Assert(initial.Life(), gc.Equals, Alive)
+	initial.Life_ = Dying
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.Life(), gc.Equals, Dying)
+
+	machine = s.exportImportVersion(c, initial, 3)
+	c.Assert(machine.Life(), gc.Equals, Alive)
+}
+
+func (s *MachineSerializationSuite) TestValidateLife(c *gc.C) {
+	m := minimalMachine("1")
+	m.Life_ = "zombie"
+	err := m.Validate()
+	c.Assert(err, gc.ErrorMatches, `machine "1" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *MachineSerializationSuite) TestAgentVersion(c *gc.C) {
+	initial := newMachine(MachineArgs{
+		Id:           names.NewMachineTag("1"),
+		AgentVersion: "3.1.2",
+	})
+	initial.SetStatus(minimalStatusArgs())
+	initial.SetTools(minimalAgentToolsArgs())
+	c.Assert(initial.AgentVersion(), gc.Equals, "3.1.2")
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.AgentVersion(), gc.Equals, "3.1.2")
+
+	machine = s.exportImportVersion(c, initial, 5)
+	c.Assert(machine.AgentVersion(), gc.Equals, "")
+}
+
+func (s *MachineSerializationSuite) TestValidateAgentVersion(c *gc.C) {
+	m := minimalMachine("1")
+	m.AgentVersion_ = "3.1.2"
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+
+	m.AgentVersion_ = "three"
+	err := m.Validate()
+	c.Assert(err, gc.ErrorMatches, `machine "1" agent version "three" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *MachineSerializationSuite) TestRebootRequired(c *gc.C) {
+	initial := newMachine(MachineArgs{
+		Id:             names.NewMachineTag("1"),
+		RebootRequired: true,
+	})
+	initial.SetStatus(minimalStatusArgs())
+	initial.SetTools(minimalAgentToolsArgs())
+	c.Assert(initial.RebootRequired(), jc.IsTrue)
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.RebootRequired(), jc.IsTrue)
+
+	machine = s.exportImportVersion(c, initial, 6)
+	c.Assert(machine.RebootRequired(), jc.IsFalse)
+}
+
+func (s *MachineSerializationSuite) TestUpgradeSeriesLock(c *gc.C) {
+	initial := minimalMachine("1")
+	c.Assert(initial.UpgradeSeriesLock(), gc.IsNil)
+
+	statuses := map[string]string{
+		"ubuntu/0": "prepare completed",
+		"ubuntu/1": "prepare started",
+	}
+	lock := initial.SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  statuses,
+	})
+	statuses["ubuntu/0"] = "changed"
+	c.Assert(lock.FromBase(), gc.Equals, "ubuntu@20.04")
+	c.Assert(lock.ToBase(), gc.Equals, "ubuntu@22.04")
+	c.Assert(lock.MachineStatus(), gc.Equals, "prepare started")
+	c.Assert(lock.UnitStatuses(), jc.DeepEquals, map[string]string{
+		"ubuntu/0": "prepare completed",
+		"ubuntu/1": "prepare started",
+	})
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.UpgradeSeriesLock(), jc.DeepEquals, lock)
+
+	machine = s.exportImportVersion(c, initial, 6)
+	c.Assert(machine.UpgradeSeriesLock(), gc.IsNil)
+}
+
+func (s *MachineSerializationSuite) TestUpgradeSeriesLockWithoutUnits(c *gc.C) {
+	initial := minimalMachine("1")
+	initial.SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+	})
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.UpgradeSeriesLock(), gc.NotNil)
+	c.Assert(machine.UpgradeSeriesLock().UnitStatuses(), gc.IsNil)
+}
+
+func (s *MachineSerializationSuite) TestValidateUpgradeSeriesLock(c *gc.C) {
+	for i, test := range []struct {
+		args   UpgradeSeriesLockArgs
+		errMsg string
+	}{{
+		args:   UpgradeSeriesLockArgs{FromBase: "ubuntu", ToBase: "ubuntu@22.04", MachineStatus: "prepare started"},
+		errMsg: `machine "1" upgrade series from base "ubuntu" not valid`,
+	}, {
+		args:   UpgradeSeriesLockArgs{FromBase: "ubuntu@20.04", MachineStatus: "prepare started"},
+		errMsg: `machine "1" upgrade series to base "" not valid`,
+	}, {
+		args:   UpgradeSeriesLockArgs{FromBase: "ubuntu@20.04", ToBase: "ubuntu@22.04"},
+		errMsg: `machine "1" upgrade series missing status not valid`,
+	}, {
+		args: UpgradeSeriesLockArgs{
+			FromBase:      "ubuntu@20.04",
+			ToBase:        "ubuntu@22.04",
+			MachineStatus: "prepare started",
+			UnitStatuses:  map[string]string{"ubuntu": "prepare started"},
+		},
+		errMsg: `machine "1" upgrade series unit "ubuntu" not valid`,
+	}, {
+		args: UpgradeSeriesLockArgs{
+			FromBase:      "ubuntu@20.04",
+			ToBase:        "ubuntu@22.04",
+			MachineStatus: "prepare started",
+			UnitStatuses:  map[string]string{"ubuntu/0": ""},
+		},
+		errMsg: `machine "1" upgrade series unit "ubuntu/0" missing status not valid`,
+	}} {
+		c.Logf("test %d", i)
+		m := minimalMachine("1")
+		m.SetUpgradeSeriesLock(test.args)
+		err := m.Validate()
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+
+	m := minimalMachine("1")
+	m.SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  map[string]string{"ubuntu/0": "prepare started"},
+	})
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+}
+
+func (s *MachineSerializationSuite) TestAgentTimes(c *gc.C) {
+	started := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	verified := started.Add(time.Minute)
+	initial := newMachine(MachineArgs{
+		Id:                 names.NewMachineTag("1"),
+		AgentStartTime:     started,
+		HostnameVerifiedAt: verified,
+	})
+	initial.SetStatus(minimalStatusArgs())
+	initial.SetTools(minimalAgentToolsArgs())
+	c.Assert(initial.AgentStartTime(), gc.Equals, started)
+	c.Assert(initial.HostnameVerifiedAt(), gc.Equals, verified)
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine, jc.DeepEquals, initial)
+
+	machine = s.exportImportVersion(c, initial, 4)
+	c.Assert(machine.AgentStartTime().IsZero(), jc.IsTrue)
+	c.Assert(machine.HostnameVerifiedAt().IsZero(), jc.IsTrue)
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"encoding/hex"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// The kinds of binary blob a manifest entry may describe.
+const (
+	ManifestCharm       = "charm"
+	ManifestResource    = "resource"
+	ManifestAgentBinary = "agent-binary"
+)
+
+// Manifest is the inventory of the binary blobs, such as charms, resources
+// and agent binaries, that are moved along with a model during migration.
+// It is serialized as a document of its own, alongside the model.
+type Manifest interface {
+	Entries() []ManifestEntry
+	AddEntry(ManifestEntryArgs) ManifestEntry
+
+	Validate() error
+}
+
+// ManifestEntry describes a single binary blob in a Manifest.
+type ManifestEntry interface {
+	Kind() string
+	Name() string
+	SHA256() string
+	Size() int64
+}
+
+type manifest struct {
+	Version  int              `yaml:"version"`
+	Entries_ []*manifestEntry `yaml:"entries"`
+}
+
+type manifestEntry struct {
+	Kind_   string `yaml:"kind"`
+	Name_   string `yaml:"name"`
+	SHA256_ string `yaml:"sha256"`
+	Size_   int64  `yaml:"size"`
+}
+
+// ManifestEntryArgs is an argument struct used to add an entry to a
+// Manifest.
+type ManifestEntryArgs struct {
+	Kind   string
+	Name   string
+	SHA256 string
+	Size   int64
+}
+
+// NewManifest returns an empty Manifest.
+func NewManifest() Manifest {
+	return &manifest{Version: 1}
+}
+
+// SerializeManifest serializes the manifest to YAML.
+func SerializeManifest(m Manifest) ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// DeserializeManifest constructs a Manifest from its serialized YAML form.
+func DeserializeManifest(bytes []byte) (Manifest, error) {
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries, err := importManifestEntries(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &manifest{Version: 1, Entries_: entries}, nil
+}
+
+// Entries implements Manifest.
+func (m *manifest) Entries() []ManifestEntry {
+	result := make([]ManifestEntry, len(m.Entries_))
+	for i, entry := range m.Entries_ {
+		result[i] = entry
+	}
+	return result
+}
+
+// AddEntry implements Manifest.
+func (m *manifest) AddEntry(args ManifestEntryArgs) ManifestEntry {
+	entry := &manifestEntry{
+		Kind_:   args.Kind,
+		Name_:   args.Name,
+		SHA256_: args.SHA256,
+		Size_:   args.Size,
+	}
+	m.Entries_ = append(m.Entries_, entry)
+	return entry
+}
+
+// Validate implements Manifest. It checks that each entry is of a known
+// kind, has a name that is unique for the kind, and a well formed sha256.
+func (m *manifest) Validate() error {
+	seen := set.NewStrings()
+	for i, entry := range m.Entries_ {
+		switch entry.Kind_ {
+		case ManifestCharm, ManifestResource, ManifestAgentBinary:
+		default:
+			return errors.NotValidf("manifest entry %d kind %q", i, entry.Kind_)
+		}
+		if entry.Name_ == "" {
+			return errors.NotValidf("manifest entry %d missing name", i)
+		}
+		key := entry.Kind_ + "/" + entry.Name_
+		if seen.Contains(key) {
+			return errors.NotValidf("manifest entry %d duplicate %s %q", i, entry.Kind_, entry.Name_)
+		}
+		seen.Add(key)
+		if hash, err := hex.DecodeString(entry.SHA256_); err != nil || len(hash) != 32 {
+			return errors.NotValidf("manifest entry %d sha256 %q", i, entry.SHA256_)
+		}
+		if entry.Size_ < 0 {
+			return errors.NotValidf("manifest entry %d size %d", i, entry.Size_)
+		}
+	}
+	return nil
+}
+
+// Kind implements ManifestEntry.
+func (e *manifestEntry) Kind() string {
+	return e.Kind_
+}
+
+// Name implements ManifestEntry.
+func (e *manifestEntry) Name() string {
+	return e.Name_
+}
+
+// SHA256 implements ManifestEntry.
+func (e *manifestEntry) SHA256() string {
+	return e.SHA256_
+}
+
+// Size implements ManifestEntry.
+func (e *manifestEntry) Size() int64 {
+	return e.Size_
+}
+
+func importManifestEntries(source map[string]interface{}) ([]*manifestEntry, error) {
+	checker := versionedChecker("entries")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "manifest version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := manifestEntryDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["entries"].([]interface{})
+	result := make([]*manifestEntry, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for manifest entry %d, %T", i, value)
+		}
+		entry, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "manifest entry %d", i)
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+type manifestEntryDeserializationFunc func(map[string]interface{}) (*manifestEntry, error)
+
+var manifestEntryDeserializationFuncs = map[int]manifestEntryDeserializationFunc{
+	1: importManifestEntryV1,
+}
+
+func importManifestEntryV1(source map[string]interface{}) (*manifestEntry, error) {
+	fields := schema.Fields{
+		"kind":   schema.String(),
+		"name":   schema.String(),
+		"sha256": schema.String(),
+		"size":   schema.Int(),
+	}
+	checker := schema.FieldMap(fields, nil) // no defaults
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "manifest entry v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	return &manifestEntry{
+		Kind_:   valid["kind"].(string),
+		Name_:   valid["name"].(string),
+		SHA256_: valid["sha256"].(string),
+		Size_:   valid["size"].(int64),
+	}, nil
+}
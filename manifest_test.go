@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ManifestSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifestSuite{})
+
+var testSHA256 = strings.Repeat("ab", 32)
+
+func testManifest() Manifest {
+	m := NewManifest()
+	m.AddEntry(ManifestEntryArgs{Kind: ManifestCharm, Name: "ch:amd64/ubuntu-42", SHA256: testSHA256, Size: 1024})
+	m.AddEntry(ManifestEntryArgs{Kind: ManifestResource, Name: "ubuntu/data", SHA256: testSHA256, Size: 2048})
+	m.AddEntry(ManifestEntryArgs{Kind: ManifestAgentBinary, Name: "3.1.1-ubuntu-amd64", SHA256: testSHA256, Size: 4096})
+	return m
+}
+
+func (*ManifestSuite) TestRoundTrip(c *gc.C) {
+	initial := testManifest()
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	bytes, err := SerializeManifest(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := DeserializeManifest(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial)
+
+	entry := imported.Entries()[2]
+	c.Check(entry.Kind(), gc.Equals, ManifestAgentBinary)
+	c.Check(entry.Name(), gc.Equals, "3.1.1-ubuntu-amd64")
+	c.Check(entry.SHA256(), gc.Equals, testSHA256)
+	c.Check(entry.Size(), gc.Equals, int64(4096))
+}
+
+func (*ManifestSuite) TestDeserializeBadVersion(c *gc.C) {
+	_, err := DeserializeManifest([]byte("version: 2\nentries: []\n"))
+	c.Assert(err, gc.ErrorMatches, "version 2 not valid")
+}
+
+func (*ManifestSuite) TestDeserializeBadEntry(c *gc.C) {
+	_, err := DeserializeManifest([]byte("version: 1\nentries:\n- kind: charm\n"))
+	c.Assert(err, gc.ErrorMatches, `manifest entry 0: manifest entry v1 schema check failed: .*`)
+}
+
+func (*ManifestSuite) TestValidate(c *gc.C) {
+	for _, test := range []struct {
+		args     ManifestEntryArgs
+		expected string
+	}{{
+		args:     ManifestEntryArgs{Kind: "blob", Name: "x", SHA256: testSHA256},
+		expected: `manifest entry 3 kind "blob" not valid`,
+	}, {
+		args:     ManifestEntryArgs{Kind: ManifestCharm, SHA256: testSHA256},
+		expected: `manifest entry 3 missing name not valid`,
+	}, {
+		args:     ManifestEntryArgs{Kind: ManifestCharm, Name: "ch:amd64/ubuntu-42", SHA256: testSHA256},
+		expected: `manifest entry 3 duplicate charm "ch:amd64/ubuntu-42" not valid`,
+	}, {
+		args:     ManifestEntryArgs{Kind: ManifestCharm, Name: "x", SHA256: "abc"},
+		expected: `manifest entry 3 sha256 "abc" not valid`,
+	}, {
+		args:     ManifestEntryArgs{Kind: ManifestCharm, Name: "x", SHA256: testSHA256, Size: -1},
+		expected: `manifest entry 3 size -1 not valid`,
+	}} {
+		m := testManifest()
+		m.AddEntry(test.args)
+		err := m.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expected)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// MigrationAttempt records which attempt to migrate the model it was
+// exported for, so that a migration retried after an earlier attempt was
+// aborted carries what happened to that attempt.
+type MigrationAttempt interface {
+	// Attempt is the number of the attempt, counting from one.
+	Attempt() int
+	// PreviousTarget is the UUID of the controller the previous attempt
+	// migrated the model to, if there was one.
+	PreviousTarget() string
+	// PhaseReached is the last phase the previous attempt reached, such
+	// as "IMPORT", before it was aborted.
+	PhaseReached() string
+}
+
+// MigrationAttemptArgs is an argument struct used to set the migration
+// attempt of the Model.
+type MigrationAttemptArgs struct {
+	Attempt        int
+	PreviousTarget string
+	PhaseReached   string
+}
+
+type migrationAttempt struct {
+	Attempt_        int    `yaml:"attempt"`
+	PreviousTarget_ string `yaml:"previous-target,omitempty"`
+	PhaseReached_   string `yaml:"phase-reached,omitempty"`
+}
+
+func newMigrationAttempt(args MigrationAttemptArgs) *migrationAttempt {
+	return &migrationAttempt{
+		Attempt_:        args.Attempt,
+		PreviousTarget_: args.PreviousTarget,
+		PhaseReached_:   args.PhaseReached,
+	}
+}
+
+// Attempt implements MigrationAttempt.
+func (a *migrationAttempt) Attempt() int {
+	return a.Attempt_
+}
+
+// PreviousTarget implements MigrationAttempt.
+func (a *migrationAttempt) PreviousTarget() string {
+	return a.PreviousTarget_
+}
+
+// PhaseReached implements MigrationAttempt.
+func (a *migrationAttempt) PhaseReached() string {
+	return a.PhaseReached_
+}
+
+// validate checks that the attempt is numbered, and that only an attempt
+// that follows another records what happened to it.
+func (a *migrationAttempt) validate() error {
+	if a.Attempt_ < 1 {
+		return errors.NotValidf("migration attempt %d", a.Attempt_)
+	}
+	if a.Attempt_ == 1 && (a.PreviousTarget_ != "" || a.PhaseReached_ != "") {
+		return errors.NotValidf("first migration attempt with previous attempt")
+	}
+	return nil
+}
+
+func migrationAttemptFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"attempt":         schema.Int(),
+		"previous-target": schema.String(),
+		"phase-reached":   schema.String(),
+	}
+	defaults := schema.Defaults{
+		"previous-target": "",
+		"phase-reached":   "",
+	}
+	return fields, defaults
+}
+
+func importMigrationAttempt(source map[string]interface{}) *migrationAttempt {
+	return &migrationAttempt{
+		Attempt_:        int(source["attempt"].(int64)),
+		PreviousTarget_: source["previous-target"].(string),
+		PhaseReached_:   source["phase-reached"].(string),
+	}
+}
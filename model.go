@@ -5,6 +5,8 @@ package description
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"net"
 	"sort"
 	"strings"
@@ -18,6 +20,18 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	// PasswordHashSHA512 is the algorithm of agent password hashes, the
+	// salted SHA-512 digests Juju gives agents. A password hash without
+	// an algorithm, as written before algorithms were recorded, is
+	// assumed to be one.
+	PasswordHashSHA512 = "sha512"
+
+	// PasswordHashPBKDF2 is the algorithm of the PBKDF2 password hashes
+	// Juju gives users.
+	PasswordHashPBKDF2 = "pbkdf2"
+)
+
 const (
 	// IAAS is the type for IAAS models.
 	IAAS = "iaas"
@@ -27,6 +41,14 @@ const (
 )
 
 // Model is a database agnostic representation of an existing model.
+//
+// The slice getters of a model, and the Containers of a machine and the
+// Units of an application, return entities sorted by their natural key:
+// their id, or their name if they aren't given an id, with runs of digits
+// compared by value so that "ubuntu/2" sorts before "ubuntu/10". Devices
+// and addresses sort by machine first. The model keeps its entities in
+// that order, inserting them as they are added and sorting them once they
+// are imported, so they are serialized in that order too.
 type Model interface {
 	HasAnnotations
 	HasConstraints
@@ -43,6 +65,9 @@ type Model interface {
 	SetCloudCredential(CloudCredentialArgs)
 	Tag() names.ModelTag
 	Owner() names.UserTag
+	// Description returns the free text notes the operator attached to
+	// the model, such as its purpose and who owns it.
+	Description() string
 	Config() map[string]interface{}
 	LatestToolsVersion() version.Number
 	EnvironVersion() int
@@ -63,6 +88,17 @@ type Model interface {
 	Applications() []Application
 	AddApplication(ApplicationArgs) Application
 
+	// ApplicationNames returns the names of the model's applications, in
+	// natural order, without the remote applications.
+	ApplicationNames() []string
+	// UnitNames returns the names of the units of all the model's
+	// applications, in natural order.
+	UnitNames() []string
+
+	// Charms returns the charms stored for the model's applications.
+	Charms() []Charm
+	AddCharm(CharmArgs) Charm
+
 	Relations() []Relation
 	AddRelation(RelationArgs) Relation
 
@@ -70,6 +106,12 @@ type Model interface {
 	AddRemoteEntity(RemoteEntityArgs) RemoteEntity
 
 	RelationNetworks() []RelationNetwork
+	// RelationIngressNetworks returns the relation networks that may
+	// reach the model over its cross model relations.
+	RelationIngressNetworks() []RelationNetwork
+	// RelationEgressNetworks returns the relation networks that the
+	// model reaches over its cross model relations.
+	RelationEgressNetworks() []RelationNetwork
 	AddRelationNetwork(RelationNetworkArgs) RelationNetwork
 
 	Spaces() []Space
@@ -79,6 +121,7 @@ type Model interface {
 	AddLinkLayerDevice(LinkLayerDeviceArgs) LinkLayerDevice
 
 	Subnets() []Subnet
+	SubnetsInSpace(spaceID string) []Subnet
 	AddSubnet(SubnetArgs) Subnet
 
 	IPAddresses() []IPAddress
@@ -87,6 +130,11 @@ type Model interface {
 	SSHHostKeys() []SSHHostKey
 	AddSSHHostKey(SSHHostKeyArgs) SSHHostKey
 
+	// VirtualHostKeys returns the host keys presented for the machines
+	// and units of the model when SSH connections to them are proxied.
+	VirtualHostKeys() []VirtualHostKey
+	AddVirtualHostKey(VirtualHostKeyArgs) VirtualHostKey
+
 	CloudImageMetadata() []CloudImageMetadata
 	AddCloudImageMetadata(CloudImageMetadataArgs) CloudImageMetadata
 
@@ -116,6 +164,11 @@ type Model interface {
 
 	SecretBackendID() string
 
+	// SecretBackends returns the backends holding the content of the
+	// model's secrets.
+	SecretBackends() []SecretBackend
+	AddSecretBackend(SecretBackendArgs) SecretBackend
+
 	Secrets() []Secret
 	AddSecret(args SecretArgs) Secret
 
@@ -132,6 +185,51 @@ type Model interface {
 	AddExternalController(ExternalControllerArgs) ExternalController
 
 	Validate() error
+	// ValidateAll returns every validation failure rather than just the
+	// first, as ValidationErrors.
+	ValidateAll() error
+	// ValidateWith validates the model as Validate does, and then checks
+	// it against the rules in turn, returning the first failure.
+	ValidateWith(rules ...ValidationRule) error
+
+	// Checksum returns a digest of the serialized model, combining the
+	// digests of its sections that an integrity section records.
+	Checksum() (string, error)
+
+	// Summary writes a report of the model for people to read, with
+	// tables of where its units are placed, its machines, its storage
+	// by pool, its storage instances and its relations.
+	Summary(w io.Writer) error
+
+	// DanglingPrincipals returns the sorted names of the users that are
+	// referenced by access control lists in the model, or that granted
+	// users access to it, but are not users of the model. Validate doesn't
+	// fail because of them; DanglingPrincipalsRule does.
+	DanglingPrincipals() []string
+	// CleanDanglingPrincipals removes the users returned by
+	// DanglingPrincipals from the access control lists and access grants
+	// that reference them, returning the names of the users removed.
+	CleanDanglingPrincipals() []string
+	// RemoveDanglingRelations removes the relations with an endpoint on
+	// an application, local or remote, that isn't in the model, returning
+	// the sorted keys of the relations removed.
+	RemoveDanglingRelations() []string
+
+	// AnnotationsForEntity returns the annotations of the entity with the
+	// tag, which may be the model itself, or nil if it has none.
+	AnnotationsForEntity(names.Tag) map[string]string
+	// SetAnnotationsForEntity replaces the annotations of the entity with
+	// the tag. It fails if the model has no such entity, or if entities
+	// of its kind can't be annotated.
+	SetAnnotationsForEntity(names.Tag, map[string]string) error
+	// AnnotationsIndex returns the annotations of every annotated entity
+	// of the model, keyed by the string form of its tag.
+	AnnotationsIndex() map[string]map[string]string
+	// NormalizeRelationNetworks merges the relation networks of each
+	// relation with the same direction and origin into the first of
+	// them, which is given the CIDRs of all of them, returning the sorted
+	// ids of the relation networks merged away.
+	NormalizeRelationNetworks() []string
 
 	SetSLA(level, owner, credentials string) SLA
 	SLA() SLA
@@ -139,9 +237,49 @@ type Model interface {
 	SetMeterStatus(code, info string) MeterStatus
 	MeterStatus() MeterStatus
 
+	SetTelemetry(TelemetryArgs) Telemetry
+	// Telemetry returns the telemetry settings of the model, or nil if
+	// they haven't been set.
+	Telemetry() Telemetry
+
+	// MigrationAttempt returns the attempt to migrate the model that it
+	// was exported for, or nil if it hasn't been set.
+	SetMigrationAttempt(MigrationAttemptArgs) MigrationAttempt
+	MigrationAttempt() MigrationAttempt
+
+	// Lease returns the singular lease of the model, or nil if it hasn't
+	// been set.
+	SetLease(LeaseArgs) Lease
+	Lease() Lease
+
 	PasswordHash() string
+	// PasswordHashAlgorithm returns the algorithm of the password hash,
+	// which is empty if it wasn't recorded.
+	PasswordHashAlgorithm() string
+	// SetPasswordHash replaces the model's password hash, as when the
+	// credentials are rotated during a migration.
+	SetPasswordHash(hash, algorithm string)
 
 	AddBlockDevice(string, BlockDeviceArgs) error
+
+	// Freeze marks the model as read-only and returns a read-only view of
+	// it. Any later call of a method of the model itself that modifies it
+	// panics, as does setting the workload version of one of its units.
+	// That is all that is guarded: the machines, applications and other
+	// entities the model returned before it was frozen don't know it is,
+	// and changing them still changes the model. The entities the view
+	// returns are copies, so changing them leaves the model as it was.
+	Freeze() ModelReader
+
+	// Labels returns the labels of the document the model was read from,
+	// which ExportOptions.Labels set. They aren't part of the model, and
+	// are written out again with it.
+	Labels() map[string]string
+
+	// Copy returns a deep copy of the model, which shares none of its
+	// entities, so that either can be changed without affecting the other.
+	// The copy isn't frozen, even if the model is.
+	Copy() Model
 }
 
 // ModelArgs represent the bare minimum information that is needed
@@ -159,30 +297,37 @@ type ModelArgs struct {
 	Cloud              string
 	CloudRegion        string
 	PasswordHash       string
-	SecretBackendID    string
+	// PasswordHashAlgorithm is one of PasswordHashSHA512 and
+	// PasswordHashPBKDF2.
+	PasswordHashAlgorithm string
+	SecretBackendID       string
+	Description           string
 }
 
 // NewModel returns a Model based on the args specified.
 func NewModel(args ModelArgs) Model {
 	m := &model{
-		Version:             11,
-		AgentVersion_:       args.AgentVersion,
-		Type_:               args.Type,
-		Owner_:              args.Owner.Id(),
-		Config_:             args.Config,
-		LatestToolsVersion_: args.LatestToolsVersion,
-		EnvironVersion_:     args.EnvironVersion,
-		Sequences_:          make(map[string]int),
-		Blocks_:             args.Blocks,
-		Cloud_:              args.Cloud,
-		CloudRegion_:        args.CloudRegion,
-		PasswordHash_:       args.PasswordHash,
-		SecretBackendID_:    args.SecretBackendID,
-		StatusHistory_:      newStatusHistory(),
+		Version:                20,
+		AgentVersion_:          args.AgentVersion,
+		Type_:                  args.Type,
+		Owner_:                 args.Owner.Id(),
+		Config_:                args.Config,
+		LatestToolsVersion_:    args.LatestToolsVersion,
+		EnvironVersion_:        args.EnvironVersion,
+		Sequences_:             make(map[string]int),
+		Blocks_:                args.Blocks,
+		Cloud_:                 args.Cloud,
+		CloudRegion_:           args.CloudRegion,
+		PasswordHash_:          args.PasswordHash,
+		PasswordHashAlgorithm_: args.PasswordHashAlgorithm,
+		SecretBackendID_:       args.SecretBackendID,
+		Description_:           args.Description,
+		StatusHistory_:         NewStatusHistory(),
 	}
 	m.setUsers(nil)
 	m.setMachines(nil)
 	m.setApplications(nil)
+	m.setCharms(nil)
 	m.setRelations(nil)
 	m.setRemoteEntities(nil)
 	m.setRelationNetworks(nil)
@@ -191,6 +336,7 @@ func NewModel(args ModelArgs) Model {
 	m.setSubnets(nil)
 	m.setIPAddresses(nil)
 	m.setSSHHostKeys(nil)
+	m.setVirtualHostKeys(nil)
 	m.setCloudImageMetadatas(nil)
 	m.setActions(nil)
 	m.setOperations(nil)
@@ -198,6 +344,7 @@ func NewModel(args ModelArgs) Model {
 	m.setFilesystems(nil)
 	m.setStorages(nil)
 	m.setStoragePools(nil)
+	m.setSecretBackends(nil)
 	m.setSecrets(nil)
 	m.setRemoteSecrets(nil)
 	m.setRemoteApplications(nil)
@@ -211,26 +358,54 @@ func NewModel(args ModelArgs) Model {
 // Serialize mirrors the Deserialize method, and makes sure that
 // the same serialization method is used.
 func Serialize(model Model) ([]byte, error) {
-	return yaml.Marshal(model)
+	if synchronized, ok := model.(*synchronizedModel); ok {
+		return synchronized.serialize()
+	}
+	return marshalYAML(model)
 }
 
 // Deserialize constructs a Model from a serialized YAML byte stream. The
 // normal use for this is to construct the Model representation after getting
-// the byte stream from an API connection or read from a file.
-func Deserialize(bytes []byte) (Model, error) {
+// the byte stream from an API connection or read from a file. If the model
+// was serialized with an integrity section, its sections are checked
+// against it before the model is imported.
+func Deserialize(bytes []byte, options ...ImportOption) (Model, error) {
 	var source map[string]interface{}
 	err := yaml.Unmarshal(bytes, &source)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	model, err := importModel(source)
+	model, err := importModel(source, options...)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return model, nil
 }
 
+// ValidateDocument reports whether a serialized model would import cleanly,
+// without returning the model. The document is imported as Deserialize
+// would import it, with the same options, and the model is then validated.
+// The parsed source of each section is dropped as soon as the section has
+// been imported, so the whole document and the whole model aren't held in
+// memory together. A logger given by WithLogger is also given how long
+// each part of the validation took, and the failure that stopped it.
+func ValidateDocument(bytes []byte, options ...ImportOption) error {
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return errors.Trace(err)
+	}
+
+	options = append([]ImportOption{releaseSections()}, options...)
+	model, err := importModel(source, options...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errs := validationErrors{logger: newImportOptions(options).logger, last: time.Now()}
+	model.validate(&errs)
+	return errors.Trace(errs.first())
+}
+
 // parseLinkLayerDeviceGlobalKey is used to validate that the parent device
 // referenced by a LinkLayerDevice exists. Copied from state to avoid exporting
 // and will be replaced by device.ParentMachineID() at some point.
@@ -269,12 +444,15 @@ type model struct {
 	Config_ map[string]interface{} `yaml:"config"`
 	Blocks_ map[string]string      `yaml:"blocks,omitempty"`
 
+	Description_ string `yaml:"description,omitempty"`
+
 	LatestToolsVersion_ version.Number `yaml:"latest-tools,omitempty"`
 	EnvironVersion_     int            `yaml:"environ-version"`
 
 	Users_               users               `yaml:"users"`
 	Machines_            machines            `yaml:"machines"`
 	Applications_        applications        `yaml:"applications"`
+	Charms_              charms              `yaml:"charms"`
 	Relations_           relations           `yaml:"relations"`
 	RemoteEntities_      remoteEntities      `yaml:"remote-entities"`
 	RelationNetworks_    relationNetworks    `yaml:"relation-networks"`
@@ -293,11 +471,15 @@ type model struct {
 	Actions_    actions    `yaml:"actions"`
 	Operations_ operations `yaml:"operations"`
 
-	SSHHostKeys_ sshHostKeys `yaml:"ssh-host-keys"`
+	SSHHostKeys_     sshHostKeys     `yaml:"ssh-host-keys"`
+	VirtualHostKeys_ virtualHostKeys `yaml:"virtual-host-keys"`
 
 	Sequences_ map[string]int `yaml:"sequences"`
 
 	Annotations_ `yaml:"annotations,omitempty"`
+	// EntityAnnotations_ holds the annotations of the entities that don't
+	// hold their own, keyed by tag.
+	EntityAnnotations_ map[string]map[string]string `yaml:"entity-annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
@@ -314,14 +496,35 @@ type model struct {
 
 	RemoteApplications_ remoteApplications `yaml:"remote-applications"`
 
-	SecretBackendID_ string        `yaml:"secret-backend-id,omitempty"`
-	Secrets_         secrets       `yaml:"secrets"`
-	RemoteSecrets_   remoteSecrets `yaml:"remote-secrets"`
+	SecretBackendID_ string         `yaml:"secret-backend-id,omitempty"`
+	SecretBackends_  secretBackends `yaml:"secret-backends"`
+	Secrets_         secrets        `yaml:"secrets"`
+	RemoteSecrets_   remoteSecrets  `yaml:"remote-secrets"`
 
 	SLA_         sla         `yaml:"sla"`
 	MeterStatus_ meterStatus `yaml:"meter-status"`
+	Telemetry_   *telemetry  `yaml:"telemetry,omitempty"`
+
+	MigrationAttempt_ *migrationAttempt `yaml:"migration-attempt,omitempty"`
+	Lease_            *lease            `yaml:"lease,omitempty"`
+
+	PasswordHash_          string `yaml:"password-hash,omitempty"`
+	PasswordHashAlgorithm_ string `yaml:"password-hash-algorithm,omitempty"`
+
+	// Labels_ are the labels of the document the model is written in.
+	Labels_ map[string]string `yaml:"labels,omitempty"`
 
-	PasswordHash_ string `yaml:"password-hash,omitempty"`
+	// UnknownFields_ are the fields of the model that weren't known when
+	// it was imported with PreserveUnknownFields.
+	UnknownFields_ map[string]interface{} `yaml:",inline"`
+
+	// sourceVersion is the earlier version the model was upgraded from on
+	// import, or zero if it wasn't upgraded, and sourceSectionVersions
+	// are the versions its sections were imported at.
+	sourceVersion         int
+	sourceSectionVersions map[string]int
+
+	frozen bool
 }
 
 // AgentVersion returns the current agent version in use the by the model.
@@ -357,6 +560,7 @@ func (m *model) Config() map[string]interface{} {
 
 // UpdateConfig implements Model.
 func (m *model) UpdateConfig(config map[string]interface{}) {
+	m.checkMutable()
 	for key, value := range config {
 		m.Config_[key] = value
 	}
@@ -367,6 +571,18 @@ func (m *model) PasswordHash() string {
 	return m.PasswordHash_
 }
 
+// PasswordHashAlgorithm implements Model.
+func (m *model) PasswordHashAlgorithm() string {
+	return m.PasswordHashAlgorithm_
+}
+
+// SetPasswordHash implements Model.
+func (m *model) SetPasswordHash(hash, algorithm string) {
+	m.checkMutable()
+	m.PasswordHash_ = hash
+	m.PasswordHashAlgorithm_ = algorithm
+}
+
 // LatestToolsVersion implements Model.
 func (m *model) LatestToolsVersion() version.Number {
 	return m.LatestToolsVersion_
@@ -387,7 +603,7 @@ func (m *model) Blocks() map[string]string {
 type ByName []User
 
 func (a ByName) Len() int           { return len(a) }
-func (a ByName) Less(i, j int) bool { return a[i].Name().Id() < a[j].Name().Id() }
+func (a ByName) Less(i, j int) bool { return naturalLess(a[i].Name().Id(), a[j].Name().Id()) }
 func (a ByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 // Users implements Model.
@@ -402,12 +618,13 @@ func (m *model) Users() []User {
 
 // AddUser implements Model.
 func (m *model) AddUser(args UserArgs) {
-	m.Users_.Users_ = append(m.Users_.Users_, newUser(args))
+	m.checkMutable()
+	m.Users_.Users_ = insertByKey(m.Users_.Users_, newUser(args), userKey)
 }
 
 func (m *model) setUsers(userList []*user) {
 	m.Users_ = users{
-		Version: 1,
+		Version: 2,
 		Users_:  userList,
 	}
 }
@@ -423,9 +640,28 @@ func (m *model) Status() Status {
 
 // SetStatus implements Model.
 func (m *model) SetStatus(args StatusArgs) {
+	m.checkMutable()
 	m.Status_ = newStatus(args)
 }
 
+// SetStatusHistory implements Model.
+func (m *model) SetStatusHistory(args []StatusArgs) {
+	m.checkMutable()
+	m.StatusHistory_.SetStatusHistory(args)
+}
+
+// AppendStatusHistory implements Model.
+func (m *model) AppendStatusHistory(args ...StatusArgs) {
+	m.checkMutable()
+	m.StatusHistory_.AppendStatusHistory(args...)
+}
+
+// SetAnnotations implements Model.
+func (m *model) SetAnnotations(annotations map[string]string) {
+	m.checkMutable()
+	m.Annotations_.SetAnnotations(annotations)
+}
+
 // Machines implements Model.
 func (m *model) Machines() []Machine {
 	var result []Machine
@@ -437,20 +673,22 @@ func (m *model) Machines() []Machine {
 
 // AddMachine implements Model.
 func (m *model) AddMachine(args MachineArgs) Machine {
+	m.checkMutable()
 	machine := newMachine(args)
-	m.Machines_.Machines_ = append(m.Machines_.Machines_, machine)
+	m.Machines_.Machines_ = insertByKey(m.Machines_.Machines_, machine, machineKey)
 	return machine
 }
 
 func (m *model) setMachines(machineList []*machine) {
 	m.Machines_ = machines{
-		Version:   3,
+		Version:   7,
 		Machines_: machineList,
 	}
 }
 
 // AddBlockDevice adds a block device for the specified machine.
 func (m *model) AddBlockDevice(machineId string, bdArgs BlockDeviceArgs) error {
+	m.checkMutable()
 	for i := range m.Machines_.Machines_ {
 		if m.Machines_.Machines_[i].Id_ != machineId {
 			continue
@@ -470,6 +708,32 @@ func (m *model) Applications() []Application {
 	return result
 }
 
+// ApplicationNames implements Model.
+func (m *model) ApplicationNames() []string {
+	result := make([]string, 0, len(m.Applications_.Applications_))
+	for _, application := range m.Applications_.Applications_ {
+		result = append(result, application.Name())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return naturalLess(result[i], result[j])
+	})
+	return result
+}
+
+// UnitNames implements Model.
+func (m *model) UnitNames() []string {
+	var result []string
+	for _, application := range m.Applications_.Applications_ {
+		for _, unit := range application.Units_.Units_ {
+			result = append(result, unit.Name())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return naturalLess(result[i], result[j])
+	})
+	return result
+}
+
 func (m *model) application(name string) *application {
 	for _, application := range m.Applications_.Applications_ {
 		if application.Name() == name {
@@ -481,14 +745,15 @@ func (m *model) application(name string) *application {
 
 // AddApplication implements Model.
 func (m *model) AddApplication(args ApplicationArgs) Application {
+	m.checkMutable()
 	application := newApplication(args)
-	m.Applications_.Applications_ = append(m.Applications_.Applications_, application)
+	m.Applications_.Applications_ = insertByKey(m.Applications_.Applications_, application, applicationKey)
 	return application
 }
 
 func (m *model) setApplications(applicationList []*application) {
 	m.Applications_ = applications{
-		Version:       13,
+		Version:       16,
 		Applications_: applicationList,
 	}
 }
@@ -504,14 +769,15 @@ func (m *model) Relations() []Relation {
 
 // AddRelation implements Model.
 func (m *model) AddRelation(args RelationArgs) Relation {
+	m.checkMutable()
 	relation := newRelation(args)
-	m.Relations_.Relations_ = append(m.Relations_.Relations_, relation)
+	m.Relations_.Relations_ = insertByKey(m.Relations_.Relations_, relation, relationKey)
 	return relation
 }
 
 func (m *model) setRelations(relationList []*relation) {
 	m.Relations_ = relations{
-		Version:    3,
+		Version:    4,
 		Relations_: relationList,
 	}
 }
@@ -527,8 +793,9 @@ func (m *model) RemoteEntities() []RemoteEntity {
 
 // AddRemoteEntity implements Model.
 func (m *model) AddRemoteEntity(args RemoteEntityArgs) RemoteEntity {
+	m.checkMutable()
 	remoteEntity := newRemoteEntity(args)
-	m.RemoteEntities_.RemoteEntities = append(m.RemoteEntities_.RemoteEntities, remoteEntity)
+	m.RemoteEntities_.RemoteEntities = insertByKey(m.RemoteEntities_.RemoteEntities, remoteEntity, remoteEntityKey)
 	return remoteEntity
 }
 
@@ -548,16 +815,37 @@ func (m *model) RelationNetworks() []RelationNetwork {
 	return result
 }
 
+// RelationIngressNetworks implements Model.
+func (m *model) RelationIngressNetworks() []RelationNetwork {
+	return m.relationNetworksInDirection(RelationNetworkIngress)
+}
+
+// RelationEgressNetworks implements Model.
+func (m *model) RelationEgressNetworks() []RelationNetwork {
+	return m.relationNetworksInDirection(RelationNetworkEgress)
+}
+
+func (m *model) relationNetworksInDirection(direction string) []RelationNetwork {
+	var result []RelationNetwork
+	for _, network := range m.RelationNetworks() {
+		if network.Direction() == direction {
+			result = append(result, network)
+		}
+	}
+	return result
+}
+
 // AddRelationNetwork implements Model.
 func (m *model) AddRelationNetwork(args RelationNetworkArgs) RelationNetwork {
+	m.checkMutable()
 	network := newRelationNetwork(args)
-	m.RelationNetworks_.RelationNetworks = append(m.RelationNetworks_.RelationNetworks, network)
+	m.RelationNetworks_.RelationNetworks = insertByKey(m.RelationNetworks_.RelationNetworks, network, relationNetworkKey)
 	return network
 }
 
 func (m *model) setRelationNetworks(relationNetworkList []*relationNetwork) {
 	m.RelationNetworks_ = relationNetworks{
-		Version:          1,
+		Version:          2,
 		RelationNetworks: relationNetworkList,
 	}
 }
@@ -573,14 +861,15 @@ func (m *model) Spaces() []Space {
 
 // AddSpace implements Model.
 func (m *model) AddSpace(args SpaceArgs) Space {
+	m.checkMutable()
 	space := newSpace(args)
-	m.Spaces_.Spaces_ = append(m.Spaces_.Spaces_, space)
+	m.Spaces_.Spaces_ = insertByKey(m.Spaces_.Spaces_, space, spaceKey)
 	return space
 }
 
 func (m *model) setSpaces(spaceList []*space) {
 	m.Spaces_ = spaces{
-		Version: 2,
+		Version: 3,
 		Spaces_: spaceList,
 	}
 }
@@ -596,14 +885,17 @@ func (m *model) LinkLayerDevices() []LinkLayerDevice {
 
 // AddLinkLayerDevice implements Model.
 func (m *model) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	m.checkMutable()
 	device := newLinkLayerDevice(args)
-	m.LinkLayerDevices_.LinkLayerDevices_ = append(m.LinkLayerDevices_.LinkLayerDevices_, device)
+	m.LinkLayerDevices_.LinkLayerDevices_ = insertByKey(m.LinkLayerDevices_.LinkLayerDevices_, device, func(d *linklayerdevice) string {
+		return linkLayerDeviceKey(d)
+	})
 	return device
 }
 
 func (m *model) setLinkLayerDevices(devicesList []*linklayerdevice) {
 	m.LinkLayerDevices_ = linklayerdevices{
-		Version:           1,
+		Version:           3,
 		LinkLayerDevices_: devicesList,
 	}
 }
@@ -617,16 +909,28 @@ func (m *model) Subnets() []Subnet {
 	return result
 }
 
+// SubnetsInSpace implements Model.
+func (m *model) SubnetsInSpace(spaceID string) []Subnet {
+	var result []Subnet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		if subnet.SpaceID() == spaceID {
+			result = append(result, subnet)
+		}
+	}
+	return result
+}
+
 // AddSubnet implements Model.
 func (m *model) AddSubnet(args SubnetArgs) Subnet {
+	m.checkMutable()
 	subnet := newSubnet(args)
-	m.Subnets_.Subnets_ = append(m.Subnets_.Subnets_, subnet)
+	m.Subnets_.Subnets_ = insertByKey(m.Subnets_.Subnets_, subnet, subnetKey)
 	return subnet
 }
 
 func (m *model) setSubnets(subnetList []*subnet) {
 	m.Subnets_ = subnets{
-		Version:  6,
+		Version:  7,
 		Subnets_: subnetList,
 	}
 }
@@ -642,8 +946,11 @@ func (m *model) IPAddresses() []IPAddress {
 
 // AddIPAddress implements Model.
 func (m *model) AddIPAddress(args IPAddressArgs) IPAddress {
+	m.checkMutable()
 	addr := newIPAddress(args)
-	m.IPAddresses_.IPAddresses_ = append(m.IPAddresses_.IPAddresses_, addr)
+	m.IPAddresses_.IPAddresses_ = insertByKey(m.IPAddresses_.IPAddresses_, addr, func(a *ipaddress) string {
+		return ipAddressKey(a)
+	})
 	return addr
 }
 
@@ -665,8 +972,9 @@ func (m *model) SSHHostKeys() []SSHHostKey {
 
 // AddSSHHostKey implements Model.
 func (m *model) AddSSHHostKey(args SSHHostKeyArgs) SSHHostKey {
+	m.checkMutable()
 	addr := newSSHHostKey(args)
-	m.SSHHostKeys_.SSHHostKeys_ = append(m.SSHHostKeys_.SSHHostKeys_, addr)
+	m.SSHHostKeys_.SSHHostKeys_ = insertByKey(m.SSHHostKeys_.SSHHostKeys_, addr, sshHostKeyKey)
 	return addr
 }
 
@@ -706,44 +1014,74 @@ func (m *model) Operations() []Operation {
 
 // AddCloudImageMetadata implements Model.
 func (m *model) AddCloudImageMetadata(args CloudImageMetadataArgs) CloudImageMetadata {
+	m.checkMutable()
 	md := newCloudImageMetadata(args)
-	m.CloudImageMetadata_.CloudImageMetadata_ = append(m.CloudImageMetadata_.CloudImageMetadata_, md)
+	m.CloudImageMetadata_.CloudImageMetadata_ = insertByKey(m.CloudImageMetadata_.CloudImageMetadata_, md, imageMetadataKey)
 	return md
 }
 
 func (m *model) setCloudImageMetadatas(cloudimagemetadataList []*cloudimagemetadata) {
 	m.CloudImageMetadata_ = cloudimagemetadataset{
-		Version:             2,
+		Version:             3,
 		CloudImageMetadata_: cloudimagemetadataList,
 	}
 }
 
 // AddAction implements Model.
 func (m *model) AddAction(args ActionArgs) Action {
+	m.checkMutable()
 	addr := newAction(args)
-	m.Actions_.Actions_ = append(m.Actions_.Actions_, addr)
+	m.Actions_.Actions_ = insertByKey(m.Actions_.Actions_, addr, actionKey)
+	for _, op := range m.Operations_.Operations_ {
+		if op.Id_ == addr.Operation_ {
+			op.actions = append(op.actions, addr)
+		}
+	}
 	return addr
 }
 
 func (m *model) setActions(actionsList []*action) {
 	m.Actions_ = actions{
-		Version:  4,
+		Version:  5,
 		Actions_: actionsList,
 	}
+	m.linkOperationActions()
 }
 
 // AddOperation implements Model.
 func (m *model) AddOperation(args OperationArgs) Operation {
+	m.checkMutable()
 	op := newOperation(args)
-	m.Operations_.Operations_ = append(m.Operations_.Operations_, op)
+	m.Operations_.Operations_ = insertByKey(m.Operations_.Operations_, op, operationKey)
+	for _, action := range m.Actions_.Actions_ {
+		if action.Operation_ == op.Id_ {
+			op.actions = append(op.actions, action)
+		}
+	}
 	return op
 }
 
 func (m *model) setOperations(operationsList []*operation) {
 	m.Operations_ = operations{
-		Version:     2,
+		Version:     4,
 		Operations_: operationsList,
 	}
+	m.linkOperationActions()
+}
+
+// linkOperationActions links each operation to the actions spawned as its
+// tasks, replacing any links it had.
+func (m *model) linkOperationActions() {
+	byId := make(map[string]*operation)
+	for _, op := range m.Operations_.Operations_ {
+		op.actions = nil
+		byId[op.Id_] = op
+	}
+	for _, action := range m.Actions_.Actions_ {
+		if op, ok := byId[action.Operation_]; ok {
+			op.actions = append(op.actions, action)
+		}
+	}
 }
 
 // Sequences implements Model.
@@ -753,6 +1091,7 @@ func (m *model) Sequences() map[string]int {
 
 // SetSequence implements Model.
 func (m *model) SetSequence(name string, value int) {
+	m.checkMutable()
 	m.Sequences_[name] = value
 }
 
@@ -766,6 +1105,7 @@ func (m *model) Constraints() Constraints {
 
 // SetConstraints implements HasConstraints.
 func (m *model) SetConstraints(args ConstraintsArgs) {
+	m.checkMutable()
 	m.Constraints_ = newConstraints(args)
 }
 
@@ -779,6 +1119,11 @@ func (m *model) CloudRegion() string {
 	return m.CloudRegion_
 }
 
+// Description implements Model.
+func (m *model) Description() string {
+	return m.Description_
+}
+
 // CloudCredential implements Model.
 func (m *model) CloudCredential() CloudCredential {
 	if m.CloudCredential_ == nil {
@@ -789,11 +1134,13 @@ func (m *model) CloudCredential() CloudCredential {
 
 // SetCloudCredential implements Model.
 func (m *model) SetCloudCredential(args CloudCredentialArgs) {
+	m.checkMutable()
 	m.CloudCredential_ = newCloudCredential(args)
 }
 
 // SetSLA implements Model.
 func (m *model) SetSLA(level, owner, creds string) SLA {
+	m.checkMutable()
 	m.SLA_ = sla{
 		Level_:       level,
 		Owner_:       owner,
@@ -804,6 +1151,7 @@ func (m *model) SetSLA(level, owner, creds string) SLA {
 
 // SetMeterStatus implements Model.
 func (m *model) SetMeterStatus(code, info string) MeterStatus {
+	m.checkMutable()
 	m.MeterStatus_ = meterStatus{
 		Code_: code,
 		Info_: info,
@@ -821,6 +1169,54 @@ func (m *model) MeterStatus() MeterStatus {
 	return m.MeterStatus_
 }
 
+// SetTelemetry implements Model.
+func (m *model) SetTelemetry(args TelemetryArgs) Telemetry {
+	m.checkMutable()
+	m.Telemetry_ = newTelemetry(args)
+	return m.Telemetry_
+}
+
+// SetMigrationAttempt implements Model.
+func (m *model) SetMigrationAttempt(args MigrationAttemptArgs) MigrationAttempt {
+	m.checkMutable()
+	m.MigrationAttempt_ = newMigrationAttempt(args)
+	return m.MigrationAttempt_
+}
+
+// MigrationAttempt implements Model.
+func (m *model) MigrationAttempt() MigrationAttempt {
+	// To avoid typed nils check nil here.
+	if m.MigrationAttempt_ == nil {
+		return nil
+	}
+	return m.MigrationAttempt_
+}
+
+// SetLease implements Model.
+func (m *model) SetLease(args LeaseArgs) Lease {
+	m.checkMutable()
+	m.Lease_ = newLease(args)
+	return m.Lease_
+}
+
+// Lease implements Model.
+func (m *model) Lease() Lease {
+	// To avoid typed nils check nil here.
+	if m.Lease_ == nil {
+		return nil
+	}
+	return m.Lease_
+}
+
+// Telemetry implements Model.
+func (m *model) Telemetry() Telemetry {
+	// To avoid typed nils check nil here.
+	if m.Telemetry_ == nil {
+		return nil
+	}
+	return m.Telemetry_
+}
+
 // Volumes implements Model.
 func (m *model) Volumes() []Volume {
 	var result []Volume
@@ -832,14 +1228,15 @@ func (m *model) Volumes() []Volume {
 
 // AddVolume implements Model.
 func (m *model) AddVolume(args VolumeArgs) Volume {
+	m.checkMutable()
 	volume := newVolume(args)
-	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	m.Volumes_.Volumes_ = insertByKey(m.Volumes_.Volumes_, volume, volumeKey)
 	return volume
 }
 
 func (m *model) setVolumes(volumeList []*volume) {
 	m.Volumes_ = volumes{
-		Version:  1,
+		Version:  4,
 		Volumes_: volumeList,
 	}
 }
@@ -855,18 +1252,43 @@ func (m *model) Filesystems() []Filesystem {
 
 // AddFilesystem implemets Model.
 func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	m.checkMutable()
 	filesystem := newFilesystem(args)
-	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	m.Filesystems_.Filesystems_ = insertByKey(m.Filesystems_.Filesystems_, filesystem, filesystemKey)
 	return filesystem
 }
 
 func (m *model) setFilesystems(filesystemList []*filesystem) {
 	m.Filesystems_ = filesystems{
-		Version:      1,
+		Version:      2,
 		Filesystems_: filesystemList,
 	}
 }
 
+// Charms implements Model.
+func (m *model) Charms() []Charm {
+	var result []Charm
+	for _, charm := range m.Charms_.Charms_ {
+		result = append(result, charm)
+	}
+	return result
+}
+
+// AddCharm implements Model.
+func (m *model) AddCharm(args CharmArgs) Charm {
+	m.checkMutable()
+	charm := newCharm(args)
+	m.Charms_.Charms_ = insertByKey(m.Charms_.Charms_, charm, charmKey)
+	return charm
+}
+
+func (m *model) setCharms(charmList []*charm) {
+	m.Charms_ = charms{
+		Version: 1,
+		Charms_: charmList,
+	}
+}
+
 func (m *model) FirewallRules() []FirewallRule {
 	var result []FirewallRule
 	for _, firewallRule := range m.FirewallRules_.FirewallRules {
@@ -876,8 +1298,9 @@ func (m *model) FirewallRules() []FirewallRule {
 }
 
 func (m *model) AddFirewallRule(args FirewallRuleArgs) FirewallRule {
+	m.checkMutable()
 	firewallRule := newFirewallRule(args)
-	m.FirewallRules_.FirewallRules = append(m.FirewallRules_.FirewallRules, firewallRule)
+	m.FirewallRules_.FirewallRules = insertByKey(m.FirewallRules_.FirewallRules, firewallRule, firewallRuleKey)
 	return firewallRule
 }
 
@@ -899,14 +1322,15 @@ func (m *model) Storages() []Storage {
 
 // AddStorage implemets Model.
 func (m *model) AddStorage(args StorageArgs) Storage {
+	m.checkMutable()
 	storage := newStorage(args)
-	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	m.Storages_.Storages_ = insertByKey(m.Storages_.Storages_, storage, storageKey)
 	return storage
 }
 
 func (m *model) setStorages(storageList []*storage) {
 	m.Storages_ = storages{
-		Version:   3,
+		Version:   5,
 		Storages_: storageList,
 	}
 }
@@ -922,8 +1346,9 @@ func (m *model) StoragePools() []StoragePool {
 
 // AddStoragePool implemets Model.
 func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	m.checkMutable()
 	pool := newStoragePool(args)
-	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	m.StoragePools_.Pools_ = insertByKey(m.StoragePools_.Pools_, pool, storagePoolKey)
 	return pool
 }
 
@@ -934,11 +1359,59 @@ func (m *model) setStoragePools(poolList []*storagepool) {
 	}
 }
 
+// VirtualHostKeys implements Model.
+func (m *model) VirtualHostKeys() []VirtualHostKey {
+	var result []VirtualHostKey
+	for _, key := range m.VirtualHostKeys_.VirtualHostKeys_ {
+		result = append(result, key)
+	}
+	return result
+}
+
+// AddVirtualHostKey implements Model.
+func (m *model) AddVirtualHostKey(args VirtualHostKeyArgs) VirtualHostKey {
+	m.checkMutable()
+	key := newVirtualHostKey(args)
+	m.VirtualHostKeys_.VirtualHostKeys_ = insertByKey(m.VirtualHostKeys_.VirtualHostKeys_, key, virtualHostKeyKey)
+	return key
+}
+
+func (m *model) setVirtualHostKeys(keyList []*virtualHostKey) {
+	m.VirtualHostKeys_ = virtualHostKeys{
+		Version:          1,
+		VirtualHostKeys_: keyList,
+	}
+}
+
 // SecretBackendID implements Model.
 func (m *model) SecretBackendID() string {
 	return m.SecretBackendID_
 }
 
+// SecretBackends implements Model.
+func (m *model) SecretBackends() []SecretBackend {
+	var result []SecretBackend
+	for _, backend := range m.SecretBackends_.SecretBackends_ {
+		result = append(result, backend)
+	}
+	return result
+}
+
+// AddSecretBackend implements Model.
+func (m *model) AddSecretBackend(args SecretBackendArgs) SecretBackend {
+	m.checkMutable()
+	backend := newSecretBackend(args)
+	m.SecretBackends_.SecretBackends_ = insertByKey(m.SecretBackends_.SecretBackends_, backend, secretBackendKey)
+	return backend
+}
+
+func (m *model) setSecretBackends(backendList []*secretBackend) {
+	m.SecretBackends_ = secretBackends{
+		Version:         1,
+		SecretBackends_: backendList,
+	}
+}
+
 // Secrets implements Model.
 func (m *model) Secrets() []Secret {
 	var result []Secret
@@ -950,14 +1423,15 @@ func (m *model) Secrets() []Secret {
 
 // AddSecret implements Model.
 func (m *model) AddSecret(args SecretArgs) Secret {
+	m.checkMutable()
 	secret := newSecret(args)
-	m.Secrets_.Secrets_ = append(m.Secrets_.Secrets_, secret)
+	m.Secrets_.Secrets_ = insertByKey(m.Secrets_.Secrets_, secret, secretKey)
 	return secret
 }
 
 func (m *model) setSecrets(secretList []*secret) {
 	m.Secrets_ = secrets{
-		Version:  2,
+		Version:  3,
 		Secrets_: secretList,
 	}
 }
@@ -973,8 +1447,9 @@ func (m *model) RemoteSecrets() []RemoteSecret {
 
 // AddRemoteSecret implements Model.
 func (m *model) AddRemoteSecret(args RemoteSecretArgs) RemoteSecret {
+	m.checkMutable()
 	remoteSecret := newRemoteSecret(args)
-	m.RemoteSecrets_.RemoteSecrets_ = append(m.RemoteSecrets_.RemoteSecrets_, remoteSecret)
+	m.RemoteSecrets_.RemoteSecrets_ = insertByKey(m.RemoteSecrets_.RemoteSecrets_, remoteSecret, remoteSecretKey)
 	return remoteSecret
 }
 
@@ -1005,8 +1480,9 @@ func (m *model) remoteApplication(name string) *remoteApplication {
 
 // AddRemoteApplication implements Model.
 func (m *model) AddRemoteApplication(args RemoteApplicationArgs) RemoteApplication {
+	m.checkMutable()
 	app := newRemoteApplication(args)
-	m.RemoteApplications_.RemoteApplications = append(m.RemoteApplications_.RemoteApplications, app)
+	m.RemoteApplications_.RemoteApplications = insertByKey(m.RemoteApplications_.RemoteApplications, app, remoteApplicationKey)
 	return app
 }
 
@@ -1028,11 +1504,13 @@ func (m *model) OfferConnections() []OfferConnection {
 }
 
 // AddOfferConnection adds a new offer connections to model
-// Adding the same offer connection multiple times will not de-dupe, uniquely
-// sorting them when getting them will be required.
+// Adding the same offer connection multiple times will not de-dupe it in
+// the model, but only the first connection to an offer for a relation is
+// serialized.
 func (m *model) AddOfferConnection(args OfferConnectionArgs) OfferConnection {
+	m.checkMutable()
 	offer := newOfferConnection(args)
-	m.OfferConnections_.OfferConnections = append(m.OfferConnections_.OfferConnections, offer)
+	m.OfferConnections_.OfferConnections = insertByKey(m.OfferConnections_.OfferConnections, offer, offerConnectionKey)
 	return offer
 }
 
@@ -1057,8 +1535,9 @@ func (m *model) ExternalControllers() []ExternalController {
 // Adding the same offer connection multiple times will not de-dupe, uniquely
 // sorting them when getting them will be required.
 func (m *model) AddExternalController(args ExternalControllerArgs) ExternalController {
+	m.checkMutable()
 	ctrl := newExternalController(args)
-	m.ExternalControllers_.ExternalControllers = append(m.ExternalControllers_.ExternalControllers, ctrl)
+	m.ExternalControllers_.ExternalControllers = insertByKey(m.ExternalControllers_.ExternalControllers, ctrl, externalControllerKey)
 	return ctrl
 }
 
@@ -1084,6 +1563,97 @@ type validationContext struct {
 	allUnits              set.Strings
 	unitsWithOpenPorts    set.Strings
 	unknownUnitsWithPorts set.Strings
+
+	// applicationUnits indexes the unit names of each application by the
+	// application's name, so that checking the relations doesn't scan the
+	// model for the application of each endpoint.
+	applicationUnits map[string]set.Strings
+}
+
+// addApplication records the application and its units, indexing them
+// unless an application of the same name has been indexed already.
+func (v *validationContext) addApplication(application *application) {
+	name := application.Name()
+	units := application.unitNames()
+	v.allApplications.Add(name)
+	for unit := range units {
+		v.allUnits.Add(unit)
+	}
+	if _, ok := v.applicationUnits[name]; !ok {
+		v.applicationUnits[name] = units
+	}
+}
+
+// validationErrors collects the failures found while validating a
+// model. Unless it is collecting all of them, validation stops at the
+// first failure.
+type validationErrors struct {
+	all    bool
+	errors []error
+
+	// logger, if set, is given each failure and how long each part of
+	// the validation took, at debug level, as ValidateDocument does with
+	// the logger given by WithLogger.
+	logger *slog.Logger
+	last   time.Time
+}
+
+// add records the failure, if there is one, of the entity at the path,
+// and returns true if validation should stop.
+func (v *validationErrors) add(path string, err error) bool {
+	if err == nil {
+		return false
+	}
+	if v.logger != nil {
+		v.logger.Debug("validation failure", "path", path, "error", err)
+	}
+	if v.all && path != "" {
+		err = errors.Annotate(err, path)
+	}
+	v.errors = append(v.errors, err)
+	return v.stopped()
+}
+
+// mark logs the time taken to validate the part of the model, as the time
+// since the previous part was validated.
+func (v *validationErrors) mark(part string) {
+	if v.logger == nil {
+		return
+	}
+	now := time.Now()
+	v.logger.Debug("validated section", "section", part, "duration", now.Sub(v.last))
+	v.last = now
+}
+
+// stopped returns true if validation should stop.
+func (v *validationErrors) stopped() bool {
+	return !v.all && len(v.errors) > 0
+}
+
+func (v *validationErrors) first() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors[0]
+}
+
+// ValidationErrors holds every failure found by Model.ValidateAll, each
+// annotated with the path of the entity that failed, such as
+// "applications[3].units[1]".
+type ValidationErrors []error
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the failures, for use with errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	return e
 }
 
 func newValidationContext() *validationContext {
@@ -1094,101 +1664,163 @@ func newValidationContext() *validationContext {
 		allUnits:              set.NewStrings(),
 		unitsWithOpenPorts:    set.NewStrings(),
 		unknownUnitsWithPorts: set.NewStrings(),
+		applicationUnits:      make(map[string]set.Strings),
 	}
 }
 
 // Validate implements Model.
 func (m *model) Validate() error {
+	var errs validationErrors
+	m.validate(&errs)
+	return errs.first()
+}
+
+// ValidateAll implements Model.
+func (m *model) ValidateAll() error {
+	errs := validationErrors{all: true}
+	m.validate(&errs)
+	if len(errs.errors) == 0 {
+		return nil
+	}
+	return ValidationErrors(errs.errors)
+}
+
+func (m *model) validate(errs *validationErrors) {
 	// A model needs an owner.
 	if m.Owner_ == "" {
-		return errors.NotValidf("missing model owner")
+		if errs.add("", errors.NotValidf("missing model owner")) {
+			return
+		}
 	}
 	if m.Status_ == nil {
-		return errors.NotValidf("missing status")
+		if errs.add("", errors.NotValidf("missing status")) {
+			return
+		}
 	}
 
 	if m.AgentVersion_ != "" {
 		agentVersion, err := version.Parse(m.AgentVersion_)
 		if err != nil {
-			return errors.Annotate(err, "agent version not parsable")
+			if errs.add("", errors.Annotate(err, "agent version not parsable")) {
+				return
+			}
 		} else if agentVersion == version.Zero {
-			return errors.NotValidf("agent version cannot be zero")
+			if errs.add("", errors.NotValidf("agent version cannot be zero")) {
+				return
+			}
 		}
 	}
 
+	if errs.add("", m.validateModelType()) {
+		return
+	}
+	if errs.add("", m.validatePasswordHash()) {
+		return
+	}
+
+	errs.mark("model")
+
 	validationCtx := newValidationContext()
-	for _, machine := range m.Machines_.Machines_ {
-		if err := m.validateMachine(validationCtx, machine); err != nil {
-			return errors.Trace(err)
+	for i, machine := range m.Machines_.Machines_ {
+		if m.validateMachine(validationCtx, errs, fmt.Sprintf("machines[%d]", i), machine) {
+			return
 		}
 	}
-	for _, application := range m.Applications_.Applications_ {
-		if err := application.Validate(); err != nil {
-			return errors.Trace(err)
+	errs.mark("machines")
+	for i, application := range m.Applications_.Applications_ {
+		path := fmt.Sprintf("applications[%d]", i)
+		if application.validate(errs, path); errs.stopped() {
+			return
 		}
 		for unitName := range application.OpenedPortRanges().ByUnit() {
 			validationCtx.unitsWithOpenPorts.Add(unitName)
 		}
-		validationCtx.allApplications.Add(application.Name())
-		validationCtx.allUnits = validationCtx.allUnits.Union(application.unitNames())
+		validationCtx.addApplication(application)
 	}
+	errs.mark("applications")
 	// Make sure that all the unit names specified in machine opened ports
 	// exist as units of applications.
 	unknownUnitsWithPorts := validationCtx.unitsWithOpenPorts.Difference(validationCtx.allUnits)
 	if len(unknownUnitsWithPorts) > 0 {
-		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
+		if errs.add("", errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())) {
+			return
+		}
 	}
 	for _, application := range m.RemoteApplications_.RemoteApplications {
 		validationCtx.allRemoteApplications.Add(application.Name())
 	}
 
-	err := m.validateRelations()
-	if err != nil {
-		return errors.Trace(err)
+	if errs.add("", m.validateRelations(validationCtx)) {
+		return
 	}
+	errs.mark("relations")
 
-	err = m.validateSubnets()
-	if err != nil {
-		return errors.Trace(err)
+	for i, controller := range m.ExternalControllers_.ExternalControllers {
+		if errs.add(fmt.Sprintf("external-controllers[%d]", i), controller.Validate()) {
+			return
+		}
 	}
 
-	err = m.validateLinkLayerDevices()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	err = m.validateAddresses()
-	if err != nil {
-		return errors.Trace(err)
+	for _, check := range []func() error{
+		m.validateCharms,
+		m.validateSubnets,
+		m.validateSubnetCIDRs,
+		m.validateSpaceReferences,
+		m.validateLinkLayerDevices,
+		m.validateAddresses,
+		func() error { return m.validateStorage(validationCtx) },
+		m.validateRelationNetworks,
+		func() error { return m.validateLeadership(validationCtx) },
+		m.validateEntityAnnotations,
+		m.validateMigrationAttempt,
+		m.validateLease,
+		m.validateOperations,
+		m.validateActions,
+		m.validateSecretBackends,
+		func() error { return m.validateSecrets(validationCtx) },
+		func() error { return m.validateVirtualHostKeys(validationCtx) },
+	} {
+		if errs.add("", check()) {
+			return
+		}
 	}
+	errs.mark("cross checks")
+}
 
-	err = m.validateStorage(validationCtx)
-	if err != nil {
-		return errors.Trace(err)
+// validateModelType makes sure that the entities in the model agree with
+// the type of the model. CAAS models don't have machines, and applications
+// must be of the same type as the model they are in.
+func (m *model) validateModelType() error {
+	if m.Type_ == CAAS && len(m.Machines_.Machines_) > 0 {
+		return errors.NotValidf("machine %q in CAAS model", m.Machines_.Machines_[0].Id_)
 	}
-
-	err = m.validateSecrets(validationCtx)
-	if err != nil {
-		return errors.Trace(err)
+	for _, application := range m.Applications_.Applications_ {
+		if m.Type_ == "" || application.Type_ == "" {
+			continue
+		}
+		if application.Type_ != m.Type_ {
+			return errors.NotValidf("%s application %q in %s model", application.Type_, application.Name_, m.Type_)
+		}
 	}
-
 	return nil
 }
 
-func (m *model) validateMachine(validationCtx *validationContext, machine Machine) error {
+// validateMachine records the failures of the machine and its
+// containers, and returns true if validation should stop.
+func (m *model) validateMachine(validationCtx *validationContext, errs *validationErrors, path string, machine Machine) bool {
 	if err := machine.Validate(); err != nil {
-		return errors.Trace(err)
+		return errs.add(path, errors.Trace(err))
 	}
 	validationCtx.allMachines.Add(machine.Id())
 	for unitName := range machine.OpenedPortRanges().ByUnit() {
 		validationCtx.unitsWithOpenPorts.Add(unitName)
 	}
-	for _, container := range machine.Containers() {
-		err := m.validateMachine(validationCtx, container)
-		if err != nil {
-			return errors.Trace(err)
+	for i, container := range machine.Containers() {
+		if m.validateMachine(validationCtx, errs, fmt.Sprintf("%s.containers[%d]", path, i), container) {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
 func (m *model) validateStorage(validationCtx *validationContext) error {
@@ -1256,16 +1888,70 @@ func (m *model) validateStorage(validationCtx *validationContext) error {
 	return nil
 }
 
-// validateSubnets makes sure that any spaces referenced by subnets exist.
+// validatePasswordHash checks that a password hash is printable, and
+// that its algorithm, if recorded, is known.
+func (m *model) validatePasswordHash() error {
+	if m.PasswordHash_ == "" {
+		if m.PasswordHashAlgorithm_ != "" {
+			return errors.NotValidf("password hash algorithm %q without password hash", m.PasswordHashAlgorithm_)
+		}
+		return nil
+	}
+	if !validPasswordHash(m.PasswordHash_) {
+		return errors.NotValidf("model password hash")
+	}
+	switch m.PasswordHashAlgorithm_ {
+	case "", PasswordHashSHA512, PasswordHashPBKDF2:
+		return nil
+	}
+	return errors.NotValidf("password hash algorithm %q", m.PasswordHashAlgorithm_)
+}
+
+// validateCharms checks that the charms are unique, and that every
+// application's charm is among them. Models exported before charms were
+// recorded have none, and aren't checked.
+func (m *model) validateCharms() error {
+	if len(m.Charms_.Charms_) == 0 {
+		return nil
+	}
+	urls := set.NewStrings()
+	for i, charm := range m.Charms_.Charms_ {
+		if charm.URL_ == "" {
+			return errors.NotValidf("charm[%d] missing url", i)
+		}
+		if urls.Contains(charm.URL_) {
+			return errors.NotValidf("charm[%d] duplicate url %q", i, charm.URL_)
+		}
+		urls.Add(charm.URL_)
+	}
+	for _, application := range m.Applications_.Applications_ {
+		if !urls.Contains(application.CharmURL_) {
+			return errors.NotValidf("application %q charm %q not in model charms", application.Name_, application.CharmURL_)
+		}
+	}
+	return nil
+}
+
+// validateSubnets makes sure that any spaces referenced by subnets exist,
+// and that the fan configuration of the subnets is consistent.
 func (m *model) validateSubnets() error {
 	spaceIDs := set.NewStrings()
+	providerIDs := set.NewStrings()
 	for _, space := range m.Spaces_.Spaces_ {
 		spaceIDs.Add(space.Id())
+		if space.ProviderID() == "" {
+			continue
+		}
+		if providerIDs.Contains(space.ProviderID()) {
+			return errors.NotValidf("space %q duplicate provider id %q", space.Name(), space.ProviderID())
+		}
+		providerIDs.Add(space.ProviderID())
 	}
 	for _, subnet := range m.Subnets_.Subnets_ {
-		// space "0" is the new, in juju 2.7, default space,
-		// created with each new model.
-		if subnet.SpaceID() == "" || subnet.SpaceID() == "0" {
+		if err := validateSubnetFan(subnet); err != nil {
+			return errors.Trace(err)
+		}
+		if IsDefaultSpace(subnet.SpaceID()) {
 			continue
 		}
 		if !spaceIDs.Contains(subnet.SpaceID()) {
@@ -1276,25 +1962,148 @@ func (m *model) validateSubnets() error {
 	return nil
 }
 
-func (m *model) validateSecrets(validationCtx *validationContext) error {
-	appsAndUnits := validationCtx.allApplications.Union(validationCtx.allUnits)
-
-	checkValidAppOrUnit := func(i int, entityName string, label string, entity names.Tag) error {
-		if entity.Kind() == names.ApplicationTagKind || entity.Kind() == names.UnitTagKind {
-			entityID := entity.Id()
-			if !appsAndUnits.Contains(entityID) {
-				return errors.NotValidf("%s[%d] %s (%s)", entityName, i, label, entityID)
+// validateSpaceReferences checks that the spaces applications bind their
+// endpoints to, and the spaces in constraints, are spaces of the model.
+// Bindings give spaces by id, or by name before juju 2.7, and constraints
+// give them by name, negated with a leading "^". The default space needn't
+// be one of the model's spaces.
+func (m *model) validateSpaceReferences() error {
+	known := set.NewStrings(DefaultSpaceName)
+	for _, space := range m.Spaces_.Spaces_ {
+		known.Add(space.Id_)
+		known.Add(space.Name_)
+	}
+	checkConstraints := func(owner string, constraints *constraints) error {
+		if constraints == nil {
+			return nil
+		}
+		for _, space := range constraints.Spaces_ {
+			if name := strings.TrimPrefix(space, "^"); !known.Contains(name) {
+				return errors.Errorf("%s constraints reference non-existent space %q", owner, name)
 			}
 		}
 		return nil
 	}
-	checkValidRemoteEntity := func(i int, label string, entity names.Tag) error {
-		valid := false
-		for _, app := range validationCtx.allRemoteApplications.Values() {
-			valid = entity.Kind() == names.ApplicationTagKind && app == entity.Id()
-			if !valid && entity.Kind() == names.UnitTagKind {
-				consumerApp, _ := names.UnitApplication(entity.Id())
-				valid = app == consumerApp
+
+	if err := checkConstraints("model", m.Constraints_); err != nil {
+		return errors.Trace(err)
+	}
+	for _, application := range m.Applications_.Applications_ {
+		owner := fmt.Sprintf("application %q", application.Name_)
+		if err := checkConstraints(owner, application.Constraints_); err != nil {
+			return errors.Trace(err)
+		}
+		endpoints := make([]string, 0, len(application.EndpointBindings_))
+		for endpoint := range application.EndpointBindings_ {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		for _, endpoint := range endpoints {
+			space := application.EndpointBindings_[endpoint]
+			if IsDefaultSpace(space) || known.Contains(space) {
+				continue
+			}
+			return errors.Errorf("%s endpoint %q bound to non-existent space %q", owner, endpoint, space)
+		}
+	}
+	var checkMachines func([]*machine) error
+	checkMachines = func(machines []*machine) error {
+		for _, machine := range machines {
+			if err := checkConstraints(fmt.Sprintf("machine %q", machine.Id_), machine.Constraints_); err != nil {
+				return errors.Trace(err)
+			}
+			if err := checkMachines(machine.Containers_); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	return checkMachines(m.Machines_.Machines_)
+}
+
+// validateSubnetCIDRs checks that the subnets of a space don't overlap,
+// and that no CIDR is used by subnets of different spaces. Either imports
+// cleanly, but breaks the networking of the target controller. Subnets
+// without a space are in the default space, and CIDRs that can't be parsed
+// are left alone.
+func (m *model) validateSubnetCIDRs() error {
+	type spaceNet struct {
+		cidr    string
+		spaceID string
+		ipNet   *net.IPNet
+	}
+	var seen []spaceNet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		_, ipNet, err := net.ParseCIDR(subnet.CIDR_)
+		if err != nil {
+			continue
+		}
+		spaceID := subnet.SpaceID_
+		if IsDefaultSpace(spaceID) {
+			spaceID = DefaultSpaceId
+		}
+		for _, other := range seen {
+			if other.spaceID != spaceID {
+				if other.ipNet.String() == ipNet.String() {
+					return errors.NotValidf("subnet %q in spaces %q and %q", subnet.CIDR_, other.spaceID, spaceID)
+				}
+				continue
+			}
+			if other.ipNet.Contains(ipNet.IP) || ipNet.Contains(other.ipNet.IP) {
+				return errors.NotValidf("subnet %q overlapping %q in space %q", subnet.CIDR_, other.cidr, spaceID)
+			}
+		}
+		seen = append(seen, spaceNet{cidr: subnet.CIDR_, spaceID: spaceID, ipNet: ipNet})
+	}
+	return nil
+}
+
+// validateSubnetFan checks that a fan subnet records both the underlay
+// and overlay networks, and that the subnet is part of the overlay.
+func validateSubnetFan(subnet *subnet) error {
+	underlay, overlay := subnet.FanLocalUnderlay_, subnet.FanOverlay_
+	if underlay == "" && overlay == "" {
+		return nil
+	}
+	if underlay == "" || overlay == "" {
+		return errors.NotValidf("subnet %q fan underlay %q and overlay %q", subnet.CIDR_, underlay, overlay)
+	}
+	if _, _, err := net.ParseCIDR(underlay); err != nil {
+		return errors.NotValidf("subnet %q fan underlay %q", subnet.CIDR_, underlay)
+	}
+	_, overlayNet, err := net.ParseCIDR(overlay)
+	if err != nil {
+		return errors.NotValidf("subnet %q fan overlay %q", subnet.CIDR_, overlay)
+	}
+	subnetIP, _, err := net.ParseCIDR(subnet.CIDR_)
+	if err != nil {
+		return errors.NotValidf("subnet CIDR %q", subnet.CIDR_)
+	}
+	if !overlayNet.Contains(subnetIP) {
+		return errors.NotValidf("subnet %q not in fan overlay %q", subnet.CIDR_, overlay)
+	}
+	return nil
+}
+
+func (m *model) validateSecrets(validationCtx *validationContext) error {
+	appsAndUnits := validationCtx.allApplications.Union(validationCtx.allUnits)
+
+	checkValidAppOrUnit := func(i int, entityName string, label string, entity names.Tag) error {
+		if entity.Kind() == names.ApplicationTagKind || entity.Kind() == names.UnitTagKind {
+			entityID := entity.Id()
+			if !appsAndUnits.Contains(entityID) {
+				return errors.NotValidf("%s[%d] %s (%s)", entityName, i, label, entityID)
+			}
+		}
+		return nil
+	}
+	checkValidRemoteEntity := func(i int, label string, entity names.Tag) error {
+		valid := false
+		for _, app := range validationCtx.allRemoteApplications.Values() {
+			valid = entity.Kind() == names.ApplicationTagKind && app == entity.Id()
+			if !valid && entity.Kind() == names.UnitTagKind {
+				consumerApp, _ := names.UnitApplication(entity.Id())
+				valid = app == consumerApp
 			}
 			if valid {
 				break
@@ -1349,6 +2158,7 @@ func (m *model) validateSecrets(validationCtx *validationContext) error {
 		}
 	}
 
+	sourceModels := m.remoteSourceModels()
 	for i, remoteSecret := range m.RemoteSecrets_.RemoteSecrets_ {
 		if err := remoteSecret.Validate(); err != nil {
 			return errors.Annotatef(err, "remote secret[%d]", i)
@@ -1360,11 +2170,373 @@ func (m *model) validateSecrets(validationCtx *validationContext) error {
 		if err := checkValidAppOrUnit(i, "remote secret", "consumer", consumer); err != nil {
 			return err
 		}
+		// A remote secret can only be resolved if the model it comes
+		// from is one we know how to reach.
+		if !sourceModels.Contains(remoteSecret.SourceUUID_) {
+			return errors.NotValidf("remote secret[%d] source (%s)", i, remoteSecret.SourceUUID_)
+		}
+	}
+
+	return nil
+}
+
+// validateRelationNetworks checks that the relation networks are valid, and
+// that none is an exact duplicate of another: the same CIDRs for the same
+// relation, direction and origin. NormalizeRelationNetworks removes
+// duplicates.
+func (m *model) validateRelationNetworks() error {
+	ids := set.NewStrings()
+	networks := m.RelationNetworks_.RelationNetworks
+	for i, network := range networks {
+		if err := network.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if ids.Contains(network.ID_) {
+			return errors.NotValidf("relation network %q duplicate id", network.ID_)
+		}
+		ids.Add(network.ID_)
+		for _, other := range networks[:i] {
+			if other.RelationKey_ == network.RelationKey_ &&
+				other.Direction_ == network.Direction_ &&
+				other.Origin_ == network.Origin_ &&
+				other.sameCIDRs(network) {
+				return errors.NotValidf("relation network %q duplicates %q", network.ID_, other.ID_)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLeadership checks leadership across the applications: each
+// application validates that its leader is one of its units, but a unit
+// mustn't lead more than one application, nor an application other than
+// its own, and the lease of an application's leadership must be held by
+// one of the units of the application it is named for.
+func (m *model) validateLeadership(validationCtx *validationContext) error {
+	leaders := make(map[string]string)
+	for _, application := range m.Applications_.Applications_ {
+		leader := application.Leader_
+		if leader == "" {
+			continue
+		}
+		if other, ok := leaders[leader]; ok {
+			return errors.NotValidf("unit %q leader of applications %q and %q", leader, other, application.Name())
+		}
+		leaders[leader] = application.Name()
+		owner, err := names.UnitApplication(leader)
+		if err != nil {
+			return errors.NotValidf("application %q leader %q", application.Name(), leader)
+		}
+		if owner != application.Name() {
+			return errors.NotValidf("application %q leader %q of application %q", application.Name(), leader, owner)
+		}
+	}
+	for _, application := range m.Applications_.Applications_ {
+		lease := application.Lease_
+		if lease == nil || lease.Holder_ == "" {
+			continue
+		}
+		if !validationCtx.applicationUnits[lease.Name_].Contains(lease.Holder_) {
+			return errors.NotValidf("application %q lease holder %q not a unit of application %q", application.Name(), lease.Holder_, lease.Name_)
+		}
+	}
+	return nil
+}
+
+// validateMigrationAttempt checks the migration attempt, if there is one.
+func (m *model) validateMigrationAttempt() error {
+	if m.MigrationAttempt_ == nil {
+		return nil
+	}
+	return m.MigrationAttempt_.validate()
+}
+
+// validateLease checks that the singular lease of the model, if there is
+// one, is named for the model.
+func (m *model) validateLease() error {
+	if m.Lease_ == nil {
+		return nil
+	}
+	if err := m.Lease_.validate(); err != nil {
+		return errors.Annotate(err, "model")
+	}
+	if m.Lease_.Name_ != m.Tag().Id() {
+		return errors.NotValidf("model lease %q", m.Lease_.Name_)
+	}
+	return nil
+}
+
+// validateOperations checks that every operation has one of the
+// enumerated statuses.
+func (m *model) validateOperations() error {
+	for _, operation := range m.Operations_.Operations_ {
+		if err := validateActionStatus(operation.Status_); err != nil {
+			return errors.Annotatef(err, "operation %q", operation.Id_)
+		}
+	}
+	return nil
+}
+
+// validateActions checks that every action has one of the enumerated
+// statuses, and that the operation it was spawned by, if any, is in the
+// model.
+func (m *model) validateActions() error {
+	operationIds := set.NewStrings()
+	for _, op := range m.Operations_.Operations_ {
+		operationIds.Add(op.Id_)
+	}
+	for _, action := range m.Actions_.Actions_ {
+		if err := validateActionStatus(action.Status_); err != nil {
+			return errors.Annotatef(err, "action %q", action.Id_)
+		}
+		if action.Operation_ != "" && !operationIds.Contains(action.Operation_) {
+			return errors.Errorf("action %q references non-existent operation %q", action.Id_, action.Operation_)
+		}
 	}
+	return nil
+}
 
+// validateSecretBackends checks that the secret backends are complete and
+// unique, and that the backend of every externally stored secret revision
+// is among them. Models exported before secret backends were recorded have
+// none, and their revisions aren't checked.
+func (m *model) validateSecretBackends() error {
+	if len(m.SecretBackends_.SecretBackends_) == 0 {
+		return nil
+	}
+	ids := set.NewStrings()
+	backendNames := set.NewStrings()
+	for i, backend := range m.SecretBackends_.SecretBackends_ {
+		if err := backend.Validate(); err != nil {
+			return errors.Annotatef(err, "secret backend[%d]", i)
+		}
+		if ids.Contains(backend.ID_) {
+			return errors.NotValidf("secret backend[%d] duplicate id %q", i, backend.ID_)
+		}
+		if backendNames.Contains(backend.Name_) {
+			return errors.NotValidf("secret backend[%d] duplicate name %q", i, backend.Name_)
+		}
+		ids.Add(backend.ID_)
+		backendNames.Add(backend.Name_)
+	}
+	for i, secret := range m.Secrets_.Secrets_ {
+		for _, revision := range secret.Revisions_ {
+			if revision.ValueRef_ == nil {
+				continue
+			}
+			if !ids.Contains(revision.ValueRef_.BackendId_) {
+				return errors.NotValidf("secret[%d] revision %d backend %q", i, revision.Number_, revision.ValueRef_.BackendId_)
+			}
+		}
+	}
 	return nil
 }
 
+// validateVirtualHostKeys checks that the virtual host keys are complete
+// and unique, and that the machine or unit each is for exists.
+func (m *model) validateVirtualHostKeys(validationCtx *validationContext) error {
+	ids := set.NewStrings()
+	for i, key := range m.VirtualHostKeys_.VirtualHostKeys_ {
+		if err := key.Validate(); err != nil {
+			return errors.Annotatef(err, "virtual host key[%d]", i)
+		}
+		if ids.Contains(key.ID_) {
+			return errors.NotValidf("virtual host key[%d] duplicate id %q", i, key.ID_)
+		}
+		ids.Add(key.ID_)
+		switch target := key.Target().(type) {
+		case names.MachineTag:
+			if !validationCtx.allMachines.Contains(target.Id()) {
+				return errors.Errorf("virtual host key %q references non-existent machine %q", key.ID_, target.Id())
+			}
+		case names.UnitTag:
+			if !validationCtx.allUnits.Contains(target.Id()) {
+				return errors.Errorf("virtual host key %q references non-existent unit %q", key.ID_, target.Id())
+			}
+		}
+	}
+	return nil
+}
+
+// remoteSourceModels returns the uuids of the other models this model knows
+// about, through its external controllers and remote applications.
+func (m *model) remoteSourceModels() set.Strings {
+	uuids := set.NewStrings()
+	for _, controller := range m.ExternalControllers_.ExternalControllers {
+		uuids = uuids.Union(set.NewStrings(controller.Models_...))
+	}
+	for _, application := range m.RemoteApplications_.RemoteApplications {
+		uuids.Add(application.SourceModelUUID_)
+	}
+	return uuids
+}
+
+// everyoneUserName is the name of the pseudo user that represents all
+// users, it is never a user of the model.
+const everyoneUserName = "everyone@external"
+
+// knownUsers returns the canonical names of the users of the model.
+func (m *model) knownUsers() set.Strings {
+	known := set.NewStrings(everyoneUserName)
+	if m.Owner_ != "" {
+		known.Add(m.Owner().Id())
+	}
+	for _, user := range m.Users_.Users_ {
+		known.Add(user.Name().Id())
+	}
+	return known
+}
+
+// isKnownUser returns true if the name references a user of the model.
+func isKnownUser(known set.Strings, name string) bool {
+	if !names.IsValidUser(name) {
+		return false
+	}
+	return known.Contains(names.NewUserTag(name).Id())
+}
+
+// secretUserSubject returns the user name referenced by the secret access
+// subject, if the subject is a user tag.
+func secretUserSubject(subject string) (string, bool) {
+	tag, err := names.ParseTag(subject)
+	if err != nil || tag.Kind() != names.UserTagKind {
+		return "", false
+	}
+	return tag.Id(), true
+}
+
+// DanglingPrincipals implements Model.
+func (m *model) DanglingPrincipals() []string {
+	known := m.knownUsers()
+	dangling := set.NewStrings()
+	for _, application := range m.Applications_.Applications_ {
+		if application.Offers_ == nil {
+			continue
+		}
+		for _, offer := range application.Offers_.Offers {
+			for name := range offer.ACL_ {
+				if !isKnownUser(known, name) {
+					dangling.Add(name)
+				}
+			}
+		}
+	}
+	for _, secret := range m.Secrets_.Secrets_ {
+		for subject := range secret.ACL_ {
+			if name, ok := secretUserSubject(subject); ok && !isKnownUser(known, name) {
+				dangling.Add(name)
+			}
+		}
+	}
+	for _, user := range m.Users_.Users_ {
+		for _, grant := range user.AccessHistory_ {
+			if grant.GrantedBy_ != "" && !isKnownUser(known, grant.GrantedBy_) {
+				dangling.Add(grant.GrantedBy_)
+			}
+		}
+	}
+	return dangling.SortedValues()
+}
+
+// CleanDanglingPrincipals implements Model.
+func (m *model) CleanDanglingPrincipals() []string {
+	m.checkMutable()
+	known := m.knownUsers()
+	removed := set.NewStrings()
+	for _, application := range m.Applications_.Applications_ {
+		if application.Offers_ == nil {
+			continue
+		}
+		for _, offer := range application.Offers_.Offers {
+			for name := range offer.ACL_ {
+				if !isKnownUser(known, name) {
+					delete(offer.ACL_, name)
+					removed.Add(name)
+				}
+			}
+		}
+	}
+	for _, secret := range m.Secrets_.Secrets_ {
+		for subject := range secret.ACL_ {
+			if name, ok := secretUserSubject(subject); ok && !isKnownUser(known, name) {
+				delete(secret.ACL_, subject)
+				removed.Add(name)
+			}
+		}
+	}
+	// The grants themselves are kept, as the access they record was
+	// given, but no longer name who gave it.
+	for _, user := range m.Users_.Users_ {
+		for _, grant := range user.AccessHistory_ {
+			if grant.GrantedBy_ != "" && !isKnownUser(known, grant.GrantedBy_) {
+				removed.Add(grant.GrantedBy_)
+				grant.GrantedBy_ = ""
+			}
+		}
+	}
+	return removed.SortedValues()
+}
+
+// NormalizeRelationNetworks implements Model.
+func (m *model) NormalizeRelationNetworks() []string {
+	m.checkMutable()
+	type networkKey struct {
+		relationKey, direction, origin string
+	}
+	merged := make(map[networkKey]*relationNetwork)
+	var kept []*relationNetwork
+	removed := set.NewStrings()
+	for _, network := range m.RelationNetworks_.RelationNetworks {
+		key := networkKey{network.RelationKey_, network.Direction_, network.Origin_}
+		first, ok := merged[key]
+		if !ok {
+			merged[key] = network
+			kept = append(kept, network)
+			continue
+		}
+		cidrs := set.NewStrings(first.CIDRS_...)
+		for _, cidr := range network.CIDRS_ {
+			if !cidrs.Contains(cidr) {
+				first.CIDRS_ = append(first.CIDRS_, cidr)
+				cidrs.Add(cidr)
+			}
+		}
+		removed.Add(network.ID_)
+	}
+	m.RelationNetworks_.RelationNetworks = kept
+	return removed.SortedValues()
+}
+
+// RemoveDanglingRelations implements Model.
+func (m *model) RemoveDanglingRelations() []string {
+	m.checkMutable()
+	known := set.NewStrings()
+	for _, application := range m.Applications_.Applications_ {
+		known.Add(application.Name_)
+	}
+	for _, application := range m.RemoteApplications_.RemoteApplications {
+		known.Add(application.Name_)
+	}
+	var relations []*relation
+	removed := set.NewStrings()
+	for _, relation := range m.Relations_.Relations_ {
+		dangling := false
+		for _, endpoint := range relation.Endpoints_.Endpoints_ {
+			if !known.Contains(endpoint.ApplicationName_) {
+				dangling = true
+				break
+			}
+		}
+		if dangling {
+			removed.Add(relation.Key_)
+			continue
+		}
+		relations = append(relations, relation)
+	}
+	m.setRelations(relations)
+	return removed.SortedValues()
+}
+
 func (m *model) machineMaps() (map[string]Machine, map[string]map[string]LinkLayerDevice) {
 	machineIDs := make(map[string]Machine)
 	for _, machine := range m.Machines_.Machines_ {
@@ -1406,6 +2578,11 @@ func (m *model) validateAddresses() error {
 		if ip := net.ParseIP(addr.Value()); ip == nil {
 			return errors.Errorf("ip address has invalid value %q", addr.Value())
 		}
+		if !validAddressConfigMethod(addr.ConfigMethod()) {
+			return errors.Errorf("ip address %q has invalid config method %q", addr.Value(), addr.ConfigMethod())
+		}
+		// Every address, and so every static one, needs a subnet for
+		// its configuration to be regenerated on the target.
 		if addr.SubnetCIDR() == "" {
 			return errors.Errorf("ip address %q has empty subnet CIDR", addr.Value())
 		}
@@ -1419,6 +2596,62 @@ func (m *model) validateAddresses() error {
 			}
 		}
 	}
+	return m.validateSecondaryAddresses()
+}
+
+// validateSecondaryAddresses makes sure that where a device has secondary
+// addresses in a subnet, it also has exactly one primary address in that
+// subnet. Shadow addresses aren't assigned to the device, so are ignored.
+func (m *model) validateSecondaryAddresses() error {
+	type deviceSubnet struct {
+		machineID, deviceName, subnetCIDR string
+	}
+	primaries := make(map[deviceSubnet]int)
+	hasSecondary := make(map[deviceSubnet]bool)
+	var keys []deviceSubnet
+	for _, addr := range m.IPAddresses_.IPAddresses_ {
+		if addr.IsShadow_ {
+			continue
+		}
+		key := deviceSubnet{addr.MachineID_, addr.DeviceName_, addr.SubnetCIDR_}
+		if _, seen := primaries[key]; !seen {
+			primaries[key] = 0
+			keys = append(keys, key)
+		}
+		if addr.IsSecondary_ {
+			hasSecondary[key] = true
+		} else {
+			primaries[key]++
+		}
+	}
+	for _, key := range keys {
+		if !hasSecondary[key] || primaries[key] == 1 {
+			continue
+		}
+		return errors.Errorf("device %q on machine %q has %d primary addresses in subnet %q, expected 1",
+			key.deviceName, key.machineID, primaries[key], key.subnetCIDR)
+	}
+	return nil
+}
+
+// vlanParentTypes are the types of device that a VLAN device can be on.
+var vlanParentTypes = set.NewStrings("ethernet", "bond", "bridge")
+
+// validateLinkLayerDeviceType checks that only VLAN devices have a VLAN
+// tag, which must be in range, and only bond devices a bond mode. Devices
+// exported before tags were recorded have none, even when they are VLANs.
+func validateLinkLayerDeviceType(device *linklayerdevice) error {
+	if tag := device.VLANTag_; tag != 0 {
+		if device.Type_ != "802.1q" {
+			return errors.Errorf("device %q of type %q has VLAN tag %d", device.Name_, device.Type_, tag)
+		}
+		if tag < 1 || tag > 4094 {
+			return errors.Errorf("device %q has invalid VLAN tag %d", device.Name_, tag)
+		}
+	}
+	if device.BondMode_ != "" && device.Type_ != "bond" {
+		return errors.Errorf("device %q of type %q has bond mode %q", device.Name_, device.Type_, device.BondMode_)
+	}
 	return nil
 }
 
@@ -1439,6 +2672,9 @@ func (m *model) validateLinkLayerDevices() error {
 				return errors.Errorf("device %q has invalid MACAddress %q", device.Name(), device.MACAddress())
 			}
 		}
+		if err := validateLinkLayerDeviceType(device); err != nil {
+			return errors.Trace(err)
+		}
 		if device.ParentName() == "" {
 			continue
 		}
@@ -1455,6 +2691,9 @@ func (m *model) validateLinkLayerDevices() error {
 			if device.Name() == parentDeviceName {
 				return errors.Errorf("device %q is its own parent", device.Name())
 			}
+			if device.Type() == "802.1q" && !vlanParentTypes.Contains(parentDevice.Type()) {
+				return errors.Errorf("VLAN device %q has parent %q of type %q", device.Name(), parentDeviceName, parentDevice.Type())
+			}
 			continue
 		}
 		// The device is on a container.
@@ -1474,21 +2713,23 @@ func (m *model) validateLinkLayerDevices() error {
 
 // validateRelations makes sure that for each endpoint in each relation there
 // are settings for all units of that application for that endpoint.
-func (m *model) validateRelations() error {
+func (m *model) validateRelations(validationCtx *validationContext) error {
 	for _, relation := range m.Relations_.Relations_ {
+		if err := validateLife("relation", relation.Key_, relation.Life_); err != nil {
+			return errors.Trace(err)
+		}
 		isRemote := false
-		for _, ep := range relation.Endpoints() {
-			if m.remoteApplication(ep.ApplicationName()) != nil {
+		for _, ep := range relation.Endpoints_.Endpoints_ {
+			if validationCtx.allRemoteApplications.Contains(ep.ApplicationName()) {
 				isRemote = true
 				break
 			}
 		}
 		for _, ep := range relation.Endpoints_.Endpoints_ {
 			// Check application exists.
-			application := m.application(ep.ApplicationName())
-			if application == nil {
-				remoteApp := m.remoteApplication(ep.ApplicationName())
-				if remoteApp != nil {
+			applicationUnits, ok := validationCtx.applicationUnits[ep.ApplicationName()]
+			if !ok {
+				if validationCtx.allRemoteApplications.Contains(ep.ApplicationName()) {
 					// There are no units to check for a remote
 					// application (the units live in the other model).
 					continue
@@ -1496,7 +2737,6 @@ func (m *model) validateRelations() error {
 				return errors.Errorf("unknown application %q for relation id %d", ep.ApplicationName(), relation.Id())
 			}
 			// Check that all units have settings.
-			applicationUnits := application.unitNames()
 			epUnits := ep.unitNames()
 			if ep.Scope() != "container" {
 				// If the application is a subordinate, and it is related to multiple
@@ -1519,7 +2759,8 @@ func (m *model) validateRelations() error {
 // will be the result of interpreting a large YAML document.
 //
 // This method is a package internal serialisation method.
-func importModel(source map[string]interface{}) (*model, error) {
+func importModel(source map[string]interface{}, opts ...ImportOption) (*model, error) {
+	options := newImportOptions(opts)
 	version, err := getVersion(source)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1530,10 +2771,57 @@ func importModel(source map[string]interface{}) (*model, error) {
 		return nil, errors.NotValidf("version %d", version)
 	}
 
-	return importFunc(source)
+	if source, err = verifyIntegrity(source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	labels, err := importLabels(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if _, ok := source[statusStringsKey]; ok {
+		if source, err = expandStatusHistory(source); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	if options.skipped != nil {
+		source = options.skipInvalidEntities(source)
+	}
+
+	if options.requireStatusTimes {
+		if err := checkStatusTimes(source, version); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	start := time.Now()
+	result, err := importFunc(source, options)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setLabels(labels)
+	if options.preserveUnknownFields {
+		if options.lazy != nil {
+			// The unknown fields are kept once the sections are imported.
+			options.lazy.source = source
+		} else {
+			keepUnknownFields(result, source)
+		}
+	}
+	// Documents written before the model kept its entities sorted, or by
+	// hand, may list them in any order.
+	result.canonicalize()
+	if version != result.Version {
+		result.sourceVersion = version
+		result.sourceSectionVersions = sectionVersions(source)
+		options.debug("upgraded model version", "from", version, "to", result.Version)
+	}
+	options.debug("imported model", "version", version, "duration", time.Since(start))
+	return result, nil
 }
 
-type modelDeserializationFunc func(map[string]interface{}) (*model, error)
+type modelDeserializationFunc func(map[string]interface{}, importOptions) (*model, error)
 
 var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 	1:  newModelImporter(1, schema.FieldMap(modelV1Fields())),
@@ -1547,6 +2835,15 @@ var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 	9:  newModelImporter(9, schema.FieldMap(modelV9Fields())),
 	10: newModelImporter(10, schema.FieldMap(modelV10Fields())),
 	11: newModelImporter(11, schema.FieldMap(modelV11Fields())),
+	12: newModelImporter(12, schema.FieldMap(modelV12Fields())),
+	13: newModelImporter(13, schema.FieldMap(modelV13Fields())),
+	14: newModelImporter(14, schema.FieldMap(modelV14Fields())),
+	15: newModelImporter(15, schema.FieldMap(modelV15Fields())),
+	16: newModelImporter(16, schema.FieldMap(modelV16Fields())),
+	17: newModelImporter(17, schema.FieldMap(modelV17Fields())),
+	18: newModelImporter(18, schema.FieldMap(modelV18Fields())),
+	19: newModelImporter(19, schema.FieldMap(modelV19Fields())),
+	20: newModelImporter(20, schema.FieldMap(modelV20Fields())),
 }
 
 func modelV1Fields() (schema.Fields, schema.Defaults) {
@@ -1582,8 +2879,8 @@ func modelV1Fields() (schema.Fields, schema.Defaults) {
 		"cloud-region":     "",
 		"cloud-credential": schema.Omit,
 	}
-	addAnnotationSchema(fields, defaults)
-	addConstraintsSchema(fields, defaults)
+	AddAnnotationSchema(fields, defaults)
+	AddConstraintsSchema(fields, defaults)
 	return fields, defaults
 }
 
@@ -1602,7 +2899,7 @@ func modelV2Fields() (schema.Fields, schema.Defaults) {
 			"info": schema.String(),
 		}, nil)
 	fields["status"] = schema.StringMap(schema.Any())
-	addStatusHistorySchema(fields)
+	AddStatusHistorySchema(fields)
 	return fields, defaults
 }
 
@@ -1666,11 +2963,74 @@ func modelV11Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
-func newModelFromValid(valid map[string]interface{}, importVersion int) (*model, error) {
+func modelV12Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV11Fields()
+	fields["telemetry"] = schema.StringMap(schema.Any())
+	defaults["telemetry"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV13Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV12Fields()
+	fields["description"] = schema.String()
+	defaults["description"] = ""
+	return fields, defaults
+}
+
+func modelV14Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV13Fields()
+	fields["charms"] = schema.StringMap(schema.Any())
+	defaults["charms"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV15Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV14Fields()
+	fields["password-hash-algorithm"] = schema.String()
+	defaults["password-hash-algorithm"] = ""
+	return fields, defaults
+}
+
+func modelV16Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV15Fields()
+	fields["secret-backends"] = schema.StringMap(schema.Any())
+	defaults["secret-backends"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV17Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV16Fields()
+	fields["entity-annotations"] = schema.StringMap(schema.StringMap(schema.String()))
+	defaults["entity-annotations"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV18Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV17Fields()
+	fields["migration-attempt"] = schema.FieldMap(migrationAttemptFields())
+	defaults["migration-attempt"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV19Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV18Fields()
+	fields["lease"] = schema.FieldMap(leaseFields())
+	defaults["lease"] = schema.Omit
+	return fields, defaults
+}
+
+func modelV20Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV19Fields()
+	fields["virtual-host-keys"] = schema.StringMap(schema.Any())
+	defaults["virtual-host-keys"] = schema.Omit
+	return fields, defaults
+}
+
+func newModelFromValid(valid map[string]interface{}, importVersion int, options importOptions) (*model, error) {
 	// We're always making a version 8 model, no matter what we got on
 	// the way in.
 	result := &model{
-		Version:        11,
+		Version:        20,
 		Type_:          IAAS,
 		Owner_:         valid["owner"].(string),
 		Config_:        valid["config"].(map[string]interface{}),
@@ -1678,8 +3038,40 @@ func newModelFromValid(valid map[string]interface{}, importVersion int) (*model,
 		Blocks_:        convertToStringMap(valid["blocks"]),
 		Cloud_:         valid["cloud"].(string),
 		CloudRegion_:   valid["cloud-region"].(string),
-		StatusHistory_: newStatusHistory(),
-	}
+		StatusHistory_: NewStatusHistory(),
+	}
+	// Sections start out empty, so that those added after the version
+	// being imported are written at their current versions, and those
+	// not yet imported by a lazy model are there to be read.
+	result.setUsers(nil)
+	result.setMachines(nil)
+	result.setApplications(nil)
+	result.setRelations(nil)
+	result.setSpaces(nil)
+	result.setLinkLayerDevices(nil)
+	result.setSubnets(nil)
+	result.setIPAddresses(nil)
+	result.setSSHHostKeys(nil)
+	result.setVirtualHostKeys(nil)
+	result.setCloudImageMetadatas(nil)
+	result.setOperations(nil)
+	result.setActions(nil)
+	result.setVolumes(nil)
+	result.setFilesystems(nil)
+	result.setStorages(nil)
+	result.setStoragePools(nil)
+	result.setRemoteApplications(nil)
+	result.setRemoteEntities(nil)
+	result.setRelationNetworks(nil)
+	result.setFirewallRules(nil)
+	result.setOfferConnections(nil)
+	result.setExternalControllers(nil)
+	result.setSecretBackends(nil)
+	result.setSecrets(nil)
+	result.setRemoteSecrets(nil)
+	result.setCharms(nil)
+	options.logSectionVersions(valid)
+	timer := options.newSectionTimer(valid)
 	if importVersion >= 4 {
 		result.Type_ = valid["type"].(string)
 	}
@@ -1692,7 +3084,7 @@ func newModelFromValid(valid map[string]interface{}, importVersion int) (*model,
 		result.CloudCredential_ = creds
 	}
 
-	result.importAnnotations(valid)
+	result.ImportAnnotations(valid)
 	sequences := valid["sequences"].(map[string]interface{})
 	for key, value := range sequences {
 		result.SetSequence(key, int(value.(int64)))
@@ -1714,117 +3106,6 @@ func newModelFromValid(valid map[string]interface{}, importVersion int) (*model,
 		result.LatestToolsVersion_ = num
 	}
 
-	userMap := valid["users"].(map[string]interface{})
-	users, err := importUsers(userMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "users")
-	}
-	result.setUsers(users)
-
-	machineMap := valid["machines"].(map[string]interface{})
-	machines, err := importMachines(machineMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "machines")
-	}
-	result.setMachines(machines)
-
-	applicationMap := valid["applications"].(map[string]interface{})
-	applications, err := importApplications(applicationMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "applications")
-	}
-	result.setApplications(applications)
-
-	relationMap := valid["relations"].(map[string]interface{})
-	relations, err := importRelations(relationMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "relations")
-	}
-	result.setRelations(relations)
-
-	spaceMap := valid["spaces"].(map[string]interface{})
-	spaces, err := importSpaces(spaceMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "spaces")
-	}
-	result.setSpaces(spaces)
-
-	deviceMap := valid["link-layer-devices"].(map[string]interface{})
-	devices, err := importLinkLayerDevices(deviceMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "link-layer-devices")
-	}
-	result.setLinkLayerDevices(devices)
-
-	subnetsMap := valid["subnets"].(map[string]interface{})
-	subnets, err := importSubnets(subnetsMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "subnets")
-	}
-	result.setSubnets(subnets)
-
-	addressMap := valid["ip-addresses"].(map[string]interface{})
-	addresses, err := importIPAddresses(addressMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "ip-addresses")
-	}
-	result.setIPAddresses(addresses)
-
-	sshHostKeyMap := valid["ssh-host-keys"].(map[string]interface{})
-	hostKeys, err := importSSHHostKeys(sshHostKeyMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "ssh-host-keys")
-	}
-	result.setSSHHostKeys(hostKeys)
-
-	cloudimagemetadataMap := valid["cloud-image-metadata"].(map[string]interface{})
-	cloudimagemetadata, err := importCloudImageMetadatas(cloudimagemetadataMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "cloud-image-metadata")
-	}
-	result.setCloudImageMetadatas(cloudimagemetadata)
-
-	actionsMap := valid["actions"].(map[string]interface{})
-	actions, err := importActions(actionsMap)
-	if err != nil {
-		return nil, errors.Annotate(err, "actions")
-	}
-	result.setActions(actions)
-
-	volumes, err := importVolumes(valid["volumes"].(map[string]interface{}))
-	if err != nil {
-		return nil, errors.Annotate(err, "volumes")
-	}
-	result.setVolumes(volumes)
-
-	filesystems, err := importFilesystems(valid["filesystems"].(map[string]interface{}))
-	if err != nil {
-		return nil, errors.Annotate(err, "filesystems")
-	}
-	result.setFilesystems(filesystems)
-
-	storages, err := importStorages(valid["storages"].(map[string]interface{}))
-	if err != nil {
-		return nil, errors.Annotate(err, "storages")
-	}
-	result.setStorages(storages)
-
-	pools, err := importStoragePools(valid["storage-pools"].(map[string]interface{}))
-	if err != nil {
-		return nil, errors.Annotate(err, "storage-pools")
-	}
-	result.setStoragePools(pools)
-
-	var remoteApplications []*remoteApplication
-	// Remote applications only exist in version 2.
-	if importVersion >= 2 {
-		remoteApplications, err = importRemoteApplications(valid["remote-applications"].(map[string]interface{}))
-		if err != nil {
-			return nil, errors.Annotate(err, "remote-applications")
-		}
-	}
-	result.setRemoteApplications(remoteApplications)
-
 	// environ-version was added in version 3. For older schema versions,
 	// the environ-version will be set to the zero value.
 	if importVersion >= 3 {
@@ -1846,7 +3127,7 @@ func newModelFromValid(valid map[string]interface{}, importVersion int) (*model,
 			result.Status_ = status
 		}
 
-		if err := result.importStatusHistory(valid); err != nil {
+		if err := result.ImportStatusHistory(valid); err != nil {
 			return nil, errors.Trace(err)
 		}
 	} else {
@@ -1856,97 +3137,409 @@ func newModelFromValid(valid map[string]interface{}, importVersion int) (*model,
 			Updated: time.Now(),
 		})
 	}
-	if importVersion >= 5 {
-		if rawRemoteEntities, ok := valid["remote-entities"]; ok {
-			remoteEntitiesMap := rawRemoteEntities.(map[string]interface{})
-			remoteEntities, err := importRemoteEntities(remoteEntitiesMap)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			result.setRemoteEntities(remoteEntities)
-		}
-		if rawRelationNetworks, ok := valid["relation-networks"]; ok {
-			relationNetworksMap := rawRelationNetworks.(map[string]interface{})
-			relationNetworks, err := importRelationNetworks(relationNetworksMap)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			result.setRelationNetworks(relationNetworks)
-		}
+
+	if importVersion >= 8 {
+		result.PasswordHash_ = valid["password-hash"].(string)
 	}
 
-	if importVersion >= 6 {
-		if rawFirewallRules, ok := valid["firewall-rules"]; ok {
-			firewallRulesMap := rawFirewallRules.(map[string]interface{})
-			firewallRules, err := importFirewallRules(firewallRulesMap)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			result.setFirewallRules(firewallRules)
-		}
-		if rawOfferConnections, ok := valid["offer-connections"]; ok {
-			offerConnectionsMap := rawOfferConnections.(map[string]interface{})
-			offerConnections, err := importOfferConnections(offerConnectionsMap)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			result.setOfferConnections(offerConnections)
-		}
-		if rawExternalControllers, ok := valid["external-controllers"]; ok {
-			externalControllersMap := rawExternalControllers.(map[string]interface{})
-			externalControllers, err := importExternalControllers(externalControllersMap)
+	if importVersion >= 10 {
+		result.SecretBackendID_ = valid["secret-backend-id"].(string)
+	}
+
+	// When we are importing v11 onwards agent version will be a first class
+	// citizen on the model. Before this we can attempt to get the value from
+	// config.
+	if importVersion >= 11 {
+		result.AgentVersion_ = valid["agent-version"].(string)
+	} else if result.Config_ != nil && result.Config_["agent-version"] != nil {
+		result.AgentVersion_ = result.Config_["agent-version"].(string)
+	}
+
+	if importVersion >= 12 {
+		if telemetryMap, ok := valid["telemetry"]; ok {
+			telemetry, err := importTelemetry(telemetryMap.(map[string]interface{}))
 			if err != nil {
-				return nil, errors.Trace(err)
+				return nil, errors.Annotate(err, "telemetry")
 			}
-			result.setExternalControllers(externalControllers)
+			result.Telemetry_ = telemetry
 		}
+	} else {
+		result.Telemetry_ = telemetryFromConfig(result.Config_)
 	}
 
-	if importVersion >= 7 {
-		operationsMap := valid["operations"].(map[string]interface{})
-		operations, err := importOperations(operationsMap)
-		if err != nil {
-			return nil, errors.Annotate(err, "operations")
-		}
-		result.setOperations(operations)
+	if importVersion >= 13 {
+		result.Description_ = valid["description"].(string)
 	}
 
-	if importVersion >= 8 {
-		result.PasswordHash_ = valid["password-hash"].(string)
+	if importVersion >= 15 {
+		result.PasswordHashAlgorithm_ = valid["password-hash-algorithm"].(string)
 	}
 
-	if importVersion >= 9 {
-		secretsMap := valid["secrets"].(map[string]interface{})
-		secrets, err := importSecrets(secretsMap)
-		if err != nil {
-			return nil, errors.Annotate(err, "secrets")
+	// The annotations the model holds for its entities are imported ahead
+	// of the entities, which take over their own as they are imported.
+	if importVersion >= 17 {
+		if rawAnnotations, ok := valid["entity-annotations"]; ok {
+			result.EntityAnnotations_ = make(map[string]map[string]string)
+			for tag, annotations := range rawAnnotations.(map[string]interface{}) {
+				result.EntityAnnotations_[tag] = convertToStringMap(annotations)
+			}
 		}
-		result.setSecrets(secrets)
 	}
 
-	if importVersion >= 10 {
-		remoteSecretsMap := valid["remote-secrets"].(map[string]interface{})
-		remoteSecrets, err := importRemoteSecrets(remoteSecretsMap)
-		if err != nil {
-			return nil, errors.Annotate(err, "remote secrets")
+	if importVersion >= 18 {
+		if attemptMap, ok := valid["migration-attempt"]; ok {
+			result.MigrationAttempt_ = importMigrationAttempt(attemptMap.(map[string]interface{}))
 		}
-		result.setRemoteSecrets(remoteSecrets)
-
-		result.SecretBackendID_ = valid["secret-backend-id"].(string)
 	}
 
-	// When we are importing v11 onwards agent version will be a first class
-	// citizen on the model. Before this we can attempt to get the value from
-	// config.
-	if importVersion >= 11 {
-		result.AgentVersion_ = valid["agent-version"].(string)
-	} else if result.Config_ != nil && result.Config_["agent-version"] != nil {
-		result.AgentVersion_ = result.Config_["agent-version"].(string)
+	if importVersion >= 19 {
+		if leaseMap, ok := valid["lease"]; ok {
+			result.Lease_ = importLease(leaseMap.(map[string]interface{}))
+		}
 	}
 
+	if options.lazy != nil {
+		// The sections are left for the lazy model to import on first use.
+		options.lazy.valid = valid
+		options.lazy.version = importVersion
+		return result, nil
+	}
+	for _, section := range entitySections {
+		if err := section.importInto(result, valid, importVersion, options); err != nil {
+			return nil, errors.Trace(err)
+		}
+		timer.mark(section.name)
+	}
 	return result, nil
 }
 
+// modelSection imports one of the top level sections of a model that
+// hold its entities.
+type modelSection struct {
+	name string
+	// since is the model version the section was added in.
+	since int
+	load  func(m *model, source map[string]interface{}, options importOptions) error
+}
+
+// importInto imports the section from the valid source of a model of the
+// given version, if the model has it.
+func (s modelSection) importInto(m *model, valid map[string]interface{}, version int, options importOptions) error {
+	source, ok := valid[s.name].(map[string]interface{})
+	if version < s.since || !ok {
+		return nil
+	}
+	if err := s.load(m, source, options); err != nil {
+		return errors.Annotate(err, s.name)
+	}
+	// Entities imported take over the annotations the model holds for
+	// them.
+	m.adoptEntityAnnotations()
+	return nil
+}
+
+// entitySections lists the sections of a model that hold its entities, in
+// the order they are imported.
+var entitySections = []modelSection{{
+	name:  "users",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		users, err := importUsers(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setUsers(users)
+		return nil
+	},
+}, {
+	name:  "machines",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		machines, err := importMachines(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setMachines(machines)
+		return nil
+	},
+}, {
+	name:  "applications",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, options importOptions) error {
+		applications, err := importApplications(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setApplications(applications)
+		options.translateCharmStoreURLs(m)
+		return nil
+	},
+}, {
+	name:  "relations",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		relations, err := importRelations(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setRelations(relations)
+		return nil
+	},
+}, {
+	name:  "spaces",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		spaces, err := importSpaces(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setSpaces(spaces)
+		return nil
+	},
+}, {
+	name:  "link-layer-devices",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		devices, err := importLinkLayerDevices(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setLinkLayerDevices(devices)
+		return nil
+	},
+}, {
+	name:  "subnets",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		subnets, err := importSubnets(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setSubnets(subnets)
+		return nil
+	},
+}, {
+	name:  "ip-addresses",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		addresses, err := importIPAddresses(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setIPAddresses(addresses)
+		return nil
+	},
+}, {
+	name:  "ssh-host-keys",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		hostKeys, err := importSSHHostKeys(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setSSHHostKeys(hostKeys)
+		return nil
+	},
+}, {
+	name:  "cloud-image-metadata",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		metadata, err := importCloudImageMetadatas(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setCloudImageMetadatas(metadata)
+		return nil
+	},
+}, {
+	name:  "actions",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		actions, err := importActions(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setActions(actions)
+		return nil
+	},
+}, {
+	name:  "volumes",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		volumes, err := importVolumes(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setVolumes(volumes)
+		return nil
+	},
+}, {
+	name:  "filesystems",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		filesystems, err := importFilesystems(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setFilesystems(filesystems)
+		return nil
+	},
+}, {
+	name:  "storages",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		storages, err := importStorages(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setStorages(storages)
+		return nil
+	},
+}, {
+	name:  "storage-pools",
+	since: 1,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		pools, err := importStoragePools(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setStoragePools(pools)
+		return nil
+	},
+}, {
+	name:  "remote-applications",
+	since: 2,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		remoteApplications, err := importRemoteApplications(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setRemoteApplications(remoteApplications)
+		return nil
+	},
+}, {
+	name:  "remote-entities",
+	since: 5,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		remoteEntities, err := importRemoteEntities(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setRemoteEntities(remoteEntities)
+		return nil
+	},
+}, {
+	name:  "relation-networks",
+	since: 5,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		relationNetworks, err := importRelationNetworks(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setRelationNetworks(relationNetworks)
+		return nil
+	},
+}, {
+	name:  "firewall-rules",
+	since: 6,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		firewallRules, err := importFirewallRules(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setFirewallRules(firewallRules)
+		return nil
+	},
+}, {
+	name:  "offer-connections",
+	since: 6,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		offerConnections, err := importOfferConnections(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setOfferConnections(offerConnections)
+		return nil
+	},
+}, {
+	name:  "external-controllers",
+	since: 6,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		externalControllers, err := importExternalControllers(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setExternalControllers(externalControllers)
+		return nil
+	},
+}, {
+	name:  "operations",
+	since: 7,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		operations, err := importOperations(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setOperations(operations)
+		return nil
+	},
+}, {
+	name:  "secrets",
+	since: 9,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		secrets, err := importSecrets(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setSecrets(secrets)
+		return nil
+	},
+}, {
+	name:  "remote-secrets",
+	since: 10,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		remoteSecrets, err := importRemoteSecrets(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setRemoteSecrets(remoteSecrets)
+		return nil
+	},
+}, {
+	name:  "charms",
+	since: 14,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		charms, err := importCharms(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setCharms(charms)
+		return nil
+	},
+}, {
+	name:  "secret-backends",
+	since: 16,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		backends, err := importSecretBackends(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setSecretBackends(backends)
+		return nil
+	},
+}, {
+	name:  "virtual-host-keys",
+	since: 20,
+	load: func(m *model, source map[string]interface{}, _ importOptions) error {
+		keys, err := importVirtualHostKeys(source)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m.setVirtualHostKeys(keys)
+		return nil
+	},
+}}
+
 func importSLA(source map[string]interface{}) sla {
 	return sla{
 		Level_:       source["level"].(string),
@@ -1962,15 +3555,20 @@ func importMeterStatus(source map[string]interface{}) meterStatus {
 	}
 }
 
-func newModelImporter(v int, checker schema.Checker) func(map[string]interface{}) (*model, error) {
-	return func(source map[string]interface{}) (*model, error) {
+func newModelImporter(v int, checker schema.Checker) modelDeserializationFunc {
+	return func(source map[string]interface{}, options importOptions) (*model, error) {
 		coerced, err := checker.Coerce(source, nil)
 		if err != nil {
+			options.debug("model schema check failed", "version", v, "error", err)
 			return nil, errors.Annotatef(err, "model v%d schema check failed", v)
 		}
 		valid := coerced.(map[string]interface{})
+		if options.releaseSections {
+			// The coerced map holds the sections from here on.
+			clear(source)
+		}
 		// From here we know that the map returned from the schema coercion
 		// contains fields of the right type.
-		return newModelFromValid(valid, v)
+		return newModelFromValid(valid, v, options)
 	}
 }
@@ -4,7 +4,12 @@
 package description
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	stdtesting "testing"
 	"time"
 
 	"github.com/juju/errors"
@@ -113,6 +118,44 @@ func (s *ModelSerializationSuite) exportImport(c *gc.C, initial Model) Model {
 	return model
 }
 
+func (s *ModelSerializationSuite) TestValidateDocument(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ValidateDocument(bytes), jc.ErrorIsNil)
+	c.Check(ValidateDocument([]byte(modelV1example)), jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestValidateDocumentInvalidModel(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ValidateDocument(bytes)
+	c.Assert(err, gc.ErrorMatches, "missing status not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestValidateDocumentImportFailure(c *gc.C) {
+	err := ValidateDocument([]byte("version: 42\n"))
+	c.Assert(err, gc.ErrorMatches, "version 42 not valid")
+	err = ValidateDocument([]byte("not: [valid"))
+	c.Assert(err, gc.ErrorMatches, "yaml: .*")
+}
+
+func (s *ModelSerializationSuite) TestImportReleasingSections(c *gc.C) {
+	var source map[string]interface{}
+	err := yaml.Unmarshal([]byte(modelV1example), &source)
+	c.Assert(err, jc.ErrorIsNil)
+	released, err := importModel(source, releaseSections())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(source, gc.HasLen, 0)
+
+	model, err := Deserialize([]byte(modelV1example))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(released.Machines(), gc.HasLen, len(model.Machines()))
+	c.Check(released.Applications(), gc.HasLen, len(model.Applications()))
+}
+
 func (s *ModelSerializationSuite) TestParsingModelV1(c *gc.C) {
 	model, err := Deserialize([]byte(modelV1example))
 	c.Assert(err, jc.ErrorIsNil)
@@ -143,8 +186,8 @@ func (s *ModelSerializationSuite) TestVersions(c *gc.C) {
 	}
 	initial := NewModel(args).(*model)
 	c.Assert(initial.Applications_.Version, gc.Equals, len(applicationDeserializationFuncs))
-	c.Assert(initial.Actions_.Version, gc.Equals, 4)
-	c.Assert(initial.Operations_.Version, gc.Equals, 2)
+	c.Assert(initial.Actions_.Version, gc.Equals, len(actionFieldsFuncs))
+	c.Assert(initial.Operations_.Version, gc.Equals, len(operationFieldsFuncs))
 	c.Assert(initial.Secrets_.Version, gc.Equals, 2)
 	c.Assert(initial.Filesystems_.Version, gc.Equals, len(filesystemDeserializationFuncs))
 	c.Assert(initial.Relations_.Version, gc.Equals, len(relationFieldsFuncs))
@@ -157,6 +200,296 @@ func (s *ModelSerializationSuite) TestVersions(c *gc.C) {
 	c.Assert(initial.ExternalControllers_.Version, gc.Equals, len(externalControllerDeserializationFuncs))
 }
 
+func (s *ModelSerializationSuite) TestDeserializeWithLogger(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	data := asStringMap(c, initial)
+	data["version"] = 11
+	serialized, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err = Deserialize(serialized, WithLogger(logger))
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := buf.String()
+	c.Check(output, jc.Contains, `msg="section version" section=machines version=`)
+	c.Check(output, jc.Contains, `msg="imported section" section=applications duration=`)
+	c.Check(output, jc.Contains, `msg="upgraded model version" from=11 to=19`)
+	c.Check(output, jc.Contains, `msg="imported model" version=11 duration=`)
+}
+
+func (s *ModelSerializationSuite) TestDeserializeWithLoggerSchemaFailure(c *gc.C) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err := Deserialize([]byte("version: 12\n"), WithLogger(logger))
+	c.Assert(err, gc.ErrorMatches, "model v12 schema check failed: .*")
+	c.Check(buf.String(), jc.Contains, `msg="model schema check failed" version=12`)
+}
+
+func (s *ModelSerializationSuite) TestTranslateCharmStoreURLs(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	for name, url := range map[string]string{
+		"ubuntu": "cs:jammy/ubuntu-12",
+		"mysql":  "cs:mysql-58",
+	} {
+		args := minimalApplicationArgs(IAAS)
+		args.Tag = names.NewApplicationTag(name)
+		args.CharmURL = url
+		app := initial.AddApplication(args)
+		app.SetStatus(minimalStatusArgs())
+		app.SetCharmOrigin(CharmOriginArgs{Source: "charm-store"})
+	}
+	serialized, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	var translated []CharmURLTranslation
+	imported, err := Deserialize(serialized, WithLogger(logger), TranslateCharmStoreURLs(map[string]string{
+		"cs:jammy/ubuntu-12": "ch:amd64/jammy/ubuntu-24",
+	}, &translated))
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := make(map[string]Application)
+	for _, app := range imported.Applications() {
+		applications[app.Name()] = app
+	}
+	c.Assert(applications, gc.HasLen, 2)
+	c.Check(applications["ubuntu"].CharmURL(), gc.Equals, "ch:amd64/jammy/ubuntu-24")
+	c.Check(applications["ubuntu"].CharmOrigin().Source(), gc.Equals, "charm-hub")
+	c.Check(applications["mysql"].CharmURL(), gc.Equals, "cs:mysql-58")
+	c.Check(applications["mysql"].CharmOrigin().Source(), gc.Equals, "charm-store")
+
+	output := buf.String()
+	c.Check(output, jc.Contains, `msg="translated charm store URL" application=ubuntu from=cs:jammy/ubuntu-12 to=ch:amd64/jammy/ubuntu-24`)
+	c.Check(output, jc.Contains, `msg="charm store URL not translated" application=mysql url=cs:mysql-58`)
+
+	sort.Slice(translated, func(i, j int) bool {
+		return translated[i].Application < translated[j].Application
+	})
+	c.Check(translated, jc.DeepEquals, []CharmURLTranslation{{
+		Application: "mysql",
+		From:        "cs:mysql-58",
+	}, {
+		Application: "ubuntu",
+		From:        "cs:jammy/ubuntu-12",
+		To:          "ch:amd64/jammy/ubuntu-24",
+	}})
+}
+
+func (s *ModelSerializationSuite) TestSkipInvalidEntities(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	addMinimalMachine(initial, "1")
+	addMinimalApplication(initial)
+	unitArgs := minimalUnitArgs(IAAS)
+	unitArgs.Tag = names.NewUnitTag("ubuntu/1")
+	unit := initial.Applications()[0].AddUnit(unitArgs)
+	unit.SetAgentStatus(minimalStatusArgs())
+	unit.SetWorkloadStatus(minimalStatusArgs())
+	unit.SetTools(minimalAgentToolsArgs())
+
+	// Damage machine 1 and unit ubuntu/1.
+	data := asStringMap(c, initial)
+	machines := data["machines"].(map[interface{}]interface{})["machines"].([]interface{})
+	machines[1].(map[interface{}]interface{})["id"] = []interface{}{1}
+	applications := data["applications"].(map[interface{}]interface{})["applications"].([]interface{})
+	units := applications[0].(map[interface{}]interface{})["units"].(map[interface{}]interface{})["units"].([]interface{})
+	units[1].(map[interface{}]interface{})["name"] = []interface{}{"ubuntu/1"}
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes)
+	c.Assert(err, gc.ErrorMatches, `machines: machine 1: machine v\d+ schema check failed: id: .*`)
+
+	var skipped []SkippedEntity
+	model, err := Deserialize(bytes, SkipInvalidEntities(&skipped))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Machines(), gc.HasLen, 1)
+	c.Check(model.Machines()[0].Id(), gc.Equals, "0")
+	c.Assert(model.Applications(), gc.HasLen, 1)
+	c.Assert(model.Applications()[0].Units(), gc.HasLen, 1)
+	c.Check(model.Applications()[0].Units()[0].Name(), gc.Equals, "ubuntu/0")
+
+	c.Assert(skipped, gc.HasLen, 2)
+	c.Check(skipped[0].Kind, gc.Equals, "machine")
+	c.Check(skipped[0].Err, gc.ErrorMatches, `machine 1: machine v\d+ schema check failed: id: .*`)
+	c.Check(skipped[0].Source.(map[string]interface{})["nonce"], gc.Equals, "a-nonce")
+	c.Check(skipped[1].Kind, gc.Equals, "unit")
+	c.Check(skipped[1].Err, gc.ErrorMatches, `unit 1: unit v\d+ schema check failed: name: .*`)
+	c.Check(skipped[1].Source.(map[string]interface{})["name"], jc.DeepEquals, []interface{}{"ubuntu/1"})
+}
+
+func (s *ModelSerializationSuite) TestSkipInvalidEntitiesNoneSkipped(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	addMinimalApplication(initial)
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var skipped []SkippedEntity
+	model, err := Deserialize(bytes, SkipInvalidEntities(&skipped))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, gc.HasLen, 0)
+	c.Assert(Diff(initial, model).Empty(), jc.IsTrue)
+}
+
+// withUnknownFields returns the self test model serialized with a field
+// added to the model, and to its machine, container, application, unit
+// and relation, that no version of them has.
+func (s *ModelSerializationSuite) withUnknownFields(c *gc.C) []byte {
+	data := asStringMap(c, selfTestModel())
+	data["future"] = map[interface{}]interface{}{"model": 1}
+	machine := data["machines"].(map[interface{}]interface{})["machines"].([]interface{})[0].(map[interface{}]interface{})
+	machine["future"] = "machine"
+	container := machine["containers"].([]interface{})[0].(map[interface{}]interface{})
+	container["future"] = []interface{}{"container"}
+	application := data["applications"].(map[interface{}]interface{})["applications"].([]interface{})[0].(map[interface{}]interface{})
+	application["future"] = "application"
+	unit := application["units"].(map[interface{}]interface{})["units"].([]interface{})[0].(map[interface{}]interface{})
+	unit["future"] = "unit"
+	relation := data["relations"].(map[interface{}]interface{})["relations"].([]interface{})[0].(map[interface{}]interface{})
+	relation["future"] = "relation"
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ModelSerializationSuite) TestPreserveUnknownFields(c *gc.C) {
+	bytes := s.withUnknownFields(c)
+	imported, err := Deserialize(bytes, PreserveUnknownFields())
+	c.Assert(err, jc.ErrorIsNil)
+	reserialized, err := Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unknown fields are written out where they were read.
+	var obtained, source map[string]interface{}
+	c.Assert(yaml.Unmarshal(reserialized, &obtained), jc.ErrorIsNil)
+	c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, source)
+
+	// They are written out as the yaml package would write them.
+	expected, err := yaml.Marshal(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reserialized), gc.Equals, string(expected))
+}
+
+func (s *ModelSerializationSuite) TestUnknownFieldsDropped(c *gc.C) {
+	imported, err := Deserialize(s.withUnknownFields(c))
+	c.Assert(err, jc.ErrorIsNil)
+	reserialized, err := Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reserialized), gc.Equals, string(expected))
+}
+
+func (s *ModelSerializationSuite) TestPreserveUnknownFieldsNoneUnknown(c *gc.C) {
+	initial := selfTestModel()
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes, PreserveUnknownFields())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.(*model).UnknownFields_, gc.IsNil)
+	c.Assert(imported.Machines()[0].(*machine).UnknownFields_, gc.IsNil)
+
+	reserialized, err := Serialize(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(reserialized), gc.Equals, string(bytes))
+}
+
+func (s *ModelSerializationSuite) TestPreserveUnknownFieldsNewerVersion(c *gc.C) {
+	data := asStringMap(c, selfTestModel())
+	data["version"] = 42
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes, PreserveUnknownFields())
+	c.Assert(err, gc.ErrorMatches, "version 42 not valid")
+
+	data = asStringMap(c, selfTestModel())
+	data["machines"].(map[interface{}]interface{})["version"] = 42
+	bytes, err = yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes, PreserveUnknownFields())
+	c.Assert(err, gc.ErrorMatches, "machines: version 42 not valid")
+}
+
+func (s *ModelSerializationSuite) TestPreserveUnknownFieldsLabels(c *gc.C) {
+	bytes, err := SerializeWithOptions(selfTestModel(), ExportOptions{
+		Labels:    map[string]string{"environment": "staging"},
+		Integrity: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes, PreserveUnknownFields())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The keys the import reads aren't unknown, though they aren't
+	// fields of the model.
+	c.Assert(imported.(*model).UnknownFields_, gc.IsNil)
+	c.Assert(imported.Labels(), jc.DeepEquals, map[string]string{"environment": "staging"})
+}
+
+func (s *ModelSerializationSuite) TestRequireStatusTimes(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes, RequireStatusTimes())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestRequireStatusTimesModelV1(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	data := asStringMap(c, initial)
+	data["version"] = 1
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes, RequireStatusTimes())
+	c.Assert(err, gc.ErrorMatches, "model v1 missing status not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestRequireStatusTimesModificationStatus(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachineWithMissingModificationStatus(initial, "0")
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes, RequireStatusTimes())
+	c.Assert(err, gc.ErrorMatches, "machine 0 instance v6 missing modification status not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestValidateDocumentWithLogger(c *gc.C) {
+	serialized, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c.Assert(ValidateDocument(serialized, WithLogger(logger)), jc.ErrorIsNil)
+
+	output := buf.String()
+	c.Check(output, jc.Contains, `msg="imported section" section=applications duration=`)
+	c.Check(output, jc.Contains, `msg="validated section" section=machines duration=`)
+	c.Check(output, jc.Contains, `msg="validated section" section="cross checks" duration=`)
+	c.Check(output, gc.Not(jc.Contains), `msg="validation failure"`)
+
+	invalid := selfTestModel()
+	invalid.Machines_.Machines_[0].Base_ = "ubuntu"
+	serialized, err = Serialize(invalid)
+	c.Assert(err, jc.ErrorIsNil)
+	buf.Reset()
+	err = ValidateDocument(serialized, WithLogger(logger))
+	c.Assert(err, gc.ErrorMatches, `machine "0" base "ubuntu" not valid`)
+	c.Check(buf.String(), jc.Contains, `msg="validation failure"`)
+	c.Check(buf.String(), jc.Contains, `base \"ubuntu\" not valid`)
+}
+
 func (s *ModelSerializationSuite) TestParsingYAML(c *gc.C) {
 	s.testParsingYAMLWithMachine(c, func(initial Model) {
 		addMinimalMachine(initial, "0")
@@ -164,12 +497,15 @@ func (s *ModelSerializationSuite) TestParsingYAML(c *gc.C) {
 }
 
 func (s *ModelSerializationSuite) TestParsingYAMLWithMissingModificationStatus(c *gc.C) {
-	s.testParsingYAMLWithMachine(c, func(initial Model) {
+	model := s.testParsingYAMLWithMachine(c, func(initial Model) {
 		addMinimalMachineWithMissingModificationStatus(initial, "0")
 	})
+	status := model.Machines()[0].Instance().ModificationStatus()
+	c.Assert(status.Value(), gc.Equals, "idle")
+	c.Assert(model.Validate(), jc.ErrorIsNil)
 }
 
-func (s *ModelSerializationSuite) testParsingYAMLWithMachine(c *gc.C, machineFn func(Model)) {
+func (s *ModelSerializationSuite) testParsingYAMLWithMachine(c *gc.C, machineFn func(Model)) Model {
 	args := ModelArgs{
 		AgentVersion: "3.1.1",
 		Type:         IAAS,
@@ -223,6 +559,7 @@ func (s *ModelSerializationSuite) testParsingYAMLWithMachine(c *gc.C, machineFn
 	applications := model.Applications()
 	c.Assert(applications, gc.HasLen, 1)
 	c.Assert(applications[0].Name(), gc.Equals, "ubuntu")
+	return model
 }
 
 func (s *ModelSerializationSuite) newModel(args ModelArgs) Model {
@@ -316,6 +653,23 @@ func (s *ModelSerializationSuite) TestModelValidationChecksMachines(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotValid)
 }
 
+func (s *ModelSerializationSuite) TestModelValidationCAASModelWithMachines(c *gc.C) {
+	model := s.newModel(ModelArgs{Type: CAAS, Owner: names.NewUserTag("owner")})
+	s.addMachineToModel(model, "0")
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `machine "0" in CAAS model not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationApplicationTypeMismatch(c *gc.C) {
+	model := s.newModel(ModelArgs{Type: IAAS, Owner: names.NewUserTag("owner")})
+	application := model.AddApplication(minimalApplicationArgs(CAAS))
+	application.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `caas application "ubuntu" in iaas model not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *ModelSerializationSuite) addMachineToModel(model Model, id string) Machine {
 	machine := model.AddMachine(MachineArgs{Id: names.NewMachineTag(id)})
 	machine.SetInstance(CloudInstanceArgs{InstanceId: "magic"})
@@ -400,6 +754,89 @@ func (s *ModelSerializationSuite) TestModelValidationChecksApplications(c *gc.C)
 	c.Assert(err, jc.Satisfies, errors.IsNotValid)
 }
 
+func (s *ModelSerializationSuite) TestModelValidationChecksLeadership(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	ubuntu := s.addApplicationToModel(model, "ubuntu", 1).(*application)
+	wordpress := s.addApplicationToModel(model, "wordpress", 1).(*application)
+	ubuntu.Leader_ = "ubuntu/0"
+	wordpress.Leader_ = "wordpress/0"
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+	addUnit := func(name string) {
+		unit := wordpress.AddUnit(UnitArgs{Tag: names.NewUnitTag(name), Machine: names.NewMachineTag("0")})
+		unit.SetTools(minimalAgentToolsArgs())
+		unit.SetAgentStatus(minimalStatusArgs())
+		unit.SetWorkloadStatus(minimalStatusArgs())
+	}
+
+	// A unit named for another application leading this one.
+	addUnit("ubuntu/1")
+	wordpress.Leader_ = "ubuntu/1"
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" leader "ubuntu/1" of application "ubuntu" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	// The same unit leading two applications.
+	addUnit("ubuntu/0")
+	wordpress.Leader_ = "ubuntu/0"
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" leader of applications "ubuntu" and "wordpress" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLeaseHolders(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addApplicationToModel(model, "ubuntu", 1)
+	wordpress := s.addApplicationToModel(model, "wordpress", 1).(*application)
+	wordpress.Leader_ = "wordpress/0"
+	lease := LeaseArgs{
+		Name:   "wordpress",
+		Holder: "wordpress/0",
+		Start:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Expiry: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+	}
+	wordpress.SetLease(lease)
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	// The application checks its lease against its leader first, so only
+	// ValidateAll goes on to report the holder isn't one of its units.
+	lease.Holder = "ubuntu/0"
+	wordpress.SetLease(lease)
+	err := model.ValidateAll()
+	c.Assert(err, gc.ErrorMatches, `(?s).*application "wordpress" lease holder "ubuntu/0" not a unit of application "wordpress" not valid.*`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksActionStatuses(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	operation := model.AddOperation(OperationArgs{Id: "1", Status: OperationRunning}).(*operation)
+	action := model.AddAction(ActionArgs{Id: "2", Operation: "1", Status: ActionPending}).(*action)
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	action.Status_ = ActionAborting
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	action.Status_ = "stopped"
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `action "2": status "stopped" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	action.Status_ = ActionCompleted
+	operation.Status_ = ""
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `operation "1": status "" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksActionOperations(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddOperation(OperationArgs{Id: "1", Status: OperationRunning})
+	model.AddAction(ActionArgs{Id: "2", Operation: "1", Status: ActionPending})
+	model.AddAction(ActionArgs{Id: "3", Status: ActionPending})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddAction(ActionArgs{Id: "4", Operation: "5", Status: ActionPending})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `action "4" references non-existent operation "5"`)
+}
+
 func (s *ModelSerializationSuite) addApplicationToModel(model Model, name string, numUnits int) Application {
 	application := model.AddApplication(ApplicationArgs{
 		Tag:                names.NewApplicationTag(name),
@@ -463,6 +900,26 @@ func (s *ModelSerializationSuite) wordpressModelWithSettings() Model {
 	return model
 }
 
+func (s *ModelSerializationSuite) TestApplicationAndUnitNames(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	c.Check(model.ApplicationNames(), gc.HasLen, 0)
+	c.Check(model.UnitNames(), gc.HasLen, 0)
+
+	for _, name := range []string{"wordpress", "mysql"} {
+		application := model.AddApplication(ApplicationArgs{Tag: names.NewApplicationTag(name)})
+		for _, n := range []int{10, 2} {
+			application.AddUnit(UnitArgs{Tag: names.NewUnitTag(fmt.Sprintf("%s/%d", name, n))})
+		}
+	}
+	model.AddRemoteApplication(RemoteApplicationArgs{Tag: names.NewApplicationTag("remote")})
+
+	c.Check(model.ApplicationNames(), jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Check(model.UnitNames(), jc.DeepEquals, []string{
+		"mysql/2", "mysql/10", "wordpress/2", "wordpress/10",
+	})
+	c.Check(NewSynchronizedModel(model).UnitNames(), jc.DeepEquals, model.UnitNames())
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksRelationsMissingSettings(c *gc.C) {
 	model, _, _ := s.wordpressModel()
 	err := model.Validate()
@@ -594,6 +1051,109 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelationNetworks(c *
 	c.Assert(result, jc.DeepEquals, model)
 }
 
+func (s *ModelSerializationSuite) TestRelationNetworksByDirection(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:admin",
+		RelationKey: "wordpress:db mysql:server",
+		CIDRS:       []string{"10.0.0.0/16"},
+		Origin:      RelationNetworkOriginAdmin,
+	})
+	initial.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:egress:default",
+		RelationKey: "wordpress:db mysql:server",
+		CIDRS:       []string{"10.1.0.0/16"},
+		Direction:   RelationNetworkEgress,
+	})
+
+	model := s.exportImport(c, initial)
+	ingress := model.RelationIngressNetworks()
+	c.Assert(ingress, gc.HasLen, 1)
+	c.Check(ingress[0].ID(), gc.Equals, "rel:ingress:admin")
+	c.Check(ingress[0].Origin(), gc.Equals, RelationNetworkOriginAdmin)
+	egress := model.RelationEgressNetworks()
+	c.Assert(egress, gc.HasLen, 1)
+	c.Check(egress[0].ID(), gc.Equals, "rel:egress:default")
+	c.Check(egress[0].CIDRS(), jc.DeepEquals, []string{"10.1.0.0/16"})
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksRelationNetworks(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:default",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.0.0.0/16", "10.1.0.0/16"},
+	})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:override",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.0.0.0/16", "10.1.0.0/16"},
+		Origin:      RelationNetworkOriginAdmin,
+	})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:other",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.1.0.0/16", "10.0.0.0/16"},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `relation network "rel:ingress:other" duplicates "rel:ingress:default" not valid`)
+	c.Assert(model.NormalizeRelationNetworks(), jc.DeepEquals, []string{"rel:ingress:other"})
+
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:default",
+		RelationKey: "rel",
+		Direction:   RelationNetworkEgress,
+	})
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `relation network "rel:ingress:default" duplicate id not valid`)
+}
+
+func (s *ModelSerializationSuite) TestNormalizeRelationNetworks(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:default",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.0.0.0/16"},
+	})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:egress:default",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.0.0.0/16"},
+		Direction:   RelationNetworkEgress,
+	})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:other",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.1.0.0/16", "10.0.0.0/16"},
+	})
+	model.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "rel:ingress:again",
+		RelationKey: "rel",
+		CIDRS:       []string{"10.2.0.0/16"},
+	})
+
+	removed := model.NormalizeRelationNetworks()
+	c.Assert(removed, jc.DeepEquals, []string{"rel:ingress:again", "rel:ingress:other"})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	networks := model.RelationNetworks()
+	c.Assert(networks, gc.HasLen, 2)
+	for _, network := range networks {
+		switch network.Direction() {
+		case RelationNetworkIngress:
+			c.Check(network.ID(), gc.Equals, "rel:ingress:default")
+			c.Check(network.CIDRS(), jc.DeepEquals, []string{"10.0.0.0/16", "10.1.0.0/16", "10.2.0.0/16"})
+		case RelationNetworkEgress:
+			c.Check(network.ID(), gc.Equals, "rel:egress:default")
+			c.Check(network.CIDRS(), jc.DeepEquals, []string{"10.0.0.0/16"})
+		}
+	}
+
+	c.Assert(model.NormalizeRelationNetworks(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksSubnets(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceID: "3"})
@@ -605,6 +1165,175 @@ func (s *ModelSerializationSuite) TestModelValidationChecksSubnets(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSerializationSuite) TestModelValidationChecksSpaceReferences(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSpace(SpaceArgs{Id: "1", Name: "beta"})
+	application := s.addApplicationToModel(model, "ubuntu", 1).(*application)
+	application.Leader_ = "ubuntu/0"
+	machine := model.Machines()[0]
+	application.EndpointBindings_ = map[string]string{
+		"":          DefaultSpaceId,
+		"juju-info": "1",
+		"db":        "beta",
+		"admin":     DefaultSpaceName,
+	}
+	application.SetConstraints(ConstraintsArgs{Spaces: []string{"beta", "^alpha"}})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	application.EndpointBindings_["website"] = "2"
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" endpoint "website" bound to non-existent space "2"`)
+	delete(application.EndpointBindings_, "website")
+
+	application.SetConstraints(ConstraintsArgs{Spaces: []string{"^gamma"}})
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" constraints reference non-existent space "gamma"`)
+	application.SetConstraints(ConstraintsArgs{})
+
+	machine.SetConstraints(ConstraintsArgs{Spaces: []string{"delta"}})
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `machine "0" constraints reference non-existent space "delta"`)
+	machine.SetConstraints(ConstraintsArgs{})
+
+	model.SetConstraints(ConstraintsArgs{Spaces: []string{"epsilon"}})
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `model constraints reference non-existent space "epsilon"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetCIDRs(c *gc.C) {
+	for i, test := range []struct {
+		subnets []SubnetArgs
+		err     string
+	}{{
+		subnets: []SubnetArgs{
+			{CIDR: "10.0.0.0/24", SpaceID: "3"},
+			{CIDR: "10.0.1.0/24", SpaceID: "3"},
+			{CIDR: "10.0.0.0/16", SpaceID: "4"},
+			{CIDR: "2001:db8::/64", SpaceID: "3"},
+		},
+	}, {
+		subnets: []SubnetArgs{
+			{CIDR: "10.0.0.0/16", SpaceID: "3"},
+			{CIDR: "10.0.1.0/24", SpaceID: "3"},
+		},
+		err: `subnet "10.0.1.0/24" overlapping "10.0.0.0/16" in space "3" not valid`,
+	}, {
+		subnets: []SubnetArgs{
+			{CIDR: "10.0.1.0/24"},
+			{CIDR: "10.0.0.0/16", SpaceID: "0"},
+		},
+		err: `subnet "10.0.0.0/16" overlapping "10.0.1.0/24" in space "0" not valid`,
+	}, {
+		subnets: []SubnetArgs{
+			{CIDR: "10.0.0.0/24", SpaceID: "3"},
+			{CIDR: "10.0.0.0/24", SpaceID: "4"},
+		},
+		err: `subnet "10.0.0.0/24" in spaces "3" and "4" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+		model.AddSpace(SpaceArgs{Id: "3", Name: "three"})
+		model.AddSpace(SpaceArgs{Id: "4", Name: "four"})
+		for _, args := range test.subnets {
+			model.AddSubnet(args)
+		}
+		err := model.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ModelSerializationSuite) TestValidateAll(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	c.Assert(model.ValidateAll(), jc.ErrorIsNil)
+
+	model.AddMachine(MachineArgs{Id: names.NewMachineTag("0"), Base: "ubuntu"})
+	addMinimalApplication(model)
+	model.Applications()[0].AddUnit(UnitArgs{Tag: names.NewUnitTag("ubuntu/1")})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceID: "3"})
+
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `machine "0" base "ubuntu" not valid`)
+
+	err = model.ValidateAll()
+	c.Assert(err, gc.FitsTypeOf, ValidationErrors{})
+	c.Check(err, gc.ErrorMatches, ""+
+		`machines\[0\]: machine "0" base "ubuntu" not valid\n`+
+		`applications\[0\].units\[1\]: unit "ubuntu/1" missing agent status not valid\n`+
+		`subnet "10.0.0.0/24" references non-existent space "3"`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSpaceProviderIDs(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSpace(SpaceArgs{Id: "1", Name: "one", ProviderID: "provider-one"})
+	model.AddSpace(SpaceArgs{Id: "2", Name: "two"})
+	model.AddSpace(SpaceArgs{Id: "3", Name: "three"})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddSpace(SpaceArgs{Id: "4", Name: "four", ProviderID: "provider-one"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `space "four" duplicate provider id "provider-one" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestSubnetsInSpace(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSpace(SpaceArgs{Id: "3", Name: "three"})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceID: "3"})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.1.0/24"})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.2.0/24", SpaceID: "3"})
+
+	subnets := model.SubnetsInSpace("3")
+	c.Assert(subnets, gc.HasLen, 2)
+	c.Check(subnets[0].CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(subnets[1].CIDR(), gc.Equals, "10.0.2.0/24")
+	c.Check(model.SubnetsInSpace("4"), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetFan(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSubnet(SubnetArgs{CIDR: "172.31.0.0/16"})
+	model.AddSubnet(SubnetArgs{
+		CIDR:             "252.0.0.0/12",
+		FanLocalUnderlay: "172.31.0.0/16",
+		FanOverlay:       "252.0.0.0/8",
+	})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetFanErrors(c *gc.C) {
+	for i, test := range []struct {
+		args SubnetArgs
+		err  string
+	}{{
+		args: SubnetArgs{CIDR: "252.0.0.0/12", FanOverlay: "252.0.0.0/8"},
+		err:  `subnet "252.0.0.0/12" fan underlay "" and overlay "252.0.0.0/8" not valid`,
+	}, {
+		args: SubnetArgs{CIDR: "252.0.0.0/12", FanLocalUnderlay: "172.31.0.0/16"},
+		err:  `subnet "252.0.0.0/12" fan underlay "172.31.0.0/16" and overlay "" not valid`,
+	}, {
+		args: SubnetArgs{CIDR: "252.0.0.0/12", FanLocalUnderlay: "bad", FanOverlay: "252.0.0.0/8"},
+		err:  `subnet "252.0.0.0/12" fan underlay "bad" not valid`,
+	}, {
+		args: SubnetArgs{CIDR: "252.0.0.0/12", FanLocalUnderlay: "172.31.0.0/16", FanOverlay: "bad"},
+		err:  `subnet "252.0.0.0/12" fan overlay "bad" not valid`,
+	}, {
+		args: SubnetArgs{CIDR: "10.0.0.0/24", FanLocalUnderlay: "172.31.0.0/16", FanOverlay: "252.0.0.0/8"},
+		err:  `subnet "10.0.0.0/24" not in fan overlay "252.0.0.0/8" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+		model.AddSubnet(test.args)
+		err := model.Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksAddressMachineID(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddIPAddress(IPAddressArgs{Value: "192.168.1.0", MachineID: "42"})
@@ -681,6 +1410,22 @@ func (s *ModelSerializationSuite) TestModelValidationChecksAddressSucceeds(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSerializationSuite) TestModelValidationChecksAddressConfigMethod(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := IPAddressArgs{
+		MachineID:    "42",
+		DeviceName:   "foo",
+		Value:        "192.168.1.1",
+		SubnetCIDR:   "192.168.1.0/24",
+		ConfigMethod: "bogus",
+	}
+	model.AddIPAddress(args)
+	s.addMachineToModel(model, "42")
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "foo", MachineID: "42"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `ip address "192.168.1.1" has invalid config method "bogus"`)
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksAddressGatewayAddressInvalid(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	args := IPAddressArgs{
@@ -714,6 +1459,54 @@ func (s *ModelSerializationSuite) TestModelValidationChecksAddressGatewayAddress
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSerializationSuite) addSecondaryAddressTestModel(primaries int, shadow bool) Model {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addMachineToModel(model, "42")
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "foo", MachineID: "42"})
+	for i := 0; i < primaries; i++ {
+		model.AddIPAddress(IPAddressArgs{
+			MachineID:  "42",
+			DeviceName: "foo",
+			Value:      fmt.Sprintf("192.168.1.%d", i+1),
+			SubnetCIDR: "192.168.1.0/24",
+		})
+	}
+	model.AddIPAddress(IPAddressArgs{
+		MachineID:   "42",
+		DeviceName:  "foo",
+		Value:       "192.168.1.100",
+		SubnetCIDR:  "192.168.1.0/24",
+		IsSecondary: true,
+	})
+	if shadow {
+		model.AddIPAddress(IPAddressArgs{
+			MachineID:  "42",
+			DeviceName: "foo",
+			Value:      "54.1.2.3",
+			SubnetCIDR: "192.168.1.0/24",
+			IsShadow:   true,
+		})
+	}
+	return model
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSecondaryAddressPrimary(c *gc.C) {
+	model := s.addSecondaryAddressTestModel(1, true)
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSecondaryAddressNoPrimary(c *gc.C) {
+	model := s.addSecondaryAddressTestModel(0, true)
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "foo" on machine "42" has 0 primary addresses in subnet "192.168.1.0/24", expected 1`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSecondaryAddressManyPrimaries(c *gc.C) {
+	model := s.addSecondaryAddressTestModel(2, false)
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "foo" on machine "42" has 2 primary addresses in subnet "192.168.1.0/24", expected 1`)
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksLinkLayerDeviceMachineId(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "foo", MachineID: "42"})
@@ -774,6 +1567,56 @@ func (s *ModelSerializationSuite) TestModelValidationChecksParentIsABridge(c *gc
 	c.Assert(err, gc.ErrorMatches, `device "foo" on a container but not a bridge`)
 }
 
+func (s *ModelSerializationSuite) TestModelValidationChecksVLANTag(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := LinkLayerDeviceArgs{MachineID: "42", Name: "eth0", Type: "ethernet", VLANTag: 100}
+	model.AddLinkLayerDevice(args)
+	s.addMachineToModel(model, "42")
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "eth0" of type "ethernet" has VLAN tag 100`)
+
+	model = s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args = LinkLayerDeviceArgs{MachineID: "42", Name: "eth0.5000", Type: "802.1q", VLANTag: 5000}
+	model.AddLinkLayerDevice(args)
+	s.addMachineToModel(model, "42")
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "eth0.5000" has invalid VLAN tag 5000`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksBondMode(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := LinkLayerDeviceArgs{MachineID: "42", Name: "br0", Type: "bridge", BondMode: "802.3ad"}
+	model.AddLinkLayerDevice(args)
+	s.addMachineToModel(model, "42")
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "br0" of type "bridge" has bond mode "802.3ad"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVLANParent(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{MachineID: "42", Name: "lo", Type: "loopback"})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{MachineID: "42", Name: "bond0", Type: "bond", BondMode: "active-backup"})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		MachineID:  "42",
+		Name:       "bond0.100",
+		Type:       "802.1q",
+		ParentName: "bond0",
+		VLANTag:    100,
+	})
+	s.addMachineToModel(model, "42")
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		MachineID:  "42",
+		Name:       "lo.100",
+		Type:       "802.1q",
+		ParentName: "lo",
+		VLANTag:    100,
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `VLAN device "lo.100" has parent "lo" of type "loopback"`)
+}
+
 func (s *ModelSerializationSuite) TestModelValidationChecksChildDeviceContained(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	args := LinkLayerDeviceArgs{MachineID: "42", Name: "foo", ParentName: "m#43#d#bar"}
@@ -796,6 +1639,7 @@ func (s *ModelSerializationSuite) TestModelValidationChecksParentOnHost(c *gc.C)
 	container := machine.AddContainer(MachineArgs{Id: names.NewMachineTag("41/lxd/0")})
 	container.SetInstance(CloudInstanceArgs{InstanceId: "magic"})
 	container.Instance().SetStatus(minimalStatusArgs())
+	container.Instance().SetModificationStatus(minimalStatusArgs())
 	container.SetTools(minimalAgentToolsArgs())
 	container.SetStatus(minimalStatusArgs())
 	s.addMachineToModel(model, "43")
@@ -813,6 +1657,7 @@ func (s *ModelSerializationSuite) TestModelValidationLinkLayerDeviceContainer(c
 	container := machine.AddContainer(MachineArgs{Id: names.NewMachineTag("43/lxd/0")})
 	container.SetInstance(CloudInstanceArgs{InstanceId: "magic"})
 	container.Instance().SetStatus(minimalStatusArgs())
+	container.Instance().SetModificationStatus(minimalStatusArgs())
 	container.SetTools(minimalAgentToolsArgs())
 	container.SetStatus(minimalStatusArgs())
 	err := model.Validate()
@@ -952,6 +1797,71 @@ func (s *ModelSerializationSuite) TestImportingWithRemoteApplications(c *gc.C) {
 	c.Assert(ra[0], gc.DeepEquals, remoteApplications[0])
 }
 
+func (s *ModelSerializationSuite) TestImportingRemoteApplicationSpacesAndBindings(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("veils")})
+	rapp := initial.AddRemoteApplication(RemoteApplicationArgs{
+		Tag:         names.NewApplicationTag("bloom"),
+		OfferUUID:   "offer-uuid",
+		URL:         "other.mysql",
+		SourceModel: names.NewModelTag("some-model"),
+		Bindings:    map[string]string{"db": "private", "admin": "public"},
+	})
+	rapp.AddEndpoint(RemoteEndpointArgs{Name: "db", Role: "provider", Interface: "mysql"})
+	rapp.AddEndpoint(RemoteEndpointArgs{Name: "admin", Role: "provider", Interface: "http"})
+	private := rapp.AddSpace(RemoteSpaceArgs{
+		CloudType:          "gce",
+		Name:               "private",
+		ProviderId:         "juju-space-private",
+		ProviderAttributes: map[string]interface{}{"project": "gothic", "zones": 2},
+	})
+	private.AddSubnet(SubnetArgs{
+		CIDR:              "2.3.4.0/24",
+		ProviderId:        "juju-subnet-1",
+		VLANTag:           42,
+		AvailabilityZones: []string{"az1", "az2"},
+		ProviderSpaceId:   "juju-space-private",
+		ProviderNetworkId: "network-1",
+	})
+	rapp.AddSpace(RemoteSpaceArgs{
+		CloudType:  "gce",
+		Name:       "public",
+		ProviderId: "juju-space-public",
+	})
+
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remoteApplications := result.RemoteApplications()
+	c.Assert(remoteApplications, gc.HasLen, 1)
+	imported := remoteApplications[0]
+	c.Check(imported.Bindings(), jc.DeepEquals, map[string]string{"db": "private", "admin": "public"})
+
+	spaces := imported.Spaces()
+	c.Assert(spaces, gc.HasLen, 2)
+	c.Check(spaces[0].CloudType(), gc.Equals, "gce")
+	c.Check(spaces[0].Name(), gc.Equals, "private")
+	c.Check(spaces[0].ProviderId(), gc.Equals, "juju-space-private")
+	c.Check(spaces[0].ProviderAttributes(), jc.DeepEquals, map[string]interface{}{"project": "gothic", "zones": 2})
+	subnets := spaces[0].Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].CIDR(), gc.Equals, "2.3.4.0/24")
+	c.Check(subnets[0].ProviderId(), gc.Equals, "juju-subnet-1")
+	c.Check(subnets[0].VLANTag(), gc.Equals, 42)
+	c.Check(subnets[0].AvailabilityZones(), jc.DeepEquals, []string{"az1", "az2"})
+	c.Check(subnets[0].ProviderSpaceId(), gc.Equals, "juju-space-private")
+	c.Check(subnets[0].ProviderNetworkId(), gc.Equals, "network-1")
+	c.Check(spaces[1].Name(), gc.Equals, "public")
+	c.Check(spaces[1].ProviderId(), gc.Equals, "juju-space-public")
+	c.Check(spaces[1].Subnets(), gc.HasLen, 0)
+
+	// The remote application is the same after a second round trip.
+	again, err := Serialize(result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(again), gc.Equals, string(bytes))
+}
+
 func (s *ModelSerializationSuite) TestRemoteApplicationsGetter(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("veils")})
 	model.AddRemoteApplication(RemoteApplicationArgs{
@@ -1167,7 +2077,7 @@ func (s *ModelSerializationSuite) TestSerializesToLatestVersion(c *gc.C) {
 	c.Assert(ok, jc.IsTrue)
 	version, ok := versionValue.(int)
 	c.Assert(ok, jc.IsTrue)
-	c.Assert(version, gc.Equals, 11)
+	c.Assert(version, gc.Equals, 19)
 }
 
 func (s *ModelSerializationSuite) TestVersion1Works(c *gc.C) {
@@ -1292,6 +2202,66 @@ func (s *ModelSerializationSuite) TestSSHHostKey(c *gc.C) {
 	c.Assert(model.SSHHostKeys(), jc.DeepEquals, keys)
 }
 
+func (s *ModelSerializationSuite) TestVirtualHostKeys(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	unitKey := initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("unit key")))
+	machineKey := initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("machine key")))
+	keys := initial.VirtualHostKeys()
+	c.Assert(keys, jc.DeepEquals, []VirtualHostKey{machineKey, unitKey})
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.VirtualHostKeys(), jc.DeepEquals, keys)
+}
+
+func (s *ModelSerializationSuite) TestVirtualHostKeysPre20Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("machine key")))
+	data := asStringMap(c, initial)
+	data["version"] = 19
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.VirtualHostKeys(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestVirtualHostKeysValidate(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	addMinimalApplication(initial)
+	initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("machine key")))
+	initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("unit key")))
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("other key")))
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `virtual host key\[2\] duplicate id "machine-0-hostkey" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestVirtualHostKeysValidateTargets(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	initial.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("1"), []byte("machine key")))
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `virtual host key "machine-1-hostkey" references non-existent machine "1"`)
+
+	initial = s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalMachine(initial, "0")
+	initial.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("unit key")))
+	err = initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `virtual host key "unit-ubuntu-0-hostkey" references non-existent unit "ubuntu/0"`)
+
+	initial = s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddVirtualHostKey(VirtualHostKeyArgs{ID: "key-id", Target: names.NewApplicationTag("ubuntu"), HostKey: []byte("key")})
+	err = initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `virtual host key\[0\]: virtual host key "key-id" target "application-ubuntu" not valid`)
+}
+
 func (s *ModelSerializationSuite) TestCloudImageMetadata(c *gc.C) {
 	storageSize := uint64(3)
 	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
@@ -1382,6 +2352,36 @@ func (s *ModelSerializationSuite) TestOperation(c *gc.C) {
 	c.Assert(model.Operations(), jc.DeepEquals, operations)
 }
 
+func (s *ModelSerializationSuite) TestOperationActions(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	// Actions are linked to their operation whichever is added first.
+	initial.AddAction(ActionArgs{Id: "10", Operation: "1", Status: ActionCompleted})
+	op := initial.AddOperation(OperationArgs{Id: "1", Status: OperationRunning})
+	initial.AddAction(ActionArgs{Id: "2", Operation: "1", Status: ActionRunning})
+	initial.AddAction(ActionArgs{Id: "3", Status: ActionPending})
+	actionIds := func(op Operation) []string {
+		var ids []string
+		for _, action := range op.Actions() {
+			c.Check(action.Operation(), gc.Equals, op.Id())
+			ids = append(ids, action.Id())
+		}
+		return ids
+	}
+	c.Assert(actionIds(op), jc.DeepEquals, []string{"2", "10"})
+
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Operations(), gc.HasLen, 1)
+	c.Assert(actionIds(model.Operations()[0]), jc.DeepEquals, []string{"2", "10"})
+
+	// A copy's operations are linked to the copy's actions.
+	copied := model.Copy()
+	c.Assert(copied.Operations()[0].Actions()[0], gc.Not(gc.Equals), model.Operations()[0].Actions()[0])
+	c.Assert(actionIds(copied.Operations()[0]), jc.DeepEquals, []string{"2", "10"})
+}
+
 func (s *ModelSerializationSuite) TestVolumeValidation(c *gc.C) {
 	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddVolume(testVolumeArgs())
@@ -1513,29 +2513,208 @@ func (s *ModelSerializationSuite) TestStoragePools(c *gc.C) {
 	c.Check(two.Attributes(), jc.DeepEquals, poolTwo)
 }
 
-func (s *ModelSerializationSuite) TestStatus(c *gc.C) {
-	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
-	initial.SetStatus(minimalStatusArgs())
-	expected := minimalStatus()
-	c.Check(initial.Status(), jc.DeepEquals, expected)
+func (s *ModelSerializationSuite) TestStatus(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.SetStatus(minimalStatusArgs())
+	expected := minimalStatus()
+	c.Check(initial.Status(), jc.DeepEquals, expected)
+
+	model := s.exportImport(c, initial)
+	c.Check(model.Status(), jc.DeepEquals, expected)
+}
+
+func (s *ModelSerializationSuite) TestSecretBackend(c *gc.C) {
+	initial := s.newModel(ModelArgs{
+		Owner:           names.NewUserTag("owner"),
+		SecretBackendID: "backend-id",
+	})
+	c.Assert(initial.SecretBackendID(), gc.Equals, "backend-id")
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SecretBackendID(), jc.DeepEquals, "backend-id")
+}
+
+func (s *ModelSerializationSuite) TestSecretBackends(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	interval := time.Hour
+	backend := initial.AddSecretBackend(SecretBackendArgs{
+		ID:                  "backend-id",
+		Name:                "myvault",
+		BackendType:         "vault",
+		Config:              map[string]interface{}{"endpoint": "http://vault:8200"},
+		TokenRotateInterval: &interval,
+	})
+	backends := initial.SecretBackends()
+	c.Assert(backends, gc.HasLen, 1)
+	c.Assert(backends[0], jc.DeepEquals, backend)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SecretBackends(), jc.DeepEquals, backends)
+}
+
+func (s *ModelSerializationSuite) TestSecretBackendsPre16Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddSecretBackend(minimalSecretBackendArgs())
+	data := asStringMap(c, initial)
+	data["version"] = 15
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SecretBackends(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) annotatedModel(c *gc.C) Model {
+	model := s.newModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	addMinimalMachine(model, "0")
+	addMinimalApplication(model)
+	model.AddUser(UserArgs{Name: names.NewUserTag("bob"), CreatedBy: names.NewUserTag("owner")})
+	model.AddSpace(SpaceArgs{Id: "1", Name: "alpha"})
+	for i, tag := range []names.Tag{
+		names.NewModelTag("some-uuid"),
+		names.NewMachineTag("0"),
+		names.NewApplicationTag("ubuntu"),
+		names.NewUnitTag("ubuntu/0"),
+		names.NewUserTag("bob"),
+		names.NewSpaceTag("alpha"),
+	} {
+		err := model.SetAnnotationsForEntity(tag, map[string]string{"index": fmt.Sprint(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return model
+}
+
+func (s *ModelSerializationSuite) TestAnnotationsForEntity(c *gc.C) {
+	model := s.annotatedModel(c)
+
+	// Entities with their own annotations are set directly.
+	c.Check(model.Annotations(), jc.DeepEquals, map[string]string{"index": "0"})
+	c.Check(model.Machines()[0].Annotations(), jc.DeepEquals, map[string]string{"index": "1"})
+	c.Check(model.Applications()[0].Annotations(), jc.DeepEquals, map[string]string{"index": "2"})
+	c.Check(model.Applications()[0].Units()[0].Annotations(), jc.DeepEquals, map[string]string{"index": "3"})
+
+	c.Check(model.AnnotationsForEntity(names.NewUnitTag("ubuntu/0")), jc.DeepEquals, map[string]string{"index": "3"})
+	c.Check(model.AnnotationsForEntity(names.NewSpaceTag("alpha")), jc.DeepEquals, map[string]string{"index": "5"})
+	c.Check(model.AnnotationsForEntity(names.NewSpaceTag("beta")), gc.IsNil)
+
+	err := model.SetAnnotationsForEntity(names.NewSpaceTag("beta"), map[string]string{"key": "value"})
+	c.Check(err, gc.ErrorMatches, `space "beta" not found`)
+	c.Check(errors.Is(err, errors.NotFound), jc.IsTrue)
+	err = model.SetAnnotationsForEntity(names.NewCloudTag("vapour"), map[string]string{"key": "value"})
+	c.Check(err, gc.ErrorMatches, `annotations for cloud not supported`)
+
+	err = model.SetAnnotationsForEntity(names.NewSpaceTag("alpha"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.AnnotationsForEntity(names.NewSpaceTag("alpha")), gc.IsNil)
+}
+
+func (s *ModelSerializationSuite) TestAnnotationsIndex(c *gc.C) {
+	model := s.annotatedModel(c)
+	c.Assert(model.AnnotationsIndex(), jc.DeepEquals, map[string]map[string]string{
+		"model-some-uuid":    {"index": "0"},
+		"machine-0":          {"index": "1"},
+		"application-ubuntu": {"index": "2"},
+		"unit-ubuntu-0":      {"index": "3"},
+		"user-bob":           {"index": "4"},
+		"space-alpha":        {"index": "5"},
+	})
+
+	bytes, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+	c.Assert(imported.AnnotationsIndex(), jc.DeepEquals, model.AnnotationsIndex())
+}
+
+func (s *ModelSerializationSuite) TestModelValidationEntityAnnotations(c *gc.C) {
+	m := s.annotatedModel(c).(*model)
+	c.Assert(m.Validate(), jc.ErrorIsNil)
+
+	m.EntityAnnotations_["space-beta"] = map[string]string{"key": "value"}
+	c.Assert(m.Validate(), gc.ErrorMatches, `annotations for "space-beta": space "beta" not found not valid`)
+	delete(m.EntityAnnotations_, "space-beta")
+
+	m.EntityAnnotations_["machine-0"] = map[string]string{"key": "value"}
+	c.Assert(m.Validate(), gc.ErrorMatches, `annotations for "machine-0" held by the model not valid`)
+	delete(m.EntityAnnotations_, "machine-0")
 
-	model := s.exportImport(c, initial)
-	c.Check(model.Status(), jc.DeepEquals, expected)
+	m.EntityAnnotations_["not a tag"] = map[string]string{"key": "value"}
+	c.Assert(m.Validate(), gc.ErrorMatches, `annotations for "not a tag" not valid`)
 }
 
-func (s *ModelSerializationSuite) TestSecretBackend(c *gc.C) {
-	initial := s.newModel(ModelArgs{
-		Owner:           names.NewUserTag("owner"),
-		SecretBackendID: "backend-id",
-	})
-	c.Assert(initial.SecretBackendID(), gc.Equals, "backend-id")
-
-	bytes, err := yaml.Marshal(initial)
+func (s *ModelSerializationSuite) TestEntityAnnotationsPre17Import(c *gc.C) {
+	data := asStringMap(c, s.annotatedModel(c))
+	data["version"] = 16
+	bytes, err := yaml.Marshal(data)
 	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := Deserialize(bytes)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(model.SecretBackendID(), jc.DeepEquals, "backend-id")
+	c.Assert(model.AnnotationsForEntity(names.NewUserTag("bob")), gc.IsNil)
+	c.Assert(model.AnnotationsForEntity(names.NewMachineTag("0")), jc.DeepEquals, map[string]string{"index": "1"})
+	c.Assert(model.AnnotationsForEntity(names.NewSpaceTag("alpha")), jc.DeepEquals, map[string]string{"index": "5"})
+}
+
+func (s *ModelSerializationSuite) TestEntityAnnotationsAdoptedBySpaces(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddSpace(SpaceArgs{Id: "1", Name: "alpha"})
+	initial.AddSpace(SpaceArgs{Id: "2", Name: "beta"})
+	data := asStringMap(c, initial)
+	// Spaces couldn't hold annotations before v3 of their section, so
+	// the model held them.
+	data["spaces"] = map[string]interface{}{
+		"version": 2,
+		"spaces": []interface{}{
+			map[string]interface{}{"id": "1", "name": "alpha", "public": false},
+			map[string]interface{}{"id": "2", "name": "beta", "public": false},
+		},
+	}
+	data["entity-annotations"] = map[string]interface{}{
+		"space-alpha": map[string]interface{}{"key": "value"},
+	}
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Validate(), jc.ErrorIsNil)
+	c.Assert(imported.Spaces()[0].Annotations(), jc.DeepEquals, map[string]string{"key": "value"})
+	c.Assert(imported.Spaces()[1].Annotations(), gc.HasLen, 0)
+	c.Assert(imported.(*model).EntityAnnotations_, gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestSecretBackendsValidate(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalApplication(initial)
+	secretArgs := testSecretArgs()
+	secretArgs.Owner = names.NewApplicationTag("ubuntu")
+	secretArgs.ACL = nil
+	secretArgs.Consumers = nil
+	secretArgs.RemoteConsumers = nil
+	initial.AddSecret(secretArgs)
+	// Without described backends the revisions aren't checked.
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	initial.AddSecretBackend(SecretBackendArgs{ID: "other-id", Name: "myvault", BackendType: "vault"})
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `secret\[0\] revision 2 backend "backend-id" not valid`)
+
+	initial.AddSecretBackend(SecretBackendArgs{ID: "backend-id", Name: "myvault", BackendType: "vault"})
+	err = initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `secret backend\[1\] duplicate name "myvault" not valid`)
 }
 
 func (s *ModelSerializationSuite) TestSecrets(c *gc.C) {
@@ -1601,6 +2780,126 @@ func (s *ModelSerializationSuite) TestSecretValidateRemoteConsumer(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `secret\[0\] remote consumer \(bar/0\) not valid`)
 }
 
+func (s *ModelSerializationSuite) danglingPrincipalsModel() Model {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddUser(UserArgs{
+		Name:      names.NewUserTag("bob"),
+		CreatedBy: names.NewUserTag("owner"),
+		Access:    "read",
+		AccessHistory: []AccessGrantArgs{
+			{Access: "read", GrantedBy: names.NewUserTag("owner")},
+			{Access: "write", GrantedBy: names.NewUserTag("carol")},
+		},
+	})
+	application := s.addApplicationToModel(model, "ubuntu", 1)
+	application.AddOffer(ApplicationOfferArgs{
+		OfferName: "my-offer",
+		ACL: map[string]string{
+			"owner":             "admin",
+			"bob":               "consume",
+			"mary":              "read",
+			"everyone@external": "read",
+		},
+		ApplicationName: "ubuntu",
+	})
+	secretArgs := testSecretArgs()
+	secretArgs.Owner = names.NewApplicationTag("ubuntu")
+	secretArgs.Consumers = nil
+	secretArgs.RemoteConsumers = nil
+	secretArgs.ACL = map[string]SecretAccessArgs{
+		"application-ubuntu": {Scope: "application-ubuntu", Role: "manage"},
+		"user-bob":           {Scope: "model-deadbeef", Role: "view"},
+		"user-jane":          {Scope: "model-deadbeef", Role: "view"},
+	}
+	model.AddSecret(secretArgs)
+	return model
+}
+
+func (s *ModelSerializationSuite) TestDanglingPrincipals(c *gc.C) {
+	model := s.danglingPrincipalsModel()
+	c.Assert(model.DanglingPrincipals(), jc.DeepEquals, []string{"carol", "jane", "mary"})
+
+	// Only importers that ask for it fail because of them.
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+	err := model.ValidateWith(DanglingPrincipalsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "dangling principals": application "ubuntu" offer "my-offer" unknown user "mary" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	delete(model.Applications()[0].Offers()[0].ACL(), "mary")
+	err = model.ValidateWith(DanglingPrincipalsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "dangling principals": secret ".*" unknown user "jane" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksOfferEndpoints(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	application := s.addApplicationToModel(model, "mysql", 1)
+	application.AddOffer(ApplicationOfferArgs{
+		OfferName:       "my-offer",
+		Endpoints:       map[string]string{"db": "server", "monitoring": "prometheus"},
+		ACL:             map[string]string{"owner": "admin"},
+		ApplicationName: "mysql",
+	})
+	// Without the charm metadata the endpoints can't be checked.
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	application.SetCharmMetadata(CharmMetadataArgs{
+		Name: "mysql",
+		Provides: map[string]CharmMetadataRelation{
+			"server": charmMetadataRelation{Name_: "server", Role_: "provider", Interface_: "mysql"},
+		},
+		Peers: map[string]CharmMetadataRelation{
+			"prometheus": charmMetadataRelation{Name_: "prometheus", Role_: "peer", Interface_: "prometheus"},
+		},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "mysql" offer "my-offer" endpoint "monitoring" unknown charm relation "prometheus" not valid`)
+
+	err = model.ValidateAll()
+	c.Assert(err, gc.ErrorMatches, `applications\[0\].offers\[0\]: application "mysql" offer .* not valid`)
+}
+
+func (s *ModelSerializationSuite) TestCleanDanglingPrincipals(c *gc.C) {
+	model := s.danglingPrincipalsModel()
+	c.Assert(model.CleanDanglingPrincipals(), jc.DeepEquals, []string{"carol", "jane", "mary"})
+	c.Assert(model.DanglingPrincipals(), gc.HasLen, 0)
+	c.Assert(model.ValidateWith(DanglingPrincipalsRule()), jc.ErrorIsNil)
+
+	offer := model.Applications()[0].Offers()[0]
+	c.Assert(offer.ACL(), jc.DeepEquals, map[string]string{
+		"owner":             "admin",
+		"bob":               "consume",
+		"everyone@external": "read",
+	})
+	acl := model.Secrets()[0].ACL()
+	c.Assert(acl, gc.HasLen, 2)
+	c.Assert(acl["user-bob"], gc.NotNil)
+	history := model.Users()[0].AccessHistory()
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].GrantedBy(), gc.Equals, names.NewUserTag("owner"))
+	c.Assert(history[1].GrantedBy(), gc.Equals, names.UserTag{})
+}
+
+func (s *ModelSerializationSuite) TestRemoveDanglingRelations(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalApplication(model)
+	model.AddRemoteApplication(RemoteApplicationArgs{Tag: names.NewApplicationTag("remote")})
+	for i, key := range []string{"ubuntu:peer", "ubuntu:db remote:db", "ubuntu:db gone:db", "gone:peer"} {
+		relation := model.AddRelation(RelationArgs{Id: i, Key: key})
+		for _, endpoint := range strings.Split(key, " ") {
+			parts := strings.Split(endpoint, ":")
+			relation.AddEndpoint(EndpointArgs{ApplicationName: parts[0], Name: parts[1]})
+		}
+	}
+
+	c.Assert(model.RemoveDanglingRelations(), jc.DeepEquals, []string{"gone:peer", "ubuntu:db gone:db"})
+	var keys []string
+	for _, relation := range model.Relations() {
+		keys = append(keys, relation.Key())
+	}
+	c.Assert(keys, jc.DeepEquals, []string{"ubuntu:peer", "ubuntu:db remote:db"})
+	c.Assert(model.RemoveDanglingRelations(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestRemoteSecrets(c *gc.C) {
 	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	remoteSecretArgs := testRemoteSecretArgs()
@@ -1637,6 +2936,40 @@ func (s *ModelSerializationSuite) TestRemoteSecretsValidate(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `remote secret\[0\] consumer \(foo\) not valid`)
 }
 
+func (s *ModelSerializationSuite) TestRemoteSecretsValidateSource(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalApplication(initial)
+	remoteSecretArgs := testRemoteSecretArgs()
+	remoteSecretArgs.Consumer = names.NewApplicationTag("ubuntu")
+	remoteSecretArgs.SourceUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	initial.AddRemoteSecret(remoteSecretArgs)
+
+	err := initial.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `remote secret\[0\] source \(deadbeef-0bad-400d-8000-4b1d0d06f00d\) not valid`)
+
+	initial.AddRemoteApplication(RemoteApplicationArgs{
+		Tag:         names.NewApplicationTag("foo"),
+		SourceModel: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestRemoteSecretsValidateSourceExternalController(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalApplication(initial)
+	remoteSecretArgs := testRemoteSecretArgs()
+	remoteSecretArgs.Consumer = names.NewApplicationTag("ubuntu")
+	remoteSecretArgs.SourceUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	initial.AddRemoteSecret(remoteSecretArgs)
+	initial.AddExternalController(ExternalControllerArgs{
+		Tag:    names.NewControllerTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		CACert: selfTestCACert,
+		Models: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+}
+
 func (s *ModelSerializationSuite) TestAgentVersionPre11Import(c *gc.C) {
 	initial := s.newModel(ModelArgs{
 		Config: map[string]any{
@@ -1654,6 +2987,247 @@ func (s *ModelSerializationSuite) TestAgentVersionPre11Import(c *gc.C) {
 	c.Check(model.AgentVersion(), gc.Equals, "3.3.3")
 }
 
+func (s *ModelSerializationSuite) TestTelemetry(c *gc.C) {
+	initial := s.newModel(ModelArgs{})
+	c.Assert(initial.Telemetry(), gc.IsNil)
+
+	reported := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	initial.SetTelemetry(TelemetryArgs{Enabled: true, LastReportTime: reported})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	telemetry := model.Telemetry()
+	c.Assert(telemetry, gc.NotNil)
+	c.Check(telemetry.Enabled(), jc.IsTrue)
+	c.Check(telemetry.LastReportTime(), gc.Equals, reported)
+}
+
+func (s *ModelSerializationSuite) TestTelemetryUnknownVersion(c *gc.C) {
+	initial := s.newModel(ModelArgs{})
+	initial.SetTelemetry(TelemetryArgs{Enabled: true})
+	data := asStringMap(c, initial)
+	data["telemetry"].(map[interface{}]interface{})["version"] = 42
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = Deserialize(bytes)
+	c.Assert(err, gc.ErrorMatches, "telemetry: version 42 not valid")
+}
+
+func (s *ModelSerializationSuite) TestTelemetryWithoutVersion(c *gc.C) {
+	reported := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	initial := s.newModel(ModelArgs{})
+	initial.SetTelemetry(TelemetryArgs{Enabled: true, LastReportTime: reported})
+	data := asStringMap(c, initial)
+	delete(data["telemetry"].(map[interface{}]interface{}), "version")
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	telemetry := model.Telemetry()
+	c.Assert(telemetry, gc.NotNil)
+	c.Check(telemetry.Enabled(), jc.IsTrue)
+	c.Check(telemetry.LastReportTime(), gc.Equals, reported)
+}
+
+func (s *ModelSerializationSuite) TestTelemetryPre12Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{
+		Config: map[string]any{
+			"disable-telemetry": true,
+		},
+	})
+	data := asStringMap(c, initial)
+	data["version"] = 11
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	telemetry := model.Telemetry()
+	c.Assert(telemetry, gc.NotNil)
+	c.Check(telemetry.Enabled(), jc.IsFalse)
+	c.Check(telemetry.LastReportTime().IsZero(), jc.IsTrue)
+}
+
+func (s *ModelSerializationSuite) TestMigrationAttempt(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	c.Assert(initial.MigrationAttempt(), gc.IsNil)
+
+	initial.SetMigrationAttempt(MigrationAttemptArgs{
+		Attempt:        3,
+		PreviousTarget: "target-uuid",
+		PhaseReached:   "VALIDATION",
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	attempt := model.MigrationAttempt()
+	c.Assert(attempt, gc.NotNil)
+	c.Check(attempt.Attempt(), gc.Equals, 3)
+	c.Check(attempt.PreviousTarget(), gc.Equals, "target-uuid")
+	c.Check(attempt.PhaseReached(), gc.Equals, "VALIDATION")
+}
+
+func (s *ModelSerializationSuite) TestMigrationAttemptPre18Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.SetMigrationAttempt(MigrationAttemptArgs{Attempt: 1})
+	data := asStringMap(c, initial)
+	data["version"] = 17
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationAttempt(), gc.IsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksMigrationAttempt(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.SetMigrationAttempt(MigrationAttemptArgs{})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, "migration attempt 0 not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	model.SetMigrationAttempt(MigrationAttemptArgs{Attempt: 1, PhaseReached: "IMPORT"})
+	c.Assert(model.Validate(), gc.ErrorMatches, "first migration attempt with previous attempt not valid")
+}
+
+func (s *ModelSerializationSuite) TestLease(c *gc.C) {
+	initial := s.newModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	c.Assert(initial.Lease(), gc.IsNil)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	initial.SetLease(LeaseArgs{
+		Name:   initial.Tag().Id(),
+		Holder: "controller-0",
+		Start:  start,
+		Expiry: start.Add(time.Minute),
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	lease := imported.Lease()
+	c.Assert(lease, gc.NotNil)
+	c.Check(lease.Name(), gc.Equals, initial.Tag().Id())
+	c.Check(lease.Holder(), gc.Equals, "controller-0")
+	c.Check(lease.Start(), gc.Equals, start)
+	c.Check(lease.Expiry(), gc.Equals, start.Add(time.Minute))
+	c.Check(lease.Pinned(), jc.IsFalse)
+}
+
+func (s *ModelSerializationSuite) TestLeasePre19Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	initial.SetLease(LeaseArgs{Name: initial.Tag().Id(), Holder: "controller-0"})
+	data := asStringMap(c, initial)
+	data["version"] = 18
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Lease(), gc.IsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLease(c *gc.C) {
+	model := s.newModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	model.SetLease(LeaseArgs{Name: model.Tag().Id()})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `model: lease "some-uuid" missing holder not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	model.SetLease(LeaseArgs{Name: "elsewhere", Holder: "controller-0"})
+	c.Assert(model.Validate(), gc.ErrorMatches, `model lease "elsewhere" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestDescription(c *gc.C) {
+	initial := s.newModel(ModelArgs{Description: "prod payments cluster, owner: team-x"})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Description(), gc.Equals, "prod payments cluster, owner: team-x")
+}
+
+func (s *ModelSerializationSuite) TestDescriptionPre13Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{Description: "ignored"})
+	data := asStringMap(c, initial)
+	data["version"] = 12
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Description(), gc.Equals, "")
+}
+
+func (s *ModelSerializationSuite) TestSetPasswordHash(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner"), PasswordHash: "old-hash"})
+	c.Assert(initial.PasswordHashAlgorithm(), gc.Equals, "")
+	initial.SetPasswordHash("new-hash", PasswordHashPBKDF2)
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.PasswordHash(), gc.Equals, "new-hash")
+	c.Assert(model.PasswordHashAlgorithm(), gc.Equals, PasswordHashPBKDF2)
+}
+
+func (s *ModelSerializationSuite) TestPasswordHashAlgorithmPre15Import(c *gc.C) {
+	initial := s.newModel(ModelArgs{
+		Owner:                 names.NewUserTag("owner"),
+		PasswordHash:          "hash",
+		PasswordHashAlgorithm: PasswordHashSHA512,
+	})
+	data := asStringMap(c, initial)
+	data["version"] = 14
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.PasswordHash(), gc.Equals, "hash")
+	c.Assert(model.PasswordHashAlgorithm(), gc.Equals, "")
+}
+
+func (s *ModelSerializationSuite) TestModelValidationPasswordHash(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.SetPasswordHash("hash", "md5")
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `password hash algorithm "md5" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	model.SetPasswordHash("", PasswordHashSHA512)
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `password hash algorithm "sha512" without password hash not valid`)
+
+	model.SetPasswordHash("bad hash", PasswordHashSHA512)
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `model password hash not valid`)
+
+	model.SetPasswordHash("hash", PasswordHashSHA512)
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+}
+
 // modelV1example was taken from a Juju 2.1 model dump, which is version
 // 1, and among other things is missing model status, which version 2 makes
 // manditory.
@@ -2151,3 +3725,92 @@ volumes:
   version: 1
   volumes: []
 `
+
+func (s *ModelSerializationSuite) TestModelValidationChecksCharms(c *gc.C) {
+	model := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addMinimalApplication(model)
+	// Models without charms, from before they were recorded, are valid.
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddCharm(CharmArgs{URL: "cs:trusty/mysql"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" charm "cs:trusty/ubuntu" not in model charms not valid`)
+
+	model.AddCharm(CharmArgs{URL: "cs:trusty/ubuntu"})
+	c.Assert(model.Validate(), jc.ErrorIsNil)
+
+	model.AddCharm(CharmArgs{URL: "cs:trusty/ubuntu"})
+	err = model.Validate()
+	c.Assert(err, gc.ErrorMatches, `charm\[2\] duplicate url "cs:trusty/ubuntu" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestCharms(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddCharm(CharmArgs{URL: "cs:trusty/ubuntu", Revision: 3, SHA256: "deadbeef"})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	charms := model.Charms()
+	c.Assert(charms, gc.HasLen, 1)
+	c.Check(charms[0].URL(), gc.Equals, "cs:trusty/ubuntu")
+	c.Check(charms[0].Revision(), gc.Equals, 3)
+	c.Check(charms[0].SHA256(), gc.Equals, "deadbeef")
+}
+
+func (s *ModelSerializationSuite) TestUpgradedModelReserializes(c *gc.C) {
+	initial := s.newModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	data := asStringMap(c, initial)
+	data["version"] = 4
+	bytes, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Sections added since v4 are written at their current versions, so
+	// the model can be read back.
+	bytes, err = Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// validationBenchmarkModel returns a model with many applications, each
+// related to the next.
+func validationBenchmarkModel() Model {
+	m := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	m.SetStatus(minimalStatusArgs())
+	const count = 2000
+	for i := 0; i < count; i++ {
+		application := m.AddApplication(ApplicationArgs{
+			Tag:                names.NewApplicationTag(fmt.Sprintf("app-%d", i)),
+			CharmConfig:        map[string]interface{}{},
+			LeadershipSettings: map[string]interface{}{},
+		})
+		application.SetStatus(minimalStatusArgs())
+	}
+	for i := 0; i < count-1; i++ {
+		relation := m.AddRelation(RelationArgs{
+			Id:  i,
+			Key: fmt.Sprintf("app-%d:db app-%d:db", i, i+1),
+		})
+		relation.SetStatus(minimalStatusArgs())
+		relation.AddEndpoint(EndpointArgs{ApplicationName: fmt.Sprintf("app-%d", i), Name: "db"})
+		relation.AddEndpoint(EndpointArgs{ApplicationName: fmt.Sprintf("app-%d", i+1), Name: "db"})
+	}
+	return m
+}
+
+func BenchmarkValidate(b *stdtesting.B) {
+	m := validationBenchmarkModel()
+	if err := m.Validate(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := m.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// NetworkInterface represents a network interface of a cloud instance, as
+// the provider reports it.
+type NetworkInterface interface {
+	DeviceName() string
+	MACAddress() string
+	ProviderID() string
+	ProviderSubnetID() string
+	ProviderNetworkID() string
+	Addresses() []Address
+	// ShadowAddresses are the addresses that the provider routes to the
+	// interface, such as public addresses mapped onto it by NAT, which
+	// the instance itself can't see.
+	ShadowAddresses() []Address
+}
+
+// NetworkInterfaceArgs is an argument struct used to add a network
+// interface to a CloudInstance.
+type NetworkInterfaceArgs struct {
+	DeviceName        string
+	MACAddress        string
+	ProviderID        string
+	ProviderSubnetID  string
+	ProviderNetworkID string
+	Addresses         []AddressArgs
+	ShadowAddresses   []AddressArgs
+}
+
+type networkInterface struct {
+	DeviceName_        string     `yaml:"device-name"`
+	MACAddress_        string     `yaml:"mac-address,omitempty"`
+	ProviderID_        string     `yaml:"provider-id,omitempty"`
+	ProviderSubnetID_  string     `yaml:"provider-subnet-id,omitempty"`
+	ProviderNetworkID_ string     `yaml:"provider-network-id,omitempty"`
+	Addresses_         []*address `yaml:"addresses,omitempty"`
+	ShadowAddresses_   []*address `yaml:"shadow-addresses,omitempty"`
+}
+
+func newNetworkInterface(args NetworkInterfaceArgs) *networkInterface {
+	iface := &networkInterface{
+		DeviceName_:        args.DeviceName,
+		MACAddress_:        args.MACAddress,
+		ProviderID_:        args.ProviderID,
+		ProviderSubnetID_:  args.ProviderSubnetID,
+		ProviderNetworkID_: args.ProviderNetworkID,
+	}
+	for _, args := range args.Addresses {
+		iface.Addresses_ = append(iface.Addresses_, newAddress(args))
+	}
+	for _, args := range args.ShadowAddresses {
+		iface.ShadowAddresses_ = append(iface.ShadowAddresses_, newAddress(args))
+	}
+	return iface
+}
+
+// DeviceName implements NetworkInterface.
+func (n *networkInterface) DeviceName() string {
+	return n.DeviceName_
+}
+
+// MACAddress implements NetworkInterface.
+func (n *networkInterface) MACAddress() string {
+	return n.MACAddress_
+}
+
+// ProviderID implements NetworkInterface.
+func (n *networkInterface) ProviderID() string {
+	return n.ProviderID_
+}
+
+// ProviderSubnetID implements NetworkInterface.
+func (n *networkInterface) ProviderSubnetID() string {
+	return n.ProviderSubnetID_
+}
+
+// ProviderNetworkID implements NetworkInterface.
+func (n *networkInterface) ProviderNetworkID() string {
+	return n.ProviderNetworkID_
+}
+
+// Addresses implements NetworkInterface.
+func (n *networkInterface) Addresses() []Address {
+	var result []Address
+	for _, addr := range n.Addresses_ {
+		result = append(result, addr)
+	}
+	return result
+}
+
+// ShadowAddresses implements NetworkInterface.
+func (n *networkInterface) ShadowAddresses() []Address {
+	var result []Address
+	for _, addr := range n.ShadowAddresses_ {
+		result = append(result, addr)
+	}
+	return result
+}
+
+// validate checks that the interface has a device name, that its MAC
+// address, if any, is well formed, and that its addresses have values.
+func (n *networkInterface) validate() error {
+	if n.DeviceName_ == "" {
+		return errors.NotValidf("network interface missing device name")
+	}
+	if n.MACAddress_ != "" {
+		if _, err := net.ParseMAC(n.MACAddress_); err != nil {
+			return errors.NotValidf("network interface %q MAC address %q", n.DeviceName_, n.MACAddress_)
+		}
+	}
+	for _, addr := range n.Addresses_ {
+		if addr.Value_ == "" {
+			return errors.NotValidf("network interface %q address missing value", n.DeviceName_)
+		}
+	}
+	for _, addr := range n.ShadowAddresses_ {
+		if addr.Value_ == "" {
+			return errors.NotValidf("network interface %q shadow address missing value", n.DeviceName_)
+		}
+	}
+	return nil
+}
+
+func importNetworkInterfaces(sourceList []interface{}) ([]*networkInterface, error) {
+	checker := schema.FieldMap(networkInterfaceFields())
+	result := make([]*networkInterface, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for network interface %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "network interface %d schema check failed", i)
+		}
+		valid := coerced.(map[string]interface{})
+		// From here we know that the map returned from the schema coercion
+		// contains fields of the right type.
+		iface := &networkInterface{
+			DeviceName_:        valid["device-name"].(string),
+			MACAddress_:        valid["mac-address"].(string),
+			ProviderID_:        valid["provider-id"].(string),
+			ProviderSubnetID_:  valid["provider-subnet-id"].(string),
+			ProviderNetworkID_: valid["provider-network-id"].(string),
+		}
+		if addresses, ok := valid["addresses"]; ok {
+			iface.Addresses_, err = importAddresses(addresses.([]interface{}))
+			if err != nil {
+				return nil, errors.Annotatef(err, "network interface %d addresses", i)
+			}
+		}
+		if addresses, ok := valid["shadow-addresses"]; ok {
+			iface.ShadowAddresses_, err = importAddresses(addresses.([]interface{}))
+			if err != nil {
+				return nil, errors.Annotatef(err, "network interface %d shadow addresses", i)
+			}
+		}
+		result[i] = iface
+	}
+	return result, nil
+}
+
+func networkInterfaceFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"device-name":         schema.String(),
+		"mac-address":         schema.String(),
+		"provider-id":         schema.String(),
+		"provider-subnet-id":  schema.String(),
+		"provider-network-id": schema.String(),
+		"addresses":           schema.List(schema.StringMap(schema.Any())),
+		"shadow-addresses":    schema.List(schema.StringMap(schema.Any())),
+	}
+	defaults := schema.Defaults{
+		"mac-address":         "",
+		"provider-id":         "",
+		"provider-subnet-id":  "",
+		"provider-network-id": "",
+		"addresses":           schema.Omit,
+		"shadow-addresses":    schema.Omit,
+	}
+	return fields, defaults
+}
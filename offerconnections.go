@@ -4,6 +4,9 @@
 package description
 
 import (
+	"sort"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
@@ -42,6 +45,44 @@ type OfferConnectionArgs struct {
 	SourceModelUUID string
 }
 
+// MarshalYAML implements yaml.Marshaler. The connections are written in
+// order of offer uuid and relation id, and a connection added more than
+// once is written only once, so that exports of the same model serialize
+// the same however the connections were added.
+func (c offerConnections) MarshalYAML() (interface{}, error) {
+	type plain offerConnections
+	return plain{
+		Version:          c.Version,
+		OfferConnections: uniqueOfferConnections(c.OfferConnections),
+	}, nil
+}
+
+// uniqueOfferConnections returns the connections sorted by offer uuid and
+// relation id, keeping the first of any connections to the same offer for
+// the same relation.
+func uniqueOfferConnections(connections []*offerConnection) []*offerConnection {
+	if len(connections) < 2 {
+		return connections
+	}
+	sorted := make([]*offerConnection, len(connections))
+	copy(sorted, connections)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].OfferUUID_ != sorted[j].OfferUUID_ {
+			return sorted[i].OfferUUID_ < sorted[j].OfferUUID_
+		}
+		return sorted[i].RelationID_ < sorted[j].RelationID_
+	})
+	result := sorted[:1]
+	for _, conn := range sorted[1:] {
+		last := result[len(result)-1]
+		if conn.OfferUUID_ == last.OfferUUID_ && conn.RelationID_ == last.RelationID_ {
+			continue
+		}
+		result = append(result, conn)
+	}
+	return result
+}
+
 func newOfferConnection(args OfferConnectionArgs) *offerConnection {
 	return &offerConnection{
 		OfferUUID_:       args.OfferUUID,
@@ -77,6 +118,33 @@ func (c *offerConnection) SourceModelUUID() string {
 	return c.SourceModelUUID_
 }
 
+// OfferConnectionCount returns the number of connections made to the offer
+// with the given uuid, as shown by the status of the offering model.
+func OfferConnectionCount(connections []OfferConnection, offerUUID string) int {
+	count := 0
+	for _, conn := range connections {
+		if conn.OfferUUID() == offerUUID {
+			count++
+		}
+	}
+	return count
+}
+
+// OfferConsumerModelUUIDs returns the sorted uuids of the models consuming
+// the offer with the given uuid, each listed once however many connections
+// it has made.
+func OfferConsumerModelUUIDs(connections []OfferConnection, offerUUID string) []string {
+	uuids := set.NewStrings()
+	for _, conn := range connections {
+		if conn.OfferUUID() == offerUUID {
+			uuids.Add(conn.SourceModelUUID())
+		}
+	}
+	result := uuids.Values()
+	sort.Strings(result)
+	return result
+}
+
 var offerConnectionDeserializationFuncs = map[int]offerConnectionDeserializationFunc{
 	1: importOfferConnectionV1,
 }
@@ -4,6 +4,7 @@
 package description
 
 import (
+	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/yaml.v2"
@@ -56,6 +57,83 @@ func (s *OfferConnectionSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(offer, jc.DeepEquals, initial)
 }
 
+func (s *OfferConnectionSerializationSuite) TestConnectionSummary(c *gc.C) {
+	var connections []OfferConnection
+	for i, args := range []OfferConnectionArgs{
+		{OfferUUID: "offer-1", SourceModelUUID: "model-b"},
+		{OfferUUID: "offer-1", SourceModelUUID: "model-a"},
+		{OfferUUID: "offer-1", SourceModelUUID: "model-b"},
+		{OfferUUID: "offer-2", SourceModelUUID: "model-c"},
+	} {
+		args.RelationID = i
+		connections = append(connections, newOfferConnection(args))
+	}
+
+	c.Check(OfferConnectionCount(connections, "offer-1"), gc.Equals, 3)
+	c.Check(OfferConsumerModelUUIDs(connections, "offer-1"), gc.DeepEquals, []string{"model-a", "model-b"})
+	c.Check(OfferConnectionCount(connections, "offer-3"), gc.Equals, 0)
+	c.Check(OfferConsumerModelUUIDs(connections, "offer-3"), gc.HasLen, 0)
+}
+
+func (s *OfferConnectionSerializationSuite) TestSerializedSortedAndUnique(c *gc.C) {
+	initial := &offerConnections{Version: 1}
+	for _, args := range []OfferConnectionArgs{
+		{OfferUUID: "offer-2", RelationID: 1, UserName: "fred"},
+		{OfferUUID: "offer-1", RelationID: 10, UserName: "fred"},
+		{OfferUUID: "offer-1", RelationID: 2, UserName: "mary"},
+		{OfferUUID: "offer-1", RelationID: 10, UserName: "fred"},
+		{OfferUUID: "offer-1", RelationID: 2, UserName: "bob"},
+	} {
+		initial.OfferConnections = append(initial.OfferConnections, newOfferConnection(args))
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	offers, err := importOfferConnections(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	type key struct {
+		offerUUID  string
+		relationID int
+		userName   string
+	}
+	var keys []key
+	for _, offer := range offers {
+		keys = append(keys, key{offer.OfferUUID(), offer.RelationID(), offer.UserName()})
+	}
+	c.Check(keys, jc.DeepEquals, []key{
+		{"offer-1", 2, "mary"},
+		{"offer-1", 10, "fred"},
+		{"offer-2", 1, "fred"},
+	})
+	// The connections held aren't changed.
+	c.Check(initial.OfferConnections, gc.HasLen, 5)
+	c.Check(initial.OfferConnections[0].OfferUUID_, gc.Equals, "offer-2")
+}
+
+func (s *OfferConnectionSerializationSuite) TestModelsSerializeTheSame(c *gc.C) {
+	connections := []OfferConnectionArgs{
+		{OfferUUID: "offer-1", RelationID: 3, RelationKey: "a:db b:db"},
+		{OfferUUID: "offer-1", RelationID: 1, RelationKey: "a:db c:db"},
+		{OfferUUID: "offer-2", RelationID: 2, RelationKey: "d:db e:db"},
+	}
+	serialize := func(order ...int) string {
+		model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+		for _, i := range order {
+			model.AddOfferConnection(connections[i])
+		}
+		bytes, err := Serialize(model)
+		c.Assert(err, jc.ErrorIsNil)
+		return string(bytes)
+	}
+	expected := serialize(0, 1, 2)
+	c.Check(serialize(2, 1, 0), gc.Equals, expected)
+	c.Check(serialize(1, 2, 0, 1), gc.Equals, expected)
+}
+
 func (s *OfferConnectionSerializationSuite) exportImportLatest(c *gc.C, offer *offerConnection) *offerConnection {
 	return s.exportImportVersion(c, offer, 1)
 }
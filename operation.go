@@ -4,12 +4,25 @@
 package description
 
 import (
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
 
+// The statuses of an operation are those of its actions.
+const (
+	OperationPending   = ActionPending
+	OperationRunning   = ActionRunning
+	OperationCompleted = ActionCompleted
+	OperationFailed    = ActionFailed
+	OperationCancelled = ActionCancelled
+	OperationError     = ActionError
+	OperationAborting  = ActionAborting
+	OperationAborted   = ActionAborted
+)
+
 // Operation represents an operation.
 type Operation interface {
 	Id() string
@@ -21,6 +34,9 @@ type Operation interface {
 	Status() string
 	CompleteTaskCount() int
 	SpawnedTaskCount() int
+	// Actions returns the actions of the model that were spawned as
+	// tasks of the operation, sorted by id.
+	Actions() []Action
 }
 
 type operations struct {
@@ -40,6 +56,10 @@ type operation struct {
 	Fail_              string     `yaml:"fail,omitempty"`
 	CompleteTaskCount_ int        `yaml:"complete-task-count"`
 	SpawnedTaskCount_  int        `yaml:"spawned-task-count"`
+
+	// actions are the tasks of the operation, which the model links to
+	// it as either is added.
+	actions []*action
 }
 
 // Id implements Operation.
@@ -95,6 +115,18 @@ func (i *operation) SpawnedTaskCount() int {
 	return i.SpawnedTaskCount_
 }
 
+// Actions implements Operation.
+func (i *operation) Actions() []Action {
+	result := make([]Action, len(i.actions))
+	for j, action := range i.actions {
+		result[j] = action
+	}
+	sort.SliceStable(result, func(a, b int) bool {
+		return naturalLess(result[a].Id(), result[b].Id())
+	})
+	return result
+}
+
 // OperationArgs is an argument struct used to create a
 // new internal operation type that supports the Operation interface.
 type OperationArgs struct {
@@ -168,6 +200,7 @@ var operationFieldsFuncs = map[int]fieldsFunc{
 	1: operationV1Fields,
 	2: operationV2Fields,
 	3: operationV3Fields,
+	4: operationV4Fields,
 }
 
 func operationV1Fields() (schema.Fields, schema.Defaults) {
@@ -201,6 +234,12 @@ func operationV3Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+// operationV4Fields is the same as v3. From v4 the status is one of the
+// enumerated statuses.
+func operationV4Fields() (schema.Fields, schema.Defaults) {
+	return operationV3Fields()
+}
+
 func importOperation(source map[string]interface{}, importVersion int, fieldFunc func() (schema.Fields, schema.Defaults)) (*operation, error) {
 	fields, defaults := fieldFunc()
 	checker := schema.FieldMap(fields, defaults)
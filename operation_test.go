@@ -135,7 +135,7 @@ func (s *OperationSerializationSuite) TestParsingSerializedDataV2(c *gc.C) {
 }
 
 func (s *OperationSerializationSuite) exportImportLatest(c *gc.C, operation_ *operation) *operation {
-	return s.exportImportVersion(c, operation_, 3)
+	return s.exportImportVersion(c, operation_, 4)
 }
 
 func (s *OperationSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -143,3 +143,13 @@ func (s *OperationSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	operationResult := s.exportImportLatest(c, operation)
 	c.Assert(operationResult, jc.DeepEquals, operation)
 }
+
+func (s *OperationSerializationSuite) TestErrorAndAbortingStatuses(c *gc.C) {
+	for version := 1; version <= 4; version++ {
+		operation := minimalOperation()
+		operation.Status_ = OperationError
+		c.Check(s.exportImportVersion(c, operation, version).Status(), gc.Equals, OperationError)
+		operation.Status_ = OperationAborting
+		c.Check(s.exportImportVersion(c, operation, version).Status(), gc.Equals, OperationAborting)
+	}
+}
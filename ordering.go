@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"sort"
+)
+
+// naturalLess reports whether a sorts before b, comparing runs of digits
+// by their numeric value, so that "ubuntu/2" sorts before "ubuntu/10" and
+// machine "2" before machine "10". It is the order of the natural keys,
+// such as ids and names, that a model keeps its entities in.
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			var numA, numB string
+			numA, a = digitPrefix(a)
+			numB, b = digitPrefix(b)
+			if len(numA) != len(numB) {
+				return len(numA) < len(numB)
+			}
+			if numA != numB {
+				return numA < numB
+			}
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// sortByKey sorts the entities by their natural keys, keeping entities
+// with the same key in the order they were in.
+func sortByKey[T any](entities []T, key func(T) string) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		return naturalLess(key(entities[i]), key(entities[j]))
+	})
+}
+
+// insertByKey returns the entities, sorted by their natural keys, with the
+// entity inserted after those whose keys don't sort after its own, so that
+// they stay sorted and entities with the same key stay in the order they
+// were added in.
+func insertByKey[T any](entities []T, entity T, key func(T) string) []T {
+	entityKey := key(entity)
+	i := sort.Search(len(entities), func(i int) bool {
+		return naturalLess(entityKey, key(entities[i]))
+	})
+	var zero T
+	entities = append(entities, zero)
+	copy(entities[i+1:], entities[i:])
+	entities[i] = entity
+	return entities
+}
+
+// The natural keys of the entities of a model.
+func userKey(u *user) string                             { return u.Name().Id() }
+func machineKey(m *machine) string                       { return m.Id() }
+func applicationKey(a *application) string               { return a.Name() }
+func unitKey(u *unit) string                             { return u.Name() }
+func charmKey(c *charm) string                           { return c.URL() }
+func relationKey(r *relation) string                     { return fmt.Sprint(r.Id()) }
+func remoteEntityKey(e *remoteEntity) string             { return e.ID() }
+func relationNetworkKey(n *relationNetwork) string       { return n.ID() }
+func spaceKey(s *space) string                           { return s.Id() }
+func subnetKey(s *subnet) string                         { return s.ID() }
+func imageMetadataKey(c *cloudimagemetadata) string      { return c.ImageId() }
+func actionKey(a *action) string                         { return a.Id() }
+func operationKey(o *operation) string                   { return o.Id() }
+func sshHostKeyKey(k *sshHostKey) string                 { return k.MachineID() }
+func virtualHostKeyKey(k *virtualHostKey) string         { return k.ID() }
+func volumeKey(v *volume) string                         { return v.Tag().Id() }
+func filesystemKey(f *filesystem) string                 { return f.Tag().Id() }
+func storageKey(s *storage) string                       { return s.Tag().Id() }
+func storagePoolKey(p *storagepool) string               { return p.Name() }
+func firewallRuleKey(r *firewallRule) string             { return r.ID() }
+func remoteApplicationKey(a *remoteApplication) string   { return a.Name() }
+func secretBackendKey(b *secretBackend) string           { return b.Id() }
+func secretKey(s *secret) string                         { return s.Id() }
+func remoteSecretKey(s *remoteSecret) string             { return s.ID() }
+func offerConnectionKey(c *offerConnection) string       { return fmt.Sprint(c.RelationID()) }
+func externalControllerKey(c *externalController) string { return c.ID().Id() }
+
+// linkLayerDeviceKey is the natural key of a link layer device.
+func linkLayerDeviceKey(device LinkLayerDevice) string {
+	return device.MachineID() + "#" + device.Name()
+}
+
+// ipAddressKey is the natural key of an IP address.
+func ipAddressKey(addr IPAddress) string {
+	return addr.MachineID() + "#" + addr.DeviceName() + "#" + addr.Value()
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitPrefix splits the leading run of digits, without leading zeros,
+// from the rest of the string.
+func digitPrefix(s string) (string, string) {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	start := 0
+	for start < end-1 && s[start] == '0' {
+		start++
+	}
+	return s[start:end], s[end:]
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type OrderingSuite struct{}
+
+var _ = gc.Suite(&OrderingSuite{})
+
+func (*OrderingSuite) TestNaturalLess(c *gc.C) {
+	for i, test := range []struct {
+		a, b string
+		less bool
+	}{
+		{"", "", false},
+		{"", "a", true},
+		{"a", "", false},
+		{"a", "b", true},
+		{"b", "a", false},
+		{"2", "10", true},
+		{"10", "2", false},
+		{"ubuntu/2", "ubuntu/10", true},
+		{"ubuntu/10", "ubuntu/2", false},
+		{"0/lxd/2", "0/lxd/10", true},
+		{"007", "7", false},
+		{"7", "007", false},
+		{"7a", "007b", true},
+		{"abc", "abcd", true},
+	} {
+		c.Check(naturalLess(test.a, test.b), gc.Equals, test.less, gc.Commentf("test %d: %q < %q", i, test.a, test.b))
+	}
+}
+
+func (*OrderingSuite) TestGettersSortByNaturalKey(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	for _, id := range []string{"10", "2", "1"} {
+		machine := model.AddMachine(MachineArgs{Id: names.NewMachineTag(id)})
+		if id == "2" {
+			machine.AddContainer(MachineArgs{Id: names.NewMachineTag("2/lxd/10")})
+			machine.AddContainer(MachineArgs{Id: names.NewMachineTag("2/lxd/9")})
+		}
+	}
+	for _, name := range []string{"mysql", "app10", "app9"} {
+		model.AddApplication(ApplicationArgs{Tag: names.NewApplicationTag(name)})
+	}
+	application := model.Applications()[0]
+	for _, name := range []string{"app9/11", "app9/3"} {
+		application.AddUnit(UnitArgs{Tag: names.NewUnitTag(name)})
+	}
+	for _, id := range []string{"b", "a"} {
+		model.AddSpace(SpaceArgs{Id: id})
+	}
+
+	var machineIDs []string
+	for _, machine := range model.Machines() {
+		machineIDs = append(machineIDs, machine.Id())
+	}
+	c.Check(machineIDs, jc.DeepEquals, []string{"1", "2", "10"})
+
+	var containerIDs []string
+	for _, container := range model.Machines()[1].Containers() {
+		containerIDs = append(containerIDs, container.Id())
+	}
+	c.Check(containerIDs, jc.DeepEquals, []string{"2/lxd/9", "2/lxd/10"})
+
+	var applicationNames []string
+	for _, application := range model.Applications() {
+		applicationNames = append(applicationNames, application.Name())
+	}
+	c.Check(applicationNames, jc.DeepEquals, []string{"app9", "app10", "mysql"})
+
+	var unitNames []string
+	for _, unit := range application.Units() {
+		unitNames = append(unitNames, unit.Name())
+	}
+	c.Check(unitNames, jc.DeepEquals, []string{"app9/3", "app9/11"})
+
+	c.Check(model.Spaces()[0].Id(), gc.Equals, "a")
+
+	// The entities are kept in that order, so are serialized in it too.
+	data := asStringMap(c, model)
+	machines := data["machines"].(map[interface{}]interface{})["machines"].([]interface{})
+	c.Check(machines[0].(map[interface{}]interface{})["id"], gc.Equals, "1")
+}
+
+func (*OrderingSuite) TestImportSortsEntities(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")}).(*model)
+	for _, id := range []string{"10", "2"} {
+		addMinimalMachine(initial, id)
+	}
+	// A document written by hand, or before entities were kept sorted,
+	// may list them in any order.
+	initial.Machines_.Machines_ = append(initial.Machines_.Machines_, minimalMachine("1"))
+
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var machineIDs []string
+	for _, machine := range model.Machines() {
+		machineIDs = append(machineIDs, machine.Id())
+	}
+	c.Check(machineIDs, jc.DeepEquals, []string{"1", "2", "10"})
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Platform is the architecture, OS and channel a charm is deployed for, as
+// recorded by the platform of its CharmOrigin in the form
+// "architecture/os/channel", such as "amd64/ubuntu/22.04/stable".
+type Platform struct {
+	Architecture string
+	OS           string
+	// Channel is the OS track, such as "22.04", optionally followed by
+	// a risk, as in "22.04/stable".
+	Channel string
+}
+
+// ParsePlatform parses and normalizes a platform string, returning an
+// error satisfying errors.IsNotValid if it isn't a valid platform.
+func ParsePlatform(s string) (Platform, error) {
+	parts := strings.SplitN(s, "/", 3)
+	if len(parts) < 3 {
+		return Platform{}, errors.NotValidf("platform %q", s)
+	}
+	p := Platform{
+		Architecture: parts[0],
+		OS:           parts[1],
+		Channel:      parts[2],
+	}.Normalize()
+	if err := p.Validate(); err != nil {
+		return Platform{}, errors.NotValidf("platform %q", s)
+	}
+	return p, nil
+}
+
+// Normalize returns the platform with its architecture, OS and channel
+// lowercased, and any empty risk dropped from the channel.
+func (p Platform) Normalize() Platform {
+	return Platform{
+		Architecture: strings.ToLower(p.Architecture),
+		OS:           strings.ToLower(p.OS),
+		Channel:      strings.TrimSuffix(strings.ToLower(p.Channel), "/"),
+	}
+}
+
+// Validate checks that the platform has an architecture, OS and channel,
+// and that any risk in the channel is a known one.
+func (p Platform) Validate() error {
+	if p.Architecture == "" || strings.Contains(p.Architecture, "/") {
+		return errors.NotValidf("platform architecture %q", p.Architecture)
+	}
+	if p.OS == "" || strings.Contains(p.OS, "/") {
+		return errors.NotValidf("platform os %q", p.OS)
+	}
+	parts := strings.Split(p.Channel, "/")
+	if len(parts) > 2 || parts[0] == "" {
+		return errors.NotValidf("platform channel %q", p.Channel)
+	}
+	if len(parts) == 2 && !validPlatformRisk(parts[1]) {
+		return errors.NotValidf("platform channel %q", p.Channel)
+	}
+	return nil
+}
+
+// validPlatformRisk reports whether the risk is one a platform channel may
+// have following its track.
+func validPlatformRisk(risk string) bool {
+	switch risk {
+	case "stable", "candidate", "beta", "edge":
+		return true
+	}
+	return false
+}
+
+// String returns the platform in the form stored by a CharmOrigin.
+func (p Platform) String() string {
+	return p.Architecture + "/" + p.OS + "/" + p.Channel
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PlatformSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PlatformSuite{})
+
+func (*PlatformSuite) TestParsePlatform(c *gc.C) {
+	for _, test := range []struct {
+		platform string
+		expected Platform
+	}{
+		{"amd64/ubuntu/22.04", Platform{"amd64", "ubuntu", "22.04"}},
+		{"amd64/ubuntu/22.04/stable", Platform{"amd64", "ubuntu", "22.04/stable"}},
+		{"AMD64/Ubuntu/22.04/Edge", Platform{"amd64", "ubuntu", "22.04/edge"}},
+		{"arm64/ubuntu/22.04/", Platform{"arm64", "ubuntu", "22.04"}},
+	} {
+		p, err := ParsePlatform(test.platform)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(p, gc.Equals, test.expected)
+	}
+}
+
+func (*PlatformSuite) TestParsePlatformInvalid(c *gc.C) {
+	for _, platform := range []string{
+		"",
+		"amd64/ubuntu",
+		"/ubuntu/22.04",
+		"amd64//22.04",
+		"amd64/ubuntu//stable",
+		"amd64/ubuntu/22.04/risky",
+		"amd64/ubuntu/22.04/stable/extra",
+	} {
+		_, err := ParsePlatform(platform)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `platform ".*" not valid`)
+	}
+}
+
+func (*PlatformSuite) TestString(c *gc.C) {
+	p := Platform{Architecture: "amd64", OS: "ubuntu", Channel: "22.04/stable"}
+	c.Assert(p.String(), gc.Equals, "amd64/ubuntu/22.04/stable")
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// PreviousInstance records an instance that was provisioned for a machine
+// before it was re-provisioned, for audit purposes.
+type PreviousInstance interface {
+	InstanceId() string
+	// Replaced is when the instance stopped being the machine's instance.
+	Replaced() time.Time
+}
+
+// PreviousInstanceArgs is an argument struct used to add a previous
+// instance to a CloudInstance.
+type PreviousInstanceArgs struct {
+	InstanceId string
+	Replaced   time.Time
+}
+
+type previousInstance struct {
+	InstanceId_ string    `yaml:"instance-id"`
+	Replaced_   time.Time `yaml:"replaced"`
+}
+
+func newPreviousInstance(args PreviousInstanceArgs) *previousInstance {
+	return &previousInstance{
+		InstanceId_: args.InstanceId,
+		Replaced_:   args.Replaced.UTC(),
+	}
+}
+
+// InstanceId implements PreviousInstance.
+func (p *previousInstance) InstanceId() string {
+	return p.InstanceId_
+}
+
+// Replaced implements PreviousInstance.
+func (p *previousInstance) Replaced() time.Time {
+	return p.Replaced_
+}
+
+func importPreviousInstances(sourceList []interface{}) ([]*previousInstance, error) {
+	checker := schema.FieldMap(previousInstanceFields())
+	result := make([]*previousInstance, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for previous instance %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "previous instance %d schema check failed", i)
+		}
+		valid := coerced.(map[string]interface{})
+		// From here we know that the map returned from the schema coercion
+		// contains fields of the right type.
+		result[i] = &previousInstance{
+			InstanceId_: valid["instance-id"].(string),
+			Replaced_:   valid["replaced"].(time.Time).UTC(),
+		}
+	}
+	return result, nil
+}
+
+func previousInstanceFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"instance-id": schema.String(),
+		"replaced":    schema.Time(),
+	}
+	return fields, schema.Defaults{}
+}
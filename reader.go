@@ -0,0 +1,620 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+)
+
+// ModelReader is a read-only view of a Model, as returned by Model.Freeze.
+// It is intended to be handed to code that inspects a model, such as
+// verification, without giving that code the ability to change it.
+type ModelReader interface {
+	Annotations() map[string]string
+	AnnotationsForEntity(names.Tag) map[string]string
+	AnnotationsIndex() map[string]map[string]string
+	Constraints() Constraints
+	Status() Status
+	StatusHistory() []Status
+
+	AgentVersion() string
+	Type() string
+	Cloud() string
+	CloudRegion() string
+	Description() string
+	CloudCredential() CloudCredential
+	Tag() names.ModelTag
+	Owner() names.UserTag
+	Config() map[string]interface{}
+	LatestToolsVersion() version.Number
+	EnvironVersion() int
+	Blocks() map[string]string
+	PasswordHash() string
+	PasswordHashAlgorithm() string
+	SecretBackendID() string
+	SecretBackends() []SecretBackend
+	SLA() SLA
+	MeterStatus() MeterStatus
+	Telemetry() Telemetry
+	MigrationAttempt() MigrationAttempt
+	Lease() Lease
+	Sequences() map[string]int
+
+	Users() []User
+	Machines() []MachineReader
+	Applications() []ApplicationReader
+	ApplicationNames() []string
+	UnitNames() []string
+	Relations() []Relation
+	RemoteEntities() []RemoteEntity
+	RelationNetworks() []RelationNetwork
+	RelationIngressNetworks() []RelationNetwork
+	RelationEgressNetworks() []RelationNetwork
+	Spaces() []Space
+	LinkLayerDevices() []LinkLayerDevice
+	Subnets() []Subnet
+	SubnetsInSpace(spaceID string) []Subnet
+	IPAddresses() []IPAddress
+	SSHHostKeys() []SSHHostKey
+	VirtualHostKeys() []VirtualHostKey
+	CloudImageMetadata() []CloudImageMetadata
+	Actions() []Action
+	Operations() []Operation
+	Volumes() []Volume
+	FirewallRules() []FirewallRule
+	Charms() []Charm
+	Filesystems() []Filesystem
+	Storages() []Storage
+	StoragePools() []StoragePool
+	Secrets() []Secret
+	RemoteSecrets() []RemoteSecret
+	RemoteApplications() []RemoteApplication
+	OfferConnections() []OfferConnection
+	ExternalControllers() []ExternalController
+
+	Validate() error
+	ValidateAll() error
+	ValidateWith(rules ...ValidationRule) error
+	Checksum() (string, error)
+	Summary(w io.Writer) error
+	Labels() map[string]string
+	Copy() Model
+	DanglingPrincipals() []string
+}
+
+// MachineReader is a read-only view of a Machine.
+type MachineReader interface {
+	Annotations() map[string]string
+	Constraints() Constraints
+	Status() Status
+	StatusHistory() []Status
+
+	Id() string
+	Tag() names.MachineTag
+	Nonce() string
+	PasswordHash() string
+	Placement() string
+	Base() string
+	ContainerType() string
+	Life() string
+	AgentStartTime() time.Time
+	HostnameVerifiedAt() time.Time
+	Jobs() []string
+	SupportedContainers() ([]string, bool)
+
+	Instance() CloudInstance
+	ProviderAddresses() []Address
+	MachineAddresses() []Address
+	PreferredPublicAddress() Address
+	PreferredPrivateAddress() Address
+	Tools() AgentTools
+	AgentVersion() string
+	RebootRequired() bool
+	UpgradeSeriesLock() UpgradeSeriesLock
+	Containers() []MachineReader
+	BlockDevices() []BlockDevice
+	OpenedPortRanges() PortRanges
+
+	Validate() error
+}
+
+// ApplicationReader is a read-only view of an Application.
+type ApplicationReader interface {
+	Annotations() map[string]string
+	Constraints() Constraints
+	OperatorStatus() Status
+	Status() Status
+	StatusHistory() []Status
+
+	Tag() names.ApplicationTag
+	Name() string
+	Type() string
+	Subordinate() bool
+	CharmURL() string
+	Channel() string
+	CharmModifiedVersion() int
+	ForceCharm() bool
+	MinUnits() int
+	Life() string
+	Exposed() bool
+	ExposedEndpoints() map[string]ExposedEndpoint
+	ExposedAt() time.Time
+	ExposedBy() names.UserTag
+	PasswordHash() string
+	PodSpec() string
+	DesiredScale() int
+	Placement() string
+	HasResources() bool
+	CloudService() CloudService
+	EndpointBindings() map[string]string
+	CharmConfig() map[string]interface{}
+	ApplicationConfig() map[string]interface{}
+	Leader() string
+	Lease() Lease
+	LeadershipSettings() map[string]interface{}
+	MetricsCredentials() []byte
+	StorageDirectives() map[string]StorageDirective
+
+	Resources() []Resource
+	Units() []UnitReader
+	CharmOrigin() CharmOrigin
+	CharmMetadata() CharmMetadata
+	CharmManifest() CharmManifest
+	CharmActions() CharmActions
+	CharmConfigs() CharmConfigs
+	Tools() AgentTools
+	Offers() []ApplicationOffer
+	OpenedPortRanges() PortRanges
+	ProvisioningState() ProvisioningState
+
+	Validate() error
+}
+
+// UnitReader is a read-only view of a Unit.
+type UnitReader interface {
+	Annotations() map[string]string
+	Constraints() Constraints
+
+	Tag() names.UnitTag
+	Name() string
+	Type() string
+	Machine() names.MachineTag
+	Life() string
+	PasswordHash() string
+	Nonce() string
+	AgentStartTime() time.Time
+	Principal() names.UnitTag
+	Subordinates() []names.UnitTag
+	MeterStatusCode() string
+	MeterStatusInfo() string
+	Tools() AgentTools
+	AgentVersion() string
+	WorkloadStatus() Status
+	WorkloadStatusHistory() []Status
+	WorkloadVersion() string
+	WorkloadVersionHistory() []Status
+	AgentStatus() Status
+	AgentStatusHistory() []Status
+	Resources() []UnitResource
+	Payloads() []Payload
+	CloudContainer() CloudContainer
+
+	CharmState() map[string]string
+	RelationState() map[int]string
+	UniterState() string
+	StorageState() string
+	MeterStatusState() string
+
+	Validate() error
+}
+
+// Freeze implements Model.
+func (m *model) Freeze() ModelReader {
+	m.setFrozen(true)
+	return frozenModel{m}
+}
+
+// setFrozen marks the model and the entities that guard their own
+// mutators as frozen or not.
+func (m *model) setFrozen(frozen bool) {
+	m.frozen = frozen
+	for _, a := range m.Applications_.Applications_ {
+		for _, u := range a.Units_.Units_ {
+			u.frozen = frozen
+		}
+	}
+}
+
+// checkMutable panics if the model has been frozen, as the callers that
+// mutate the model have no way to return an error.
+func (m *model) checkMutable() {
+	if m.frozen {
+		panic("description: attempt to modify a frozen model")
+	}
+}
+
+// frozenModel narrows a model to the ModelReader interface. The model
+// isn't embedded, so that none of its mutators can be reached through the
+// reader, and its entities have no way of knowing the model is frozen, so
+// everything the reader returns that could be changed is a copy.
+type frozenModel struct {
+	model *model
+}
+
+// Annotations implements ModelReader.
+func (f frozenModel) Annotations() map[string]string {
+	return cloned(f.model.Annotations())
+}
+
+// AnnotationsForEntity implements ModelReader.
+func (f frozenModel) AnnotationsForEntity(tag names.Tag) map[string]string {
+	return cloned(f.model.AnnotationsForEntity(tag))
+}
+
+// AnnotationsIndex implements ModelReader.
+func (f frozenModel) AnnotationsIndex() map[string]map[string]string {
+	return cloned(f.model.AnnotationsIndex())
+}
+
+// Constraints implements ModelReader.
+func (f frozenModel) Constraints() Constraints {
+	return cloned(f.model.Constraints())
+}
+
+// Status implements ModelReader.
+func (f frozenModel) Status() Status {
+	return cloned(f.model.Status())
+}
+
+// StatusHistory implements ModelReader.
+func (f frozenModel) StatusHistory() []Status {
+	return cloned(f.model.StatusHistory())
+}
+
+// CloudCredential implements ModelReader.
+func (f frozenModel) CloudCredential() CloudCredential {
+	return cloned(f.model.CloudCredential())
+}
+
+// Config implements ModelReader.
+func (f frozenModel) Config() map[string]interface{} {
+	return cloned(f.model.Config())
+}
+
+// Blocks implements ModelReader.
+func (f frozenModel) Blocks() map[string]string {
+	return cloned(f.model.Blocks())
+}
+
+// Sequences implements ModelReader.
+func (f frozenModel) Sequences() map[string]int {
+	return cloned(f.model.Sequences())
+}
+
+// SecretBackends implements ModelReader.
+func (f frozenModel) SecretBackends() []SecretBackend {
+	return cloned(f.model.SecretBackends())
+}
+
+// Telemetry implements ModelReader.
+func (f frozenModel) Telemetry() Telemetry {
+	return cloned(f.model.Telemetry())
+}
+
+// MigrationAttempt implements ModelReader.
+func (f frozenModel) MigrationAttempt() MigrationAttempt {
+	return cloned(f.model.MigrationAttempt())
+}
+
+// Lease implements ModelReader.
+func (f frozenModel) Lease() Lease {
+	return cloned(f.model.Lease())
+}
+
+// AgentVersion implements ModelReader.
+func (f frozenModel) AgentVersion() string {
+	return f.model.AgentVersion()
+}
+
+// Type implements ModelReader.
+func (f frozenModel) Type() string {
+	return f.model.Type()
+}
+
+// Cloud implements ModelReader.
+func (f frozenModel) Cloud() string {
+	return f.model.Cloud()
+}
+
+// CloudRegion implements ModelReader.
+func (f frozenModel) CloudRegion() string {
+	return f.model.CloudRegion()
+}
+
+// Description implements ModelReader.
+func (f frozenModel) Description() string {
+	return f.model.Description()
+}
+
+// Tag implements ModelReader.
+func (f frozenModel) Tag() names.ModelTag {
+	return f.model.Tag()
+}
+
+// Owner implements ModelReader.
+func (f frozenModel) Owner() names.UserTag {
+	return f.model.Owner()
+}
+
+// LatestToolsVersion implements ModelReader.
+func (f frozenModel) LatestToolsVersion() version.Number {
+	return f.model.LatestToolsVersion()
+}
+
+// EnvironVersion implements ModelReader.
+func (f frozenModel) EnvironVersion() int {
+	return f.model.EnvironVersion()
+}
+
+// PasswordHash implements ModelReader.
+func (f frozenModel) PasswordHash() string {
+	return f.model.PasswordHash()
+}
+
+// PasswordHashAlgorithm implements ModelReader.
+func (f frozenModel) PasswordHashAlgorithm() string {
+	return f.model.PasswordHashAlgorithm()
+}
+
+// SecretBackendID implements ModelReader.
+func (f frozenModel) SecretBackendID() string {
+	return f.model.SecretBackendID()
+}
+
+// SLA implements ModelReader.
+func (f frozenModel) SLA() SLA {
+	return cloned(f.model.SLA())
+}
+
+// MeterStatus implements ModelReader.
+func (f frozenModel) MeterStatus() MeterStatus {
+	return cloned(f.model.MeterStatus())
+}
+
+// ApplicationNames implements ModelReader.
+func (f frozenModel) ApplicationNames() []string {
+	return f.model.ApplicationNames()
+}
+
+// UnitNames implements ModelReader.
+func (f frozenModel) UnitNames() []string {
+	return f.model.UnitNames()
+}
+
+// Users implements ModelReader.
+func (f frozenModel) Users() []User {
+	return cloned(f.model.Users())
+}
+
+// Machines implements ModelReader.
+func (f frozenModel) Machines() []MachineReader {
+	return frozenMachines(cloned(f.model.Machines_.Machines_))
+}
+
+// Applications implements ModelReader.
+func (f frozenModel) Applications() []ApplicationReader {
+	var result []ApplicationReader
+	for _, application := range cloned(f.model.Applications_.Applications_) {
+		result = append(result, frozenApplication{application})
+	}
+	return result
+}
+
+// Relations implements ModelReader.
+func (f frozenModel) Relations() []Relation {
+	return cloned(f.model.Relations())
+}
+
+// RemoteEntities implements ModelReader.
+func (f frozenModel) RemoteEntities() []RemoteEntity {
+	return cloned(f.model.RemoteEntities())
+}
+
+// RelationNetworks implements ModelReader.
+func (f frozenModel) RelationNetworks() []RelationNetwork {
+	return cloned(f.model.RelationNetworks())
+}
+
+// RelationIngressNetworks implements ModelReader.
+func (f frozenModel) RelationIngressNetworks() []RelationNetwork {
+	return cloned(f.model.RelationIngressNetworks())
+}
+
+// RelationEgressNetworks implements ModelReader.
+func (f frozenModel) RelationEgressNetworks() []RelationNetwork {
+	return cloned(f.model.RelationEgressNetworks())
+}
+
+// Spaces implements ModelReader.
+func (f frozenModel) Spaces() []Space {
+	return cloned(f.model.Spaces())
+}
+
+// LinkLayerDevices implements ModelReader.
+func (f frozenModel) LinkLayerDevices() []LinkLayerDevice {
+	return cloned(f.model.LinkLayerDevices())
+}
+
+// Subnets implements ModelReader.
+func (f frozenModel) Subnets() []Subnet {
+	return cloned(f.model.Subnets())
+}
+
+// SubnetsInSpace implements ModelReader.
+func (f frozenModel) SubnetsInSpace(spaceID string) []Subnet {
+	return cloned(f.model.SubnetsInSpace(spaceID))
+}
+
+// IPAddresses implements ModelReader.
+func (f frozenModel) IPAddresses() []IPAddress {
+	return cloned(f.model.IPAddresses())
+}
+
+// SSHHostKeys implements ModelReader.
+func (f frozenModel) SSHHostKeys() []SSHHostKey {
+	return cloned(f.model.SSHHostKeys())
+}
+
+// VirtualHostKeys implements ModelReader.
+func (f frozenModel) VirtualHostKeys() []VirtualHostKey {
+	return cloned(f.model.VirtualHostKeys())
+}
+
+// CloudImageMetadata implements ModelReader.
+func (f frozenModel) CloudImageMetadata() []CloudImageMetadata {
+	return cloned(f.model.CloudImageMetadata())
+}
+
+// Actions implements ModelReader.
+func (f frozenModel) Actions() []Action {
+	return cloned(f.model.Actions())
+}
+
+// Operations implements ModelReader.
+func (f frozenModel) Operations() []Operation {
+	return cloned(f.model.Operations())
+}
+
+// Volumes implements ModelReader.
+func (f frozenModel) Volumes() []Volume {
+	return cloned(f.model.Volumes())
+}
+
+// FirewallRules implements ModelReader.
+func (f frozenModel) FirewallRules() []FirewallRule {
+	return cloned(f.model.FirewallRules())
+}
+
+// Charms implements ModelReader.
+func (f frozenModel) Charms() []Charm {
+	return cloned(f.model.Charms())
+}
+
+// Filesystems implements ModelReader.
+func (f frozenModel) Filesystems() []Filesystem {
+	return cloned(f.model.Filesystems())
+}
+
+// Storages implements ModelReader.
+func (f frozenModel) Storages() []Storage {
+	return cloned(f.model.Storages())
+}
+
+// StoragePools implements ModelReader.
+func (f frozenModel) StoragePools() []StoragePool {
+	return cloned(f.model.StoragePools())
+}
+
+// Secrets implements ModelReader.
+func (f frozenModel) Secrets() []Secret {
+	return cloned(f.model.Secrets())
+}
+
+// RemoteSecrets implements ModelReader.
+func (f frozenModel) RemoteSecrets() []RemoteSecret {
+	return cloned(f.model.RemoteSecrets())
+}
+
+// RemoteApplications implements ModelReader.
+func (f frozenModel) RemoteApplications() []RemoteApplication {
+	return cloned(f.model.RemoteApplications())
+}
+
+// OfferConnections implements ModelReader.
+func (f frozenModel) OfferConnections() []OfferConnection {
+	return cloned(f.model.OfferConnections())
+}
+
+// ExternalControllers implements ModelReader.
+func (f frozenModel) ExternalControllers() []ExternalController {
+	return cloned(f.model.ExternalControllers())
+}
+
+// Validate implements ModelReader.
+func (f frozenModel) Validate() error {
+	return f.model.Validate()
+}
+
+// ValidateAll implements ModelReader.
+func (f frozenModel) ValidateAll() error {
+	return f.model.ValidateAll()
+}
+
+// ValidateWith implements ModelReader.
+func (f frozenModel) ValidateWith(rules ...ValidationRule) error {
+	return f.model.ValidateWith(rules...)
+}
+
+// Checksum implements ModelReader.
+func (f frozenModel) Checksum() (string, error) {
+	return f.model.Checksum()
+}
+
+// Summary implements ModelReader.
+func (f frozenModel) Summary(w io.Writer) error {
+	return f.model.Summary(w)
+}
+
+// Labels implements ModelReader.
+func (f frozenModel) Labels() map[string]string {
+	return f.model.Labels()
+}
+
+// Copy implements ModelReader. The copy isn't frozen.
+func (f frozenModel) Copy() Model {
+	return f.model.Copy()
+}
+
+// DanglingPrincipals implements ModelReader.
+func (f frozenModel) DanglingPrincipals() []string {
+	return f.model.DanglingPrincipals()
+}
+
+// frozenMachine is a read-only view of a copy of a machine of a frozen
+// model.
+type frozenMachine struct {
+	*machine
+}
+
+// Containers implements MachineReader.
+func (f frozenMachine) Containers() []MachineReader {
+	return frozenMachines(f.machine.Containers_)
+}
+
+func frozenMachines(machines []*machine) []MachineReader {
+	var result []MachineReader
+	for _, machine := range machines {
+		result = append(result, frozenMachine{machine})
+	}
+	return result
+}
+
+// frozenApplication is a read-only view of a copy of an application of a
+// frozen model.
+type frozenApplication struct {
+	*application
+}
+
+// Units implements ApplicationReader.
+func (f frozenApplication) Units() []UnitReader {
+	var result []UnitReader
+	for _, unit := range f.application.Units_.Units_ {
+		result = append(result, unit)
+	}
+	return result
+}
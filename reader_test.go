@@ -0,0 +1,135 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+func (s *ReaderSuite) newModel() Model {
+	model := NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	})
+	model.SetStatus(minimalStatusArgs())
+	machine := model.AddMachine(MachineArgs{Id: names.NewMachineTag("0")})
+	machine.AddContainer(MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+	application := model.AddApplication(ApplicationArgs{
+		Tag: names.NewApplicationTag("ubuntu"),
+	})
+	application.AddUnit(UnitArgs{
+		Tag:     names.NewUnitTag("ubuntu/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+	return model
+}
+
+func (s *ReaderSuite) TestFreezeReturnsReadOnlyView(c *gc.C) {
+	reader := s.newModel().Freeze()
+
+	c.Assert(reader.Owner(), gc.Equals, names.NewUserTag("owner"))
+	c.Assert(reader.Tag(), gc.Equals, names.NewModelTag("some-uuid"))
+
+	machines := reader.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Id(), gc.Equals, "0")
+	containers := machines[0].Containers()
+	c.Assert(containers, gc.HasLen, 1)
+	c.Assert(containers[0].Id(), gc.Equals, "0/lxd/0")
+
+	applications := reader.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Name(), gc.Equals, "ubuntu")
+	units := applications[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Name(), gc.Equals, "ubuntu/0")
+}
+
+func (s *ReaderSuite) TestFrozenModelPanicsOnMutation(c *gc.C) {
+	model := s.newModel()
+	model.Freeze()
+
+	c.Assert(func() { model.AddUser(UserArgs{Name: names.NewUserTag("bob")}) },
+		gc.PanicMatches, "description: attempt to modify a frozen model")
+	c.Assert(func() { model.UpdateConfig(map[string]interface{}{"name": "foo"}) },
+		gc.PanicMatches, "description: attempt to modify a frozen model")
+	c.Assert(func() { model.SetAnnotations(map[string]string{"foo": "bar"}) },
+		gc.PanicMatches, "description: attempt to modify a frozen model")
+	c.Assert(model.Users(), gc.HasLen, 0)
+	c.Assert(model.Annotations(), gc.HasLen, 0)
+}
+
+func (s *ReaderSuite) TestFrozenModelPanicsOnWorkloadVersion(c *gc.C) {
+	model := s.newModel()
+	unit := model.Applications()[0].Units()[0]
+	model.Freeze()
+
+	c.Assert(func() { unit.SetWorkloadVersion("2.0") },
+		gc.PanicMatches, "description: attempt to modify a frozen model")
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "")
+
+	copied := model.Copy()
+	copied.Applications()[0].Units()[0].SetWorkloadVersion("2.0")
+	c.Assert(copied.Applications()[0].Units()[0].WorkloadVersion(), gc.Equals, "2.0")
+}
+
+func (s *ReaderSuite) TestReaderGettersReturnCopies(c *gc.C) {
+	model := s.newModel()
+	model.SetSequence("machine", 1)
+	model.AddRelation(RelationArgs{Id: 1, Key: "ubuntu:juju-info"}).SetStatus(minimalStatusArgs())
+	model.AddVolume(VolumeArgs{Tag: names.NewVolumeTag("1")}).SetStatus(minimalStatusArgs())
+	model.AddFilesystem(FilesystemArgs{Tag: names.NewFilesystemTag("1")}).SetStatus(minimalStatusArgs())
+	model.AddRemoteApplication(RemoteApplicationArgs{Tag: names.NewApplicationTag("mysql")})
+	model.SetAnnotations(map[string]string{"foo": "bar"})
+	before, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reader := model.Freeze()
+	reader.Config()["name"] = "changed"
+	reader.Sequences()["machine"] = 10
+	reader.Annotations()["foo"] = "changed"
+	reader.AnnotationsForEntity(model.Tag())["foo"] = "changed"
+	reader.AnnotationsIndex()[model.Tag().String()]["foo"] = "changed"
+	reader.Status().(*status).Value_ = "changed"
+
+	machine := reader.Machines()[0]
+	machine.(HasStatus).SetStatus(StatusArgs{Value: "changed"})
+	machine.(HasAnnotations).SetAnnotations(map[string]string{"foo": "changed"})
+	machine.Containers()[0].(HasStatus).SetStatus(StatusArgs{Value: "changed"})
+	application := reader.Applications()[0]
+	application.(HasStatus).SetStatus(StatusArgs{Value: "changed"})
+	application.(Application).AddUnit(UnitArgs{Tag: names.NewUnitTag("ubuntu/1")})
+	application.Units()[0].(Unit).SetWorkloadVersion("changed")
+	reader.Relations()[0].SetStatus(StatusArgs{Value: "changed"})
+	reader.Volumes()[0].SetStatus(StatusArgs{Value: "changed"})
+	reader.Filesystems()[0].SetStatus(StatusArgs{Value: "changed"})
+	reader.RemoteApplications()[0].SetStatus(StatusArgs{Value: "changed"})
+
+	after, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(after), gc.Equals, string(before))
+}
+
+func (s *ReaderSuite) TestReaderHidesMutators(c *gc.C) {
+	reader := s.newModel().Freeze()
+	_, ok := reader.(Model)
+	c.Assert(ok, jc.IsFalse)
+	_, ok = reader.(HasAnnotations)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ReaderSuite) TestUnfrozenModelMutable(c *gc.C) {
+	model := s.newModel()
+	model.SetAnnotations(map[string]string{"foo": "bar"})
+	c.Assert(model.Annotations(), jc.DeepEquals, map[string]string{"foo": "bar"})
+}
@@ -18,6 +18,9 @@ type Relation interface {
 	Suspended() bool
 	SuspendedReason() string
 
+	// Life returns the life of the relation, one of Alive, Dying or Dead.
+	Life() string
+
 	Endpoints() []Endpoint
 	AddEndpoint(EndpointArgs) Endpoint
 }
@@ -33,7 +36,10 @@ type relation struct {
 	Endpoints_       *endpoints `yaml:"endpoints"`
 	Suspended_       bool       `yaml:"suspended"`
 	SuspendedReason_ string     `yaml:"suspended-reason"`
+	Life_            string     `yaml:"life,omitempty"`
 	Status_          *status    `yaml:"status,omitempty"`
+
+	UnknownFields_ map[string]interface{} `yaml:",inline"`
 }
 
 // RelationArgs is an argument struct used to specify a relation.
@@ -42,6 +48,7 @@ type RelationArgs struct {
 	Key             string
 	Suspended       bool
 	SuspendedReason string
+	Life            string
 }
 
 func newRelation(args RelationArgs) *relation {
@@ -50,6 +57,7 @@ func newRelation(args RelationArgs) *relation {
 		Key_:             args.Key,
 		Suspended_:       args.Suspended,
 		SuspendedReason_: args.SuspendedReason,
+		Life_:            args.Life,
 	}
 	relation.setEndpoints(nil)
 	return relation
@@ -75,6 +83,11 @@ func (r *relation) SuspendedReason() string {
 	return r.SuspendedReason_
 }
 
+// Life implements Relation.
+func (r *relation) Life() string {
+	return lifeOrAlive(r.Life_)
+}
+
 // Status implements Relation.
 func (r *relation) Status() Status {
 	// To avoid typed nils check nil here.
@@ -161,6 +174,7 @@ var relationFieldsFuncs = map[int]fieldsFunc{
 	1: relationV1Fields,
 	2: relationV2Fields,
 	3: relationV3Fields,
+	4: relationV4Fields,
 }
 
 func relationV1Fields() (schema.Fields, schema.Defaults) {
@@ -189,6 +203,13 @@ func relationV3Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func relationV4Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := relationV3Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
 func newRelationFromValid(valid map[string]interface{}, importVersion int) (*relation, error) {
 	suspended := false
 	suspendedReason := ""
@@ -204,6 +225,9 @@ func newRelationFromValid(valid map[string]interface{}, importVersion int) (*rel
 		Suspended_:       suspended,
 		SuspendedReason_: suspendedReason,
 	}
+	if importVersion >= 4 {
+		result.Life_ = valid["life"].(string)
+	}
 	// Version 1 relations don't have status info in the export yaml.
 	// Some relations also don't have status.
 	_, ok := valid["status"]
@@ -4,6 +4,7 @@
 package description
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/yaml.v2"
@@ -81,7 +82,7 @@ func (s *RelationSerializationSuite) TestRelationEndpoints(c *gc.C) {
 
 func (s *RelationSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	initial := relations{
-		Version:    3,
+		Version:    4,
 		Relations_: []*relation{s.completeRelation()},
 	}
 
@@ -156,3 +157,33 @@ func (s *RelationSerializationSuite) TestVersion2Works(c *gc.C) {
 	c.Assert(relations[0].Suspended(), jc.IsFalse)
 	c.Assert(relations[0].SuspendedReason(), gc.Equals, "")
 }
+
+func (s *RelationSerializationSuite) TestLife(c *gc.C) {
+	initial := relations{
+		Version:    4,
+		Relations_: []*relation{s.completeRelation()},
+	}
+	c.Assert(initial.Relations_[0].Life(), gc.Equals, Alive)
+	initial.Relations_[0].Life_ = Dying
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	var data map[string]interface{}
+	err = yaml.Unmarshal(bytes, &data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	relations, err := importRelations(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relations[0].Life(), gc.Equals, Dying)
+
+	data["version"] = 3
+	relations, err = importRelations(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relations[0].Life(), gc.Equals, Alive)
+}
+
+func (s *RelationSerializationSuite) TestValidateLife(c *gc.C) {
+	err := validateLife("relation", "special", "zombie")
+	c.Assert(err, gc.ErrorMatches, `relation "special" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -4,16 +4,42 @@
 package description
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
 
+const (
+	// RelationNetworkIngress is the direction of the networks that may
+	// reach the model over a cross model relation.
+	RelationNetworkIngress = "ingress"
+
+	// RelationNetworkEgress is the direction of the networks that the
+	// model reaches over a cross model relation.
+	RelationNetworkEgress = "egress"
+
+	// RelationNetworkOriginDefault is the origin of the networks that
+	// Juju determines for a relation.
+	RelationNetworkOriginDefault = "default"
+
+	// RelationNetworkOriginAdmin is the origin of the networks that an
+	// admin sets for a relation, which override the defaults.
+	RelationNetworkOriginAdmin = "admin"
+)
+
 // RelationNetwork instances describe the ingress or egress
 // networks required for a cross model relation.
 type RelationNetwork interface {
 	ID() string
 	RelationKey() string
 	CIDRS() []string
+	// Direction is one of RelationNetworkIngress and
+	// RelationNetworkEgress.
+	Direction() string
+	// Origin is one of RelationNetworkOriginDefault and
+	// RelationNetworkOriginAdmin.
+	Origin() string
 }
 
 type relationNetworks struct {
@@ -25,6 +51,8 @@ type relationNetwork struct {
 	ID_          string   `yaml:"id"`
 	RelationKey_ string   `yaml:"relation-key"`
 	CIDRS_       []string `yaml:"cidrs"`
+	Direction_   string   `yaml:"direction"`
+	Origin_      string   `yaml:"origin"`
 }
 
 // RelationNetworkArgs is an argument struct used to add a relation network
@@ -33,6 +61,10 @@ type RelationNetworkArgs struct {
 	ID          string
 	RelationKey string
 	CIDRS       []string
+	// Direction defaults to RelationNetworkIngress.
+	Direction string
+	// Origin defaults to RelationNetworkOriginDefault.
+	Origin string
 }
 
 func newRelationNetwork(args RelationNetworkArgs) *relationNetwork {
@@ -40,6 +72,14 @@ func newRelationNetwork(args RelationNetworkArgs) *relationNetwork {
 		ID_:          args.ID,
 		RelationKey_: args.RelationKey,
 		CIDRS_:       args.CIDRS,
+		Direction_:   args.Direction,
+		Origin_:      args.Origin,
+	}
+	if r.Direction_ == "" {
+		r.Direction_ = RelationNetworkIngress
+	}
+	if r.Origin_ == "" {
+		r.Origin_ = RelationNetworkOriginDefault
 	}
 	return r
 }
@@ -59,6 +99,51 @@ func (r *relationNetwork) CIDRS() []string {
 	return r.CIDRS_
 }
 
+// Direction implements RelationNetwork
+func (r *relationNetwork) Direction() string {
+	return r.Direction_
+}
+
+// Origin implements RelationNetwork
+func (r *relationNetwork) Origin() string {
+	return r.Origin_
+}
+
+// Validate checks that the relation network has a known direction and
+// origin.
+func (r *relationNetwork) Validate() error {
+	if r.ID_ == "" {
+		return errors.NotValidf("relation network missing id")
+	}
+	switch r.Direction_ {
+	case RelationNetworkIngress, RelationNetworkEgress:
+	default:
+		return errors.NotValidf("relation network %q direction %q", r.ID_, r.Direction_)
+	}
+	switch r.Origin_ {
+	case RelationNetworkOriginDefault, RelationNetworkOriginAdmin:
+	default:
+		return errors.NotValidf("relation network %q origin %q", r.ID_, r.Origin_)
+	}
+	return nil
+}
+
+// sameCIDRs returns true if the networks hold the same set of CIDRs.
+func (r *relationNetwork) sameCIDRs(other *relationNetwork) bool {
+	cidrs := make(map[string]bool)
+	for _, cidr := range r.CIDRS_ {
+		cidrs[cidr] = true
+	}
+	others := make(map[string]bool)
+	for _, cidr := range other.CIDRS_ {
+		if !cidrs[cidr] {
+			return false
+		}
+		others[cidr] = true
+	}
+	return len(cidrs) == len(others)
+}
+
 func importRelationNetworks(source interface{}) ([]*relationNetwork, error) {
 	checker := versionedChecker("relation-networks")
 	coerced, err := checker.Coerce(source, nil)
@@ -105,11 +190,38 @@ func newRelationNetworkFromValid(valid map[string]interface{}, version int) (*re
 		RelationKey_: valid["relation-key"].(string),
 		CIDRS_:       convertToStringSlice(valid["cidrs"]),
 	}
+	if version >= 2 {
+		result.Direction_ = valid["direction"].(string)
+		result.Origin_ = valid["origin"].(string)
+	} else {
+		result.Direction_, result.Origin_ = relationNetworkIDParts(result.ID_)
+	}
 	return result, nil
 }
 
+// relationNetworkIDParts returns the direction and origin of a relation
+// network written before they were recorded. Juju gives relation networks
+// ids of the form "<relation key>:<direction>:<origin>", where the origin
+// of networks set by an admin is "override". Networks with other ids are
+// the default ingress networks.
+func relationNetworkIDParts(id string) (string, string) {
+	parts := strings.Split(id, ":")
+	if len(parts) < 3 {
+		return RelationNetworkIngress, RelationNetworkOriginDefault
+	}
+	direction, origin := parts[len(parts)-2], parts[len(parts)-1]
+	if direction != RelationNetworkIngress && direction != RelationNetworkEgress {
+		return RelationNetworkIngress, RelationNetworkOriginDefault
+	}
+	if origin == "override" {
+		return direction, RelationNetworkOriginAdmin
+	}
+	return direction, RelationNetworkOriginDefault
+}
+
 var relationNetworksFieldsFuncs = map[int]fieldsFunc{
 	1: relationNetworksV1Fields,
+	2: relationNetworksV2Fields,
 }
 
 func relationNetworksV1Fields() (schema.Fields, schema.Defaults) {
@@ -121,3 +233,10 @@ func relationNetworksV1Fields() (schema.Fields, schema.Defaults) {
 	defaults := schema.Defaults{}
 	return fields, defaults
 }
+
+func relationNetworksV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := relationNetworksV1Fields()
+	fields["direction"] = schema.String()
+	fields["origin"] = schema.String()
+	return fields, defaults
+}
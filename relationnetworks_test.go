@@ -35,7 +35,8 @@ func minimalRelationNetworkMap() map[interface{}]interface{} {
 			"1.2.3.4/24",
 			"0.0.0.1",
 		},
-		"type": "ingress",
+		"direction": "egress",
+		"origin":    "admin",
 	}
 }
 
@@ -47,6 +48,8 @@ func minimalRelationNetwork() *relationNetwork {
 			"1.2.3.4/24",
 			"0.0.0.1",
 		},
+		Direction: RelationNetworkEgress,
+		Origin:    RelationNetworkOriginAdmin,
 	})
 	return c
 }
@@ -59,6 +62,69 @@ func (*RelationNetworkSerializationSuite) TestNew(c *gc.C) {
 		"1.2.3.4/24",
 		"0.0.0.1",
 	})
+	c.Check(e.Direction(), gc.Equals, RelationNetworkEgress)
+	c.Check(e.Origin(), gc.Equals, RelationNetworkOriginAdmin)
+}
+
+func (*RelationNetworkSerializationSuite) TestNewDefaults(c *gc.C) {
+	e := newRelationNetwork(RelationNetworkArgs{ID: "rel-netw-id"})
+	c.Check(e.Direction(), gc.Equals, RelationNetworkIngress)
+	c.Check(e.Origin(), gc.Equals, RelationNetworkOriginDefault)
+}
+
+func (*RelationNetworkSerializationSuite) TestValidate(c *gc.C) {
+	e := minimalRelationNetwork()
+	c.Assert(e.Validate(), jc.ErrorIsNil)
+
+	e.Direction_ = "sideways"
+	c.Assert(e.Validate(), gc.ErrorMatches, `relation network "rel-netw-id" direction "sideways" not valid`)
+
+	e = minimalRelationNetwork()
+	e.Origin_ = "user"
+	c.Assert(e.Validate(), gc.ErrorMatches, `relation network "rel-netw-id" origin "user" not valid`)
+
+	e = minimalRelationNetwork()
+	e.ID_ = ""
+	c.Assert(e.Validate(), gc.ErrorMatches, `relation network missing id not valid`)
+}
+
+func (*RelationNetworkSerializationSuite) TestV1DirectionAndOriginFromID(c *gc.C) {
+	for i, test := range []struct {
+		id        string
+		direction string
+		origin    string
+	}{{
+		id:        "rel-netw-id",
+		direction: RelationNetworkIngress,
+		origin:    RelationNetworkOriginDefault,
+	}, {
+		id:        "wordpress:db mysql:server:ingress:default",
+		direction: RelationNetworkIngress,
+		origin:    RelationNetworkOriginDefault,
+	}, {
+		id:        "wordpress:db mysql:server:egress:default",
+		direction: RelationNetworkEgress,
+		origin:    RelationNetworkOriginDefault,
+	}, {
+		id:        "wordpress:db mysql:server:ingress:override",
+		direction: RelationNetworkIngress,
+		origin:    RelationNetworkOriginAdmin,
+	}} {
+		c.Logf("test %d: %q", i, test.id)
+		m := minimalRelationNetworkMap()
+		m["id"] = test.id
+		delete(m, "direction")
+		delete(m, "origin")
+		container := map[string]interface{}{
+			"version":           1,
+			"relation-networks": []interface{}{m},
+		}
+		networks, err := importRelationNetworks(container)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(networks, gc.HasLen, 1)
+		c.Check(networks[0].Direction(), gc.Equals, test.direction)
+		c.Check(networks[0].Origin(), gc.Equals, test.origin)
+	}
 }
 
 func (*RelationNetworkSerializationSuite) TestBadSchema1(c *gc.C) {
@@ -99,7 +165,7 @@ func (s *RelationNetworkSerializationSuite) TestRoundTrip(c *gc.C) {
 
 func (s *RelationNetworkSerializationSuite) exportImport(c *gc.C, relationNetworkIn *relationNetwork) *relationNetwork {
 	relationNetworksIn := &relationNetworks{
-		Version:          1,
+		Version:          2,
 		RelationNetworks: []*relationNetwork{relationNetworkIn},
 	}
 	bytes, err := yaml.Marshal(relationNetworksIn)
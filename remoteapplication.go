@@ -26,9 +26,15 @@ type RemoteApplication interface {
 	Endpoints() []RemoteEndpoint
 	AddEndpoint(RemoteEndpointArgs) RemoteEndpoint
 
+	// Spaces returns the spaces in the offering model that the
+	// endpoints of the remote application are bound to, with their
+	// provider attributes and subnets.
 	Spaces() []RemoteSpace
 	AddSpace(RemoteSpaceArgs) RemoteSpace
 
+	// Bindings returns the names of the spaces in the offering model
+	// that the endpoints of the remote application are bound to, keyed
+	// by endpoint name.
 	Bindings() map[string]string
 }
 
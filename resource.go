@@ -49,6 +49,26 @@ type ResourceRevision interface {
 	Size() int64
 	Timestamp() time.Time
 	Username() string
+	// Image returns the registry details of an OCI image resource, or
+	// nil if the revision isn't an image or they weren't recorded.
+	Image() ResourceImage
+}
+
+// ResourceOCIImage is the type of resources that are OCI images.
+const ResourceOCIImage = "oci-image"
+
+// ResourceImage holds what is needed to pull an OCI image resource from its
+// registry: the path of the image, and the credentials for the registry,
+// if it needs any. The credentials are either a username and password, or
+// a reference to a secret holding them. The password hash, recorded from
+// resources v3, lets the target controller check the credentials it is
+// given for the registry without them having been exported.
+type ResourceImage interface {
+	RegistryPath() string
+	Username() string
+	Password() string
+	PasswordHash() string
+	SecretRef() string
 }
 
 // ResourceArgs is an argument struct used to create a new internal
@@ -88,6 +108,17 @@ type ResourceRevisionArgs struct {
 	Size           int64
 	Timestamp      time.Time
 	Username       string
+	Image          *ResourceImageArgs
+}
+
+// ResourceImageArgs is an argument struct used to record the registry
+// details of an OCI image resource revision.
+type ResourceImageArgs struct {
+	RegistryPath string
+	Username     string
+	Password     string
+	PasswordHash string
+	SecretRef    string
 }
 
 // Name implements Resource.
@@ -128,11 +159,19 @@ func (r *resource) Validate() error {
 	if r.ApplicationRevision_ == nil {
 		return errors.New("no application revision set")
 	}
+	if err := r.ApplicationRevision_.validate(); err != nil {
+		return errors.Annotatef(err, "resource %s: application revision", r.Name_)
+	}
+	if r.CharmStoreRevision_ != nil {
+		if err := r.CharmStoreRevision_.validate(); err != nil {
+			return errors.Annotatef(err, "resource %s: charmstore revision", r.Name_)
+		}
+	}
 	return nil
 }
 
 func newResourceRevision(args ResourceRevisionArgs) *resourceRevision {
-	return &resourceRevision{
+	rev := &resourceRevision{
 		Revision_:       args.Revision,
 		Type_:           args.Type,
 		Path_:           args.Path,
@@ -143,6 +182,16 @@ func newResourceRevision(args ResourceRevisionArgs) *resourceRevision {
 		Timestamp_:      timePtr(args.Timestamp),
 		Username_:       args.Username,
 	}
+	if args.Image != nil {
+		rev.Image_ = &resourceImage{
+			RegistryPath_: args.Image.RegistryPath,
+			Username_:     args.Image.Username,
+			Password_:     args.Image.Password,
+			PasswordHash_: args.Image.PasswordHash,
+			SecretRef_:    args.Image.SecretRef,
+		}
+	}
+	return rev
 }
 
 type resourceRevision struct {
@@ -155,6 +204,16 @@ type resourceRevision struct {
 	Size_           int64      `yaml:"size"`
 	Timestamp_      *time.Time `yaml:"timestamp,omitempty"`
 	Username_       string     `yaml:"username,omitempty"`
+
+	Image_ *resourceImage `yaml:"image,omitempty"`
+}
+
+type resourceImage struct {
+	RegistryPath_ string `yaml:"registry-path"`
+	Username_     string `yaml:"username,omitempty"`
+	Password_     string `yaml:"password,omitempty"`
+	PasswordHash_ string `yaml:"password-hash,omitempty"`
+	SecretRef_    string `yaml:"secret-ref,omitempty"`
 }
 
 // Revision implements ResourceRevision.
@@ -205,6 +264,70 @@ func (r *resourceRevision) Username() string {
 	return r.Username_
 }
 
+// Image implements ResourceRevision.
+func (r *resourceRevision) Image() ResourceImage {
+	if r.Image_ == nil {
+		return nil // Return untyped nil when not set
+	}
+	return r.Image_
+}
+
+// validate checks that only image resources have image details, and that
+// those details are complete, with either a username and password or a
+// secret reference, but not both.
+func (r *resourceRevision) validate() error {
+	if r.Image_ == nil {
+		return nil
+	}
+	if r.Type_ != ResourceOCIImage {
+		return errors.NotValidf("image details for %q resource", r.Type_)
+	}
+	image := r.Image_
+	if image.RegistryPath_ == "" {
+		return errors.NotValidf("image missing registry path")
+	}
+	if (image.Password_ != "" || image.PasswordHash_ != "") && image.Username_ == "" {
+		return errors.NotValidf("image password without username")
+	}
+	if image.SecretRef_ != "" && (image.Username_ != "" || image.Password_ != "" || image.PasswordHash_ != "") {
+		return errors.NotValidf("image with both secret reference and username")
+	}
+	return nil
+}
+
+// redact removes the image password, if any, leaving the registry path,
+// the username, the password hash and any secret reference.
+func (r *resourceRevision) redact() {
+	if r.Image_ != nil {
+		r.Image_.Password_ = ""
+	}
+}
+
+// RegistryPath implements ResourceImage.
+func (i *resourceImage) RegistryPath() string {
+	return i.RegistryPath_
+}
+
+// Username implements ResourceImage.
+func (i *resourceImage) Username() string {
+	return i.Username_
+}
+
+// Password implements ResourceImage.
+func (i *resourceImage) Password() string {
+	return i.Password_
+}
+
+// PasswordHash implements ResourceImage.
+func (i *resourceImage) PasswordHash() string {
+	return i.PasswordHash_
+}
+
+// SecretRef implements ResourceImage.
+func (i *resourceImage) SecretRef() string {
+	return i.SecretRef_
+}
+
 func importResources(source map[string]interface{}) ([]*resource, error) {
 	checker := versionedChecker("resources")
 	coerced, err := checker.Coerce(source, nil)
@@ -242,9 +365,23 @@ type resourceDeserializationFunc func(map[string]interface{}) (*resource, error)
 
 var resourceDeserializationFuncs = map[int]resourceDeserializationFunc{
 	1: importResourceV1,
+	2: importResourceV2,
+	3: importResourceV3,
 }
 
 func importResourceV1(source map[string]interface{}) (*resource, error) {
+	return importResource(source, 1)
+}
+
+func importResourceV2(source map[string]interface{}) (*resource, error) {
+	return importResource(source, 2)
+}
+
+func importResourceV3(source map[string]interface{}) (*resource, error) {
+	return importResource(source, 3)
+}
+
+func importResource(source map[string]interface{}, version int) (*resource, error) {
 	fields := schema.Fields{
 		"name":                 schema.String(),
 		"application-revision": schema.StringMap(schema.Any()),
@@ -257,7 +394,7 @@ func importResourceV1(source map[string]interface{}) (*resource, error) {
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "resource v1 schema check failed")
+		return nil, errors.Annotatef(err, "resource v%d schema check failed", version)
 	}
 	valid := coerced.(map[string]interface{})
 
@@ -266,13 +403,13 @@ func importResourceV1(source map[string]interface{}) (*resource, error) {
 	r := newResource(ResourceArgs{
 		Name: valid["name"].(string),
 	})
-	appRev, err := importResourceRevisionV1(valid["application-revision"])
+	appRev, err := importResourceRevision(valid["application-revision"], version)
 	if err != nil {
 		return nil, errors.Annotatef(err, "resource %s: application revision", r.Name_)
 	}
 	r.ApplicationRevision_ = appRev
 	if source, exists := valid["charmstore-revision"]; exists {
-		csRev, err := importResourceRevisionV1(source)
+		csRev, err := importResourceRevision(source, version)
 		if err != nil {
 			return nil, errors.Annotatef(err, "resource %s: charmstore revision", r.Name_)
 		}
@@ -281,23 +418,12 @@ func importResourceV1(source map[string]interface{}) (*resource, error) {
 	return r, nil
 }
 
-func importResourceRevisionV1(source interface{}) (*resourceRevision, error) {
-	fields := schema.Fields{
-		"revision":    schema.Int(),
-		"type":        schema.String(),
-		"path":        schema.String(),
-		"description": schema.String(),
-		"origin":      schema.String(),
-		"fingerprint": schema.String(),
-		"size":        schema.Int(),
-		"timestamp":   schema.Time(),
-		"username":    schema.String(),
-	}
-	defaults := schema.Defaults{
-		"timestamp": schema.Omit,
-		"username":  "",
+func importResourceRevision(source interface{}, version int) (*resourceRevision, error) {
+	getFields, ok := resourceRevisionFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
 	}
-	checker := schema.FieldMap(fields, defaults)
+	checker := schema.FieldMap(getFields())
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
 		return nil, errors.Annotatef(err, "resource revision schema check failed")
@@ -315,5 +441,78 @@ func importResourceRevisionV1(source interface{}) (*resourceRevision, error) {
 		Timestamp_:      fieldToTimePtr(valid, "timestamp"),
 		Username_:       valid["username"].(string),
 	}
+	if version >= 2 {
+		if image, ok := valid["image"]; ok {
+			rev.Image_, err = importResourceImage(image, version)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
 	return rev, nil
 }
+
+func importResourceImage(source interface{}, version int) (*resourceImage, error) {
+	fields := schema.Fields{
+		"registry-path": schema.String(),
+		"username":      schema.String(),
+		"password":      schema.String(),
+		"secret-ref":    schema.String(),
+	}
+	defaults := schema.Defaults{
+		"username":   "",
+		"password":   "",
+		"secret-ref": "",
+	}
+	if version >= 3 {
+		fields["password-hash"] = schema.String()
+		defaults["password-hash"] = ""
+	}
+	coerced, err := schema.FieldMap(fields, defaults).Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource image schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	image := &resourceImage{
+		RegistryPath_: valid["registry-path"].(string),
+		Username_:     valid["username"].(string),
+		Password_:     valid["password"].(string),
+		SecretRef_:    valid["secret-ref"].(string),
+	}
+	if version >= 3 {
+		image.PasswordHash_ = valid["password-hash"].(string)
+	}
+	return image, nil
+}
+
+var resourceRevisionFieldsFuncs = map[int]fieldsFunc{
+	1: resourceRevisionV1Fields,
+	2: resourceRevisionV2Fields,
+	3: resourceRevisionV2Fields,
+}
+
+func resourceRevisionV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := resourceRevisionV1Fields()
+	fields["image"] = schema.StringMap(schema.Any())
+	defaults["image"] = schema.Omit
+	return fields, defaults
+}
+
+func resourceRevisionV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"revision":    schema.Int(),
+		"type":        schema.String(),
+		"path":        schema.String(),
+		"description": schema.String(),
+		"origin":      schema.String(),
+		"fingerprint": schema.String(),
+		"size":        schema.Int(),
+		"timestamp":   schema.Time(),
+		"username":    schema.String(),
+	}
+	defaults := schema.Defaults{
+		"timestamp": schema.Omit,
+		"username":  "",
+	}
+	return fields, defaults
+}
@@ -148,7 +148,7 @@ func (s *ResourceSuite) TestRoundTrip(c *gc.C) {
 
 func (s *ResourceSuite) exportImport(c *gc.C, resourceIn *resource) *resource {
 	resourcesIn := &resources{
-		Version:    1,
+		Version:    3,
 		Resources_: []*resource{resourceIn},
 	}
 	bytes, err := yaml.Marshal(resourcesIn)
@@ -163,3 +163,142 @@ func (s *ResourceSuite) exportImport(c *gc.C, resourceIn *resource) *resource {
 	c.Assert(resourcesOut, gc.HasLen, 1)
 	return resourcesOut[0]
 }
+
+func imageResource() *resource {
+	r := newResource(ResourceArgs{Name: "image"})
+	r.SetApplicationRevision(ResourceRevisionArgs{
+		Revision:       1,
+		Type:           ResourceOCIImage,
+		Origin:         "upload",
+		FingerprintHex: "aaaaaaaa",
+		Image: &ResourceImageArgs{
+			RegistryPath: "registry.example.com/ubuntu:22.04",
+			Username:     "docker",
+			Password:     "hunter2",
+			PasswordHash: "Il/M2+WlhkUA5zASHj+QvE66",
+		},
+	})
+	r.SetCharmStoreRevision(ResourceRevisionArgs{
+		Revision:       2,
+		Type:           ResourceOCIImage,
+		Origin:         "store",
+		FingerprintHex: "bbbbbbbb",
+		Image: &ResourceImageArgs{
+			RegistryPath: "registry.example.com/ubuntu:24.04",
+			SecretRef:    "secret:cs0ba5a8e6d0rnvbuqgg",
+		},
+	})
+	return r
+}
+
+func (s *ResourceSuite) TestImage(c *gc.C) {
+	r := imageResource()
+	c.Assert(r.Validate(), jc.ErrorIsNil)
+	c.Check(minimalResource().ApplicationRevision().Image(), gc.IsNil)
+
+	image := r.ApplicationRevision().Image()
+	c.Check(image.RegistryPath(), gc.Equals, "registry.example.com/ubuntu:22.04")
+	c.Check(image.Username(), gc.Equals, "docker")
+	c.Check(image.Password(), gc.Equals, "hunter2")
+	c.Check(image.PasswordHash(), gc.Equals, "Il/M2+WlhkUA5zASHj+QvE66")
+	c.Check(image.SecretRef(), gc.Equals, "")
+
+	image = r.CharmStoreRevision().Image()
+	c.Check(image.RegistryPath(), gc.Equals, "registry.example.com/ubuntu:24.04")
+	c.Check(image.SecretRef(), gc.Equals, "secret:cs0ba5a8e6d0rnvbuqgg")
+}
+
+func (s *ResourceSuite) TestImageRoundTrip(c *gc.C) {
+	rIn := imageResource()
+	rOut := s.exportImport(c, rIn)
+	c.Assert(rOut, jc.DeepEquals, rIn)
+}
+
+func (s *ResourceSuite) TestImageIgnoredBeforeV2(c *gc.C) {
+	source := map[string]interface{}{
+		"version": 1,
+		"resources": []interface{}{map[string]interface{}{
+			"name": "image",
+			"application-revision": map[string]interface{}{
+				"revision":    1,
+				"type":        ResourceOCIImage,
+				"path":        "",
+				"description": "",
+				"origin":      "upload",
+				"fingerprint": "aaaaaaaa",
+				"size":        0,
+				"image": map[string]interface{}{
+					"registry-path": "registry.example.com/ubuntu:22.04",
+				},
+			},
+		}},
+	}
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0].ApplicationRevision().Image(), gc.IsNil)
+}
+
+func (s *ResourceSuite) TestImagePasswordHashIgnoredBeforeV3(c *gc.C) {
+	source := map[string]interface{}{
+		"version": 2,
+		"resources": []interface{}{map[string]interface{}{
+			"name": "image",
+			"application-revision": map[string]interface{}{
+				"revision":    1,
+				"type":        ResourceOCIImage,
+				"path":        "",
+				"description": "",
+				"origin":      "upload",
+				"fingerprint": "aaaaaaaa",
+				"size":        0,
+				"image": map[string]interface{}{
+					"registry-path": "registry.example.com/ubuntu:22.04",
+					"username":      "docker",
+					"password-hash": "Il/M2+WlhkUA5zASHj+QvE66",
+				},
+			},
+		}},
+	}
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	image := resources[0].ApplicationRevision().Image()
+	c.Assert(image, gc.NotNil)
+	c.Assert(image.Username(), gc.Equals, "docker")
+	c.Assert(image.PasswordHash(), gc.Equals, "")
+}
+
+func (s *ResourceSuite) TestValidateImage(c *gc.C) {
+	for i, test := range []struct {
+		revType  string
+		image    ResourceImageArgs
+		expected string
+	}{{
+		revType:  "file",
+		image:    ResourceImageArgs{RegistryPath: "ubuntu"},
+		expected: `resource image: application revision: image details for "file" resource not valid`,
+	}, {
+		revType:  ResourceOCIImage,
+		image:    ResourceImageArgs{Username: "docker"},
+		expected: `resource image: application revision: image missing registry path not valid`,
+	}, {
+		revType:  ResourceOCIImage,
+		image:    ResourceImageArgs{RegistryPath: "ubuntu", Password: "hunter2"},
+		expected: `resource image: application revision: image password without username not valid`,
+	}, {
+		revType:  ResourceOCIImage,
+		image:    ResourceImageArgs{RegistryPath: "ubuntu", Username: "docker", SecretRef: "secret:xyz"},
+		expected: `resource image: application revision: image with both secret reference and username not valid`,
+	}, {
+		revType:  ResourceOCIImage,
+		image:    ResourceImageArgs{RegistryPath: "ubuntu", PasswordHash: "hash"},
+		expected: `resource image: application revision: image password without username not valid`,
+	}} {
+		c.Logf("test %d", i)
+		image := test.image
+		r := newResource(ResourceArgs{Name: "image"})
+		r.SetApplicationRevision(ResourceRevisionArgs{Type: test.revType, Image: &image})
+		c.Check(r.Validate(), gc.ErrorMatches, test.expected)
+	}
+}
@@ -0,0 +1,549 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// modelScan holds the version of a serialized model, the number of
+// entities in each of its sections and its labels, read without importing
+// the model.
+type modelScan struct {
+	Version int
+	Labels  map[string]string
+
+	// sections holds the number of entities in each versioned section,
+	// other than the machines and applications, which are counted below.
+	sections     map[string]int
+	machines     int
+	applications int
+	units        int
+}
+
+// scanModel reads the version, section counts and labels of a serialized
+// model. The document is walked a line at a time, keeping only the keys
+// that lead to the current line, so that neither a node tree nor any of
+// the entities is built; only the labels are decoded. A document the walk
+// can't follow, such as one with flow collections, which neither
+// Serialize nor yaml.v2 writes for a model, is decoded in full instead.
+func scanModel(bytes []byte) (*modelScan, error) {
+	scan, ok := walkModel(bytes)
+	if !ok {
+		var err error
+		if scan, err = decodeModel(bytes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if scan.Version == 0 {
+		return nil, errors.NotValidf("missing model version")
+	}
+	return scan, nil
+}
+
+// counts returns the number of entities in each section of the model,
+// keyed by section name. Containers are counted with the machines, and
+// units are counted separately from their applications.
+func (s *modelScan) counts() map[string]int {
+	counts := make(map[string]int)
+	for name, count := range s.sections {
+		if count > 0 {
+			counts[name] = count
+		}
+	}
+	counts["machines"] = s.machines
+	counts["applications"] = s.applications
+	counts["units"] = s.units
+	return counts
+}
+
+// scanFrame is a key, or an item of a sequence, that leads to the line
+// being walked, along with the column it starts at.
+type scanFrame struct {
+	indent int
+	key    string
+	item   bool
+}
+
+// modelWalk walks the lines of a block style YAML document, keeping track
+// of the keys and items leading to each of them.
+type modelWalk struct {
+	scan     modelScan
+	frames   []scanFrame
+	sections map[string]int
+	// versioned holds the top level sections with a version of their
+	// own, which are the only ones whose entities are counted.
+	versioned map[string]bool
+	// labelsStart and labelsEnd are the offsets of the labels section
+	// in the document, or -1 if it hasn't been found or hasn't ended.
+	labelsStart int
+	labelsEnd   int
+}
+
+// walkModel scans a block style document without parsing it. It returns
+// false if the document has anything the walk doesn't follow.
+func walkModel(document []byte) (*modelScan, bool) {
+	w := &modelWalk{
+		sections:    make(map[string]int),
+		versioned:   make(map[string]bool),
+		labelsStart: -1,
+		labelsEnd:   -1,
+	}
+	// skipDeeper is the column that the lines of a multi-line scalar
+	// are indented beyond, or -1 if the last line didn't start one.
+	skipDeeper := -1
+	seen := false
+	for offset := 0; offset < len(document); {
+		end := len(document)
+		next := end
+		if i := bytes.IndexByte(document[offset:], '\n'); i >= 0 {
+			end = offset + i
+			next = end + 1
+		}
+		line := document[offset:end]
+		lineStart := offset
+		offset = next
+
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+		}
+		indent := 0
+		for indent < len(line) && line[indent] == ' ' {
+			indent++
+		}
+		if indent == len(line) {
+			continue
+		}
+		if skipDeeper >= 0 && indent > skipDeeper {
+			continue
+		}
+		skipDeeper = -1
+		content := line[indent:]
+		switch {
+		case content[0] == '#':
+			continue
+		case content[0] == '\t' || content[0] == '%':
+			return nil, false
+		case indent == 0 && (string(content) == "---" || string(content) == "..."):
+			if seen {
+				return nil, false
+			}
+			continue
+		}
+		seen = true
+
+		column := indent
+		for content[0] == '-' && (len(content) == 1 || content[1] == ' ') {
+			if !w.item(column) {
+				return nil, false
+			}
+			spaces := 1
+			for spaces < len(content) && content[spaces] == ' ' {
+				spaces++
+			}
+			if spaces == len(content) {
+				content = nil
+				break
+			}
+			column += spaces
+			content = content[spaces:]
+		}
+		if len(content) == 0 {
+			continue
+		}
+
+		key, value, isKey := splitKey(content)
+		if !isKey {
+			// A scalar item, which is only found after a dash.
+			if column == indent || !scalarValue(content) {
+				return nil, false
+			}
+			skipDeeper = indent
+			continue
+		}
+		if !w.key(column, key, value, lineStart) {
+			return nil, false
+		}
+		if len(value) > 0 {
+			if !scalarValue(value) {
+				return nil, false
+			}
+			skipDeeper = column
+		}
+	}
+	if w.labelsStart >= 0 {
+		labels := document[w.labelsStart:]
+		if w.labelsEnd >= 0 {
+			labels = document[w.labelsStart:w.labelsEnd]
+		}
+		var section struct {
+			Labels map[string]string `yaml:"labels"`
+		}
+		if err := yaml.Unmarshal(labels, &section); err != nil {
+			return nil, false
+		}
+		w.scan.Labels = section.Labels
+	}
+	w.scan.sections = make(map[string]int)
+	for name, count := range w.sections {
+		if w.versioned[name] {
+			w.scan.sections[name] = count
+		}
+	}
+	return &w.scan, true
+}
+
+// item records an item of a sequence starting at the column, counting it
+// if it is an entity. It returns false if the item isn't in a mapping.
+func (w *modelWalk) item(column int) bool {
+	w.pop(func(frame scanFrame) bool {
+		return frame.indent > column || frame.indent == column && frame.item
+	})
+	if len(w.frames) == 0 {
+		return false
+	}
+	w.frames = append(w.frames, scanFrame{indent: column, item: true})
+	w.count()
+	return true
+}
+
+// key records a key starting at the column, along with the value on the
+// same line, if any.
+func (w *modelWalk) key(column int, key, value []byte, lineStart int) bool {
+	w.pop(func(frame scanFrame) bool {
+		return frame.indent >= column
+	})
+	switch len(w.frames) {
+	case 0:
+		if w.labelsStart >= 0 && w.labelsEnd < 0 {
+			w.labelsEnd = lineStart
+		}
+		switch string(key) {
+		case "version":
+			version, err := strconv.Atoi(string(trimComment(value)))
+			if err != nil {
+				return false
+			}
+			w.scan.Version = version
+		case "labels":
+			w.labelsStart = lineStart
+		}
+	case 1:
+		if string(key) == "version" && len(value) > 0 {
+			w.versioned[w.frames[0].key] = true
+		}
+	}
+	if len(value) == 0 {
+		w.frames = append(w.frames, scanFrame{indent: column, key: string(key)})
+	}
+	return true
+}
+
+func (w *modelWalk) pop(done func(scanFrame) bool) {
+	for len(w.frames) > 0 && done(w.frames[len(w.frames)-1]) {
+		w.frames = w.frames[:len(w.frames)-1]
+	}
+}
+
+// count counts the item at the end of the frames if it is an entity: an
+// item of a list in a section, a container of a machine or a unit of an
+// application.
+func (w *modelWalk) count() {
+	frames := w.frames
+	if len(frames) < 3 || frames[0].item || frames[1].item {
+		return
+	}
+	section, list := frames[0].key, frames[1].key
+	switch {
+	case len(frames) == 3:
+		w.sections[section]++
+		switch {
+		case section == "machines" && list == "machines":
+			w.scan.machines++
+		case section == "applications" && list == "applications":
+			w.scan.applications++
+		}
+	case section == "machines" && list == "machines":
+		// machines, machines, -, containers, -, containers, -, ...
+		for i := 2; i < len(frames); i += 2 {
+			if !frames[i].item || i+1 < len(frames) && frames[i+1].key != "containers" {
+				return
+			}
+		}
+		if len(frames)%2 == 1 {
+			w.scan.machines++
+		}
+	case section == "applications" && list == "applications" && len(frames) == 6:
+		if frames[2].item && frames[3].key == "units" && frames[4].key == "units" && frames[5].item {
+			w.scan.units++
+		}
+	}
+}
+
+// splitKey splits a line of a mapping into its key and the value that
+// follows it on the line, if any. It returns false if the line isn't
+// a key, such as when it is a scalar item of a sequence.
+func splitKey(content []byte) (key, value []byte, ok bool) {
+	var colon int
+	switch quote := content[0]; quote {
+	case '"', '\'':
+		end := closingQuote(content, quote)
+		if end < 0 || end+1 >= len(content) || content[end+1] != ':' {
+			return nil, nil, false
+		}
+		key, colon = content[1:end], end+1
+	case '[', '{', '?', '&', '*', '!', '|', '>':
+		return nil, nil, false
+	default:
+		colon = -1
+		for i := 0; i < len(content); i++ {
+			if content[i] == '#' && i > 0 && content[i-1] == ' ' {
+				break
+			}
+			if content[i] == ':' && (i+1 == len(content) || content[i+1] == ' ') {
+				colon = i
+				break
+			}
+		}
+		if colon < 0 {
+			return nil, nil, false
+		}
+		key = content[:colon]
+	}
+	if colon+1 < len(content) && content[colon+1] != ' ' {
+		return nil, nil, false
+	}
+	value = content[colon+1:]
+	for len(value) > 0 && value[0] == ' ' {
+		value = value[1:]
+	}
+	if len(value) > 0 && value[0] == '#' {
+		value = nil
+	}
+	return key, value, true
+}
+
+// closingQuote returns the offset of the quote closing the scalar that the
+// content starts with, or -1 if it isn't closed on the same line.
+func closingQuote(content []byte, quote byte) int {
+	for i := 1; i < len(content); i++ {
+		switch {
+		case quote == '"' && content[i] == '\\':
+			i++
+		case content[i] == quote:
+			if quote == '\'' && i+1 < len(content) && content[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// scalarValue reports whether the value on the line of a key or item is
+// one the walk can skip over: anything but a flow collection with
+// something in it, or an anchor or a lone tag, which could introduce a
+// block collection.
+func scalarValue(value []byte) bool {
+	switch value[0] {
+	case '[', '{':
+		value = trimComment(value)
+		return string(value) == "[]" || string(value) == "{}"
+	case '!':
+		// A tag must be followed by the scalar it applies to, such as
+		// the "!!binary" of a string that isn't UTF-8.
+		return bytes.IndexByte(value, ' ') > 0
+	case '&', '?':
+		return false
+	}
+	return true
+}
+
+// trimComment returns the value without a trailing comment or spaces.
+func trimComment(value []byte) []byte {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && value[i-1] == ' ' {
+			value = value[:i]
+			break
+		}
+	}
+	for len(value) > 0 && value[len(value)-1] == ' ' {
+		value = value[:len(value)-1]
+	}
+	return value
+}
+
+// modelDocument is a serialized model decoded in full, but with each of
+// its entities decoded into an empty struct, so that only their number is
+// kept. It is used for documents walkModel doesn't follow.
+type modelDocument struct {
+	Version int `yaml:"version"`
+
+	Machines struct {
+		Machines []scanMachine `yaml:"machines"`
+	} `yaml:"machines"`
+	Applications struct {
+		Applications []scanApplication `yaml:"applications"`
+	} `yaml:"applications"`
+	Labels map[string]string `yaml:"labels"`
+
+	Sections map[string]scanSection `yaml:",inline"`
+}
+
+type scanMachine struct {
+	Containers []scanMachine `yaml:"containers"`
+}
+
+func (m scanMachine) count() int {
+	count := 1
+	for _, container := range m.Containers {
+		count += container.count()
+	}
+	return count
+}
+
+type scanApplication struct {
+	Units struct {
+		Units []struct{} `yaml:"units"`
+	} `yaml:"units"`
+}
+
+// scanSection is the number of entities in a versioned section of the
+// model. Anything else at the top level of the model scans as zero.
+type scanSection int
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *scanSection) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var versioned struct {
+		Version *int `yaml:"version"`
+	}
+	if err := unmarshal(&versioned); err != nil || versioned.Version == nil {
+		return nil
+	}
+	var lists map[string]scanList
+	if err := unmarshal(&lists); err != nil {
+		return nil
+	}
+	for _, list := range lists {
+		*s += scanSection(list)
+	}
+	return nil
+}
+
+// scanList is the length of a list, or zero for any other value.
+type scanList int
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *scanList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items []struct{}
+	if err := unmarshal(&items); err == nil {
+		*l = scanList(len(items))
+	}
+	return nil
+}
+
+func decodeModel(bytes []byte) (*modelScan, error) {
+	var document modelDocument
+	if err := yaml.Unmarshal(bytes, &document); err != nil {
+		return nil, errors.Trace(err)
+	}
+	scan := &modelScan{
+		Version:      document.Version,
+		Labels:       document.Labels,
+		sections:     make(map[string]int),
+		applications: len(document.Applications.Applications),
+	}
+	for name, count := range document.Sections {
+		scan.sections[name] = int(count)
+	}
+	for _, machine := range document.Machines.Machines {
+		scan.machines += machine.count()
+	}
+	for _, application := range document.Applications.Applications {
+		scan.units += len(application.Units.Units)
+	}
+	return scan, nil
+}
+
+// ModelSummary is the version of a serialized model, the number of
+// entities in each of its sections and its labels, as returned by PreScan.
+type ModelSummary struct {
+	Version int
+	// Counts holds the number of entities in each section, keyed by
+	// section name, such as "machines" or "ip-addresses". Containers
+	// are counted with the machines, and units, which are held by their
+	// applications, have a count of their own under "units".
+	Counts map[string]int
+	// Labels are the labels the model was written with, see
+	// ExportOptions.Labels.
+	Labels map[string]string
+}
+
+// PreScan returns the version, section counts and labels of a serialized
+// model without importing it. The bytes are walked a line at a time rather
+// than parsed, so no schema checks are done and neither a node tree nor any
+// of the entities is built, and the memory needed doesn't grow with the
+// size of the model, unless it was written in flow style. As the document
+// isn't parsed, a malformed one may only be reported as such when it is
+// deserialized.
+func PreScan(bytes []byte) (ModelSummary, error) {
+	scan, err := scanModel(bytes)
+	if err != nil {
+		return ModelSummary{}, errors.Trace(err)
+	}
+	return ModelSummary{
+		Version: scan.Version,
+		Counts:  scan.counts(),
+		Labels:  scan.Labels,
+	}, nil
+}
+
+// estimatedEntitySize is a rough, generous, number of bytes each entity of
+// a section takes up once imported, including the status history and
+// other entities it holds.
+var estimatedEntitySize = map[string]int64{
+	"machines":     16 << 10,
+	"applications": 64 << 10,
+	"units":        8 << 10,
+	"relations":    4 << 10,
+	"actions":      4 << 10,
+	"operations":   2 << 10,
+	"secrets":      4 << 10,
+}
+
+// defaultEntitySize is the estimated size of the entities of sections not
+// in estimatedEntitySize.
+const defaultEntitySize = 1 << 10
+
+// baseModelSize is the estimated size of an imported model with no
+// entities in it.
+const baseModelSize = 64 << 10
+
+// EstimateMemoryFootprint returns a rough estimate, in bytes, of the memory
+// the serialized model would take up once imported. It only counts the
+// entities in each section, without importing them, so that callers can
+// turn away models too large to import before doing so. The entities are
+// counted as PreScan counts them, walking the bytes rather than parsing
+// them, so the estimate itself needs little memory whatever the size of
+// the model.
+func EstimateMemoryFootprint(bytes []byte) (int64, error) {
+	scan, err := scanModel(bytes)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	size := int64(baseModelSize)
+	for name, count := range scan.counts() {
+		entitySize, ok := estimatedEntitySize[name]
+		if !ok {
+			entitySize = defaultEntitySize
+		}
+		size += int64(count) * entitySize
+	}
+	return size, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ScanSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScanSuite{})
+
+func (*ScanSuite) TestCounts(c *gc.C) {
+	bytes, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+
+	scan, err := scanModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scan.Version, gc.Equals, 20)
+	counts := scan.counts()
+	c.Check(counts["machines"], gc.Equals, 2)
+	c.Check(counts["applications"], gc.Equals, 1)
+	c.Check(counts["units"], gc.Equals, 1)
+	c.Check(counts["relations"], gc.Equals, 1)
+	c.Check(counts["spaces"], gc.Equals, 1)
+	_, ok := counts["config"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (*ScanSuite) TestWalkMatchesDecode(c *gc.C) {
+	bytes, err := SerializeWithOptions(selfTestModel(), ExportOptions{
+		Labels: map[string]string{"job": "nightly", "note": "a: b"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	walked, ok := walkModel(bytes)
+	c.Assert(ok, jc.IsTrue)
+	decoded, err := decodeModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(walked.Version, gc.Equals, decoded.Version)
+	c.Check(walked.counts(), jc.DeepEquals, decoded.counts())
+	c.Check(walked.Labels, jc.DeepEquals, map[string]string{"job": "nightly", "note": "a: b"})
+}
+
+func (*ScanSuite) TestWalkSkipsScalars(c *gc.C) {
+	bytes := []byte(`
+version: 3
+# The labels are decoded, but nothing else is.
+labels:
+  team: "a: b"
+machines:
+  version: 1
+  machines:
+  - id: "0"
+    notes: |
+      - not a machine
+      containers:
+      - not a container
+    containers:
+    - id: 0/lxd/0
+      containers:
+      - id: 0/lxd/0/kvm/0
+  - id: "1"
+    description: a plain scalar
+      carried on over
+      more lines
+applications:
+  applications:
+  - name: ubuntu
+    units:
+      units:
+      - name: ubuntu/0
+      - name: ubuntu/1
+      version: 1
+  version: 1
+"spaces":
+  spaces:
+  - name: alpha
+  version: 1
+config:
+  list:
+  - a
+`[1:])
+	walked, ok := walkModel(bytes)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(walked.Version, gc.Equals, 3)
+	c.Check(walked.Labels, jc.DeepEquals, map[string]string{"team": "a: b"})
+	c.Check(walked.counts(), jc.DeepEquals, map[string]int{
+		"machines":     4,
+		"applications": 1,
+		"units":        2,
+		"spaces":       1,
+	})
+
+	decoded, err := decodeModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decoded.counts(), jc.DeepEquals, walked.counts())
+}
+
+func (*ScanSuite) TestWalkGivesUpOnFlowStyle(c *gc.C) {
+	_, ok := walkModel([]byte("version: 3\nmachines:\n  machines: [{}]\n"))
+	c.Check(ok, jc.IsFalse)
+
+	// Empty flow collections are written by Serialize, and are followed.
+	_, ok = walkModel([]byte("version: 3\nmachines:\n  machines: []\n"))
+	c.Check(ok, jc.IsTrue)
+}
+
+func (*ScanSuite) TestEstimateMemoryFootprint(c *gc.C) {
+	empty, err := Serialize(NewModel(ModelArgs{}))
+	c.Assert(err, jc.ErrorIsNil)
+	emptySize, err := EstimateMemoryFootprint(empty)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(emptySize, gc.Equals, int64(baseModelSize))
+
+	full, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	fullSize, err := EstimateMemoryFootprint(full)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullSize > emptySize+2*estimatedEntitySize["machines"], jc.IsTrue)
+}
+
+func (*ScanSuite) TestEstimateMemoryFootprintMissingVersion(c *gc.C) {
+	_, err := EstimateMemoryFootprint([]byte("owner: admin\n"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*ScanSuite) TestPreScan(c *gc.C) {
+	bytes, err := Serialize(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+
+	summary, err := PreScan(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Version, gc.Equals, 20)
+	c.Check(summary.Counts["machines"], gc.Equals, 2)
+	c.Check(summary.Counts["applications"], gc.Equals, 1)
+	c.Check(summary.Counts["units"], gc.Equals, 1)
+	c.Check(summary.Counts["ip-addresses"], gc.Equals, 1)
+}
+
+func (*ScanSuite) TestPreScanSkipsSchemaChecks(c *gc.C) {
+	summary, err := PreScan([]byte(`
+version: 3
+machines:
+  version: 99
+  machines:
+  - id: "0"
+  - containers:
+    - {}
+applications:
+  applications:
+  - units:
+      units: [{}, {}]
+`[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Version, gc.Equals, 3)
+	c.Check(summary.Counts["machines"], gc.Equals, 3)
+	c.Check(summary.Counts["applications"], gc.Equals, 1)
+	c.Check(summary.Counts["units"], gc.Equals, 2)
+}
+
+func (*ScanSuite) TestPreScanInvalidYAML(c *gc.C) {
+	_, err := PreScan([]byte("version: [\n"))
+	c.Assert(err, gc.NotNil)
+}
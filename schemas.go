@@ -0,0 +1,172 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// EntitySchema describes the versions an entity is serialized with, and
+// the fields each of them is checked against on import, for tools that
+// check or document serialized models.
+type EntitySchema struct {
+	// Entity is the name of the entity, such as "application".
+	Entity string
+
+	// MinVersion and MaxVersion are the first and the latest versions
+	// of the entity. Every version between them can be imported, and the
+	// entity is written at the latest.
+	MinVersion int
+	MaxVersion int
+
+	versions map[int]fieldsFunc
+}
+
+// Fields returns the fields of the entity at the version.
+func (s EntitySchema) Fields(version int) (schema.Fields, error) {
+	fields, _, err := s.schema(version)
+	return fields, errors.Trace(err)
+}
+
+// Defaults returns the defaults of the fields of the entity at the
+// version. Fields with a default can be missing; a default of schema.Omit
+// means the field is left out of the imported values when missing.
+func (s EntitySchema) Defaults(version int) (schema.Defaults, error) {
+	_, defaults, err := s.schema(version)
+	return defaults, errors.Trace(err)
+}
+
+func (s EntitySchema) schema(version int) (schema.Fields, schema.Defaults, error) {
+	fieldsFunc, ok := s.versions[version]
+	if !ok {
+		return nil, nil, errors.NotFoundf("%s version %d", s.Entity, version)
+	}
+	fields, defaults := fieldsFunc()
+	return fields, defaults, nil
+}
+
+// entityVersions holds the fields of each version of the entities whose
+// fields are described separately from their import.
+var entityVersions = map[string]map[int]fieldsFunc{
+	"action": actionFieldsFuncs,
+	"application": {
+		1:  applicationV1Fields,
+		2:  applicationV2Fields,
+		3:  applicationV3Fields,
+		4:  applicationV4Fields,
+		5:  applicationV5Fields,
+		6:  applicationV6Fields,
+		7:  applicationV7Fields,
+		8:  applicationV8Fields,
+		9:  applicationV9Fields,
+		10: applicationV10Fields,
+		11: applicationV11Fields,
+		12: applicationV12Fields,
+		13: applicationV13Fields,
+		14: applicationV14Fields,
+		15: applicationV15Fields,
+		16: applicationV16Fields,
+	},
+	"application-offer":   {1: applicationOfferV1Fields, 2: applicationOfferV2Fields},
+	"block-device":        {1: blockDeviceV1Fields, 2: blockDeviceV2Fields},
+	"charm":               charmFieldsFuncs,
+	"cloud-credential":    {1: cloudCredentialV1Fields, 2: cloudCredentialV2Fields},
+	"cloud-instance":      cloudInstanceFieldsFuncs,
+	"constraints":         constraintsFieldsFuncs,
+	"controller":          controllerFieldsFuncs,
+	"controller-node":     controllerNodeFieldsFuncs,
+	"endpoint":            endpointFieldsFuncs,
+	"external-controller": {1: externalControllerV1Fields},
+	"firewall-rule":       firewallRuleFieldsFuncs,
+	"machine": {
+		1: machineSchemaV1,
+		2: machineSchemaV2,
+		3: machineSchemaV3,
+		4: machineSchemaV4,
+		5: machineSchemaV5,
+		6: machineSchemaV6,
+		7: machineSchemaV7,
+	},
+	"model": {
+		1:  modelV1Fields,
+		2:  modelV2Fields,
+		3:  modelV3Fields,
+		4:  modelV4Fields,
+		5:  modelV5Fields,
+		6:  modelV6Fields,
+		7:  modelV7Fields,
+		8:  modelV8Fields,
+		9:  modelV9Fields,
+		10: modelV10Fields,
+		11: modelV11Fields,
+		12: modelV12Fields,
+		13: modelV13Fields,
+		14: modelV14Fields,
+		15: modelV15Fields,
+		16: modelV16Fields,
+		17: modelV17Fields,
+		18: modelV18Fields,
+		19: modelV19Fields,
+		20: modelV20Fields,
+	},
+	"operation":          operationFieldsFuncs,
+	"relation":           relationFieldsFuncs,
+	"relation-network":   relationNetworksFieldsFuncs,
+	"remote-application": remoteApplicationFieldsFuncs,
+	"remote-entity":      remoteEntityFieldsFuncs,
+	"remote-secret":      remoteSecretFieldsFuncs,
+	"remote-space":       remoteSpaceFieldsFuncs,
+	"resource-revision":  resourceRevisionFieldsFuncs,
+	"secret":             secretFieldsFuncs,
+	"secret-backend":     secretBackendFieldsFuncs,
+	"status":             {1: statusV1Fields, 2: statusV2Fields},
+	"subnet":             subnetFieldsFuncs,
+	"unit": {
+		1: unitV1Fields,
+		2: unitV2Fields,
+		3: unitV3Fields,
+		4: unitV4Fields,
+		5: unitV5Fields,
+		6: unitV6Fields,
+	},
+	"virtual-host-key": virtualHostKeyFieldsFuncs,
+}
+
+func newEntitySchema(entity string, versions map[int]fieldsFunc) EntitySchema {
+	result := EntitySchema{Entity: entity, versions: versions}
+	for version := range versions {
+		if result.MinVersion == 0 || version < result.MinVersion {
+			result.MinVersion = version
+		}
+		if version > result.MaxVersion {
+			result.MaxVersion = version
+		}
+	}
+	return result
+}
+
+// EntitySchemas returns the schemas of the serialized entities, sorted by
+// entity name. Entities held in a model are described separately from it.
+func EntitySchemas() []EntitySchema {
+	result := make([]EntitySchema, 0, len(entityVersions))
+	for entity, versions := range entityVersions {
+		result = append(result, newEntitySchema(entity, versions))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Entity < result[j].Entity
+	})
+	return result
+}
+
+// EntitySchemaFor returns the schema of the named entity.
+func EntitySchemaFor(entity string) (EntitySchema, error) {
+	versions, ok := entityVersions[entity]
+	if !ok {
+		return EntitySchema{}, errors.NotFoundf("entity %q", entity)
+	}
+	return newEntitySchema(entity, versions), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type EntitySchemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&EntitySchemaSuite{})
+
+// TestVersionsFollowImport checks that the schemas describe every version
+// that can be imported, so that they don't fall behind when versions are
+// added.
+func (s *EntitySchemaSuite) TestVersionsFollowImport(c *gc.C) {
+	imported := map[string]int{
+		"application":       len(applicationDeserializationFuncs),
+		"application-offer": len(applicationOfferDeserializationFuncs),
+		"block-device":      len(blockdeviceDeserializationFuncs),
+		"cloud-credential":  len(cloudCredentialDeserializationFuncs),
+		"machine":           len(machineDeserializationFuncs),
+		"model":             len(modelDeserializationFuncs),
+		"status":            len(statusFieldsFuncs),
+		"unit":              len(unitDeserializationFuncs),
+	}
+	schemas := EntitySchemas()
+	c.Assert(schemas, gc.HasLen, len(entityVersions))
+	for i, entitySchema := range schemas {
+		if i > 0 {
+			c.Check(entitySchema.Entity > schemas[i-1].Entity, jc.IsTrue)
+		}
+		c.Check(entitySchema.MinVersion, gc.Equals, 1, gc.Commentf(entitySchema.Entity))
+		if count, ok := imported[entitySchema.Entity]; ok {
+			c.Check(entitySchema.MaxVersion, gc.Equals, count, gc.Commentf(entitySchema.Entity))
+		}
+		for version := entitySchema.MinVersion; version <= entitySchema.MaxVersion; version++ {
+			fields, err := entitySchema.Fields(version)
+			c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s v%d", entitySchema.Entity, version))
+			c.Check(fields, gc.Not(gc.HasLen), 0)
+		}
+	}
+}
+
+func (s *EntitySchemaSuite) TestModel(c *gc.C) {
+	modelSchema, err := EntitySchemaFor("model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelSchema.MaxVersion, gc.Equals, 20)
+
+	fields, err := modelSchema.Fields(17)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields["entity-annotations"], gc.NotNil)
+	defaults, err := modelSchema.Defaults(17)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(defaults["entity-annotations"], gc.Equals, schema.Omit)
+
+	fields, err = modelSchema.Fields(16)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields["entity-annotations"], gc.IsNil)
+
+	_, err = modelSchema.Fields(20)
+	c.Check(err, gc.ErrorMatches, "model version 21 not found")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *EntitySchemaSuite) TestUnknownEntity(c *gc.C) {
+	_, err := EntitySchemaFor("widget")
+	c.Check(err, gc.ErrorMatches, `entity "widget" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// SecretBackend represents a backend holding the content of the model's
+// secrets, as referenced by the backend id of secret revisions.
+type SecretBackend interface {
+	Id() string
+	Name() string
+	BackendType() string
+	Config() map[string]interface{}
+	TokenRotateInterval() *time.Duration
+}
+
+type secretBackends struct {
+	Version         int              `yaml:"version"`
+	SecretBackends_ []*secretBackend `yaml:"secret-backends"`
+}
+
+type secretBackend struct {
+	ID_          string                 `yaml:"id"`
+	Name_        string                 `yaml:"name"`
+	BackendType_ string                 `yaml:"backend-type"`
+	Config_      map[string]interface{} `yaml:"config,omitempty"`
+	// TokenRotateInterval_ is written as a duration string, such as "1h0m0s".
+	TokenRotateInterval_ string `yaml:"token-rotate-interval,omitempty"`
+}
+
+// SecretBackendArgs is an argument struct used to add a secret backend to
+// the Model.
+type SecretBackendArgs struct {
+	ID                  string
+	Name                string
+	BackendType         string
+	Config              map[string]interface{}
+	TokenRotateInterval *time.Duration
+}
+
+func newSecretBackend(args SecretBackendArgs) *secretBackend {
+	backend := &secretBackend{
+		ID_:          args.ID,
+		Name_:        args.Name,
+		BackendType_: args.BackendType,
+		Config_:      args.Config,
+	}
+	if args.TokenRotateInterval != nil {
+		backend.TokenRotateInterval_ = args.TokenRotateInterval.String()
+	}
+	return backend
+}
+
+// Id implements SecretBackend.
+func (b *secretBackend) Id() string {
+	return b.ID_
+}
+
+// Name implements SecretBackend.
+func (b *secretBackend) Name() string {
+	return b.Name_
+}
+
+// BackendType implements SecretBackend.
+func (b *secretBackend) BackendType() string {
+	return b.BackendType_
+}
+
+// Config implements SecretBackend.
+func (b *secretBackend) Config() map[string]interface{} {
+	return b.Config_
+}
+
+// TokenRotateInterval implements SecretBackend.
+func (b *secretBackend) TokenRotateInterval() *time.Duration {
+	if b.TokenRotateInterval_ == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(b.TokenRotateInterval_)
+	if err != nil {
+		return nil
+	}
+	return &interval
+}
+
+// Validate checks that the backend is complete.
+func (b *secretBackend) Validate() error {
+	if b.ID_ == "" {
+		return errors.NotValidf("secret backend missing id")
+	}
+	if b.Name_ == "" {
+		return errors.NotValidf("secret backend %q missing name", b.ID_)
+	}
+	if b.BackendType_ == "" {
+		return errors.NotValidf("secret backend %q missing backend type", b.Name_)
+	}
+	if b.TokenRotateInterval_ != "" {
+		if _, err := time.ParseDuration(b.TokenRotateInterval_); err != nil {
+			return errors.NotValidf("secret backend %q token rotate interval %q", b.Name_, b.TokenRotateInterval_)
+		}
+	}
+	return nil
+}
+
+func importSecretBackends(source map[string]interface{}) ([]*secretBackend, error) {
+	checker := versionedChecker("secret-backends")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secret backends version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	getFields, ok := secretBackendFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["secret-backends"].([]interface{})
+	return importSecretBackendList(sourceList, schema.FieldMap(getFields()), version)
+}
+
+func importSecretBackendList(sourceList []interface{}, checker schema.Checker, version int) ([]*secretBackend, error) {
+	result := make([]*secretBackend, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for secret backend %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "secret backend %d v%d schema check failed", i, version)
+		}
+		valid := coerced.(map[string]interface{})
+		// From here we know that the map returned from the schema coercion
+		// contains fields of the right type.
+		backend := &secretBackend{
+			ID_:          valid["id"].(string),
+			Name_:        valid["name"].(string),
+			BackendType_: valid["backend-type"].(string),
+		}
+		if config, ok := valid["config"]; ok {
+			backend.Config_ = config.(map[string]interface{})
+		}
+		if interval := valid["token-rotate-interval"].(time.Duration); interval != 0 {
+			backend.TokenRotateInterval_ = interval.String()
+		}
+		result[i] = backend
+	}
+	return result, nil
+}
+
+var secretBackendFieldsFuncs = map[int]fieldsFunc{
+	1: secretBackendV1Fields,
+}
+
+func secretBackendV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"id":                    schema.String(),
+		"name":                  schema.String(),
+		"backend-type":          schema.String(),
+		"config":                schema.StringMap(schema.Any()),
+		"token-rotate-interval": schema.TimeDuration(),
+	}
+	defaults := schema.Defaults{
+		"config":                schema.Omit,
+		"token-rotate-interval": "",
+	}
+	return fields, defaults
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SecretBackendSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&SecretBackendSerializationSuite{})
+
+func (s *SecretBackendSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "secret backends"
+	s.sliceName = "secret-backends"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importSecretBackends(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["secret-backends"] = []interface{}{}
+	}
+}
+
+func minimalSecretBackendMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"id":           "backend-id",
+		"name":         "myvault",
+		"backend-type": "vault",
+	}
+}
+
+func minimalSecretBackendArgs() SecretBackendArgs {
+	return SecretBackendArgs{
+		ID:          "backend-id",
+		Name:        "myvault",
+		BackendType: "vault",
+	}
+}
+
+func (*SecretBackendSerializationSuite) TestNew(c *gc.C) {
+	interval := 24 * time.Hour
+	backend := newSecretBackend(SecretBackendArgs{
+		ID:                  "backend-id",
+		Name:                "myvault",
+		BackendType:         "vault",
+		Config:              map[string]interface{}{"endpoint": "http://vault:8200"},
+		TokenRotateInterval: &interval,
+	})
+	c.Check(backend.Id(), gc.Equals, "backend-id")
+	c.Check(backend.Name(), gc.Equals, "myvault")
+	c.Check(backend.BackendType(), gc.Equals, "vault")
+	c.Check(backend.Config(), jc.DeepEquals, map[string]interface{}{"endpoint": "http://vault:8200"})
+	c.Check(backend.TokenRotateInterval(), jc.DeepEquals, &interval)
+}
+
+func (*SecretBackendSerializationSuite) TestNoTokenRotateInterval(c *gc.C) {
+	backend := newSecretBackend(minimalSecretBackendArgs())
+	c.Check(backend.TokenRotateInterval(), gc.IsNil)
+}
+
+func (*SecretBackendSerializationSuite) TestMinimalMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(newSecretBackend(minimalSecretBackendArgs()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, minimalSecretBackendMap())
+}
+
+func (*SecretBackendSerializationSuite) TestBadSchema(c *gc.C) {
+	m := minimalSecretBackendMap()
+	m["token-rotate-interval"] = "daily"
+	container := map[string]interface{}{
+		"version":         1,
+		"secret-backends": []interface{}{m},
+	}
+	_, err := importSecretBackends(container)
+	c.Assert(err, gc.ErrorMatches, `secret backend 0 v1 schema check failed: token-rotate-interval: .*`)
+}
+
+func (*SecretBackendSerializationSuite) TestValidate(c *gc.C) {
+	backend := newSecretBackend(SecretBackendArgs{Name: "myvault", BackendType: "vault"})
+	c.Check(backend.Validate(), gc.ErrorMatches, "secret backend missing id not valid")
+
+	backend = newSecretBackend(SecretBackendArgs{ID: "backend-id", BackendType: "vault"})
+	c.Check(backend.Validate(), gc.ErrorMatches, `secret backend "backend-id" missing name not valid`)
+
+	backend = newSecretBackend(SecretBackendArgs{ID: "backend-id", Name: "myvault"})
+	c.Check(backend.Validate(), gc.ErrorMatches, `secret backend "myvault" missing backend type not valid`)
+
+	c.Check(newSecretBackend(minimalSecretBackendArgs()).Validate(), jc.ErrorIsNil)
+}
+
+func (s *SecretBackendSerializationSuite) TestRoundTrip(c *gc.C) {
+	interval := 90 * time.Minute
+	in := newSecretBackend(SecretBackendArgs{
+		ID:                  "backend-id",
+		Name:                "myvault",
+		BackendType:         "vault",
+		Config:              map[string]interface{}{"endpoint": "http://vault:8200"},
+		TokenRotateInterval: &interval,
+	})
+	bytes, err := yaml.Marshal(&secretBackends{Version: 1, SecretBackends_: []*secretBackend{in}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := importSecretBackends(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, []*secretBackend{in})
+	c.Assert(out[0].TokenRotateInterval(), jc.DeepEquals, &interval)
+}
@@ -107,6 +107,7 @@ type secretRemoteConsumerDeserializationFunc func(map[interface{}]interface{}) (
 var secretRemoteConsumerDeserializationFuncs = map[int]secretRemoteConsumerDeserializationFunc{
 	1: importSecretRemoteConsumerV1,
 	2: importSecretRemoteConsumerV2,
+	3: importSecretRemoteConsumerV2,
 }
 
 func importSecretRemoteConsumerV2(source map[interface{}]interface{}) (*secretRemoteConsumer, error) {
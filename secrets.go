@@ -15,6 +15,8 @@ import (
 
 // Secret represents a secret.
 type Secret interface {
+	HasAnnotations
+
 	Id() string
 	Version() int
 	Description() string
@@ -64,6 +66,8 @@ type secret struct {
 
 	LatestRevisionChecksum_ string `yaml:"latest-revision-checksum"`
 
+	Annotations_ `yaml:"annotations,omitempty"`
+
 	// These are updated when revisions are set
 	// and are not exported.
 	LatestRevision_   int        `yaml:"-"`
@@ -339,6 +343,7 @@ func importSecretList(sourceList []interface{}, version int) ([]*secret, error)
 var secretFieldsFuncs = map[int]fieldsFunc{
 	1: secretV1Fields,
 	2: secretV2Fields,
+	3: secretV3Fields,
 }
 
 func secretV1Fields() (schema.Fields, schema.Defaults) {
@@ -377,6 +382,12 @@ func secretV2Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func secretV3Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := secretV2Fields()
+	AddAnnotationSchema(fields, defaults)
+	return fields, defaults
+}
+
 func importSecret(source map[string]interface{}, importVersion int, fieldFunc func() (schema.Fields, schema.Defaults)) (*secret, error) {
 	fields, defaults := fieldFunc()
 	checker := schema.FieldMap(fields, defaults)
@@ -407,6 +418,10 @@ func importSecret(source map[string]interface{}, importVersion int, fieldFunc fu
 		}
 	}
 
+	if importVersion >= 3 {
+		secret.ImportAnnotations(valid)
+	}
+
 	// This should be in a v2 schema but it's already also in v1.
 	if autoPrune, ok := valid["auto-prune"].(bool); ok {
 		secret.AutoPrune_ = autoPrune
@@ -516,6 +531,7 @@ type secretAccessDeserializationFunc func(map[interface{}]interface{}) (*secretA
 var secretAccessDeserializationFuncs = map[int]secretAccessDeserializationFunc{
 	1: importSecretAccessV1,
 	2: importSecretAccessV2,
+	3: importSecretAccessV2,
 }
 
 func importSecretAccessV2(source map[interface{}]interface{}) (*secretAccess, error) {
@@ -639,6 +655,7 @@ type secretConsumerDeserializationFunc func(map[interface{}]interface{}) (*secre
 var secretConsumerDeserializationFuncs = map[int]secretConsumerDeserializationFunc{
 	1: importSecretConsumerV1,
 	2: importSecretConsumerV2,
+	3: importSecretConsumerV2,
 }
 
 func importSecretConsumerV2(source map[interface{}]interface{}) (*secretConsumer, error) {
@@ -784,6 +801,10 @@ func (i *secretRevision) ExpireTime() *time.Time {
 
 // ValueRef implements SecretRevision.
 func (i *secretRevision) ValueRef() SecretValueRef {
+	// To avoid typed nils check nil here.
+	if i.ValueRef_ == nil {
+		return nil
+	}
 	return i.ValueRef_
 }
 
@@ -835,6 +856,7 @@ type secretRevisionDeserializationFunc func(map[interface{}]interface{}) (*secre
 var secretRevisionRangeDeserializationFuncs = map[int]secretRevisionDeserializationFunc{
 	1: importSecretRevisionV1,
 	2: importSecretRevisionV2,
+	3: importSecretRevisionV2,
 }
 
 func importSecretRevisionV2(source map[interface{}]interface{}) (*secretRevision, error) {
@@ -250,10 +250,20 @@ func (s *SecretsSerializationSuite) exportImport(c *gc.C, secret_ *secret, versi
 func (s *SecretsSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	args := testSecretArgs()
 	original := newSecret(args)
-	secret := s.exportImport(c, original, 2)
+	secret := s.exportImport(c, original, 3)
 	c.Assert(secret, jc.DeepEquals, original)
 }
 
+func (s *SecretsSerializationSuite) TestAnnotations(c *gc.C) {
+	original := newSecret(testSecretArgs())
+	original.SetAnnotations(map[string]string{"rotated-by": "ops"})
+	secret := s.exportImport(c, original, 3)
+	c.Assert(secret.Annotations(), jc.DeepEquals, map[string]string{"rotated-by": "ops"})
+
+	secret = s.exportImport(c, original, 2)
+	c.Assert(secret.Annotations(), gc.HasLen, 0)
+}
+
 type oldSecret struct {
 	ID_          string            `yaml:"id"`
 	Version_     int               `yaml:"secret-version"`
@@ -0,0 +1,85 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// SerializeSections serializes the named top level sections of the model,
+// such as "applications" and "relations", along with the model version, and
+// leaves the rest out. Each section is written as Serialize writes it, with
+// its own version. It is meant for tools that compare or inspect parts of a
+// model; the result isn't a model that Deserialize can import.
+func SerializeSections(model Model, sections ...string) ([]byte, error) {
+	known := modelSections()
+	wanted := set.NewStrings("version")
+	for _, section := range sections {
+		if !known.Contains(section) {
+			return nil, errors.NotValidf("section %q", section)
+		}
+		wanted.Add(section)
+	}
+	serialized, err := Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []byte
+	for _, section := range topLevelSections(serialized) {
+		if wanted.Contains(section.name) {
+			result = append(result, section.bytes...)
+		}
+	}
+	return result, nil
+}
+
+// modelSections returns the names of the top level sections a model may
+// be written with.
+func modelSections() set.Strings {
+	names := set.NewStrings(labelsKey)
+	for _, field := range structInfoOf(reflect.TypeOf(model{})).fields {
+		names.Add(field.key)
+	}
+	return names
+}
+
+type serializedSection struct {
+	name  string
+	bytes []byte
+}
+
+// topLevelSections splits a serialized model into its top level sections,
+// in the order they were written. The model is written in block style, so
+// a section runs from its key at the start of a line to the next such key;
+// the lines in between are indented, or are the items of a sequence.
+func topLevelSections(serialized []byte) []serializedSection {
+	var sections []serializedSection
+	name, start := "", 0
+	for offset := 0; offset < len(serialized); {
+		end := bytes.IndexByte(serialized[offset:], '\n')
+		if end < 0 {
+			end = len(serialized)
+		} else {
+			end += offset + 1
+		}
+		line := serialized[offset:end]
+		if c := line[0]; c != ' ' && c != '-' && c != '\n' {
+			if colon := bytes.IndexByte(line, ':'); colon > 0 {
+				if name != "" {
+					sections = append(sections, serializedSection{name, serialized[start:offset]})
+				}
+				name, start = string(line[:colon]), offset
+			}
+		}
+		offset = end
+	}
+	if name != "" {
+		sections = append(sections, serializedSection{name, serialized[start:]})
+	}
+	return sections
+}
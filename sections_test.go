@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SectionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SectionsSuite{})
+
+func (s *SectionsSuite) TestSerializeSections(c *gc.C) {
+	m := selfTestModel()
+	bytes, err := SerializeSections(m, "relations", "applications")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var obtained map[string]interface{}
+	c.Assert(yaml.Unmarshal(bytes, &obtained), jc.ErrorIsNil)
+	c.Assert(obtained, gc.HasLen, 3)
+
+	full, err := Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	var expected map[string]interface{}
+	c.Assert(yaml.Unmarshal(full, &expected), jc.ErrorIsNil)
+	for _, section := range []string{"version", "applications", "relations"} {
+		c.Check(obtained[section], jc.DeepEquals, expected[section], gc.Commentf("section %q", section))
+	}
+	c.Check(obtained["applications"].(map[interface{}]interface{})["version"], gc.NotNil)
+
+	summary, err := PreScan(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Counts["applications"], gc.Equals, 1)
+	c.Check(summary.Counts["machines"], gc.Equals, 0)
+}
+
+func (s *SectionsSuite) TestSerializeNoSections(c *gc.C) {
+	bytes, err := SerializeSections(selfTestModel())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, "version: 20\n")
+}
+
+func (s *SectionsSuite) TestSerializeMissingSection(c *gc.C) {
+	// Sections that are left out when empty can still be asked for.
+	bytes, err := SerializeSections(selfTestModel(), "labels")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, "version: 20\n")
+}
+
+func (s *SectionsSuite) TestSerializeUnknownSection(c *gc.C) {
+	_, err := SerializeSections(selfTestModel(), "applications", "widgets")
+	c.Assert(err, gc.ErrorMatches, `section "widgets" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SectionsSuite) TestTopLevelSections(c *gc.C) {
+	sections := topLevelSections([]byte("version: 1\nlist:\n- a\nmap:\n  key: value\n"))
+	c.Assert(sections, jc.DeepEquals, []serializedSection{
+		{"version", []byte("version: 1\n")},
+		{"list", []byte("list:\n- a\n")},
+		{"map", []byte("map:\n  key: value\n")},
+	})
+}
@@ -0,0 +1,516 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// selfTestCACert is a self signed certificate for the external controller
+// of the self test model.
+const selfTestCACert = `-----BEGIN CERTIFICATE-----
+MIIBejCCASGgAwIBAgIUdj6pOobSS7NN2+te8+jZnzXXAEswCgYIKoZIzj0EAwIw
+EjEQMA4GA1UEAwwHanVqdS1jYTAgFw0yNjEwMTYwMTI1MzBaGA8yMTI2MDkyMjAx
+MjUzMFowEjEQMA4GA1UEAwwHanVqdS1jYTBZMBMGByqGSM49AgEGCCqGSM49AwEH
+A0IABKd/jSmWSra6qgE9uKWrPX7l2U+iTjZbQATVrQ7th756CPYNKwDsie6758/0
+Jz5cFY8LUmGYteJ89VRTAGKWBq2jUzBRMB0GA1UdDgQWBBRMtnne1pZMa8MEqGCI
+pq8LtOX7pzAfBgNVHSMEGDAWgBRMtnne1pZMa8MEqGCIpq8LtOX7pzAPBgNVHRMB
+Af8EBTADAQH/MAoGCCqGSM49BAMCA0cAMEQCIBv7GQk/CMmmgNfdef5CcTLUyjNA
+h8saVEuuajWh6rA7AiALlidCA8/ECwjuYA0HjDFt9wJiDRAdVnF5W1kyzu01LQ==
+-----END CERTIFICATE-----
+`
+
+// SelfTestVersions are the (export, import) model version pairs that
+// SelfTest checks: every version that can be read and also written, each
+// expected to import as the current version. Earlier versions can still be
+// read, but nothing writes them any more.
+var SelfTestVersions = selfTestVersions()
+
+// SelfTestVersion is a pair of model versions, where a model serialized at
+// the Export version is expected to import at the Import version.
+type SelfTestVersion struct {
+	Export int
+	Import int
+}
+
+// SelfTestFailure records a version pair that failed to round trip.
+type SelfTestFailure struct {
+	SelfTestVersion
+	Err error
+}
+
+// Error implements error.
+func (f SelfTestFailure) Error() string {
+	return fmt.Sprintf("export v%d, import v%d: %v", f.Export, f.Import, f.Err)
+}
+
+// SelfTest round trips a synthetic model, with every section populated,
+// through each of the SelfTestVersions, and returns the pairs that failed.
+// It allows the compatibility of this package to be verified from outside
+// of it, for example by the CI of those vendoring it.
+func SelfTest() []SelfTestFailure {
+	var failures []SelfTestFailure
+	for _, pair := range SelfTestVersions {
+		if err := selfTestRoundTrip(pair); err != nil {
+			failures = append(failures, SelfTestFailure{
+				SelfTestVersion: pair,
+				Err:             err,
+			})
+		}
+	}
+	return failures
+}
+
+// selfTestVersions pairs each readable model version that can be written,
+// either by Serialize or by downgrading what it writes, with the current
+// version.
+func selfTestVersions() []SelfTestVersion {
+	current := NewModel(ModelArgs{}).(*model).Version
+	var versions []int
+	for version := range modelDeserializationFuncs {
+		if canWriteModelVersion(current, version) {
+			versions = append(versions, version)
+		}
+	}
+	sort.Ints(versions)
+	result := make([]SelfTestVersion, len(versions))
+	for i, version := range versions {
+		result[i] = SelfTestVersion{Export: version, Import: current}
+	}
+	return result
+}
+
+// canWriteModelVersion reports whether a model at the current version can
+// be written at the version.
+func canWriteModelVersion(current, version int) bool {
+	for v := current; v > version; v-- {
+		if _, ok := modelDowngrades[v]; !ok {
+			return false
+		}
+	}
+	return version <= current
+}
+
+func selfTestRoundTrip(pair SelfTestVersion) error {
+	initial := selfTestModel()
+	selfTestTrim(initial, pair.Export)
+	if err := initial.Validate(); err != nil {
+		return errors.Annotate(err, "synthetic model")
+	}
+	exported, err := selfTestSerialize(initial, pair.Export)
+	if err != nil {
+		return errors.Annotate(err, "serializing")
+	}
+
+	imported, err := Deserialize(exported)
+	if err != nil {
+		return errors.Annotate(err, "importing")
+	}
+	if version := imported.(*model).Version; version != pair.Import {
+		return errors.Errorf("imported at v%d", version)
+	}
+	if err := imported.Validate(); err != nil {
+		return errors.Annotate(err, "validating import")
+	}
+
+	reexported, err := selfTestSerialize(imported.(*model), pair.Export)
+	if err != nil {
+		return errors.Annotate(err, "serializing import")
+	}
+	if !bytes.Equal(exported, reexported) {
+		return errors.New("model changed by round trip")
+	}
+	return nil
+}
+
+// selfTestSerialize writes the model at the version, removing the fields
+// added to the model since, as a controller writing that version would.
+func selfTestSerialize(m *model, version int) ([]byte, error) {
+	exported, err := Serialize(m)
+	if err != nil || version == m.Version {
+		return exported, errors.Trace(err)
+	}
+	var source map[string]interface{}
+	if err := yaml.Unmarshal(exported, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return downgradeModel(m, exported, version, sectionVersions(source))
+}
+
+// selfTestTrim drops from the synthetic model what can't be written at
+// the version, so that the model only holds what a controller writing that
+// version could have exported.
+func selfTestTrim(m *model, version int) {
+	if version < 20 {
+		m.setVirtualHostKeys(nil)
+	}
+	if version < 19 {
+		m.Lease_ = nil
+	}
+	if version < 18 {
+		m.MigrationAttempt_ = nil
+	}
+	if version < 17 {
+		m.EntityAnnotations_ = nil
+	}
+	if version < 16 {
+		m.setSecretBackends(nil)
+	}
+	if version < 15 {
+		m.PasswordHashAlgorithm_ = ""
+	}
+	if version < 14 {
+		m.setCharms(nil)
+	}
+	if version < 13 {
+		m.Description_ = ""
+	}
+	if version < 12 {
+		m.Telemetry_ = nil
+	}
+	if version < 11 {
+		m.Config_["agent-version"] = m.AgentVersion_
+	}
+}
+
+// selfTestModel returns a model with something in each of its sections.
+func selfTestModel() *model {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := StatusArgs{Value: "active", Message: "ready", Updated: when}
+	tools := AgentToolsArgs{
+		Version: version.MustParseBinary("3.1.1-ubuntu-amd64"),
+		URL:     "https://example.com/tools",
+		SHA256:  "deadbeef",
+		Size:    1024,
+	}
+	owner := names.NewUserTag("admin")
+
+	m := NewModel(ModelArgs{
+		AgentVersion: "3.1.1",
+		Type:         IAAS,
+		Owner:        owner,
+		Config: map[string]interface{}{
+			"name": "self-test",
+			"uuid": "0c9a6c6e-1f6b-4a4c-8a57-4f1c2b1a7c11",
+		},
+		LatestToolsVersion:    version.MustParse("3.1.2"),
+		EnvironVersion:        2,
+		Blocks:                map[string]string{"all-changes": "frozen"},
+		Cloud:                 "vapour",
+		CloudRegion:           "east",
+		PasswordHash:          "model-hash",
+		PasswordHashAlgorithm: PasswordHashSHA512,
+		Description:           "self-test model",
+	}).(*model)
+	m.SetStatus(status)
+	m.SetStatusHistory([]StatusArgs{status})
+	m.SetAnnotations(map[string]string{"origin": "self-test"})
+	m.SetConstraints(ConstraintsArgs{Architecture: "amd64", Memory: 2048})
+	m.SetCloudCredential(CloudCredentialArgs{
+		Owner:    owner,
+		Cloud:    names.NewCloudTag("vapour"),
+		Name:     "creds",
+		AuthType: "userpass",
+	})
+	m.SetSLA("essential", "admin", "sla-creds")
+	m.SetMeterStatus("GREEN", "all good")
+	m.SetTelemetry(TelemetryArgs{Enabled: true, LastReportTime: when})
+	m.SetMigrationAttempt(MigrationAttemptArgs{
+		Attempt:        2,
+		PreviousTarget: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		PhaseReached:   "IMPORT",
+	})
+	m.SetLease(LeaseArgs{
+		Name:   "0c9a6c6e-1f6b-4a4c-8a57-4f1c2b1a7c11",
+		Holder: "controller-0",
+		Start:  when,
+		Expiry: when.Add(time.Minute),
+	})
+	m.SetSequence("machine", 2)
+	m.AddUser(UserArgs{
+		Name:        owner,
+		CreatedBy:   owner,
+		DateCreated: when,
+		Access:      "admin",
+	})
+
+	machine := m.AddMachine(MachineArgs{
+		Id:             names.NewMachineTag("0"),
+		Nonce:          "nonce",
+		PasswordHash:   "machine-hash",
+		Base:           "ubuntu@22.04",
+		Jobs:           []string{"host-units"},
+		AgentVersion:   "3.1.2",
+		RebootRequired: true,
+	})
+	machine.SetUpgradeSeriesLock(UpgradeSeriesLockArgs{
+		FromBase:      "ubuntu@20.04",
+		ToBase:        "ubuntu@22.04",
+		MachineStatus: "prepare started",
+		UnitStatuses:  map[string]string{"ubuntu/0": "prepare started"},
+	})
+	machine.SetInstance(CloudInstanceArgs{InstanceId: "i-0", Architecture: "amd64"})
+	machine.Instance().SetStatus(status)
+	machine.Instance().SetModificationStatus(status)
+	machine.Instance().AddNetworkInterface(NetworkInterfaceArgs{
+		DeviceName:       "eth0",
+		MACAddress:       "00:16:3e:00:00:01",
+		ProviderID:       "eni-0",
+		ProviderSubnetID: "subnet-0",
+		Addresses:        []AddressArgs{{Value: "10.0.0.10", Type: "ipv4", Scope: "local-cloud"}},
+		ShadowAddresses:  []AddressArgs{{Value: "203.0.113.10", Type: "ipv4", Scope: "public"}},
+	})
+	machine.SetTools(tools)
+	machine.SetStatus(status)
+	machine.SetAddresses(
+		[]AddressArgs{{Value: "10.0.0.2", Type: "ipv4", Scope: "local-cloud"}},
+		[]AddressArgs{{Value: "54.0.0.2", Type: "ipv4", Scope: "public"}},
+	)
+	machine.SetPreferredAddresses(
+		AddressArgs{Value: "54.0.0.2", Type: "ipv4", Scope: "public"},
+		AddressArgs{Value: "10.0.0.2", Type: "ipv4", Scope: "local-cloud"},
+	)
+	machine.AddBlockDevice(BlockDeviceArgs{Name: "sda", Size: 8192})
+	container := machine.AddContainer(MachineArgs{
+		Id:            names.NewMachineTag("0/lxd/0"),
+		Nonce:         "container-nonce",
+		Base:          "ubuntu@22.04",
+		ContainerType: ContainerTypeLXD,
+		Jobs:          []string{"host-units"},
+	})
+	container.SetInstance(CloudInstanceArgs{InstanceId: "juju-0-lxd-0"})
+	container.Instance().SetStatus(status)
+	container.Instance().SetModificationStatus(status)
+	container.SetTools(tools)
+	container.SetStatus(status)
+
+	application := m.AddApplication(ApplicationArgs{
+		Tag:                  names.NewApplicationTag("ubuntu"),
+		Type:                 IAAS,
+		CharmURL:             "ch:amd64/jammy/ubuntu-1",
+		Channel:              "stable",
+		CharmModifiedVersion: 1,
+		CharmConfig:          map[string]interface{}{"hostname": "box"},
+		Leader:               "ubuntu/0",
+		LeadershipSettings:   map[string]interface{}{"leader": "yes"},
+		EndpointBindings:     map[string]string{"juju-info": "alpha"},
+		Exposed:              true,
+		ExposedAt:            when,
+		ExposedBy:            owner,
+	})
+	application.SetStatus(status)
+	application.SetStatusHistory([]StatusArgs{status})
+	application.SetLease(LeaseArgs{
+		Name:   "ubuntu",
+		Holder: "ubuntu/0",
+		Start:  when,
+		Expiry: when.Add(time.Minute),
+		Pinned: true,
+	})
+	m.AddCharm(CharmArgs{
+		URL:         "ch:amd64/jammy/ubuntu-1",
+		Revision:    1,
+		StoragePath: "charms/ubuntu-1",
+		SHA256:      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	})
+	application.SetCharmOrigin(CharmOriginArgs{Source: "charm-hub", Platform: "amd64/ubuntu/22.04"})
+	application.AddOffer(ApplicationOfferArgs{
+		OfferUUID:              "8a0b3e52-3d45-4f38-8a2b-5c1e4b7e0a01",
+		OfferName:              "ubuntu-info",
+		Endpoints:              map[string]string{"juju-info": "juju-info"},
+		ACL:                    map[string]string{"admin": "admin"},
+		ApplicationName:        "ubuntu",
+		ApplicationDescription: "self-test offer",
+	})
+	unit := application.AddUnit(UnitArgs{
+		Tag:             names.NewUnitTag("ubuntu/0"),
+		Type:            IAAS,
+		Machine:         names.NewMachineTag("0"),
+		WorkloadVersion: "22.04",
+		AgentVersion:    "3.1.2",
+	})
+	unit.SetAgentStatus(status)
+	unit.SetWorkloadStatus(status)
+	unit.SetTools(tools)
+
+	relation := m.AddRelation(RelationArgs{Id: 1, Key: "ubuntu:juju-info"})
+	endpoint := relation.AddEndpoint(EndpointArgs{
+		ApplicationName: "ubuntu",
+		Name:            "juju-info",
+		Role:            "peer",
+		Interface:       "juju-info",
+		Scope:           "global",
+	})
+	endpoint.SetUnitSettings("ubuntu/0", map[string]interface{}{"key": "value"})
+	relation.SetStatus(status)
+
+	m.AddSpace(SpaceArgs{Id: "1", Name: "alpha", ProviderID: "space-alpha"}).
+		SetAnnotations(map[string]string{"origin": "self-test"})
+	m.AddSubnet(SubnetArgs{
+		ID:                "2",
+		ProviderId:        "subnet-2",
+		CIDR:              "10.0.0.0/24",
+		SpaceID:           "1",
+		AvailabilityZones: []string{"east-1"},
+	}).SetAnnotations(map[string]string{"origin": "self-test"})
+	m.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:      "bond0",
+		MTU:       9000,
+		MachineID: "0",
+		Type:      "bond",
+		IsUp:      true,
+		BondMode:  "802.3ad",
+	})
+	m.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:       "bond0.100",
+		MTU:        9000,
+		MachineID:  "0",
+		Type:       "802.1q",
+		IsUp:       true,
+		ParentName: "bond0",
+		VLANTag:    100,
+	})
+	m.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:        "eth0",
+		MTU:         1500,
+		MachineID:   "0",
+		Type:        "ethernet",
+		MACAddress:  "00:16:3e:00:00:01",
+		IsAutoStart: true,
+		IsUp:        true,
+		IsSRIOV:     true,
+	})
+	m.AddIPAddress(IPAddressArgs{
+		DeviceName:   "eth0",
+		MachineID:    "0",
+		SubnetCIDR:   "10.0.0.0/24",
+		ConfigMethod: "static",
+		Value:        "10.0.0.2",
+		Origin:       "machine",
+	})
+	m.AddSSHHostKey(SSHHostKeyArgs{MachineID: "0", Keys: []string{"ssh-rsa self-test"}})
+	m.AddVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("ssh-ed25519 self-test")))
+	m.AddVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("ssh-ed25519 self-test")))
+	m.AddCloudImageMetadata(CloudImageMetadataArgs{
+		Stream:      "released",
+		Region:      "east",
+		Version:     "22.04",
+		Arch:        "amd64",
+		Source:      "custom",
+		ImageId:     "ami-0",
+		DateCreated: when.UnixNano(),
+		ExpireAt:    &when,
+
+		Signature:         "self-test-signature",
+		SourceFingerprint: "0123456789ABCDEF0123456789ABCDEF01234567",
+	})
+	m.AddOperation(OperationArgs{
+		Id:       "1",
+		Summary:  "run hostname",
+		Enqueued: when,
+		Status:   "completed",
+	})
+	m.AddAction(ActionArgs{
+		Id:        "2",
+		Receiver:  "ubuntu/0",
+		Name:      "hostname",
+		Operation: "1",
+		Enqueued:  when,
+		Status:    "completed",
+	})
+
+	m.AddStoragePool(StoragePoolArgs{Name: "fast", Provider: "loop"})
+	m.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("ubuntu/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("ubuntu/0")},
+		Constraints: &StorageInstanceConstraints{Pool: "fast", Size: 1024},
+	}).SetAnnotations(map[string]string{"origin": "self-test"})
+	volume := m.AddVolume(VolumeArgs{
+		Tag:         names.NewVolumeTag("0"),
+		Storage:     names.NewStorageTag("data/0"),
+		Provisioned: true,
+		Size:        1024,
+		Pool:        "fast",
+		Encrypted:   true,
+		KMSKeyID:    "key",
+		IOPS:        3000,
+		Throughput:  125,
+	})
+	volume.SetStatus(status)
+	volume.SetAnnotations(map[string]string{"origin": "self-test"})
+
+	m.AddFirewallRule(FirewallRuleArgs{
+		ID:               "ssh",
+		WellKnownService: "ssh",
+		WhitelistCIDRs:   []string{"0.0.0.0/0"},
+	})
+	m.AddRemoteEntity(RemoteEntityArgs{ID: "application-ubuntu", Token: "token"})
+	m.AddRelationNetwork(RelationNetworkArgs{
+		ID:          "ubuntu:juju-info",
+		RelationKey: "ubuntu:juju-info",
+		CIDRS:       []string{"10.0.0.0/24"},
+	})
+	m.AddExternalController(ExternalControllerArgs{
+		Tag:    names.NewControllerTag("3a2d5e6f-7b8c-4d9e-8f0a-1b2c3d4e5f60"),
+		Alias:  "other",
+		Addrs:  []string{"10.1.0.1:17070"},
+		CACert: selfTestCACert,
+		Models: []string{"5b2c3d4e-5f60-4a71-8b82-9c3d4e5f6071"},
+	})
+	m.AddOfferConnection(OfferConnectionArgs{
+		OfferUUID:       "8a0b3e52-3d45-4f38-8a2b-5c1e4b7e0a01",
+		RelationID:      1,
+		RelationKey:     "ubuntu:juju-info",
+		UserName:        "admin",
+		SourceModelUUID: "5b2c3d4e-5f60-4a71-8b82-9c3d4e5f6071",
+	})
+	rotateInterval := time.Hour
+	m.AddSecretBackend(SecretBackendArgs{
+		ID:                  "6a3d8e1f-2b4c-4d5e-8f60-7a1b2c3d4e5f",
+		Name:                "myvault",
+		BackendType:         "vault",
+		Config:              map[string]interface{}{"endpoint": "http://vault:8200"},
+		TokenRotateInterval: &rotateInterval,
+	})
+	m.AddSecret(SecretArgs{
+		ID:      "cm0bvkq0k1jc7lq9qhs0",
+		Version: 1,
+		Owner:   names.NewApplicationTag("ubuntu"),
+		Created: when,
+		Updated: when,
+		Revisions: []SecretRevisionArgs{{
+			Number:  1,
+			Created: when,
+			Updated: when,
+			Content: map[string]string{"password": "c2Vrcml0"},
+		}, {
+			Number:  2,
+			Created: when,
+			Updated: when,
+			ValueRef: &SecretValueRefArgs{
+				BackendID:  "6a3d8e1f-2b4c-4d5e-8f60-7a1b2c3d4e5f",
+				RevisionID: "cm0bvkq0k1jc7lq9qhsg",
+			},
+		}},
+		ACL: map[string]SecretAccessArgs{
+			"application-ubuntu": {Scope: "application-ubuntu", Role: "manage"},
+		},
+	}).SetAnnotations(map[string]string{"origin": "self-test"})
+	// The user is annotated in the model, rather than by itself.
+	m.EntityAnnotations_ = map[string]map[string]string{
+		names.NewUserTag("admin").String(): {"origin": "self-test"},
+	}
+	return m
+}
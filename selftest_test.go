@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SelfTestSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SelfTestSuite{})
+
+func (s *SelfTestSuite) TestSelfTest(c *gc.C) {
+	failures := SelfTest()
+	for _, failure := range failures {
+		c.Log(failure.Error())
+	}
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (s *SelfTestSuite) TestSelfTestVersions(c *gc.C) {
+	current := NewModel(ModelArgs{}).(*model).Version
+	c.Assert(SelfTestVersions, gc.Not(gc.HasLen), 0)
+	for i, pair := range SelfTestVersions {
+		c.Check(pair.Import, gc.Equals, current)
+		c.Check(pair.Export, gc.Equals, current-len(SelfTestVersions)+1+i)
+		c.Check(modelDeserializationFuncs[pair.Export], gc.NotNil)
+	}
+	c.Assert(SelfTestVersions[len(SelfTestVersions)-1].Export, gc.Equals, current)
+	// Before the oldest version in the pairs, nothing can be written.
+	oldest := SelfTestVersions[0].Export
+	c.Assert(canWriteModelVersion(current, oldest-1), jc.IsFalse)
+}
+
+func (s *SelfTestSuite) TestSelfTestWritesExportVersion(c *gc.C) {
+	for _, pair := range SelfTestVersions {
+		model := selfTestModel()
+		selfTestTrim(model, pair.Export)
+		bytes, err := selfTestSerialize(model, pair.Export)
+		c.Assert(err, jc.ErrorIsNil)
+		var source map[string]interface{}
+		c.Assert(yaml.Unmarshal(bytes, &source), jc.ErrorIsNil)
+		c.Check(source["version"], gc.Equals, pair.Export)
+	}
+}
+
+func (s *SelfTestSuite) TestSelfTestModelPopulated(c *gc.C) {
+	model := selfTestModel()
+	c.Check(model.Machines(), gc.Not(gc.HasLen), 0)
+	c.Check(model.Applications(), gc.Not(gc.HasLen), 0)
+	c.Check(model.Relations(), gc.Not(gc.HasLen), 0)
+	c.Check(model.Storages(), gc.Not(gc.HasLen), 0)
+	c.Check(model.Secrets(), gc.Not(gc.HasLen), 0)
+}
+
+func (s *SelfTestSuite) TestSelfTestReportsFailures(c *gc.C) {
+	s.PatchValue(&SelfTestVersions, []SelfTestVersion{{Export: 20, Import: 42}, {Export: 1, Import: 20}})
+	failures := SelfTest()
+	c.Assert(failures, gc.HasLen, 2)
+	c.Check(failures[0].Error(), gc.Equals, "export v20, import v42: imported at v20")
+	c.Check(failures[1].Error(), gc.Equals, "export v1, import v20: serializing: writing model v1 not supported")
+}
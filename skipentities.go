@@ -0,0 +1,180 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// SkippedEntity describes an entity that SkipInvalidEntities left out of
+// an imported model.
+type SkippedEntity struct {
+	// Kind is one of "machine", "application", "unit" or "relation".
+	// Containers are reported as machines.
+	Kind string
+	// Source is the entity as it was read, usually a map with string
+	// keys.
+	Source interface{}
+	// Err is the error that importing the entity failed with.
+	Err error
+}
+
+// SkipInvalidEntities has Deserialize leave out the machines, applications,
+// units and relations that can't be imported, rather than fail, and
+// append them to skipped. A machine is left out with its containers, and
+// an application with its units, only if it can't be imported once its
+// invalid containers or units are left out. Other sections are imported
+// as usual. The model returned is partial, and may not pass Validate: a
+// unit left out may still be the leader of its application, or an
+// endpoint of a relation.
+func SkipInvalidEntities(skipped *[]SkippedEntity) ImportOption {
+	return func(o *importOptions) {
+		o.skipped = skipped
+	}
+}
+
+// skipInvalidEntities returns a copy of the model source without the
+// entities that can't be imported, which are recorded in the options.
+// Sections that are themselves malformed are left for the import to
+// report.
+func (o importOptions) skipInvalidEntities(source map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+	if machines, ok := o.skipInvalidMachines(source["machines"]); ok {
+		result["machines"] = machines
+	}
+	if applications, ok := o.skipInvalidApplications(source["applications"]); ok {
+		result["applications"] = applications
+	}
+	if relations, ok := o.skipInvalidRelations(source["relations"]); ok {
+		result["relations"] = relations
+	}
+	return result
+}
+
+// skip records the entity as skipped.
+func (o importOptions) skip(kind string, source interface{}, err error) {
+	o.info("skipped invalid entity", "kind", kind, "error", err)
+	*o.skipped = append(*o.skipped, SkippedEntity{
+		Kind:   kind,
+		Source: source,
+		Err:    err,
+	})
+}
+
+// versionedSource returns the version and list of entities held by a
+// versioned section, or false if the section is malformed.
+func versionedSource(value interface{}, name string) (map[string]interface{}, int, []interface{}, bool) {
+	coerced, err := versionedChecker(name).Coerce(value, nil)
+	if err != nil {
+		return nil, 0, nil, false
+	}
+	valid := coerced.(map[string]interface{})
+	return valid, int(valid["version"].(int64)), valid[name].([]interface{}), true
+}
+
+// keepValid returns the entities of the list that check accepts, and
+// records the others as skipped.
+func (o importOptions) keepValid(kind string, list []interface{}, check func(map[string]interface{}) error) []interface{} {
+	kept := make([]interface{}, 0, len(list))
+	for i, value := range list {
+		entity, ok := sourceMap(value)
+		if !ok {
+			o.skip(kind, value, errors.Errorf("unexpected value for %s %d, %T", kind, i, value))
+			continue
+		}
+		if err := check(entity); err != nil {
+			o.skip(kind, value, errors.Annotatef(err, "%s %d", kind, i))
+			continue
+		}
+		kept = append(kept, entity)
+	}
+	return kept
+}
+
+func (o importOptions) skipInvalidMachines(value interface{}) (interface{}, bool) {
+	valid, version, list, ok := versionedSource(value, "machines")
+	if !ok {
+		return nil, false
+	}
+	importFunc, ok := machineDeserializationFuncs[version]
+	if !ok {
+		return nil, false
+	}
+	valid["machines"] = o.keepValidMachines(list, importFunc)
+	return valid, true
+}
+
+func (o importOptions) keepValidMachines(list []interface{}, importFunc machineDeserializationFunc) []interface{} {
+	return o.keepValid("machine", list, func(machine map[string]interface{}) error {
+		if containers, ok := machine["containers"].([]interface{}); ok {
+			machine["containers"] = o.keepValidMachines(containers, importFunc)
+		}
+		_, err := importFunc(machine)
+		return err
+	})
+}
+
+func (o importOptions) skipInvalidApplications(value interface{}) (interface{}, bool) {
+	valid, version, list, ok := versionedSource(value, "applications")
+	if !ok {
+		return nil, false
+	}
+	importFunc, ok := applicationDeserializationFuncs[version]
+	if !ok {
+		return nil, false
+	}
+	valid["applications"] = o.keepValid("application", list, func(application map[string]interface{}) error {
+		if units, ok := o.skipInvalidUnits(application["units"]); ok {
+			application["units"] = units
+		}
+		_, err := importFunc(application)
+		return err
+	})
+	return valid, true
+}
+
+func (o importOptions) skipInvalidUnits(value interface{}) (interface{}, bool) {
+	valid, version, list, ok := versionedSource(value, "units")
+	if !ok {
+		return nil, false
+	}
+	importFunc, ok := unitDeserializationFuncs[version]
+	if !ok {
+		return nil, false
+	}
+	valid["units"] = o.keepValid("unit", list, func(unit map[string]interface{}) error {
+		_, err := importFunc(unit)
+		return err
+	})
+	return valid, true
+}
+
+func (o importOptions) skipInvalidRelations(value interface{}) (interface{}, bool) {
+	valid, version, list, ok := versionedSource(value, "relations")
+	if !ok {
+		return nil, false
+	}
+	getFields, ok := relationFieldsFuncs[version]
+	if !ok {
+		return nil, false
+	}
+	checker := schema.FieldMap(getFields())
+	valid["relations"] = o.keepValid("relation", list, func(relation map[string]interface{}) error {
+		// As for importRelationList, a nil status is left out.
+		if relation["status"] == nil {
+			delete(relation, "status")
+		}
+		coerced, err := checker.Coerce(relation, nil)
+		if err != nil {
+			return errors.Annotatef(err, "v%d schema check failed", version)
+		}
+		_, err = newRelationFromValid(coerced.(map[string]interface{}), version)
+		return err
+	})
+	return valid, true
+}
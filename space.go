@@ -8,6 +8,23 @@ import (
 	"github.com/juju/schema"
 )
 
+const (
+	// DefaultSpaceId is the id of the default space, which juju 2.7 and
+	// later create with each model. The space isn't written out with the
+	// model's spaces, and subnets and bindings that give no space are in
+	// it.
+	DefaultSpaceId = "0"
+
+	// DefaultSpaceName is the name of the default space.
+	DefaultSpaceName = "alpha"
+)
+
+// IsDefaultSpace reports whether the space, given by id, is the default
+// space. An empty id is taken to be the default space too.
+func IsDefaultSpace(id string) bool {
+	return id == "" || id == DefaultSpaceId
+}
+
 type spaces struct {
 	Version int      `yaml:"version"`
 	Spaces_ []*space `yaml:"spaces"`
@@ -18,6 +35,8 @@ type space struct {
 	Name_       string `yaml:"name"`
 	Public_     bool   `yaml:"public"`
 	ProviderID_ string `yaml:"provider-id,omitempty"`
+
+	Annotations_ `yaml:"annotations,omitempty"`
 }
 
 // SpaceArgs is an argument struct used to create a new internal space
@@ -96,6 +115,7 @@ type spaceDeserializationFunc func(map[string]interface{}) (*space, error)
 var spaceDeserializationFuncs = map[int]spaceDeserializationFunc{
 	1: importSpaceV1,
 	2: importSpaceV2,
+	3: importSpaceV3,
 }
 
 func importSpaceV1(source map[string]interface{}) (*space, error) {
@@ -138,6 +158,30 @@ func importSpaceV2(source map[string]interface{}) (*space, error) {
 	}, nil
 }
 
+func importSpaceV3(source map[string]interface{}) (*space, error) {
+	fields, defaults := spaceV1Fields()
+	fields["id"] = schema.String()
+	AddAnnotationSchema(fields, defaults)
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "space v3 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	result := &space{
+		Id_:         valid["id"].(string),
+		Name_:       valid["name"].(string),
+		Public_:     valid["public"].(bool),
+		ProviderID_: valid["provider-id"].(string),
+	}
+	result.ImportAnnotations(valid)
+	return result, nil
+}
+
 func spaceV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"name":        schema.String(),
@@ -92,3 +92,31 @@ func (s *SpaceSerializationSuite) TestParsingSerializedDataV2(c *gc.C) {
 
 	c.Assert(spaces, jc.DeepEquals, initial.Spaces_)
 }
+
+func (s *SpaceSerializationSuite) TestParsingSerializedDataV3(c *gc.C) {
+	annotated := newSpace(SpaceArgs{Id: "1", Name: "special"})
+	annotated.SetAnnotations(map[string]string{"owner": "networks"})
+	initial := spaces{
+		Version: 3,
+		Spaces_: []*space{annotated, newSpace(SpaceArgs{Name: "foo"})},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces, err := importSpaces(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(spaces, jc.DeepEquals, initial.Spaces_)
+}
+
+func (s *SpaceSerializationSuite) TestIsDefaultSpace(c *gc.C) {
+	c.Check(IsDefaultSpace(DefaultSpaceId), jc.IsTrue)
+	c.Check(IsDefaultSpace(""), jc.IsTrue)
+	c.Check(IsDefaultSpace("1"), jc.IsFalse)
+	c.Check(IsDefaultSpace(DefaultSpaceName), jc.IsFalse)
+}
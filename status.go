@@ -43,6 +43,18 @@ type HasModificationStatus interface {
 type HasStatusHistory interface {
 	StatusHistory() []Status
 	SetStatusHistory([]StatusArgs)
+	// AppendStatusHistory adds the entries to the end of the history, in
+	// amortized constant time per entry, so that a long history can be
+	// built up as the entries are read.
+	AppendStatusHistory(...StatusArgs)
+}
+
+// HasWorkloadVersionHistory defines the methods for setting and getting
+// the history of the workload versions reported by a unit's charm. Each
+// entry holds the version as its message.
+type HasWorkloadVersionHistory interface {
+	WorkloadVersionHistory() []Status
+	SetWorkloadVersionHistory([]StatusArgs)
 }
 
 // Status represents an agent, application, or workload status.
@@ -66,18 +78,24 @@ type StatusArgs struct {
 
 func newStatus(args StatusArgs) *status {
 	return &status{
-		Version: 2,
-		StatusPoint_: StatusPoint_{
-			Value_:    args.Value,
-			Message_:  args.Message,
-			Data_:     args.Data,
-			Updated_:  args.Updated.UTC(),
-			NeverSet_: args.NeverSet,
-		},
+		Version:      2,
+		StatusPoint_: newStatusPoint(args),
 	}
 }
 
-func newStatusHistory() StatusHistory_ {
+func newStatusPoint(args StatusArgs) StatusPoint_ {
+	return StatusPoint_{
+		Value_:    args.Value,
+		Message_:  args.Message,
+		Data_:     args.Data,
+		Updated_:  args.Updated.UTC(),
+		NeverSet_: args.NeverSet,
+	}
+}
+
+// NewStatusHistory returns an empty StatusHistory_ for composition into an
+// entity that implements HasStatusHistory.
+func NewStatusHistory() StatusHistory_ {
 	return StatusHistory_{
 		Version: 2,
 	}
@@ -129,6 +147,37 @@ func (a *StatusPoint_) NeverSet() bool {
 	return a.NeverSet_
 }
 
+// emitYAML implements yamlEmitter.
+func (a *StatusPoint_) emitYAML(m *mapping) {
+	m.str("value", a.Value_)
+	m.stringOmitEmpty("message", a.Message_)
+	m.valueOmitEmpty("data", a.Data_)
+	m.time("updated", a.Updated_)
+	m.bool("neverset", a.NeverSet_)
+}
+
+// emitYAML implements yamlEmitter.
+func (a *status) emitYAML(m *mapping) {
+	m.int("version", a.Version)
+	m.value("status", &a.StatusPoint_)
+}
+
+// NewStatus returns a Status for use by entities defined outside of this
+// package that implement HasStatus.
+func NewStatus(args StatusArgs) Status {
+	return newStatus(args)
+}
+
+// ImportStatus reads a Status from the serialized form written by a Status
+// returned from NewStatus.
+func ImportStatus(source map[string]interface{}) (Status, error) {
+	st, err := importStatus(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st, nil
+}
+
 func importStatus(source map[string]interface{}) (*status, error) {
 	checker := versionedEmbeddedChecker("status")
 	coerced, err := checker.Coerce(source, nil)
@@ -271,23 +320,30 @@ func (s *StatusHistory_) StatusHistory() []Status {
 
 // SetStatusHistory implements HasStatusHistory.
 func (s *StatusHistory_) SetStatusHistory(args []StatusArgs) {
-	points := make([]*StatusPoint_, len(args))
+	s.History = make([]*StatusPoint_, 0, len(args))
+	s.AppendStatusHistory(args...)
+}
+
+// AppendStatusHistory implements HasStatusHistory.
+func (s *StatusHistory_) AppendStatusHistory(args ...StatusArgs) {
+	// The points of each call share an allocation, rather than taking one
+	// each, and the history grows as append grows it, so the entries held
+	// already are only copied as often as the capacity doubles.
+	points := make([]StatusPoint_, len(args))
 	for i, arg := range args {
-		points[i] = &StatusPoint_{
-			Value_:    arg.Value,
-			Message_:  arg.Message,
-			Data_:     arg.Data,
-			Updated_:  arg.Updated.UTC(),
-			NeverSet_: arg.NeverSet,
-		}
+		points[i] = newStatusPoint(arg)
+		s.History = append(s.History, &points[i])
 	}
-	s.History = points
 }
 
-func addStatusHistorySchema(fields schema.Fields) {
+// AddStatusHistorySchema adds the "status-history" field to the schema of an
+// entity that composes StatusHistory_.
+func AddStatusHistorySchema(fields schema.Fields) {
 	fields["status-history"] = schema.StringMap(schema.Any())
 }
 
-func (s *StatusHistory_) importStatusHistory(valid map[string]interface{}) error {
+// ImportStatusHistory sets the status history from the "status-history" field
+// of a coerced entity map. It is used alongside AddStatusHistorySchema.
+func (s *StatusHistory_) ImportStatusHistory(valid map[string]interface{}) error {
 	return importStatusHistory(s, valid["status-history"].(map[string]interface{}))
 }
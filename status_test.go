@@ -4,6 +4,7 @@
 package description
 
 import (
+	stdtesting "testing"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
@@ -118,6 +119,24 @@ func (s *StatusSerializationSuite) TestNewStatus(c *gc.C) {
 	c.Assert(status.Updated(), gc.Equals, args.Updated)
 }
 
+func (s *StatusSerializationSuite) TestExportedNewAndImport(c *gc.C) {
+	initial := NewStatus(StatusArgs{
+		Value:   "running",
+		Message: "all good",
+		Updated: time.Date(2016, 1, 28, 11, 50, 0, 0, time.UTC),
+	})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := ImportStatus(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, initial)
+}
+
 func (s *StatusSerializationSuite) exportImport(c *gc.C, status_ *status) *status {
 	bytes, err := yaml.Marshal(status_)
 	c.Assert(err, jc.ErrorIsNil)
@@ -183,7 +202,7 @@ func (s *StatusHistorySerializationSuite) SetUpTest(c *gc.C) {
 	s.SerializationSuite.SetUpTest(c)
 	s.importName = "status"
 	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
-		history := newStatusHistory()
+		history := NewStatusHistory()
 		if err := importStatusHistory(&history, m); err != nil {
 			return nil, err
 		}
@@ -207,7 +226,7 @@ func (s *StatusHistorySerializationSuite) TestSetStatusHistory(c *gc.C) {
 		Value:   "stopped",
 		Updated: time.Date(2016, 1, 28, 12, 50, 0, 0, time.UTC),
 	}}
-	history := newStatusHistory()
+	history := NewStatusHistory()
 	history.SetStatusHistory(args)
 
 	for i, point := range history.StatusHistory() {
@@ -218,6 +237,32 @@ func (s *StatusHistorySerializationSuite) TestSetStatusHistory(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySerializationSuite) TestAppendStatusHistory(c *gc.C) {
+	args := testStatusHistoryArgs()
+	history := NewStatusHistory()
+	history.AppendStatusHistory(args[0])
+	history.AppendStatusHistory(args[1:]...)
+	history.AppendStatusHistory()
+
+	expected := NewStatusHistory()
+	expected.SetStatusHistory(args)
+	c.Assert(history.StatusHistory(), jc.DeepEquals, expected.StatusHistory())
+}
+
+func (s *StatusHistorySerializationSuite) TestAppendAfterSetStatusHistory(c *gc.C) {
+	args := testStatusHistoryArgs()
+	history := NewStatusHistory()
+	history.SetStatusHistory(args[:1])
+	first := history.StatusHistory()[0]
+	history.AppendStatusHistory(args[1:]...)
+
+	points := history.StatusHistory()
+	c.Assert(points, gc.HasLen, 3)
+	// Appending leaves the entries held already in place.
+	c.Assert(points[0], gc.Equals, first)
+	c.Assert(points[2].Updated(), gc.Equals, args[2].Updated)
+}
+
 func (s *StatusHistorySerializationSuite) exportImport(c *gc.C, status_ StatusHistory_) StatusHistory_ {
 	bytes, err := yaml.Marshal(status_)
 	c.Assert(err, jc.ErrorIsNil)
@@ -226,14 +271,14 @@ func (s *StatusHistorySerializationSuite) exportImport(c *gc.C, status_ StatusHi
 	err = yaml.Unmarshal(bytes, &source)
 	c.Assert(err, jc.ErrorIsNil)
 
-	history := newStatusHistory()
+	history := NewStatusHistory()
 	err = importStatusHistory(&history, source)
 	c.Assert(err, jc.ErrorIsNil)
 	return history
 }
 
 func (s *StatusHistorySerializationSuite) TestParsing(c *gc.C) {
-	initial := newStatusHistory()
+	initial := NewStatusHistory()
 	initial.SetStatusHistory(testStatusHistoryArgs())
 	history := s.exportImport(c, initial)
 	c.Assert(history, jc.DeepEquals, initial)
@@ -244,6 +289,22 @@ type StatusHistoryMixinSuite struct {
 	serializer func(*gc.C, interface{}) HasStatusHistory
 }
 
+func (s *StatusHistoryMixinSuite) TestAppendStatusHistory(c *gc.C) {
+	initial := s.creator()
+	args := testStatusHistoryArgs()
+	for _, arg := range args {
+		initial.AppendStatusHistory(arg)
+	}
+
+	entity := s.serializer(c, initial)
+	points := entity.StatusHistory()
+	c.Assert(points, gc.HasLen, len(args))
+	for i, point := range points {
+		c.Check(point.Value(), gc.Equals, args[i].Value)
+		c.Check(point.Updated(), gc.Equals, args[i].Updated)
+	}
+}
+
 func (s *StatusHistoryMixinSuite) TestStatusHistory(c *gc.C) {
 	initial := s.creator()
 	args := testStatusHistoryArgs()
@@ -257,3 +318,19 @@ func (s *StatusHistoryMixinSuite) TestStatusHistory(c *gc.C) {
 		c.Check(point.Updated(), gc.Equals, args[i].Updated)
 	}
 }
+
+// BenchmarkAppendStatusHistory builds a history of 10,000 entries an
+// entry at a time, as an exporter reading them does.
+func BenchmarkAppendStatusHistory(b *stdtesting.B) {
+	arg := StatusArgs{
+		Value:   "running",
+		Updated: time.Date(2016, 1, 28, 11, 50, 0, 0, time.UTC),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		history := NewStatusHistory()
+		for j := 0; j < 10000; j++ {
+			history.AppendStatusHistory(arg)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// statusStringsKey is the key of the model's table of the strings
+	// indexed by compacted status history.
+	statusStringsKey = "status-strings"
+
+	// compactStatusHistoryVersion is the status history version whose
+	// entries hold indexes into the status strings in place of their
+	// values and messages.
+	compactStatusHistoryVersion = 3
+)
+
+// isStatusHistoryKey returns true if the key holds a status history,
+// such as "status-history" or "workload-version-history".
+func isStatusHistoryKey(key interface{}) bool {
+	name, ok := key.(string)
+	return ok && strings.HasSuffix(name, "-history")
+}
+
+// compactStatusHistory rewrites the serialized model so that the values
+// and messages of status history entries are indexes into a table of
+// strings shared by the whole model.
+func compactStatusHistory(bytes []byte) ([]byte, error) {
+	// A MapSlice keeps the order that the model was written in.
+	var source yaml.MapSlice
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	table := statusStringTable{index: make(map[string]int)}
+	table.compact(source)
+	if len(table.strings) > 0 {
+		source = append(source, yaml.MapItem{Key: statusStringsKey, Value: table.strings})
+	}
+	return yaml.Marshal(source)
+}
+
+type statusStringTable struct {
+	strings []string
+	index   map[string]int
+}
+
+func (t *statusStringTable) add(value string) int {
+	i, ok := t.index[value]
+	if !ok {
+		i = len(t.strings)
+		t.strings = append(t.strings, value)
+		t.index[value] = i
+	}
+	return i
+}
+
+func (t *statusStringTable) compact(value interface{}) {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range value {
+			if history, ok := item.Value.(yaml.MapSlice); ok && isStatusHistoryKey(item.Key) {
+				t.compactHistory(history)
+				continue
+			}
+			t.compact(item.Value)
+		}
+	case []interface{}:
+		for _, item := range value {
+			t.compact(item)
+		}
+	}
+}
+
+func (t *statusStringTable) compactHistory(history yaml.MapSlice) {
+	for i, item := range history {
+		switch item.Key {
+		case "version":
+			history[i].Value = compactStatusHistoryVersion
+		case "history":
+			points, _ := item.Value.([]interface{})
+			for _, point := range points {
+				fields, _ := point.(yaml.MapSlice)
+				for j, field := range fields {
+					if field.Key == "value" || field.Key == "message" {
+						text, _ := field.Value.(string)
+						fields[j].Value = t.add(text)
+					}
+				}
+			}
+		}
+	}
+}
+
+// expandStatusHistory returns a copy of the model source with compacted
+// status history entries replaced by the strings they index.
+func expandStatusHistory(source map[string]interface{}) (map[string]interface{}, error) {
+	var table []string
+	if value, ok := source[statusStringsKey]; ok {
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.NotValidf("status strings %T", value)
+		}
+		for i, item := range list {
+			text, ok := item.(string)
+			if !ok {
+				return nil, errors.NotValidf("status string %d %T", i, item)
+			}
+			table = append(table, text)
+		}
+	}
+	result := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		if key == statusStringsKey {
+			continue
+		}
+		expanded, err := expandStatusValue(table, key, value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[key] = expanded
+	}
+	return result, nil
+}
+
+func expandStatusValue(table []string, key, value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		if isStatusHistoryKey(key) && value["version"] == compactStatusHistoryVersion {
+			return expandHistory(table, value)
+		}
+		result := make(map[interface{}]interface{}, len(value))
+		for childKey, child := range value {
+			expanded, err := expandStatusValue(table, childKey, child)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[childKey] = expanded
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, child := range value {
+			expanded, err := expandStatusValue(table, nil, child)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[i] = expanded
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+func expandHistory(table []string, history map[interface{}]interface{}) (interface{}, error) {
+	points, _ := history["history"].([]interface{})
+	expanded := make([]interface{}, len(points))
+	for i, point := range points {
+		fields, ok := point.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for status %d, %T", i, point)
+		}
+		result := make(map[interface{}]interface{}, len(fields))
+		for key, value := range fields {
+			if key == "value" || key == "message" {
+				index, ok := value.(int)
+				if !ok || index < 0 || index >= len(table) {
+					return nil, errors.NotValidf("status history %d %s index %v", i, key, value)
+				}
+				value = table[index]
+			}
+			result[key] = value
+		}
+		expanded[i] = result
+	}
+	return map[interface{}]interface{}{
+		"version": 2,
+		"history": expanded,
+	}, nil
+}
@@ -19,9 +19,12 @@ type storage struct {
 	Kind_  string `yaml:"kind"`
 	Owner_ string `yaml:"owner,omitempty"`
 	Name_  string `yaml:"name"`
+	Life_  string `yaml:"life,omitempty"`
 
 	Attachments_ []string                    `yaml:"attachments,omitempty"`
 	Constraints_ *StorageInstanceConstraints `yaml:"constraints,omitempty"`
+
+	Annotations_ `yaml:"annotations,omitempty"`
 }
 
 // StorageArgs is an argument struct used to add a storage to the Model.
@@ -30,6 +33,7 @@ type StorageArgs struct {
 	Kind        string
 	Owner       names.Tag
 	Name        string
+	Life        string
 	Attachments []names.UnitTag
 	Constraints *StorageInstanceConstraints
 }
@@ -39,6 +43,7 @@ func newStorage(args StorageArgs) *storage {
 		ID_:          args.Tag.Id(),
 		Kind_:        args.Kind,
 		Name_:        args.Name,
+		Life_:        args.Life,
 		Constraints_: args.Constraints,
 	}
 	if args.Owner != nil {
@@ -77,6 +82,11 @@ func (s *storage) Name() string {
 	return s.Name_
 }
 
+// Life implements Storage.
+func (s *storage) Life() string {
+	return lifeOrAlive(s.Life_)
+}
+
 // Attachments implements Storage.
 func (s *storage) Attachments() []names.UnitTag {
 	var result []names.UnitTag
@@ -99,6 +109,9 @@ func (s *storage) Validate() error {
 	if s.ID_ == "" {
 		return errors.NotValidf("storage missing id")
 	}
+	if err := validateLife("storage", s.ID_, s.Life_); err != nil {
+		return errors.Trace(err)
+	}
 	if _, err := s.Owner(); err != nil {
 		return errors.Wrap(err, errors.NotValidf("storage %q invalid owner", s.ID_))
 	}
@@ -152,6 +165,28 @@ var storageDeserializationFuncs = map[int]storageDeserializationFunc{
 	1: importStorageV1,
 	2: importStorageV2,
 	3: importStorageV3,
+	4: importStorageV4,
+	5: importStorageV5,
+}
+
+func importStorageV5(source map[string]interface{}) (*storage, error) {
+	checker := schema.FieldMap(storageV5Fields())
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "storage v5 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return newStorageFromValid(valid, 5)
+}
+
+func importStorageV4(source map[string]interface{}) (*storage, error) {
+	checker := schema.FieldMap(storageV4Fields())
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "storage v4 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return newStorageFromValid(valid, 4)
 }
 
 func importStorageV3(source map[string]interface{}) (*storage, error) {
@@ -203,9 +238,28 @@ func newStorageFromValid(valid map[string]interface{}, version int) (*storage, e
 			Size: consM["size"].(uint64),
 		}
 	}
+	if version >= 4 {
+		result.Life_ = valid["life"].(string)
+	}
+	if version >= 5 {
+		result.ImportAnnotations(valid)
+	}
 	return result, nil
 }
 
+func storageV5Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := storageV4Fields()
+	AddAnnotationSchema(fields, defaults)
+	return fields, defaults
+}
+
+func storageV4Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := storageV3Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
 func storageV3Fields() (schema.Fields, schema.Defaults) {
 	fields, defaults := storageV2Fields()
 	fields["constraints"] = schema.FieldMap(
@@ -136,3 +136,34 @@ func (s *StorageSerializationSuite) TestParsingSerializedDataV3(c *gc.C) {
 	storage := s.exportImport(c, original, 3)
 	c.Assert(storage, jc.DeepEquals, original)
 }
+
+func (s *StorageSerializationSuite) TestParsingSerializedDataV5(c *gc.C) {
+	original := testStorage()
+	original.SetAnnotations(map[string]string{"backup": "daily"})
+	storage := s.exportImport(c, original, 5)
+	c.Assert(storage, jc.DeepEquals, original)
+
+	storage = s.exportImport(c, original, 4)
+	c.Assert(storage.Annotations(), gc.HasLen, 0)
+}
+
+func (s *StorageSerializationSuite) TestLife(c *gc.C) {
+	original := testStorage()
+	c.Assert(original.Life(), gc.Equals, Alive)
+	original.Life_ = Dead
+	c.Assert(original.Validate(), jc.ErrorIsNil)
+
+	storage := s.exportImport(c, original, 4)
+	c.Assert(storage, jc.DeepEquals, original)
+
+	storage = s.exportImport(c, original, 3)
+	c.Assert(storage.Life(), gc.Equals, Alive)
+}
+
+func (s *StorageSerializationSuite) TestValidateLife(c *gc.C) {
+	original := testStorage()
+	original.Life_ = "zombie"
+	err := original.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "db/0" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
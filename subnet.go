@@ -30,6 +30,8 @@ type subnet struct {
 
 	FanLocalUnderlay_ string `yaml:"fan-local-underlay,omitempty"`
 	FanOverlay_       string `yaml:"fan-overlay,omitempty"`
+
+	Annotations_ `yaml:"annotations,omitempty"`
 }
 
 // SubnetArgs is an argument struct used to create a
@@ -174,6 +176,7 @@ var subnetFieldsFuncs = map[int]fieldsFunc{
 	4: subnetV4Fields,
 	5: subnetV5Fields,
 	6: subnetV6Fields,
+	7: subnetV7Fields,
 }
 
 func newSubnetFromValid(valid map[string]interface{}, version int) (*subnet, error) {
@@ -206,6 +209,9 @@ func newSubnetFromValid(valid map[string]interface{}, version int) (*subnet, err
 	if version >= 6 {
 		result.ID_ = valid["subnet-id"].(string)
 	}
+	if version >= 7 {
+		result.ImportAnnotations(valid)
+	}
 	return &result, nil
 }
 
@@ -265,3 +271,9 @@ func subnetV6Fields() (schema.Fields, schema.Defaults) {
 	fields["subnet-id"] = schema.String()
 	return fields, defaults
 }
+
+func subnetV7Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := subnetV6Fields()
+	AddAnnotationSchema(fields, defaults)
+	return fields, defaults
+}
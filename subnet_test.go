@@ -293,3 +293,19 @@ func (s *SubnetSerializationSuite) TestParsingV6Minimal(c *gc.C) {
 	subnet := s.exportImport(c, original, 6)
 	c.Assert(subnet, jc.DeepEquals, original)
 }
+
+func (s *SubnetSerializationSuite) TestParsingV7Full(c *gc.C) {
+	original := testSubnet(5)
+	original.ID_ = "42"
+	original.SetAnnotations(map[string]string{"owner": "networks"})
+	subnet := s.exportImport(c, original, 7)
+	c.Assert(subnet, jc.DeepEquals, original)
+}
+
+func (s *SubnetSerializationSuite) TestParsingV6IgnoresAnnotations(c *gc.C) {
+	original := testSubnet(5)
+	original.ID_ = "42"
+	original.SetAnnotations(map[string]string{"owner": "networks"})
+	subnet := s.exportImport(c, original, 6)
+	c.Assert(subnet.Annotations(), gc.HasLen, 0)
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"io"
+	"sort"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/juju/errors"
+)
+
+// summaryTemplate lays out the report written by Summary. Cells are
+// separated by tabs, which the tabwriter aligns into columns.
+var summaryTemplate = template.Must(template.New("summary").Parse(`Model:	{{.Name}}
+UUID:	{{.UUID}}
+Owner:	{{.Owner}}
+Type:	{{.Type}}
+Cloud:	{{.Cloud}}
+Agent version:	{{.AgentVersion}}
+{{with .Units}}
+App	Charm	Unit	Machine
+{{range .}}{{.Application}}	{{.Charm}}	{{.Unit}}	{{.Machine}}
+{{end}}{{end}}{{with .Machines}}
+Machine	Base	Instance
+{{range .}}{{.Id}}	{{.Base}}	{{.Instance}}
+{{end}}{{end}}{{with .Pools}}
+Pool	Provider	Volumes	Filesystems	Size
+{{range .}}{{.Name}}	{{.Provider}}	{{.Volumes}}	{{.Filesystems}}	{{.Size}}MiB
+{{end}}{{end}}{{with .Storage}}
+Storage	Kind	Owner	Attached
+{{range .}}{{.Id}}	{{.Kind}}	{{.Owner}}	{{.Attached}}
+{{end}}{{end}}{{with .Relations}}
+Relation	Endpoints
+{{range .}}{{.Id}}	{{.Key}}
+{{end}}{{end}}`))
+
+type modelSummary struct {
+	Name         string
+	UUID         string
+	Owner        string
+	Type         string
+	Cloud        string
+	AgentVersion string
+
+	Units     []unitSummary
+	Machines  []machineSummary
+	Pools     []poolSummary
+	Storage   []storageSummary
+	Relations []relationSummary
+}
+
+type unitSummary struct {
+	Application string
+	Charm       string
+	Unit        string
+	Machine     string
+}
+
+type machineSummary struct {
+	Id       string
+	Base     string
+	Instance string
+}
+
+type poolSummary struct {
+	Name        string
+	Provider    string
+	Volumes     int
+	Filesystems int
+	Size        uint64
+}
+
+type storageSummary struct {
+	Id       string
+	Kind     string
+	Owner    string
+	Attached int
+}
+
+type relationSummary struct {
+	Id  int
+	Key string
+}
+
+// Summary implements Model.
+func (m *model) Summary(w io.Writer) error {
+	summary, err := summarize(m)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	if err := summaryTemplate.Execute(tw, summary); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(tw.Flush())
+}
+
+// summarize collects what Summary reports about the model. Missing values
+// are shown as "-" so that the columns of the report stay aligned.
+func summarize(m Model) (modelSummary, error) {
+	name, _ := m.Config()["name"].(string)
+	uuid, _ := m.Config()["uuid"].(string)
+	summary := modelSummary{
+		Name:         orDash(name),
+		UUID:         orDash(uuid),
+		Owner:        orDash(m.Owner().Id()),
+		Type:         orDash(m.Type()),
+		Cloud:        orDash(m.Cloud()),
+		AgentVersion: orDash(m.AgentVersion()),
+	}
+	if region := m.CloudRegion(); region != "" {
+		summary.Cloud += "/" + region
+	}
+
+	for _, application := range m.Applications() {
+		units := application.Units()
+		if len(units) == 0 {
+			summary.Units = append(summary.Units, unitSummary{
+				Application: application.Name(),
+				Charm:       orDash(application.CharmURL()),
+				Unit:        "-",
+				Machine:     "-",
+			})
+		}
+		for _, unit := range units {
+			summary.Units = append(summary.Units, unitSummary{
+				Application: application.Name(),
+				Charm:       orDash(application.CharmURL()),
+				Unit:        unit.Name(),
+				Machine:     orDash(unit.Machine().Id()),
+			})
+		}
+	}
+
+	var addMachines func([]Machine)
+	addMachines = func(machines []Machine) {
+		for _, machine := range machines {
+			instance := "-"
+			if machine.Instance() != nil {
+				instance = orDash(machine.Instance().InstanceId())
+			}
+			summary.Machines = append(summary.Machines, machineSummary{
+				Id:       machine.Id(),
+				Base:     orDash(machine.Base()),
+				Instance: instance,
+			})
+			addMachines(machine.Containers())
+		}
+	}
+	addMachines(m.Machines())
+
+	pools := make(map[string]*poolSummary)
+	pool := func(name string) *poolSummary {
+		if _, ok := pools[name]; !ok {
+			pools[name] = &poolSummary{Name: name, Provider: "-"}
+		}
+		return pools[name]
+	}
+	for _, storagePool := range m.StoragePools() {
+		pool(storagePool.Name()).Provider = orDash(storagePool.Provider())
+	}
+	for _, volume := range m.Volumes() {
+		p := pool(orDash(volume.Pool()))
+		p.Volumes++
+		p.Size += volume.Size()
+	}
+	for _, filesystem := range m.Filesystems() {
+		p := pool(orDash(filesystem.Pool()))
+		p.Filesystems++
+		p.Size += filesystem.Size()
+	}
+	for _, p := range pools {
+		summary.Pools = append(summary.Pools, *p)
+	}
+	sort.Slice(summary.Pools, func(i, j int) bool {
+		return naturalLess(summary.Pools[i].Name, summary.Pools[j].Name)
+	})
+
+	for _, storage := range m.Storages() {
+		owner, err := storage.Owner()
+		if err != nil {
+			return modelSummary{}, errors.Annotatef(err, "storage %q", storage.Tag().Id())
+		}
+		ownerId := "-"
+		if owner != nil {
+			ownerId = owner.Id()
+		}
+		summary.Storage = append(summary.Storage, storageSummary{
+			Id:       storage.Tag().Id(),
+			Kind:     orDash(storage.Kind()),
+			Owner:    ownerId,
+			Attached: len(storage.Attachments()),
+		})
+	}
+
+	for _, relation := range m.Relations() {
+		summary.Relations = append(summary.Relations, relationSummary{
+			Id:  relation.Id(),
+			Key: relation.Key(),
+		})
+	}
+	return summary, nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"bytes"
+
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SummarySuite struct{}
+
+var _ = gc.Suite(&SummarySuite{})
+
+func summaryModel() Model {
+	model := NewModel(ModelArgs{
+		Owner:        names.NewUserTag("admin"),
+		Type:         IAAS,
+		Cloud:        "vapour",
+		CloudRegion:  "east",
+		AgentVersion: "3.1.1",
+		Config: map[string]interface{}{
+			"name": "summary",
+			"uuid": "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6",
+		},
+	})
+	machine := model.AddMachine(MachineArgs{Id: names.NewMachineTag("0"), Base: "ubuntu@22.04"})
+	machine.SetInstance(CloudInstanceArgs{InstanceId: "i-0"})
+	machine.AddContainer(MachineArgs{Id: names.NewMachineTag("0/lxd/0"), Base: "ubuntu@22.04"})
+
+	wordpress := model.AddApplication(ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		CharmURL: "ch:wordpress-3",
+	})
+	wordpress.AddUnit(UnitArgs{Tag: names.NewUnitTag("wordpress/1"), Machine: names.NewMachineTag("0/lxd/0")})
+	wordpress.AddUnit(UnitArgs{Tag: names.NewUnitTag("wordpress/0"), Machine: names.NewMachineTag("0")})
+	model.AddApplication(ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		CharmURL: "ch:mysql-1",
+	})
+
+	model.AddStoragePool(StoragePoolArgs{Name: "fast", Provider: "loop"})
+	model.AddVolume(VolumeArgs{Tag: names.NewVolumeTag("0"), Pool: "fast", Size: 1024})
+	model.AddFilesystem(FilesystemArgs{Tag: names.NewFilesystemTag("0"), Size: 512})
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("wordpress/0"),
+		Attachments: []names.UnitTag{names.NewUnitTag("wordpress/0")},
+	})
+	model.AddRelation(RelationArgs{Id: 1, Key: "wordpress:db mysql:server"})
+	return model
+}
+
+const expectedSummary = `
+Model:          summary
+UUID:           bd3fae18-5ea1-4bc5-8837-45400cf1f8f6
+Owner:          admin
+Type:           iaas
+Cloud:          vapour/east
+Agent version:  3.1.1
+
+App        Charm           Unit         Machine
+mysql      ch:mysql-1      -            -
+wordpress  ch:wordpress-3  wordpress/0  0
+wordpress  ch:wordpress-3  wordpress/1  0/lxd/0
+
+Machine  Base          Instance
+0        ubuntu@22.04  i-0
+0/lxd/0  ubuntu@22.04  -
+
+Pool  Provider  Volumes  Filesystems  Size
+-     -         0        1            512MiB
+fast  loop      1        0            1024MiB
+
+Storage  Kind   Owner        Attached
+data/0   block  wordpress/0  1
+
+Relation  Endpoints
+1         wordpress:db mysql:server
+`[1:]
+
+func (*SummarySuite) TestSummary(c *gc.C) {
+	var buf bytes.Buffer
+	err := summaryModel().Summary(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, expectedSummary)
+}
+
+func (*SummarySuite) TestSummaryEmptyModel(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("admin")})
+	var buf bytes.Buffer
+	err := model.Summary(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+Model:          -
+UUID:           -
+Owner:          admin
+Type:           -
+Cloud:          -
+Agent version:  -
+`[1:])
+}
+
+func (*SummarySuite) TestSynchronizedModel(c *gc.C) {
+	var buf bytes.Buffer
+	err := NewSynchronizedModel(summaryModel()).Summary(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, expectedSummary)
+}
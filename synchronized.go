@@ -0,0 +1,845 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+)
+
+// NewSynchronizedModel returns a Model that serializes access to the given
+// model, so that it can be built up by concurrent workers. Every method
+// of the model is guarded by a read-write mutex, with the methods that
+// modify the model taking the write lock.
+//
+// Only the model itself is guarded. The entities it returns, such as
+// machines and applications, aren't safe for concurrent modification, so
+// each of them should be populated by a single worker.
+func NewSynchronizedModel(model Model) Model {
+	if synchronized, ok := model.(*synchronizedModel); ok {
+		return synchronized
+	}
+	return &synchronizedModel{model: model}
+}
+
+type synchronizedModel struct {
+	mu    sync.RWMutex
+	model Model
+}
+
+// MarshalYAML implements yaml.Marshaler, so that marshalling the wrapper
+// writes out the wrapped model. Serialize uses serialize instead, which
+// holds the lock for the whole of the marshalling.
+func (s *synchronizedModel) MarshalYAML() (interface{}, error) {
+	return s.model, nil
+}
+
+func (s *synchronizedModel) serialize() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return marshalYAML(s.model)
+}
+
+// Annotations implements Model.
+func (s *synchronizedModel) Annotations() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Annotations()
+}
+
+// SetAnnotations implements Model.
+func (s *synchronizedModel) SetAnnotations(annotations map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetAnnotations(annotations)
+}
+
+// Constraints implements Model.
+func (s *synchronizedModel) Constraints() Constraints {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Constraints()
+}
+
+// SetConstraints implements Model.
+func (s *synchronizedModel) SetConstraints(args ConstraintsArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetConstraints(args)
+}
+
+// Status implements Model.
+func (s *synchronizedModel) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Status()
+}
+
+// SetStatus implements Model.
+func (s *synchronizedModel) SetStatus(args StatusArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetStatus(args)
+}
+
+// StatusHistory implements Model.
+func (s *synchronizedModel) StatusHistory() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.StatusHistory()
+}
+
+// SetStatusHistory implements Model.
+func (s *synchronizedModel) SetStatusHistory(args []StatusArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetStatusHistory(args)
+}
+
+// AppendStatusHistory implements Model.
+func (s *synchronizedModel) AppendStatusHistory(args ...StatusArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.AppendStatusHistory(args...)
+}
+
+// AgentVersion implements Model.
+func (s *synchronizedModel) AgentVersion() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.AgentVersion()
+}
+
+// Type implements Model.
+func (s *synchronizedModel) Type() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Type()
+}
+
+// Cloud implements Model.
+func (s *synchronizedModel) Cloud() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Cloud()
+}
+
+// Description implements Model.
+func (s *synchronizedModel) Description() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Description()
+}
+
+// CloudRegion implements Model.
+func (s *synchronizedModel) CloudRegion() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.CloudRegion()
+}
+
+// CloudCredential implements Model.
+func (s *synchronizedModel) CloudCredential() CloudCredential {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.CloudCredential()
+}
+
+// SetCloudCredential implements Model.
+func (s *synchronizedModel) SetCloudCredential(args CloudCredentialArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetCloudCredential(args)
+}
+
+// Tag implements Model.
+func (s *synchronizedModel) Tag() names.ModelTag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Tag()
+}
+
+// Owner implements Model.
+func (s *synchronizedModel) Owner() names.UserTag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Owner()
+}
+
+// Config implements Model. The config is copied, as UpdateConfig changes
+// the model's config in place.
+func (s *synchronizedModel) Config() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloned(s.model.Config())
+}
+
+// LatestToolsVersion implements Model.
+func (s *synchronizedModel) LatestToolsVersion() version.Number {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.LatestToolsVersion()
+}
+
+// EnvironVersion implements Model.
+func (s *synchronizedModel) EnvironVersion() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.EnvironVersion()
+}
+
+// UpdateConfig implements Model.
+func (s *synchronizedModel) UpdateConfig(config map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.UpdateConfig(config)
+}
+
+// Blocks implements Model.
+func (s *synchronizedModel) Blocks() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Blocks()
+}
+
+// Users implements Model.
+func (s *synchronizedModel) Users() []User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Users()
+}
+
+// AddUser implements Model.
+func (s *synchronizedModel) AddUser(args UserArgs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.AddUser(args)
+}
+
+// Machines implements Model.
+func (s *synchronizedModel) Machines() []Machine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Machines()
+}
+
+// AddMachine implements Model.
+func (s *synchronizedModel) AddMachine(args MachineArgs) Machine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddMachine(args)
+}
+
+// Applications implements Model.
+func (s *synchronizedModel) Applications() []Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Applications()
+}
+
+// ApplicationNames implements Model.
+func (s *synchronizedModel) ApplicationNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.ApplicationNames()
+}
+
+// UnitNames implements Model.
+func (s *synchronizedModel) UnitNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.UnitNames()
+}
+
+// AddApplication implements Model.
+func (s *synchronizedModel) AddApplication(args ApplicationArgs) Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddApplication(args)
+}
+
+// Relations implements Model.
+func (s *synchronizedModel) Relations() []Relation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Relations()
+}
+
+// AddRelation implements Model.
+func (s *synchronizedModel) AddRelation(args RelationArgs) Relation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddRelation(args)
+}
+
+// RemoteEntities implements Model.
+func (s *synchronizedModel) RemoteEntities() []RemoteEntity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RemoteEntities()
+}
+
+// AddRemoteEntity implements Model.
+func (s *synchronizedModel) AddRemoteEntity(args RemoteEntityArgs) RemoteEntity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddRemoteEntity(args)
+}
+
+// RelationNetworks implements Model.
+func (s *synchronizedModel) RelationNetworks() []RelationNetwork {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RelationNetworks()
+}
+
+// RelationIngressNetworks implements Model.
+func (s *synchronizedModel) RelationIngressNetworks() []RelationNetwork {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RelationIngressNetworks()
+}
+
+// RelationEgressNetworks implements Model.
+func (s *synchronizedModel) RelationEgressNetworks() []RelationNetwork {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RelationEgressNetworks()
+}
+
+// AddRelationNetwork implements Model.
+func (s *synchronizedModel) AddRelationNetwork(args RelationNetworkArgs) RelationNetwork {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddRelationNetwork(args)
+}
+
+// Spaces implements Model.
+func (s *synchronizedModel) Spaces() []Space {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Spaces()
+}
+
+// AddSpace implements Model.
+func (s *synchronizedModel) AddSpace(args SpaceArgs) Space {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddSpace(args)
+}
+
+// LinkLayerDevices implements Model.
+func (s *synchronizedModel) LinkLayerDevices() []LinkLayerDevice {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.LinkLayerDevices()
+}
+
+// AddLinkLayerDevice implements Model.
+func (s *synchronizedModel) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddLinkLayerDevice(args)
+}
+
+// Subnets implements Model.
+func (s *synchronizedModel) Subnets() []Subnet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Subnets()
+}
+
+// SubnetsInSpace implements Model.
+func (s *synchronizedModel) SubnetsInSpace(spaceID string) []Subnet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.SubnetsInSpace(spaceID)
+}
+
+// AddSubnet implements Model.
+func (s *synchronizedModel) AddSubnet(args SubnetArgs) Subnet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddSubnet(args)
+}
+
+// IPAddresses implements Model.
+func (s *synchronizedModel) IPAddresses() []IPAddress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.IPAddresses()
+}
+
+// AddIPAddress implements Model.
+func (s *synchronizedModel) AddIPAddress(args IPAddressArgs) IPAddress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddIPAddress(args)
+}
+
+// SSHHostKeys implements Model.
+func (s *synchronizedModel) SSHHostKeys() []SSHHostKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.SSHHostKeys()
+}
+
+// AddSSHHostKey implements Model.
+func (s *synchronizedModel) AddSSHHostKey(args SSHHostKeyArgs) SSHHostKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddSSHHostKey(args)
+}
+
+// VirtualHostKeys implements Model.
+func (s *synchronizedModel) VirtualHostKeys() []VirtualHostKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.VirtualHostKeys()
+}
+
+// AddVirtualHostKey implements Model.
+func (s *synchronizedModel) AddVirtualHostKey(args VirtualHostKeyArgs) VirtualHostKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddVirtualHostKey(args)
+}
+
+// CloudImageMetadata implements Model.
+func (s *synchronizedModel) CloudImageMetadata() []CloudImageMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.CloudImageMetadata()
+}
+
+// AddCloudImageMetadata implements Model.
+func (s *synchronizedModel) AddCloudImageMetadata(args CloudImageMetadataArgs) CloudImageMetadata {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddCloudImageMetadata(args)
+}
+
+// Actions implements Model.
+func (s *synchronizedModel) Actions() []Action {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Actions()
+}
+
+// AddAction implements Model.
+func (s *synchronizedModel) AddAction(args ActionArgs) Action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddAction(args)
+}
+
+// Operations implements Model.
+func (s *synchronizedModel) Operations() []Operation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Operations()
+}
+
+// AddOperation implements Model.
+func (s *synchronizedModel) AddOperation(args OperationArgs) Operation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddOperation(args)
+}
+
+// Sequences implements Model. The sequences are copied, as SetSequence
+// changes the model's sequences in place.
+func (s *synchronizedModel) Sequences() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloned(s.model.Sequences())
+}
+
+// SetSequence implements Model.
+func (s *synchronizedModel) SetSequence(name string, value int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetSequence(name, value)
+}
+
+// Volumes implements Model.
+func (s *synchronizedModel) Volumes() []Volume {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Volumes()
+}
+
+// AddVolume implements Model.
+func (s *synchronizedModel) AddVolume(args VolumeArgs) Volume {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddVolume(args)
+}
+
+// Charms implements Model.
+func (s *synchronizedModel) Charms() []Charm {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Charms()
+}
+
+// AddCharm implements Model.
+func (s *synchronizedModel) AddCharm(args CharmArgs) Charm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddCharm(args)
+}
+
+// FirewallRules implements Model.
+func (s *synchronizedModel) FirewallRules() []FirewallRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.FirewallRules()
+}
+
+// AddFirewallRule implements Model.
+func (s *synchronizedModel) AddFirewallRule(args FirewallRuleArgs) FirewallRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddFirewallRule(args)
+}
+
+// Filesystems implements Model.
+func (s *synchronizedModel) Filesystems() []Filesystem {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Filesystems()
+}
+
+// AddFilesystem implements Model.
+func (s *synchronizedModel) AddFilesystem(args FilesystemArgs) Filesystem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddFilesystem(args)
+}
+
+// Storages implements Model.
+func (s *synchronizedModel) Storages() []Storage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Storages()
+}
+
+// AddStorage implements Model.
+func (s *synchronizedModel) AddStorage(args StorageArgs) Storage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddStorage(args)
+}
+
+// StoragePools implements Model.
+func (s *synchronizedModel) StoragePools() []StoragePool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.StoragePools()
+}
+
+// AddStoragePool implements Model.
+func (s *synchronizedModel) AddStoragePool(args StoragePoolArgs) StoragePool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddStoragePool(args)
+}
+
+// SecretBackendID implements Model.
+func (s *synchronizedModel) SecretBackendID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.SecretBackendID()
+}
+
+// SecretBackends implements Model.
+func (s *synchronizedModel) SecretBackends() []SecretBackend {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.SecretBackends()
+}
+
+// AddSecretBackend implements Model.
+func (s *synchronizedModel) AddSecretBackend(args SecretBackendArgs) SecretBackend {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddSecretBackend(args)
+}
+
+// Secrets implements Model.
+func (s *synchronizedModel) Secrets() []Secret {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Secrets()
+}
+
+// AddSecret implements Model.
+func (s *synchronizedModel) AddSecret(args SecretArgs) Secret {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddSecret(args)
+}
+
+// RemoteSecrets implements Model.
+func (s *synchronizedModel) RemoteSecrets() []RemoteSecret {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RemoteSecrets()
+}
+
+// AddRemoteSecret implements Model.
+func (s *synchronizedModel) AddRemoteSecret(args RemoteSecretArgs) RemoteSecret {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddRemoteSecret(args)
+}
+
+// RemoteApplications implements Model.
+func (s *synchronizedModel) RemoteApplications() []RemoteApplication {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.RemoteApplications()
+}
+
+// AddRemoteApplication implements Model.
+func (s *synchronizedModel) AddRemoteApplication(args RemoteApplicationArgs) RemoteApplication {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddRemoteApplication(args)
+}
+
+// OfferConnections implements Model.
+func (s *synchronizedModel) OfferConnections() []OfferConnection {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.OfferConnections()
+}
+
+// AddOfferConnection implements Model.
+func (s *synchronizedModel) AddOfferConnection(args OfferConnectionArgs) OfferConnection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddOfferConnection(args)
+}
+
+// ExternalControllers implements Model.
+func (s *synchronizedModel) ExternalControllers() []ExternalController {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.ExternalControllers()
+}
+
+// AddExternalController implements Model.
+func (s *synchronizedModel) AddExternalController(args ExternalControllerArgs) ExternalController {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddExternalController(args)
+}
+
+// Validate implements Model.
+func (s *synchronizedModel) Validate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.Validate()
+}
+
+// ValidateAll implements Model.
+func (s *synchronizedModel) ValidateAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.ValidateAll()
+}
+
+// ValidateWith implements Model.
+func (s *synchronizedModel) ValidateWith(rules ...ValidationRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.ValidateWith(rules...)
+}
+
+// DanglingPrincipals implements Model.
+func (s *synchronizedModel) DanglingPrincipals() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.DanglingPrincipals()
+}
+
+// AnnotationsForEntity implements Model.
+func (s *synchronizedModel) AnnotationsForEntity(tag names.Tag) map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.AnnotationsForEntity(tag)
+}
+
+// SetAnnotationsForEntity implements Model.
+func (s *synchronizedModel) SetAnnotationsForEntity(tag names.Tag, annotations map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetAnnotationsForEntity(tag, annotations)
+}
+
+// AnnotationsIndex implements Model.
+func (s *synchronizedModel) AnnotationsIndex() map[string]map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.AnnotationsIndex()
+}
+
+// Checksum implements Model.
+func (s *synchronizedModel) Checksum() (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Checksum()
+}
+
+// Summary implements Model.
+func (s *synchronizedModel) Summary(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Summary(w)
+}
+
+// NormalizeRelationNetworks implements Model.
+func (s *synchronizedModel) NormalizeRelationNetworks() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.NormalizeRelationNetworks()
+}
+
+// CleanDanglingPrincipals implements Model.
+func (s *synchronizedModel) CleanDanglingPrincipals() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.CleanDanglingPrincipals()
+}
+
+// RemoveDanglingRelations implements Model.
+func (s *synchronizedModel) RemoveDanglingRelations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.RemoveDanglingRelations()
+}
+
+// SetSLA implements Model.
+func (s *synchronizedModel) SetSLA(level, owner, credentials string) SLA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetSLA(level, owner, credentials)
+}
+
+// SLA implements Model.
+func (s *synchronizedModel) SLA() SLA {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.SLA()
+}
+
+// SetMeterStatus implements Model.
+func (s *synchronizedModel) SetMeterStatus(code, info string) MeterStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetMeterStatus(code, info)
+}
+
+// MeterStatus implements Model.
+func (s *synchronizedModel) MeterStatus() MeterStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.MeterStatus()
+}
+
+// SetTelemetry implements Model.
+func (s *synchronizedModel) SetTelemetry(args TelemetryArgs) Telemetry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetTelemetry(args)
+}
+
+// Telemetry implements Model.
+func (s *synchronizedModel) Telemetry() Telemetry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Telemetry()
+}
+
+// SetMigrationAttempt implements Model.
+func (s *synchronizedModel) SetMigrationAttempt(args MigrationAttemptArgs) MigrationAttempt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetMigrationAttempt(args)
+}
+
+// MigrationAttempt implements Model.
+func (s *synchronizedModel) MigrationAttempt() MigrationAttempt {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.MigrationAttempt()
+}
+
+// SetLease implements Model.
+func (s *synchronizedModel) SetLease(args LeaseArgs) Lease {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.SetLease(args)
+}
+
+// Lease implements Model.
+func (s *synchronizedModel) Lease() Lease {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Lease()
+}
+
+// PasswordHash implements Model.
+func (s *synchronizedModel) PasswordHash() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.PasswordHash()
+}
+
+// PasswordHashAlgorithm implements Model.
+func (s *synchronizedModel) PasswordHashAlgorithm() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.PasswordHashAlgorithm()
+}
+
+// SetPasswordHash implements Model.
+func (s *synchronizedModel) SetPasswordHash(hash, algorithm string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model.SetPasswordHash(hash, algorithm)
+}
+
+// AddBlockDevice implements Model.
+func (s *synchronizedModel) AddBlockDevice(machineId string, args BlockDeviceArgs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.AddBlockDevice(machineId, args)
+}
+
+// Freeze implements Model.
+func (s *synchronizedModel) Freeze() ModelReader {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.model.Freeze()
+}
+
+// Labels implements Model.
+func (s *synchronizedModel) Labels() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model.Labels()
+}
+
+// Copy implements Model. The copy is synchronized too.
+func (s *synchronizedModel) Copy() Model {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSynchronizedModel(s.model.Copy())
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SynchronizedModelSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SynchronizedModelSuite{})
+
+func (s *SynchronizedModelSuite) newModel() Model {
+	return NewSynchronizedModel(NewModel(ModelArgs{
+		Owner:  names.NewUserTag("owner"),
+		Config: map[string]interface{}{"uuid": "some-uuid"},
+	}))
+}
+
+func (s *SynchronizedModelSuite) TestWrapsOnce(c *gc.C) {
+	model := s.newModel()
+	c.Assert(NewSynchronizedModel(model), gc.Equals, model)
+}
+
+func (s *SynchronizedModelSuite) TestConcurrentAdds(c *gc.C) {
+	model := s.newModel()
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			model.AddMachine(MachineArgs{Id: names.NewMachineTag(fmt.Sprint(i))})
+			model.AddSpace(SpaceArgs{Id: fmt.Sprint(i), Name: fmt.Sprintf("space-%d", i)})
+			model.SetSequence(fmt.Sprintf("seq-%d", i), i)
+			_ = model.Machines()
+		}(i)
+	}
+	wg.Wait()
+
+	c.Assert(model.Machines(), gc.HasLen, workers)
+	c.Assert(model.Spaces(), gc.HasLen, workers)
+	c.Assert(model.Sequences(), gc.HasLen, workers)
+}
+
+func (s *SynchronizedModelSuite) TestConcurrentConfigAndSequences(c *gc.C) {
+	model := s.newModel()
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			model.UpdateConfig(map[string]interface{}{fmt.Sprintf("key-%d", i): i})
+			model.SetSequence(fmt.Sprintf("seq-%d", i), i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			for range model.Config() {
+			}
+			for range model.Sequences() {
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The maps returned are copies, so changing them leaves the model as
+	// it was.
+	model.Config()["uuid"] = "changed"
+	model.Sequences()["seq-0"] = 100
+	c.Assert(model.Config()["uuid"], gc.Equals, "some-uuid")
+	c.Assert(model.Config(), gc.HasLen, workers+1)
+	c.Assert(model.Sequences()["seq-0"], gc.Equals, 0)
+}
+
+func (s *SynchronizedModelSuite) TestSerialize(c *gc.C) {
+	model := s.newModel()
+	model.SetStatus(minimalStatusArgs())
+	model.AddUser(UserArgs{
+		Name:      names.NewUserTag("bob"),
+		CreatedBy: names.NewUserTag("owner"),
+	})
+
+	bytes, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(imported.Owner(), gc.Equals, names.NewUserTag("owner"))
+	c.Assert(imported.Users(), gc.HasLen, 1)
+	c.Assert(imported.Users()[0].Name(), gc.Equals, names.NewUserTag("bob"))
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// Telemetry represents the telemetry settings of the model.
+type Telemetry interface {
+	// Enabled returns whether the model reports telemetry.
+	Enabled() bool
+	// LastReportTime returns when telemetry was last reported for the
+	// model, or the zero time if it never has been.
+	LastReportTime() time.Time
+}
+
+// TelemetryArgs is an argument struct used to set the telemetry settings
+// of the Model.
+type TelemetryArgs struct {
+	Enabled        bool
+	LastReportTime time.Time
+}
+
+type telemetry struct {
+	Version int `yaml:"version"`
+
+	Enabled_        bool       `yaml:"enabled"`
+	LastReportTime_ *time.Time `yaml:"last-report-time,omitempty"`
+}
+
+func newTelemetry(args TelemetryArgs) *telemetry {
+	return &telemetry{
+		Version:         1,
+		Enabled_:        args.Enabled,
+		LastReportTime_: timePtr(args.LastReportTime),
+	}
+}
+
+// Enabled implements Telemetry.
+func (t *telemetry) Enabled() bool {
+	return t.Enabled_
+}
+
+// LastReportTime implements Telemetry.
+func (t *telemetry) LastReportTime() time.Time {
+	var zero time.Time
+	if t.LastReportTime_ == nil {
+		return zero
+	}
+	return *t.LastReportTime_
+}
+
+func importTelemetry(source map[string]interface{}) (*telemetry, error) {
+	// The section was written without a version until it had one, by
+	// releases that wrote what is now version 1.
+	version := 1
+	if _, ok := source["version"]; ok {
+		var err error
+		version, err = getVersion(source)
+		if err != nil {
+			return nil, errors.Annotate(err, "telemetry version schema check failed")
+		}
+	}
+
+	importFunc, ok := telemetryDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+
+	return importFunc(source)
+}
+
+type telemetryDeserializationFunc func(map[string]interface{}) (*telemetry, error)
+
+var telemetryDeserializationFuncs = map[int]telemetryDeserializationFunc{
+	1: importTelemetryV1,
+}
+
+func importTelemetryV1(source map[string]interface{}) (*telemetry, error) {
+	fields := schema.Fields{
+		"enabled":          schema.Bool(),
+		"last-report-time": schema.Time(),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"last-report-time": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "telemetry v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	return &telemetry{
+		Version:         1,
+		Enabled_:        valid["enabled"].(bool),
+		LastReportTime_: fieldToTimePtr(valid, "last-report-time"),
+	}, nil
+}
+
+// telemetryFromConfig derives the telemetry settings of a model exported
+// before they had a section of their own, from the "disable-telemetry"
+// config key. It returns nil if the key isn't set.
+func telemetryFromConfig(config map[string]interface{}) *telemetry {
+	disabled, ok := config["disable-telemetry"].(bool)
+	if !ok {
+		return nil
+	}
+	return &telemetry{Version: 1, Enabled_: !disabled}
+}
@@ -4,6 +4,8 @@
 package description
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/schema"
@@ -32,6 +34,7 @@ type UnitStateGetSetter interface {
 type Unit interface {
 	HasAnnotations
 	HasConstraints
+	HasWorkloadVersionHistory
 	UnitStateGetSetter
 
 	Tag() names.UnitTag
@@ -39,7 +42,15 @@ type Unit interface {
 	Type() string
 	Machine() names.MachineTag
 
+	// Life returns the life of the unit, one of Alive, Dying or Dead.
+	Life() string
+
 	PasswordHash() string
+	Nonce() string
+
+	// AgentStartTime returns when the unit agent last started, or the
+	// zero time if that isn't known.
+	AgentStartTime() time.Time
 
 	Principal() names.UnitTag
 	Subordinates() []names.UnitTag
@@ -50,23 +61,36 @@ type Unit interface {
 	Tools() AgentTools
 	SetTools(AgentToolsArgs)
 
+	// AgentVersion returns the version that the unit agent is to run,
+	// which differs from the version of its tools while the model is
+	// being upgraded. It is empty if it wasn't recorded.
+	AgentVersion() string
+
 	WorkloadStatus() Status
 	SetWorkloadStatus(StatusArgs)
 
 	WorkloadStatusHistory() []Status
 	SetWorkloadStatusHistory([]StatusArgs)
+	// AppendWorkloadStatusHistory adds the entries to the end of the
+	// workload status history, as HasStatusHistory.AppendStatusHistory.
+	AppendWorkloadStatusHistory(...StatusArgs)
 
 	WorkloadVersion() string
-
-	WorkloadVersionHistory() []Status
-	SetWorkloadVersionHistory([]StatusArgs)
+	SetWorkloadVersion(string)
 
 	AgentStatus() Status
 	SetAgentStatus(StatusArgs)
 
 	AgentStatusHistory() []Status
 	SetAgentStatusHistory([]StatusArgs)
-
+	// AppendAgentStatusHistory adds the entries to the end of the agent
+	// status history, as HasStatusHistory.AppendStatusHistory.
+	AppendAgentStatusHistory(...StatusArgs)
+
+	// AddResource records the revision of one of the application's
+	// resources that the unit uses, which may differ from the
+	// application's revision, as during a staged rollout. Validating the
+	// application checks that it has a resource of the name.
 	AddResource(UnitResourceArgs) UnitResource
 	Resources() []UnitResource
 
@@ -87,6 +111,7 @@ type units struct {
 type unit struct {
 	Name_    string `yaml:"name"`
 	Machine_ string `yaml:"machine"`
+	Life_    string `yaml:"life,omitempty"`
 
 	// Type is not exported in YAML, it is set from the application type.
 	Type_ string `yaml:"-"`
@@ -103,8 +128,11 @@ type unit struct {
 	Principal_    string   `yaml:"principal,omitempty"`
 	Subordinates_ []string `yaml:"subordinates,omitempty"`
 
-	PasswordHash_ string      `yaml:"password-hash"`
-	Tools_        *agentTools `yaml:"tools,omitempty"`
+	PasswordHash_   string      `yaml:"password-hash"`
+	Nonce_          string      `yaml:"nonce,omitempty"`
+	AgentStartTime_ *time.Time  `yaml:"agent-start-time,omitempty"`
+	Tools_          *agentTools `yaml:"tools,omitempty"`
+	AgentVersion_   string      `yaml:"agent-version,omitempty"`
 
 	MeterStatusCode_ string `yaml:"meter-status-code,omitempty"`
 	MeterStatusInfo_ string `yaml:"meter-status-info,omitempty"`
@@ -124,6 +152,11 @@ type unit struct {
 	UniterState_      string            `yaml:"uniter-state,omitempty"`
 	StorageState_     string            `yaml:"storage-state,omitempty"`
 	MeterStatusState_ string            `yaml:"meter-status-state,omitempty"`
+
+	UnknownFields_ map[string]interface{} `yaml:",inline"`
+
+	// frozen is set when the model the unit belongs to is frozen.
+	frozen bool
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Application in the Model.
@@ -131,13 +164,17 @@ type UnitArgs struct {
 	Tag          names.UnitTag
 	Type         string
 	Machine      names.MachineTag
+	Life         string
 	PasswordHash string
+	Nonce        string
 	Principal    names.UnitTag
 	Subordinates []names.UnitTag
 
 	WorkloadVersion string
 	MeterStatusCode string
 	MeterStatusInfo string
+	AgentStartTime  time.Time
+	AgentVersion    string
 
 	CloudContainer *CloudContainerArgs
 
@@ -159,16 +196,20 @@ func newUnit(args UnitArgs) *unit {
 		Name_:                   args.Tag.Id(),
 		Type_:                   args.Type,
 		Machine_:                args.Machine.Id(),
+		Life_:                   args.Life,
 		PasswordHash_:           args.PasswordHash,
+		Nonce_:                  args.Nonce,
+		AgentStartTime_:         timePtr(args.AgentStartTime),
+		AgentVersion_:           args.AgentVersion,
 		CloudContainer_:         newCloudContainer(args.CloudContainer),
 		Principal_:              args.Principal.Id(),
 		Subordinates_:           subordinates,
 		WorkloadVersion_:        args.WorkloadVersion,
 		MeterStatusCode_:        args.MeterStatusCode,
 		MeterStatusInfo_:        args.MeterStatusInfo,
-		WorkloadStatusHistory_:  newStatusHistory(),
-		WorkloadVersionHistory_: newStatusHistory(),
-		AgentStatusHistory_:     newStatusHistory(),
+		WorkloadStatusHistory_:  NewStatusHistory(),
+		WorkloadVersionHistory_: NewStatusHistory(),
+		AgentStatusHistory_:     NewStatusHistory(),
 		CharmState_:             args.CharmState,
 		RelationState_:          args.RelationState,
 		UniterState_:            args.UniterState,
@@ -185,6 +226,40 @@ func (u *unit) Tag() names.UnitTag {
 	return names.NewUnitTag(u.Name_)
 }
 
+// emitYAML implements yamlEmitter. The entries follow the fields of unit,
+// which must be kept in step.
+func (u *unit) emitYAML(m *mapping) {
+	m.str("name", u.Name_)
+	m.str("machine", u.Machine_)
+	m.stringOmitEmpty("life", u.Life_)
+	m.value("agent-status", u.AgentStatus_)
+	m.value("agent-status-history", &u.AgentStatusHistory_)
+	m.value("workload-status", u.WorkloadStatus_)
+	m.value("workload-status-history", &u.WorkloadStatusHistory_)
+	m.stringOmitEmpty("workload-version", u.WorkloadVersion_)
+	m.value("workload-version-history", &u.WorkloadVersionHistory_)
+	m.stringOmitEmpty("principal", u.Principal_)
+	m.valueOmitEmpty("subordinates", u.Subordinates_)
+	m.str("password-hash", u.PasswordHash_)
+	m.stringOmitEmpty("nonce", u.Nonce_)
+	m.valueOmitEmpty("agent-start-time", u.AgentStartTime_)
+	m.valueOmitEmpty("tools", u.Tools_)
+	m.stringOmitEmpty("agent-version", u.AgentVersion_)
+	m.stringOmitEmpty("meter-status-code", u.MeterStatusCode_)
+	m.stringOmitEmpty("meter-status-info", u.MeterStatusInfo_)
+	m.valueOmitEmpty("annotations", u.Annotations_)
+	m.valueOmitEmpty("constraints", u.Constraints_)
+	m.value("resources", &u.Resources_)
+	m.value("payloads", &u.Payloads_)
+	m.valueOmitEmpty("cloud-container", u.CloudContainer_)
+	m.valueOmitEmpty("charm-state", u.CharmState_)
+	m.valueOmitEmpty("relation-state", u.RelationState_)
+	m.stringOmitEmpty("uniter-state", u.UniterState_)
+	m.stringOmitEmpty("storage-state", u.StorageState_)
+	m.stringOmitEmpty("meter-status-state", u.MeterStatusState_)
+	m.inlined(u.UnknownFields_)
+}
+
 // Name implements Unit.
 func (u *unit) Name() string {
 	return u.Name_
@@ -200,11 +275,30 @@ func (u *unit) Machine() names.MachineTag {
 	return names.NewMachineTag(u.Machine_)
 }
 
+// Life implements Unit.
+func (u *unit) Life() string {
+	return lifeOrAlive(u.Life_)
+}
+
 // PasswordHash implements Unit.
 func (u *unit) PasswordHash() string {
 	return u.PasswordHash_
 }
 
+// Nonce implements Unit.
+func (u *unit) Nonce() string {
+	return u.Nonce_
+}
+
+// AgentStartTime implements Unit.
+func (u *unit) AgentStartTime() time.Time {
+	var zero time.Time
+	if u.AgentStartTime_ == nil {
+		return zero
+	}
+	return *u.AgentStartTime_
+}
+
 // Principal implements Unit.
 func (u *unit) Principal() names.UnitTag {
 	if u.Principal_ == "" {
@@ -241,6 +335,11 @@ func (u *unit) Tools() AgentTools {
 	return u.Tools_
 }
 
+// AgentVersion implements Unit.
+func (u *unit) AgentVersion() string {
+	return u.AgentVersion_
+}
+
 // SetTools implements Unit.
 func (u *unit) SetTools(args AgentToolsArgs) {
 	u.Tools_ = newAgentTools(args)
@@ -251,6 +350,27 @@ func (u *unit) WorkloadVersion() string {
 	return u.WorkloadVersion_
 }
 
+// SetWorkloadVersion implements Unit. A version that differs from the
+// current one is also recorded in the workload version history.
+func (u *unit) SetWorkloadVersion(version string) {
+	u.checkMutable()
+	if version != u.WorkloadVersion_ {
+		u.WorkloadVersionHistory_.History = append(u.WorkloadVersionHistory_.History, &StatusPoint_{
+			Value_:   "active",
+			Message_: version,
+			Updated_: time.Now().UTC(),
+		})
+	}
+	u.WorkloadVersion_ = version
+}
+
+// checkMutable panics if the model the unit belongs to has been frozen.
+func (u *unit) checkMutable() {
+	if u.frozen {
+		panic("description: attempt to modify a frozen model")
+	}
+}
+
 // WorkloadStatus implements Unit.
 func (u *unit) WorkloadStatus() Status {
 	// To avoid typed nils check nil here.
@@ -275,6 +395,11 @@ func (u *unit) SetWorkloadStatusHistory(args []StatusArgs) {
 	u.WorkloadStatusHistory_.SetStatusHistory(args)
 }
 
+// AppendWorkloadStatusHistory implements Unit.
+func (u *unit) AppendWorkloadStatusHistory(args ...StatusArgs) {
+	u.WorkloadStatusHistory_.AppendStatusHistory(args...)
+}
+
 // WorkloadVersionHistory implements Unit.
 func (u *unit) WorkloadVersionHistory() []Status {
 	return u.WorkloadVersionHistory_.StatusHistory()
@@ -309,6 +434,11 @@ func (u *unit) SetAgentStatusHistory(args []StatusArgs) {
 	u.AgentStatusHistory_.SetStatusHistory(args)
 }
 
+// AppendAgentStatusHistory implements Unit.
+func (u *unit) AppendAgentStatusHistory(args ...StatusArgs) {
+	u.AgentStatusHistory_.AppendStatusHistory(args...)
+}
+
 // CloudContainer implements Unit.
 func (u *unit) CloudContainer() CloudContainer {
 	if u.CloudContainer_ == nil {
@@ -353,7 +483,7 @@ func (u *unit) Resources() []UnitResource {
 
 func (u *unit) setResources(resourceList []*unitResource) {
 	u.Resources_ = unitResources{
-		Version:    1,
+		Version:    3,
 		Resources_: resourceList,
 	}
 }
@@ -436,6 +566,12 @@ func (u *unit) Validate() error {
 	if u.Name_ == "" {
 		return errors.NotValidf("missing name")
 	}
+	if err := validateLife("unit", u.Name_, u.Life_); err != nil {
+		return errors.Trace(err)
+	}
+	if !validPasswordHash(u.PasswordHash_) {
+		return errors.NotValidf("unit %q password hash", u.Name_)
+	}
 	if u.AgentStatus_ == nil {
 		return errors.NotValidf("unit %q missing agent status", u.Name_)
 	}
@@ -445,6 +581,12 @@ func (u *unit) Validate() error {
 	if u.Tools_ == nil && u.Type_ != CAAS {
 		return errors.NotValidf("unit %q missing tools", u.Name_)
 	}
+	if err := validateAgentVersion("unit", u.Name_, u.AgentVersion_); err != nil {
+		return errors.Trace(err)
+	}
+	if u.CloudContainer_ != nil && u.Type_ == IAAS {
+		return errors.NotValidf("unit %q cloud container on IAAS unit", u.Name_)
+	}
 	return nil
 }
 
@@ -487,6 +629,9 @@ var unitDeserializationFuncs = map[int]unitDeserializationFunc{
 	1: importUnitV1,
 	2: importUnitV2,
 	3: importUnitV3,
+	4: importUnitV4,
+	5: importUnitV5,
+	6: importUnitV6,
 }
 
 func unitV1Fields() (schema.Fields, schema.Defaults) {
@@ -520,8 +665,8 @@ func unitV1Fields() (schema.Fields, schema.Defaults) {
 		"meter-status-code": "",
 		"meter-status-info": "",
 	}
-	addAnnotationSchema(fields, defaults)
-	addConstraintsSchema(fields, defaults)
+	AddAnnotationSchema(fields, defaults)
+	AddConstraintsSchema(fields, defaults)
 	return fields, defaults
 }
 
@@ -549,6 +694,29 @@ func unitV3Fields() (schema.Fields, schema.Defaults) {
 	return fields, defaults
 }
 
+func unitV4Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := unitV3Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
+func unitV5Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := unitV4Fields()
+	fields["nonce"] = schema.String()
+	fields["agent-start-time"] = schema.Time()
+	defaults["nonce"] = ""
+	defaults["agent-start-time"] = schema.Omit
+	return fields, defaults
+}
+
+func unitV6Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := unitV5Fields()
+	fields["agent-version"] = schema.String()
+	defaults["agent-version"] = ""
+	return fields, defaults
+}
+
 func importUnitV1(source map[string]interface{}) (*unit, error) {
 	fields, defaults := unitV1Fields()
 	return importUnit(fields, defaults, 1, source)
@@ -564,6 +732,21 @@ func importUnitV3(source map[string]interface{}) (*unit, error) {
 	return importUnit(fields, defaults, 3, source)
 }
 
+func importUnitV4(source map[string]interface{}) (*unit, error) {
+	fields, defaults := unitV4Fields()
+	return importUnit(fields, defaults, 4, source)
+}
+
+func importUnitV5(source map[string]interface{}) (*unit, error) {
+	fields, defaults := unitV5Fields()
+	return importUnit(fields, defaults, 5, source)
+}
+
+func importUnitV6(source map[string]interface{}) (*unit, error) {
+	fields, defaults := unitV6Fields()
+	return importUnit(fields, defaults, 6, source)
+}
+
 func importUnit(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*unit, error) {
 	checker := schema.FieldMap(fields, defaults)
 
@@ -583,11 +766,21 @@ func importUnit(fields schema.Fields, defaults schema.Defaults, importVersion in
 		WorkloadVersion_:        valid["workload-version"].(string),
 		MeterStatusCode_:        valid["meter-status-code"].(string),
 		MeterStatusInfo_:        valid["meter-status-info"].(string),
-		WorkloadStatusHistory_:  newStatusHistory(),
-		WorkloadVersionHistory_: newStatusHistory(),
-		AgentStatusHistory_:     newStatusHistory(),
+		WorkloadStatusHistory_:  NewStatusHistory(),
+		WorkloadVersionHistory_: NewStatusHistory(),
+		AgentStatusHistory_:     NewStatusHistory(),
 	}
-	result.importAnnotations(valid)
+	if importVersion >= 4 {
+		result.Life_ = valid["life"].(string)
+	}
+	if importVersion >= 5 {
+		result.Nonce_ = valid["nonce"].(string)
+		result.AgentStartTime_ = fieldToTimePtr(valid, "agent-start-time")
+	}
+	if importVersion >= 6 {
+		result.AgentVersion_ = valid["agent-version"].(string)
+	}
+	result.ImportAnnotations(valid)
 
 	workloadStatusHistory := valid["workload-status-history"].(map[string]interface{})
 	if err := importStatusHistory(&result.WorkloadStatusHistory_, workloadStatusHistory); err != nil {
@@ -686,3 +879,15 @@ func importUnit(fields schema.Fields, defaults schema.Defaults, importVersion in
 
 	return result, nil
 }
+
+// validPasswordHash reports whether the hash looks like one written by the
+// agent, which is printable ASCII without spaces. An empty hash is allowed,
+// as not every agent has set a password.
+func validPasswordHash(hash string) bool {
+	for _, r := range hash {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -4,6 +4,9 @@
 package description
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -40,7 +43,7 @@ func minimalUnitMap() map[interface{}]interface{} {
 		"password-hash":            "secure-hash",
 		"tools":                    minimalAgentToolsMap(),
 		"resources": map[interface{}]interface{}{
-			"version":   1,
+			"version":   3,
 			"resources": []interface{}{},
 		},
 		"payloads": map[interface{}]interface{}{
@@ -230,7 +233,7 @@ func (s *UnitSerializationSuite) exportImportV2(c *gc.C, unit *unit) *unit {
 }
 
 func (s *UnitSerializationSuite) exportImportLatest(c *gc.C, unit *unit) *unit {
-	return s.exportImportVersion(c, unit, 3)
+	return s.exportImportVersion(c, unit, 6)
 }
 
 func (s *UnitSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -387,6 +390,25 @@ func (s *UnitSerializationSuite) TestWorkloadStatusHistory(c *gc.C) {
 	}
 }
 
+func (s *UnitSerializationSuite) TestAppendStatusHistories(c *gc.C) {
+	initial := minimalUnit()
+	args := testStatusHistoryArgs()
+	for _, arg := range args {
+		initial.AppendAgentStatusHistory(arg)
+	}
+	initial.AppendWorkloadStatusHistory(args...)
+
+	unit := s.exportImportLatest(c, initial)
+	agent := unit.AgentStatusHistory()
+	workload := unit.WorkloadStatusHistory()
+	c.Assert(agent, gc.HasLen, len(args))
+	c.Assert(workload, gc.HasLen, len(args))
+	for i := range args {
+		c.Check(agent[i].Value(), gc.Equals, args[i].Value)
+		c.Check(workload[i].Updated(), gc.Equals, args[i].Updated)
+	}
+}
+
 func (s *UnitSerializationSuite) TestResources(c *gc.C) {
 	initial := minimalUnit()
 	rFoo := initial.AddResource(UnitResourceArgs{
@@ -428,3 +450,114 @@ func (s *UnitSerializationSuite) TestIAASMissingToolsValidated(c *gc.C) {
 	err := u.Validate()
 	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" missing tools not valid`)
 }
+
+func (s *UnitSerializationSuite) TestIAASCloudContainerValidated(c *gc.C) {
+	u := minimalUnit()
+	u.SetCloudContainer(minimalCloudContainerArgs())
+	err := u.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" cloud container on IAAS unit not valid`)
+}
+
+func (s *UnitSerializationSuite) TestLife(c *gc.C) {
+	initial := s.completeUnit()
+	c.Assert(initial.Life(), gc.Equals, Alive)
+	initial.Life_ = Dead
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	unit := s.exportImportLatest(c, initial)
+	c.Assert(unit.Life(), gc.Equals, Dead)
+
+	unit = s.exportImportVersion(c, initial, 3)
+	c.Assert(unit.Life(), gc.Equals, Alive)
+}
+
+func (s *UnitSerializationSuite) TestValidateLife(c *gc.C) {
+	initial := s.completeUnit()
+	initial.Life_ = "zombie"
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *UnitSerializationSuite) TestWorkloadVersion(c *gc.C) {
+	initial := minimalUnit()
+	initial.SetWorkloadVersion("2.0")
+	updated := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	initial.SetWorkloadVersionHistory([]StatusArgs{
+		{Value: "active", Message: "1.0", Updated: updated},
+		{Value: "active", Message: "2.0", Updated: updated.Add(time.Hour)},
+	})
+
+	unit := s.exportImportLatest(c, initial)
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "2.0")
+	versions := unit.WorkloadVersionHistory()
+	c.Assert(versions, gc.HasLen, 2)
+	c.Check(versions[0].Message(), gc.Equals, "1.0")
+	c.Check(versions[1].Message(), gc.Equals, "2.0")
+	c.Check(versions[1].Updated(), gc.Equals, updated.Add(time.Hour))
+}
+
+func (s *UnitSerializationSuite) TestSetWorkloadVersionRecordsHistory(c *gc.C) {
+	unit := minimalUnit()
+	unit.SetWorkloadVersionHistory(nil)
+	unit.SetWorkloadVersion("1.0")
+	unit.SetWorkloadVersion("1.0")
+	unit.SetWorkloadVersion("2.0")
+
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "2.0")
+	versions := unit.WorkloadVersionHistory()
+	c.Assert(versions, gc.HasLen, 2)
+	c.Check(versions[0].Message(), gc.Equals, "1.0")
+	c.Check(versions[1].Message(), gc.Equals, "2.0")
+}
+
+func (s *UnitSerializationSuite) TestAgentVersion(c *gc.C) {
+	args := minimalUnitArgs(IAAS)
+	args.AgentVersion = "3.1.2"
+	initial := minimalUnit(args)
+	c.Assert(initial.AgentVersion(), gc.Equals, "3.1.2")
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	unit := s.exportImportLatest(c, initial)
+	c.Assert(unit.AgentVersion(), gc.Equals, "3.1.2")
+
+	unit = s.exportImportVersion(c, initial, 5)
+	c.Assert(unit.AgentVersion(), gc.Equals, "")
+}
+
+func (s *UnitSerializationSuite) TestValidateAgentVersion(c *gc.C) {
+	initial := s.completeUnit()
+	initial.AgentVersion_ = "three"
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" agent version "three" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *UnitSerializationSuite) TestAgentIdentity(c *gc.C) {
+	started := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	args := minimalUnitArgs(IAAS)
+	args.Nonce = "a-nonce"
+	args.AgentStartTime = started
+	initial := minimalUnit(args)
+	c.Assert(initial.Nonce(), gc.Equals, "a-nonce")
+	c.Assert(initial.AgentStartTime(), gc.Equals, started)
+
+	unit := s.exportImportLatest(c, initial)
+	c.Assert(unit.Nonce(), gc.Equals, "a-nonce")
+	c.Assert(unit.AgentStartTime(), gc.Equals, started)
+
+	unit = s.exportImportVersion(c, initial, 4)
+	c.Assert(unit.Nonce(), gc.Equals, "")
+	c.Assert(unit.AgentStartTime().IsZero(), jc.IsTrue)
+}
+
+func (s *UnitSerializationSuite) TestValidatePasswordHash(c *gc.C) {
+	initial := minimalUnit()
+	initial.PasswordHash_ = "not a hash"
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" password hash not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	initial.PasswordHash_ = ""
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+}
@@ -61,22 +61,22 @@ func importUnitResources(source map[string]interface{}) ([]*unitResource, error)
 	valid := coerced.(map[string]interface{})
 
 	version := int(valid["version"].(int64))
-	if version != 1 {
+	if _, ok := resourceRevisionFieldsFuncs[version]; !ok {
 		return nil, errors.NotValidf("version %d", version)
 	}
 
 	sourceList := valid["resources"].([]interface{})
-	return importUnitResourceList(sourceList)
+	return importUnitResourceList(sourceList, version)
 }
 
-func importUnitResourceList(sourceList []interface{}) ([]*unitResource, error) {
+func importUnitResourceList(sourceList []interface{}, version int) ([]*unitResource, error) {
 	result := make([]*unitResource, 0, len(sourceList))
 	for i, value := range sourceList {
 		source, ok := value.(map[string]interface{})
 		if !ok {
 			return nil, errors.Errorf("unexpected value for resource %d, %T", i, value)
 		}
-		r, err := importUnitResourceV1(source)
+		r, err := importUnitResource(source, version)
 		if err != nil {
 			return nil, errors.Annotatef(err, "unit resource %d", i)
 		}
@@ -85,7 +85,7 @@ func importUnitResourceList(sourceList []interface{}) ([]*unitResource, error) {
 	return result, nil
 }
 
-func importUnitResourceV1(source map[string]interface{}) (*unitResource, error) {
+func importUnitResource(source map[string]interface{}, version int) (*unitResource, error) {
 	fields := schema.Fields{
 		"name":     schema.String(),
 		"revision": schema.StringMap(schema.Any()),
@@ -94,14 +94,14 @@ func importUnitResourceV1(source map[string]interface{}) (*unitResource, error)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "unit resource v1 schema check failed")
+		return nil, errors.Annotatef(err, "unit resource v%d schema check failed", version)
 	}
 	valid := coerced.(map[string]interface{})
 
 	r := &unitResource{
 		Name_: valid["name"].(string),
 	}
-	rev, err := importResourceRevisionV1(valid["revision"])
+	rev, err := importResourceRevision(valid["revision"], version)
 	if err != nil {
 		return nil, errors.Annotatef(err, "unit resource %s", r.Name_)
 	}
@@ -67,6 +67,22 @@ func (s *UnitResourceSuite) TestRoundTrip(c *gc.C) {
 	c.Assert(urOut, jc.DeepEquals, urIn)
 }
 
+func (s *UnitResourceSuite) TestImageRoundTrip(c *gc.C) {
+	urIn := newUnitResource(UnitResourceArgs{
+		Name: "image",
+		RevisionArgs: ResourceRevisionArgs{
+			Revision: 1,
+			Type:     ResourceOCIImage,
+			Image: &ResourceImageArgs{
+				RegistryPath: "registry.example.com/ubuntu:22.04",
+				SecretRef:    "secret:cs0ba5a8e6d0rnvbuqgg",
+			},
+		},
+	})
+	urOut := s.exportImport(c, urIn)
+	c.Assert(urOut, jc.DeepEquals, urIn)
+}
+
 func (s *UnitResourceSuite) TestImportEmpty(c *gc.C) {
 	r, err := importUnitResources(map[string]interface{}{
 		"version":   1,
@@ -86,7 +102,7 @@ func (s *UnitResourceSuite) TestUnsupportedVersion(c *gc.C) {
 
 func (s *UnitResourceSuite) exportImport(c *gc.C, ur *unitResource) *unitResource {
 	initial := unitResources{
-		Version:    1,
+		Version:    3,
 		Resources_: []*unitResource{ur},
 	}
 	bytes, err := yaml.Marshal(initial)
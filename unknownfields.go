@@ -0,0 +1,147 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+)
+
+// PreserveUnknownFields has Deserialize keep the fields of the model, and
+// of its machines, applications, units and relations, that aren't fields
+// of any version this package knows, rather than drop them. They are
+// kept as they were read, without being checked, and are written out
+// again by Serialize, so that a model written by a later version of the
+// package can pass through this one without losing them. The unknown
+// fields of other entities are dropped as usual.
+//
+// Only fields added without a version bump can be kept. A model, or a
+// section of it, written at a version newer than this package knows is
+// still refused by Deserialize, with or without this option, as the
+// fields it does know may have changed meaning too.
+func PreserveUnknownFields() ImportOption {
+	return func(o *importOptions) {
+		o.preserveUnknownFields = true
+	}
+}
+
+// modelKeys are the top level keys of the model source that aren't
+// fields of the model, but are read by the import.
+var modelKeys = set.NewStrings("version", labelsKey, integrityKey, statusStringsKey)
+
+// keepUnknownFields records on the imported model, and on its machines,
+// applications, units and relations, the fields of their source that
+// aren't known. Sections that are themselves malformed were reported by
+// the import, so are skipped here.
+func keepUnknownFields(m *model, source map[string]interface{}) {
+	known := knownFields("model").Union(modelKeys)
+	m.UnknownFields_ = unknownFields(source, known)
+
+	if _, _, list, ok := versionedSource(source["machines"], "machines"); ok {
+		keepUnknownMachineFields(m.Machines_.Machines_, list, knownFields("machine"))
+	}
+	if _, _, list, ok := versionedSource(source["applications"], "applications"); ok {
+		applicationFields, unitFields := knownFields("application"), knownFields("unit")
+		byName := make(map[string]*application)
+		for _, application := range m.Applications_.Applications_ {
+			byName[application.Name_] = application
+		}
+		for _, value := range list {
+			entity, ok := sourceMap(value)
+			if !ok {
+				continue
+			}
+			application, ok := byName[fmt.Sprint(entity["name"])]
+			if !ok {
+				continue
+			}
+			application.UnknownFields_ = unknownFields(entity, applicationFields)
+			if _, _, units, ok := versionedSource(entity["units"], "units"); ok {
+				keepUnknownUnitFields(application.Units_.Units_, units, unitFields)
+			}
+		}
+	}
+	if _, _, list, ok := versionedSource(source["relations"], "relations"); ok {
+		relationFields := knownFields("relation")
+		byKey := make(map[string]*relation)
+		for _, relation := range m.Relations_.Relations_ {
+			byKey[relation.Key_] = relation
+		}
+		for _, value := range list {
+			entity, ok := sourceMap(value)
+			if !ok {
+				continue
+			}
+			if relation, ok := byKey[fmt.Sprint(entity["key"])]; ok {
+				relation.UnknownFields_ = unknownFields(entity, relationFields)
+			}
+		}
+	}
+}
+
+func keepUnknownMachineFields(machines []*machine, list []interface{}, known set.Strings) {
+	byId := make(map[string]*machine)
+	for _, machine := range machines {
+		byId[machine.Id_] = machine
+	}
+	for _, value := range list {
+		entity, ok := sourceMap(value)
+		if !ok {
+			continue
+		}
+		machine, ok := byId[fmt.Sprint(entity["id"])]
+		if !ok {
+			continue
+		}
+		machine.UnknownFields_ = unknownFields(entity, known)
+		if containers, ok := entity["containers"].([]interface{}); ok {
+			keepUnknownMachineFields(machine.Containers_, containers, known)
+		}
+	}
+}
+
+func keepUnknownUnitFields(units []*unit, list []interface{}, known set.Strings) {
+	byName := make(map[string]*unit)
+	for _, unit := range units {
+		byName[unit.Name_] = unit
+	}
+	for _, value := range list {
+		entity, ok := sourceMap(value)
+		if !ok {
+			continue
+		}
+		if unit, ok := byName[fmt.Sprint(entity["name"])]; ok {
+			unit.UnknownFields_ = unknownFields(entity, known)
+		}
+	}
+}
+
+// unknownFields returns the fields of the source of an entity that
+// aren't known, or nil if there are none.
+func unknownFields(source map[string]interface{}, known set.Strings) map[string]interface{} {
+	var result map[string]interface{}
+	for key, value := range source {
+		if known.Contains(key) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// knownFields returns the fields of every version of the entity.
+func knownFields(entity string) set.Strings {
+	known := set.NewStrings()
+	for _, fieldsFunc := range entityVersions[entity] {
+		fields, _ := fieldsFunc()
+		for name := range fields {
+			known.Add(name)
+		}
+	}
+	return known
+}
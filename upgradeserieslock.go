@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/schema"
+)
+
+// UpgradeSeriesLock describes a series upgrade in progress on a machine
+// when the model was exported, so that the upgrade can carry on after a
+// migration rather than be abandoned. The statuses are those the upgrade
+// workers report, such as "prepare started" or "completed", for the
+// machine and for each of the units on it.
+type UpgradeSeriesLock interface {
+	// FromBase and ToBase are the bases the machine is being upgraded
+	// from and to, such as "ubuntu@20.04" and "ubuntu@22.04".
+	FromBase() string
+	ToBase() string
+	MachineStatus() string
+	// UnitStatuses returns the status of the upgrade of each unit on the
+	// machine, keyed by unit name.
+	UnitStatuses() map[string]string
+}
+
+// UpgradeSeriesLockArgs is an argument struct used to set the upgrade
+// series lock of a Machine.
+type UpgradeSeriesLockArgs struct {
+	FromBase      string
+	ToBase        string
+	MachineStatus string
+	UnitStatuses  map[string]string
+}
+
+type upgradeSeriesLock struct {
+	FromBase_      string            `yaml:"from-base"`
+	ToBase_        string            `yaml:"to-base"`
+	MachineStatus_ string            `yaml:"machine-status"`
+	UnitStatuses_  map[string]string `yaml:"unit-statuses,omitempty"`
+}
+
+func newUpgradeSeriesLock(args UpgradeSeriesLockArgs) *upgradeSeriesLock {
+	lock := &upgradeSeriesLock{
+		FromBase_:      args.FromBase,
+		ToBase_:        args.ToBase,
+		MachineStatus_: args.MachineStatus,
+	}
+	if len(args.UnitStatuses) > 0 {
+		lock.UnitStatuses_ = make(map[string]string, len(args.UnitStatuses))
+		for unit, status := range args.UnitStatuses {
+			lock.UnitStatuses_[unit] = status
+		}
+	}
+	return lock
+}
+
+// FromBase implements UpgradeSeriesLock.
+func (l *upgradeSeriesLock) FromBase() string {
+	return l.FromBase_
+}
+
+// ToBase implements UpgradeSeriesLock.
+func (l *upgradeSeriesLock) ToBase() string {
+	return l.ToBase_
+}
+
+// MachineStatus implements UpgradeSeriesLock.
+func (l *upgradeSeriesLock) MachineStatus() string {
+	return l.MachineStatus_
+}
+
+// UnitStatuses implements UpgradeSeriesLock.
+func (l *upgradeSeriesLock) UnitStatuses() map[string]string {
+	return l.UnitStatuses_
+}
+
+// validate checks that the lock of the machine has both bases and a
+// status for the machine, and for each of the units, which must be named
+// as units are.
+func (l *upgradeSeriesLock) validate(machineID string) error {
+	if !validBase(l.FromBase_) {
+		return errors.NotValidf("machine %q upgrade series from base %q", machineID, l.FromBase_)
+	}
+	if !validBase(l.ToBase_) {
+		return errors.NotValidf("machine %q upgrade series to base %q", machineID, l.ToBase_)
+	}
+	if l.MachineStatus_ == "" {
+		return errors.NotValidf("machine %q upgrade series missing status", machineID)
+	}
+	units := make([]string, 0, len(l.UnitStatuses_))
+	for unit := range l.UnitStatuses_ {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	for _, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("machine %q upgrade series unit %q", machineID, unit)
+		}
+		if l.UnitStatuses_[unit] == "" {
+			return errors.NotValidf("machine %q upgrade series unit %q missing status", machineID, unit)
+		}
+	}
+	return nil
+}
+
+func upgradeSeriesLockFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"from-base":      schema.String(),
+		"to-base":        schema.String(),
+		"machine-status": schema.String(),
+		"unit-statuses":  schema.StringMap(schema.String()),
+	}
+	defaults := schema.Defaults{
+		"unit-statuses": schema.Omit,
+	}
+	return fields, defaults
+}
+
+func importUpgradeSeriesLock(source map[string]interface{}) *upgradeSeriesLock {
+	lock := &upgradeSeriesLock{
+		FromBase_:      source["from-base"].(string),
+		ToBase_:        source["to-base"].(string),
+		MachineStatus_: source["machine-status"].(string),
+	}
+	if statuses, ok := source["unit-statuses"]; ok {
+		lock.UnitStatuses_ = convertToStringMap(statuses)
+	}
+	return lock
+}
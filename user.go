@@ -20,6 +20,16 @@ type User interface {
 	DateCreated() time.Time
 	LastConnection() time.Time
 	Access() string
+	// AccessHistory returns the changes to the user's access to the
+	// model, oldest first.
+	AccessHistory() []AccessGrant
+}
+
+// AccessGrant records a change to a user's access to the model.
+type AccessGrant interface {
+	Access() string
+	GrantedBy() names.UserTag
+	Granted() time.Time
 }
 
 type users struct {
@@ -34,6 +44,15 @@ type UserArgs struct {
 	DateCreated    time.Time
 	LastConnection time.Time
 	Access         string
+	AccessHistory  []AccessGrantArgs
+}
+
+// AccessGrantArgs is an argument struct used to record a change to a
+// user's access in UserArgs.
+type AccessGrantArgs struct {
+	Access    string
+	GrantedBy names.UserTag
+	Granted   time.Time
 }
 
 func newUser(args UserArgs) *user {
@@ -44,6 +63,13 @@ func newUser(args UserArgs) *user {
 		DateCreated_: args.DateCreated,
 		Access_:      args.Access,
 	}
+	for _, grant := range args.AccessHistory {
+		u.AccessHistory_ = append(u.AccessHistory_, &accessGrant{
+			Access_:    grant.Access,
+			GrantedBy_: grant.GrantedBy.Id(),
+			Granted_:   grant.Granted.UTC(),
+		})
+	}
 	if !args.LastConnection.IsZero() {
 		value := args.LastConnection
 		u.LastConnection_ = &value
@@ -60,6 +86,33 @@ type user struct {
 	// Can't use omitempty with time.Time, it just doesn't work,
 	// so use a pointer in the struct.
 	LastConnection_ *time.Time `yaml:"last-connection,omitempty"`
+
+	AccessHistory_ []*accessGrant `yaml:"access-history,omitempty"`
+}
+
+type accessGrant struct {
+	Access_    string    `yaml:"access"`
+	GrantedBy_ string    `yaml:"granted-by"`
+	Granted_   time.Time `yaml:"granted"`
+}
+
+// Access implements AccessGrant.
+func (g *accessGrant) Access() string {
+	return g.Access_
+}
+
+// GrantedBy implements AccessGrant. It is the zero tag if who granted the
+// access isn't known.
+func (g *accessGrant) GrantedBy() names.UserTag {
+	if g.GrantedBy_ == "" {
+		return names.UserTag{}
+	}
+	return names.NewUserTag(g.GrantedBy_)
+}
+
+// Granted implements AccessGrant.
+func (g *accessGrant) Granted() time.Time {
+	return g.Granted_
 }
 
 // Name implements User.
@@ -96,6 +149,15 @@ func (u *user) Access() string {
 	return u.Access_
 }
 
+// AccessHistory implements User.
+func (u *user) AccessHistory() []AccessGrant {
+	var result []AccessGrant
+	for _, grant := range u.AccessHistory_ {
+		result = append(result, grant)
+	}
+	return result
+}
+
 func importUsers(source map[string]interface{}) ([]*user, error) {
 	checker := versionedChecker("users")
 	coerced, err := checker.Coerce(source, nil)
@@ -133,9 +195,20 @@ type userDeserializationFunc func(map[string]interface{}) (*user, error)
 
 var userDeserializationFuncs = map[int]userDeserializationFunc{
 	1: importUserV1,
+	2: importUserV2,
 }
 
 func importUserV1(source map[string]interface{}) (*user, error) {
+	fields, defaults := userV1Fields()
+	return importUserVersion(fields, defaults, source, 1)
+}
+
+func importUserV2(source map[string]interface{}) (*user, error) {
+	fields, defaults := userV2Fields()
+	return importUserVersion(fields, defaults, source, 2)
+}
+
+func userV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"name":            schema.String(),
 		"display-name":    schema.String(),
@@ -152,10 +225,30 @@ func importUserV1(source map[string]interface{}) (*user, error) {
 		"last-connection": schema.Omit,
 		"read-only":       false,
 	}
+	return fields, defaults
+}
+
+func userV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := userV1Fields()
+	fields["access-history"] = schema.List(schema.FieldMap(accessGrantFields()))
+	defaults["access-history"] = schema.Omit
+	return fields, defaults
+}
+
+func accessGrantFields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"access":     schema.String(),
+		"granted-by": schema.String(),
+		"granted":    schema.Time(),
+	}
+	return fields, schema.Defaults{}
+}
+
+func importUserVersion(fields schema.Fields, defaults schema.Defaults, source map[string]interface{}, importVersion int) (*user, error) {
 	checker := schema.FieldMap(fields, defaults)
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "user v1 schema check failed")
+		return nil, errors.Annotatef(err, "user v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
@@ -169,6 +262,17 @@ func importUserV1(source map[string]interface{}) (*user, error) {
 		Access_:         valid["access"].(string),
 		LastConnection_: fieldToTimePtr(valid, "last-connection"),
 	}
+	if importVersion >= 2 {
+		if history, ok := valid["access-history"]; ok {
+			for _, value := range history.([]interface{}) {
+				grant := value.(map[string]interface{})
+				result.AccessHistory_ = append(result.AccessHistory_, &accessGrant{
+					Access_:    grant["access"].(string),
+					GrantedBy_: grant["granted-by"].(string),
+					Granted_:   grant["granted"].(time.Time),
+				})
+			}
+		}
+	}
 	return result, nil
-
 }
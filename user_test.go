@@ -6,6 +6,7 @@ package description
 import (
 	"time"
 
+	"github.com/juju/names/v5"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -56,7 +57,7 @@ func (*UserSerializationSuite) TestUnknownVersion(c *gc.C) {
 func (*UserSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	lastConn := time.Date(2016, 1, 15, 12, 0, 0, 0, time.UTC)
 	initial := users{
-		Version: 1,
+		Version: 2,
 		Users_: []*user{
 			{
 				Name_:           "admin",
@@ -70,6 +71,15 @@ func (*UserSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				CreatedBy_:   "admin",
 				DateCreated_: time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
 				Access_:      "read",
+				AccessHistory_: []*accessGrant{{
+					Access_:    "write",
+					GrantedBy_: "admin",
+					Granted_:   time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
+				}, {
+					Access_:    "read",
+					GrantedBy_: "admin",
+					Granted_:   time.Date(2015, 11, 9, 12, 34, 56, 0, time.UTC),
+				}},
 			},
 		},
 	}
@@ -88,3 +98,43 @@ func (*UserSerializationSuite) TestParsingSerializedData(c *gc.C) {
 
 	c.Assert(users, jc.DeepEquals, initial.Users_)
 }
+
+func (*UserSerializationSuite) TestNewUserAccessHistory(c *gc.C) {
+	granted := time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC)
+	u := newUser(UserArgs{
+		Name:      names.NewUserTag("bob"),
+		CreatedBy: names.NewUserTag("admin"),
+		Access:    "read",
+		AccessHistory: []AccessGrantArgs{{
+			Access:    "read",
+			GrantedBy: names.NewUserTag("admin"),
+			Granted:   granted,
+		}},
+	})
+	history := u.AccessHistory()
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Access(), gc.Equals, "read")
+	c.Check(history[0].GrantedBy(), gc.Equals, names.NewUserTag("admin"))
+	c.Check(history[0].Granted(), gc.Equals, granted)
+}
+
+func (*UserSerializationSuite) TestV1IgnoresAccessHistory(c *gc.C) {
+	source := map[string]interface{}{
+		"version": 1,
+		"users": []interface{}{map[string]interface{}{
+			"name":         "bob",
+			"created-by":   "admin",
+			"date-created": time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
+			"access":       "read",
+			"access-history": []interface{}{map[string]interface{}{
+				"access":     "read",
+				"granted-by": "admin",
+				"granted":    time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
+			}},
+		}},
+	}
+	users, err := importUsers(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].AccessHistory(), gc.HasLen, 0)
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// ValidationRule is a check of a model made in addition to those of
+// Model.Validate, so that users of the package can require more of the
+// models they accept, such as that every machine has a hostname.
+type ValidationRule interface {
+	// Name identifies the rule in the failures it reports.
+	Name() string
+
+	// Check returns an error if the model doesn't satisfy the rule.
+	Check(ModelReader) error
+}
+
+// NewValidationRule returns a ValidationRule with the name that checks
+// models with the function.
+func NewValidationRule(name string, check func(ModelReader) error) ValidationRule {
+	return &validationRule{name: name, check: check}
+}
+
+type validationRule struct {
+	name  string
+	check func(ModelReader) error
+}
+
+// Name implements ValidationRule.
+func (r *validationRule) Name() string {
+	return r.name
+}
+
+// Check implements ValidationRule.
+func (r *validationRule) Check(m ModelReader) error {
+	return r.check(m)
+}
+
+// ValidateWith implements Model.
+func (m *model) ValidateWith(rules ...ValidationRule) error {
+	var errs validationErrors
+	m.validate(&errs)
+	if errs.stopped() {
+		return errs.first()
+	}
+	// The rules get a read-only view, as they only inspect the model. The
+	// view reaches none of the model's mutators and hands out copies of
+	// its entities, so the model itself needn't be frozen.
+	reader := frozenModel{m}
+	for _, rule := range rules {
+		if err := rule.Check(reader); err != nil {
+			return errors.Annotatef(err, "rule %q", rule.Name())
+		}
+	}
+	return nil
+}
+
+// DanglingPrincipalsRule returns a ValidationRule that fails if the model
+// references users who aren't users of it, as DanglingPrincipals reports.
+// Validate doesn't require this, as the access control lists of offers
+// routinely name users of the controller who aren't users of the model.
+// Importers choose between strict failure, with the rule, and cleaning up
+// with CleanDanglingPrincipals.
+func DanglingPrincipalsRule() ValidationRule {
+	return NewValidationRule("dangling principals", checkDanglingPrincipals)
+}
+
+// checkDanglingPrincipals reports the first reference to a user who isn't
+// a user of the model.
+func checkDanglingPrincipals(m ModelReader) error {
+	known := set.NewStrings(everyoneUserName, m.Owner().Id())
+	users := m.Users()
+	for _, user := range users {
+		known.Add(user.Name().Id())
+	}
+	for _, application := range m.Applications() {
+		for _, offer := range application.Offers() {
+			var principals []string
+			for name := range offer.ACL() {
+				principals = append(principals, name)
+			}
+			sort.Strings(principals)
+			for _, name := range principals {
+				if !isKnownUser(known, name) {
+					return errors.NotValidf("application %q offer %q unknown user %q", application.Name(), offer.OfferName(), name)
+				}
+			}
+		}
+	}
+	for _, secret := range m.Secrets() {
+		var subjects []string
+		for subject := range secret.ACL() {
+			subjects = append(subjects, subject)
+		}
+		sort.Strings(subjects)
+		for _, subject := range subjects {
+			if name, ok := secretUserSubject(subject); ok && !isKnownUser(known, name) {
+				return errors.NotValidf("secret %q unknown user %q", secret.Id(), name)
+			}
+		}
+	}
+	for _, user := range users {
+		for _, grant := range user.AccessHistory() {
+			grantor := grant.GrantedBy().Id()
+			if grantor != "" && !isKnownUser(known, grantor) {
+				return errors.NotValidf("user %q access granted by unknown user %q", user.Name().Id(), grantor)
+			}
+		}
+	}
+	return nil
+}
+
+// builtinStorageProviders are the storage providers available in every
+// model, whose names can be used as pools without being declared.
+var builtinStorageProviders = []string{"loop", "rootfs", "tmpfs"}
+
+// StoragePoolsRule returns a ValidationRule that fails if a volume,
+// filesystem, storage instance or application storage directive names a
+// pool that isn't among the model's storage pools. The names of the
+// built-in providers, and of the providers of the model's pools, are
+// accepted as pools too. Validate doesn't require this, as a model only
+// holds the pools created by its users, and not those its provider
+// defines; the names of those are given as providerPools, such as
+// "ebs-ssd", and are accepted as well.
+func StoragePoolsRule(providerPools ...string) ValidationRule {
+	return NewValidationRule("storage pools", func(m ModelReader) error {
+		return checkStoragePools(m, providerPools)
+	})
+}
+
+// checkStoragePools reports the first reference to a storage pool that
+// the model doesn't know about.
+func checkStoragePools(m ModelReader, providerPools []string) error {
+	known := set.NewStrings(builtinStorageProviders...)
+	known = known.Union(set.NewStrings(providerPools...))
+	for _, pool := range m.StoragePools() {
+		known.Add(pool.Name())
+		known.Add(pool.Provider())
+	}
+	isKnown := func(pool string) bool {
+		// Storage that doesn't name a pool uses the model's default.
+		return pool == "" || known.Contains(pool)
+	}
+
+	for _, volume := range m.Volumes() {
+		if !isKnown(volume.Pool()) {
+			return errors.NotValidf("volume %q unknown storage pool %q", volume.Tag().Id(), volume.Pool())
+		}
+	}
+	for _, filesystem := range m.Filesystems() {
+		if !isKnown(filesystem.Pool()) {
+			return errors.NotValidf("filesystem %q unknown storage pool %q", filesystem.Tag().Id(), filesystem.Pool())
+		}
+	}
+	for _, storage := range m.Storages() {
+		constraints, ok := storage.Constraints()
+		if ok && !isKnown(constraints.Pool) {
+			return errors.NotValidf("storage %q unknown storage pool %q", storage.Tag().Id(), constraints.Pool)
+		}
+	}
+	for _, application := range m.Applications() {
+		directives := application.StorageDirectives()
+		var directiveNames []string
+		for name := range directives {
+			directiveNames = append(directiveNames, name)
+		}
+		sort.Strings(directiveNames)
+		for _, name := range directiveNames {
+			if pool := directives[name].Pool(); !isKnown(pool) {
+				return errors.NotValidf("application %q storage directive %q unknown storage pool %q", application.Name(), name, pool)
+			}
+		}
+	}
+	return nil
+}
+
+// CertificateExpiryRule returns a ValidationRule that fails if the CA
+// certificate of an external controller has expired at the time given as
+// now, or expires within the window after it. Validate only requires the
+// certificates to parse, as a model is valid whenever it is imported, but
+// importers can use the rule to turn away, or warn about, models whose
+// cross-model relations will soon stop working.
+func CertificateExpiryRule(now time.Time, window time.Duration) ValidationRule {
+	return NewValidationRule("certificate expiry", func(m ModelReader) error {
+		return checkCertificateExpiry(m, now.Add(window))
+	})
+}
+
+// checkCertificateExpiry reports the first external controller whose CA
+// certificate expires before the deadline.
+func checkCertificateExpiry(m ModelReader, deadline time.Time) error {
+	for _, controller := range m.ExternalControllers() {
+		// Validate has already rejected certificates that don't parse.
+		certificate := parseCACert(controller.CACert())
+		if certificate == nil {
+			continue
+		}
+		if certificate.NotAfter.Before(deadline) {
+			return errors.NotValidf("external controller %q CA cert expiring at %s", controller.ID().Id(), certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ValidationRuleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ValidationRuleSuite{})
+
+// placementRule requires every machine to have been placed.
+var placementRule = NewValidationRule("placement", func(m ModelReader) error {
+	for _, machine := range m.Machines() {
+		if machine.Placement() == "" {
+			return errors.NotValidf("machine %q without placement", machine.Id())
+		}
+	}
+	return nil
+})
+
+func (s *ValidationRuleSuite) TestValidateWith(c *gc.C) {
+	m := selfTestModel()
+	c.Assert(m.ValidateWith(), jc.ErrorIsNil)
+
+	err := m.ValidateWith(placementRule)
+	c.Assert(err, gc.ErrorMatches, `rule "placement": machine "0" without placement not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = NewSynchronizedModel(m).ValidateWith(placementRule)
+	c.Assert(err, gc.ErrorMatches, `rule "placement": .*`)
+
+	m.Machines_.Machines_[0].Placement_ = "zone=a"
+	c.Assert(m.ValidateWith(placementRule), jc.ErrorIsNil)
+}
+
+func (s *ValidationRuleSuite) TestRulesInTurn(c *gc.C) {
+	var checked []string
+	rule := func(name string, err error) ValidationRule {
+		return NewValidationRule(name, func(ModelReader) error {
+			checked = append(checked, name)
+			return err
+		})
+	}
+	err := selfTestModel().ValidateWith(
+		rule("first", nil),
+		rule("second", errors.New("boom")),
+		rule("third", nil),
+	)
+	c.Assert(err, gc.ErrorMatches, `rule "second": boom`)
+	c.Assert(checked, jc.DeepEquals, []string{"first", "second"})
+}
+
+func (s *ValidationRuleSuite) TestModelChecksFirst(c *gc.C) {
+	m := selfTestModel()
+	m.Owner_ = ""
+	checked := false
+	err := m.ValidateWith(NewValidationRule("any", func(ModelReader) error {
+		checked = true
+		return nil
+	}))
+	c.Assert(err, gc.ErrorMatches, "missing model owner not valid")
+	c.Assert(checked, jc.IsFalse)
+}
+
+func (s *ValidationRuleSuite) TestRulesCannotChangeModel(c *gc.C) {
+	m := selfTestModel()
+	before, err := Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var isModel bool
+	err = m.ValidateWith(NewValidationRule("meddling", func(reader ModelReader) error {
+		_, isModel = reader.(Model)
+		reader.Machines()[0].(HasStatus).SetStatus(StatusArgs{Value: "changed"})
+		reader.Applications()[0].(Application).SetStatus(StatusArgs{Value: "changed"})
+		return nil
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(isModel, jc.IsFalse)
+
+	after, err := Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(after), gc.Equals, string(before))
+
+	// The model isn't frozen by being validated.
+	m.SetAnnotations(map[string]string{"foo": "bar"})
+	c.Check(m.Annotations(), jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *ValidationRuleSuite) TestStoragePoolsRule(c *gc.C) {
+	m := selfTestModel()
+	c.Assert(m.ValidateWith(StoragePoolsRule()), jc.ErrorIsNil)
+
+	// The built-in providers, and those of the model's pools, name pools.
+	m.Volumes_.Volumes_[0].Pool_ = "tmpfs"
+	c.Assert(m.ValidateWith(StoragePoolsRule()), jc.ErrorIsNil)
+	m.Storages_.Storages_[0].Constraints_.Pool = "loop"
+	c.Assert(m.ValidateWith(StoragePoolsRule()), jc.ErrorIsNil)
+
+	m.Volumes_.Volumes_[0].Pool_ = "ebs-ssd"
+	err := m.ValidateWith(StoragePoolsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "storage pools": volume "0" unknown storage pool "ebs-ssd" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(m.ValidateWith(StoragePoolsRule("ebs-ssd")), jc.ErrorIsNil)
+}
+
+func (s *ValidationRuleSuite) TestStoragePoolsRuleReferences(c *gc.C) {
+	m := selfTestModel()
+	m.Storages_.Storages_[0].Constraints_.Pool = "slow"
+	err := m.ValidateWith(StoragePoolsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "storage pools": storage "data/0" unknown storage pool "slow" not valid`)
+
+	m = selfTestModel()
+	m.AddFilesystem(FilesystemArgs{Tag: names.NewFilesystemTag("0"), Pool: "slow", Size: 512}).SetStatus(minimalStatusArgs())
+	err = m.ValidateWith(StoragePoolsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "storage pools": filesystem "0" unknown storage pool "slow" not valid`)
+
+	m = selfTestModel()
+	m.Applications_.Applications_[0].StorageDirectives_ = map[string]*storageDirective{
+		"data": newStorageDirective(StorageDirectiveArgs{Pool: "fast", Size: 1024, Count: 1}),
+		"logs": newStorageDirective(StorageDirectiveArgs{Pool: "slow", Size: 1024, Count: 1}),
+	}
+	err = m.ValidateWith(StoragePoolsRule())
+	c.Assert(err, gc.ErrorMatches, `rule "storage pools": application "ubuntu" storage directive "logs" unknown storage pool "slow" not valid`)
+
+	// Storage that doesn't name a pool uses the default pool.
+	m.Applications_.Applications_[0].StorageDirectives_["logs"].Pool_ = ""
+	c.Assert(m.ValidateWith(StoragePoolsRule()), jc.ErrorIsNil)
+}
+
+func (s *ValidationRuleSuite) TestCertificateExpiryRule(c *gc.C) {
+	m := selfTestModel()
+	// The external controller's certificate expires at 2126-09-22T01:25:30Z.
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	c.Assert(m.ValidateWith(CertificateExpiryRule(now, 30*24*time.Hour)), jc.ErrorIsNil)
+
+	now = time.Date(2126, 9, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(m.ValidateWith(CertificateExpiryRule(now, 7*24*time.Hour)), jc.ErrorIsNil)
+	err := m.ValidateWith(CertificateExpiryRule(now, 30*24*time.Hour))
+	c.Assert(err, gc.ErrorMatches, `rule "certificate expiry": external controller "3a2d5e6f-7b8c-4d9e-8f0a-1b2c3d4e5f60" CA cert expiring at 2126-09-22T01:25:30Z not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	// An expired certificate fails whatever the window.
+	now = time.Date(2127, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = m.ValidateWith(CertificateExpiryRule(now, 0))
+	c.Assert(err, gc.ErrorMatches, `rule "certificate expiry": .* CA cert expiring at 2126-09-22T01:25:30Z not valid`)
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/schema"
+)
+
+// VirtualHostKey represents the host key that the controller presents for
+// a machine or unit when it proxies SSH connections to it.
+type VirtualHostKey interface {
+	ID() string
+	// Target returns the tag of the machine or unit that the host key is
+	// for.
+	Target() names.Tag
+	HostKey() []byte
+}
+
+type virtualHostKeys struct {
+	Version          int               `yaml:"version"`
+	VirtualHostKeys_ []*virtualHostKey `yaml:"virtual-host-keys"`
+}
+
+type virtualHostKey struct {
+	ID_ string `yaml:"id"`
+	// Target_ is the tag of the machine or unit, such as "machine-0".
+	Target_  string `yaml:"target"`
+	HostKey_ string `yaml:"host-key"`
+}
+
+// VirtualHostKeyArgs is an argument struct used to add a virtual host key
+// to the Model. VirtualHostKeyForMachine and VirtualHostKeyForUnit return
+// the args for the host key of a machine or unit.
+type VirtualHostKeyArgs struct {
+	ID      string
+	Target  names.Tag
+	HostKey []byte
+}
+
+// VirtualHostKeyForMachine returns the args of the virtual host key of the
+// machine.
+func VirtualHostKeyForMachine(machine names.MachineTag, hostKey []byte) VirtualHostKeyArgs {
+	return VirtualHostKeyArgs{
+		ID:      virtualHostKeyID(machine),
+		Target:  machine,
+		HostKey: hostKey,
+	}
+}
+
+// VirtualHostKeyForUnit returns the args of the virtual host key of the
+// unit.
+func VirtualHostKeyForUnit(unit names.UnitTag, hostKey []byte) VirtualHostKeyArgs {
+	return VirtualHostKeyArgs{
+		ID:      virtualHostKeyID(unit),
+		Target:  unit,
+		HostKey: hostKey,
+	}
+}
+
+// virtualHostKeyID returns the id of the virtual host key of the machine
+// or unit, such as "machine-0-hostkey".
+func virtualHostKeyID(target names.Tag) string {
+	return target.String() + "-hostkey"
+}
+
+func newVirtualHostKey(args VirtualHostKeyArgs) *virtualHostKey {
+	key := &virtualHostKey{
+		ID_:      args.ID,
+		HostKey_: string(args.HostKey),
+	}
+	if args.Target != nil {
+		key.Target_ = args.Target.String()
+	}
+	return key
+}
+
+// ID implements VirtualHostKey.
+func (k *virtualHostKey) ID() string {
+	return k.ID_
+}
+
+// Target implements VirtualHostKey. It is nil if the target isn't the tag
+// of a machine or unit.
+func (k *virtualHostKey) Target() names.Tag {
+	tag, err := k.target()
+	if err != nil {
+		return nil
+	}
+	return tag
+}
+
+// HostKey implements VirtualHostKey.
+func (k *virtualHostKey) HostKey() []byte {
+	return []byte(k.HostKey_)
+}
+
+// target parses the target of the host key, which must be the tag of a
+// machine or unit.
+func (k *virtualHostKey) target() (names.Tag, error) {
+	tag, err := names.ParseTag(k.Target_)
+	if err != nil {
+		return nil, errors.NotValidf("virtual host key %q target %q", k.ID_, k.Target_)
+	}
+	switch tag.Kind() {
+	case names.MachineTagKind, names.UnitTagKind:
+		return tag, nil
+	}
+	return nil, errors.NotValidf("virtual host key %q target %q", k.ID_, k.Target_)
+}
+
+// Validate checks that the host key has an id and a host key, and is for
+// a machine or unit.
+func (k *virtualHostKey) Validate() error {
+	if k.ID_ == "" {
+		return errors.NotValidf("virtual host key missing id")
+	}
+	if _, err := k.target(); err != nil {
+		return errors.Trace(err)
+	}
+	if k.HostKey_ == "" {
+		return errors.NotValidf("virtual host key %q missing host key", k.ID_)
+	}
+	return nil
+}
+
+func importVirtualHostKeys(source map[string]interface{}) ([]*virtualHostKey, error) {
+	checker := versionedChecker("virtual-host-keys")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "virtual host keys version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	getFields, ok := virtualHostKeyFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["virtual-host-keys"].([]interface{})
+	return importVirtualHostKeyList(sourceList, schema.FieldMap(getFields()), version)
+}
+
+func importVirtualHostKeyList(sourceList []interface{}, checker schema.Checker, version int) ([]*virtualHostKey, error) {
+	result := make([]*virtualHostKey, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for virtual host key %d, %T", i, value)
+		}
+		coerced, err := checker.Coerce(source, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "virtual host key %d v%d schema check failed", i, version)
+		}
+		valid := coerced.(map[string]interface{})
+		// From here we know that the map returned from the schema coercion
+		// contains fields of the right type.
+		result[i] = &virtualHostKey{
+			ID_:      valid["id"].(string),
+			Target_:  valid["target"].(string),
+			HostKey_: valid["host-key"].(string),
+		}
+	}
+	return result, nil
+}
+
+var virtualHostKeyFieldsFuncs = map[int]fieldsFunc{
+	1: virtualHostKeyV1Fields,
+}
+
+func virtualHostKeyV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"id":       schema.String(),
+		"target":   schema.String(),
+		"host-key": schema.String(),
+	}
+	return fields, schema.Defaults{}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type VirtualHostKeySerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&VirtualHostKeySerializationSuite{})
+
+func (s *VirtualHostKeySerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "virtual host keys"
+	s.sliceName = "virtual-host-keys"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importVirtualHostKeys(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["virtual-host-keys"] = []interface{}{}
+	}
+}
+
+func (*VirtualHostKeySerializationSuite) TestForMachine(c *gc.C) {
+	key := newVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0/lxd/1"), []byte("ssh-ed25519 key")))
+	c.Check(key.ID(), gc.Equals, "machine-0-lxd-1-hostkey")
+	c.Check(key.Target(), gc.Equals, names.NewMachineTag("0/lxd/1"))
+	c.Check(key.HostKey(), jc.DeepEquals, []byte("ssh-ed25519 key"))
+	c.Check(key.Validate(), jc.ErrorIsNil)
+}
+
+func (*VirtualHostKeySerializationSuite) TestForUnit(c *gc.C) {
+	key := newVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("ssh-ed25519 key")))
+	c.Check(key.ID(), gc.Equals, "unit-ubuntu-0-hostkey")
+	c.Check(key.Target(), gc.Equals, names.NewUnitTag("ubuntu/0"))
+	c.Check(key.HostKey(), jc.DeepEquals, []byte("ssh-ed25519 key"))
+	c.Check(key.Validate(), jc.ErrorIsNil)
+}
+
+func (*VirtualHostKeySerializationSuite) TestValidate(c *gc.C) {
+	key := newVirtualHostKey(VirtualHostKeyArgs{Target: names.NewMachineTag("0"), HostKey: []byte("key")})
+	c.Check(key.Validate(), gc.ErrorMatches, "virtual host key missing id not valid")
+
+	key = newVirtualHostKey(VirtualHostKeyArgs{ID: "key-id", HostKey: []byte("key")})
+	c.Check(key.Validate(), gc.ErrorMatches, `virtual host key "key-id" target "" not valid`)
+	c.Check(key.Target(), gc.IsNil)
+
+	key = newVirtualHostKey(VirtualHostKeyArgs{ID: "key-id", Target: names.NewApplicationTag("ubuntu"), HostKey: []byte("key")})
+	c.Check(key.Validate(), gc.ErrorMatches, `virtual host key "key-id" target "application-ubuntu" not valid`)
+	c.Check(key.Target(), gc.IsNil)
+
+	key = newVirtualHostKey(VirtualHostKeyArgs{ID: "key-id", Target: names.NewMachineTag("0")})
+	c.Check(key.Validate(), gc.ErrorMatches, `virtual host key "key-id" missing host key not valid`)
+}
+
+func (*VirtualHostKeySerializationSuite) TestRoundTrip(c *gc.C) {
+	in := []*virtualHostKey{
+		newVirtualHostKey(VirtualHostKeyForMachine(names.NewMachineTag("0"), []byte("machine key"))),
+		newVirtualHostKey(VirtualHostKeyForUnit(names.NewUnitTag("ubuntu/0"), []byte("unit key"))),
+	}
+	bytes, err := yaml.Marshal(&virtualHostKeys{Version: 1, VirtualHostKeys_: in})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := importVirtualHostKeys(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, in)
+}
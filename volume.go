@@ -24,12 +24,19 @@ type volume struct {
 	WWN_         string `yaml:"wwn,omitempty"`
 	VolumeID_    string `yaml:"volume-id,omitempty"`
 	Persistent_  bool   `yaml:"persistent"`
+	Life_        string `yaml:"life,omitempty"`
+	Encrypted_   bool   `yaml:"encrypted,omitempty"`
+	KMSKeyID_    string `yaml:"kms-key-id,omitempty"`
+	IOPS_        uint64 `yaml:"iops,omitempty"`
+	Throughput_  uint64 `yaml:"throughput,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
 	Attachments_     volumeAttachments     `yaml:"attachments"`
 	AttachmentPlans_ volumeAttachmentPlans `yaml:"attachmentplans"`
+
+	Annotations_ `yaml:"annotations,omitempty"`
 }
 
 type volumeAttachments struct {
@@ -94,6 +101,11 @@ type VolumeArgs struct {
 	WWN         string
 	VolumeID    string
 	Persistent  bool
+	Life        string
+	Encrypted   bool
+	KMSKeyID    string
+	IOPS        uint64
+	Throughput  uint64
 }
 
 func newVolume(args VolumeArgs) *volume {
@@ -107,7 +119,12 @@ func newVolume(args VolumeArgs) *volume {
 		WWN_:           args.WWN,
 		VolumeID_:      args.VolumeID,
 		Persistent_:    args.Persistent,
-		StatusHistory_: newStatusHistory(),
+		Life_:          args.Life,
+		Encrypted_:     args.Encrypted,
+		KMSKeyID_:      args.KMSKeyID,
+		IOPS_:          args.IOPS,
+		Throughput_:    args.Throughput,
+		StatusHistory_: NewStatusHistory(),
 	}
 	v.setAttachments(nil)
 	v.setAttachmentPlans(nil)
@@ -162,6 +179,31 @@ func (v *volume) Persistent() bool {
 	return v.Persistent_
 }
 
+// Life implements Volume.
+func (v *volume) Life() string {
+	return lifeOrAlive(v.Life_)
+}
+
+// Encrypted implements Volume.
+func (v *volume) Encrypted() bool {
+	return v.Encrypted_
+}
+
+// KMSKeyID implements Volume.
+func (v *volume) KMSKeyID() string {
+	return v.KMSKeyID_
+}
+
+// IOPS implements Volume.
+func (v *volume) IOPS() uint64 {
+	return v.IOPS_
+}
+
+// Throughput implements Volume.
+func (v *volume) Throughput() uint64 {
+	return v.Throughput_
+}
+
 // Status implements Volume.
 func (v *volume) Status() Status {
 	// To avoid typed nils check nil here.
@@ -227,6 +269,9 @@ func (v *volume) Validate() error {
 	if v.ID_ == "" {
 		return errors.NotValidf("volume missing id")
 	}
+	if err := validateLife("volume", v.ID_, v.Life_); err != nil {
+		return errors.Trace(err)
+	}
 	if v.Size_ == 0 {
 		return errors.NotValidf("volume %q missing size", v.ID_)
 	}
@@ -273,9 +318,32 @@ type volumeDeserializationFunc func(map[string]interface{}) (*volume, error)
 
 var volumeDeserializationFuncs = map[int]volumeDeserializationFunc{
 	1: importVolumeV1,
+	2: importVolumeV2,
+	3: importVolumeV3,
+	4: importVolumeV4,
 }
 
 func importVolumeV1(source map[string]interface{}) (*volume, error) {
+	fields, defaults := volumeV1Fields()
+	return importVolume(fields, defaults, 1, source)
+}
+
+func importVolumeV2(source map[string]interface{}) (*volume, error) {
+	fields, defaults := volumeV2Fields()
+	return importVolume(fields, defaults, 2, source)
+}
+
+func importVolumeV3(source map[string]interface{}) (*volume, error) {
+	fields, defaults := volumeV3Fields()
+	return importVolume(fields, defaults, 3, source)
+}
+
+func importVolumeV4(source map[string]interface{}) (*volume, error) {
+	fields, defaults := volumeV4Fields()
+	return importVolume(fields, defaults, 4, source)
+}
+
+func volumeV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"id":              schema.String(),
 		"storage-id":      schema.String(),
@@ -300,12 +368,43 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		"attachments":     schema.Omit,
 		"attachmentplans": schema.Omit,
 	}
-	addStatusHistorySchema(fields)
+	AddStatusHistorySchema(fields)
+	return fields, defaults
+}
+
+func volumeV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := volumeV1Fields()
+	fields["encrypted"] = schema.Bool()
+	fields["kms-key-id"] = schema.String()
+	fields["iops"] = schema.ForceUint()
+	fields["throughput"] = schema.ForceUint()
+
+	defaults["encrypted"] = false
+	defaults["kms-key-id"] = ""
+	defaults["iops"] = uint64(0)
+	defaults["throughput"] = uint64(0)
+	return fields, defaults
+}
+
+func volumeV3Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := volumeV2Fields()
+	fields["life"] = schema.String()
+	defaults["life"] = ""
+	return fields, defaults
+}
+
+func volumeV4Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := volumeV3Fields()
+	AddAnnotationSchema(fields, defaults)
+	return fields, defaults
+}
+
+func importVolume(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*volume, error) {
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "volume v1 schema check failed")
+		return nil, errors.Annotatef(err, "volume v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
@@ -320,9 +419,21 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		WWN_:           valid["wwn"].(string),
 		VolumeID_:      valid["volume-id"].(string),
 		Persistent_:    valid["persistent"].(bool),
-		StatusHistory_: newStatusHistory(),
+		StatusHistory_: NewStatusHistory(),
+	}
+	if importVersion >= 2 {
+		result.Encrypted_ = valid["encrypted"].(bool)
+		result.KMSKeyID_ = valid["kms-key-id"].(string)
+		result.IOPS_ = valid["iops"].(uint64)
+		result.Throughput_ = valid["throughput"].(uint64)
+	}
+	if importVersion >= 3 {
+		result.Life_ = valid["life"].(string)
+	}
+	if importVersion >= 4 {
+		result.ImportAnnotations(valid)
 	}
-	if err := result.importStatusHistory(valid); err != nil {
+	if err := result.ImportStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
 	}
 
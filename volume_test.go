@@ -148,8 +148,12 @@ func (s *VolumeSerializationSuite) TestVolumeMatches(c *gc.C) {
 }
 
 func (s *VolumeSerializationSuite) exportImport(c *gc.C, volume_ *volume) *volume {
+	return s.exportImportVersion(c, volume_, 4)
+}
+
+func (s *VolumeSerializationSuite) exportImportVersion(c *gc.C, volume_ *volume, version int) *volume {
 	initial := volumes{
-		Version:  1,
+		Version:  version,
 		Volumes_: []*volume{volume_},
 	}
 
@@ -223,6 +227,53 @@ func (s *VolumeSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(volume, jc.DeepEquals, original)
 }
 
+func (s *VolumeSerializationSuite) TestAnnotations(c *gc.C) {
+	original := testVolume()
+	original.SetAnnotations(map[string]string{"backup": "daily"})
+	volume := s.exportImport(c, original)
+	c.Assert(volume.Annotations(), jc.DeepEquals, map[string]string{"backup": "daily"})
+
+	volume = s.exportImportVersion(c, original, 3)
+	c.Assert(volume.Annotations(), gc.HasLen, 0)
+}
+
+func (s *VolumeSerializationSuite) TestEncryptionAndPerformance(c *gc.C) {
+	args := testVolumeArgs()
+	args.Encrypted = true
+	args.KMSKeyID = "arn:aws:kms:us-east-1:123456789012:key/abcd"
+	args.IOPS = 3000
+	args.Throughput = 125
+	original := newVolume(args)
+	original.SetStatus(minimalStatusArgs())
+
+	c.Check(original.Encrypted(), jc.IsTrue)
+	c.Check(original.KMSKeyID(), gc.Equals, "arn:aws:kms:us-east-1:123456789012:key/abcd")
+	c.Check(original.IOPS(), gc.Equals, uint64(3000))
+	c.Check(original.Throughput(), gc.Equals, uint64(125))
+
+	volume := s.exportImport(c, original)
+	c.Assert(volume, jc.DeepEquals, original)
+}
+
+func (s *VolumeSerializationSuite) TestV1ParsingDefaultsEncryptionAndPerformance(c *gc.C) {
+	args := testVolumeArgs()
+	args.Encrypted = true
+	args.KMSKeyID = "some-key"
+	args.IOPS = 3000
+	args.Throughput = 125
+	original := newVolume(args)
+	original.SetStatus(minimalStatusArgs())
+
+	volume := s.exportImportVersion(c, original, 1)
+	c.Check(volume.Encrypted(), jc.IsFalse)
+	c.Check(volume.KMSKeyID(), gc.Equals, "")
+	c.Check(volume.IOPS(), gc.Equals, uint64(0))
+	c.Check(volume.Throughput(), gc.Equals, uint64(0))
+
+	expected := testVolume()
+	c.Assert(volume, jc.DeepEquals, expected)
+}
+
 type VolumeAttachmentSerializationSuite struct {
 	SliceSerializationSuite
 }
@@ -353,3 +404,24 @@ func (s *VolumeAttachmentSerializationSuite) TestUnitAttachmentParsing(c *gc.C)
 		BusAddress_:  "nfi",
 	})
 }
+
+func (s *VolumeSerializationSuite) TestLife(c *gc.C) {
+	original := testVolume()
+	c.Assert(original.Life(), gc.Equals, Alive)
+	original.Life_ = Dying
+	c.Assert(original.Validate(), jc.ErrorIsNil)
+
+	volume := s.exportImport(c, original)
+	c.Assert(volume, jc.DeepEquals, original)
+
+	volume = s.exportImportVersion(c, original, 2)
+	c.Assert(volume.Life(), gc.Equals, Alive)
+}
+
+func (s *VolumeSerializationSuite) TestValidateLife(c *gc.C) {
+	original := testVolume()
+	original.Life_ = "zombie"
+	err := original.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "1234" life "zombie" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}